@@ -132,6 +132,7 @@ type TransactionManagerV2 interface {
 	BlockTime() *time.Duration
 	CustomURL() *url.URL
 	DualBroadcast() *bool
+	PersistentStore() *bool
 }
 
 type GasEstimator interface {
@@ -587,10 +587,11 @@ func (a *AutoPurgeConfig) setFrom(f *AutoPurgeConfig) {
 }
 
 type TransactionManagerV2Config struct {
-	Enabled       *bool                  `toml:",omitempty"`
-	BlockTime     *commonconfig.Duration `toml:",omitempty"`
-	CustomURL     *commonconfig.URL      `toml:",omitempty"`
-	DualBroadcast *bool                  `toml:",omitempty"`
+	Enabled         *bool                  `toml:",omitempty"`
+	BlockTime       *commonconfig.Duration `toml:",omitempty"`
+	CustomURL       *commonconfig.URL      `toml:",omitempty"`
+	DualBroadcast   *bool                  `toml:",omitempty"`
+	PersistentStore *bool                  `toml:",omitempty"`
 }
 
 func (t *TransactionManagerV2Config) setFrom(f *TransactionManagerV2Config) {
@@ -606,6 +607,9 @@ func (t *TransactionManagerV2Config) setFrom(f *TransactionManagerV2Config) {
 	if v := f.DualBroadcast; v != nil {
 		t.DualBroadcast = f.DualBroadcast
 	}
+	if v := f.PersistentStore; v != nil {
+		t.PersistentStore = f.PersistentStore
+	}
 }
 
 func (t *TransactionManagerV2Config) ValidateConfig() (err error) {
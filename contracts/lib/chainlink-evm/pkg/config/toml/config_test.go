@@ -64,6 +64,7 @@ func TestDefaults_fieldsNotNil(t *testing.T) {
 	unknown.Transactions.TransactionManagerV2.BlockTime = new(config.Duration)
 	unknown.Transactions.TransactionManagerV2.CustomURL = new(config.URL)
 	unknown.Transactions.TransactionManagerV2.DualBroadcast = ptr(false)
+	unknown.Transactions.TransactionManagerV2.PersistentStore = ptr(false)
 	unknown.Transactions.AutoPurge.Threshold = ptr(uint32(0))
 	unknown.Transactions.AutoPurge.MinAttempts = ptr(uint32(0))
 	unknown.Transactions.AutoPurge.DetectionApiUrl = new(config.URL)
@@ -166,6 +167,7 @@ func TestDocs(t *testing.T) {
 		docDefaults.Transactions.TransactionManagerV2.BlockTime = nil
 		docDefaults.Transactions.TransactionManagerV2.CustomURL = nil
 		docDefaults.Transactions.TransactionManagerV2.DualBroadcast = nil
+		docDefaults.Transactions.TransactionManagerV2.PersistentStore = nil
 
 		// Fallback DA oracle is not set
 		docDefaults.GasEstimator.DAOracle = DAOracle{}
@@ -287,10 +289,11 @@ var fullConfig = EVMConfig{
 				DetectionApiUrl: config.MustParseURL("http://example.net"),
 			},
 			TransactionManagerV2: TransactionManagerV2Config{
-				Enabled:       ptr(false),
-				DualBroadcast: ptr(true),
-				BlockTime:     config.MustNewDuration(42 * time.Second),
-				CustomURL:     config.MustParseURL("http://txs.org"),
+				Enabled:         ptr(false),
+				DualBroadcast:   ptr(true),
+				BlockTime:       config.MustNewDuration(42 * time.Second),
+				CustomURL:       config.MustParseURL("http://txs.org"),
+				PersistentStore: ptr(true),
 			},
 		},
 
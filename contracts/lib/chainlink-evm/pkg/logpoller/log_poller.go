@@ -130,6 +130,7 @@ type logPoller struct {
 
 	replayStart    chan int64
 	replayComplete chan error
+	reorgSubs      reorgSubscribers
 	stopCh         services.StopChan
 	wg             sync.WaitGroup
 	// This flag is raised whenever the log poller detects that the chain's finality has been violated.
@@ -1019,6 +1020,7 @@ func (lp *logPoller) getCurrentBlockMaybeHandleReorg(ctx context.Context, curren
 			// We return an error here which will cause us to restart polling from lastBlockSaved + 1
 			return nil, err2
 		}
+		lp.reorgSubs.notify(blockAfterLCA.Number)
 		return blockAfterLCA, nil
 	}
 	// No reorg, return current block.
@@ -1668,7 +1670,16 @@ func (lp *logPoller) IndexedLogsWithSigsExcluding(ctx context.Context, address c
 
 // DeleteLogsAndBlocksAfter - removes blocks and logs starting from the specified block
 func (lp *logPoller) DeleteLogsAndBlocksAfter(ctx context.Context, start int64) error {
-	return lp.orm.DeleteLogsAndBlocksAfter(ctx, start)
+	if err := lp.orm.DeleteLogsAndBlocksAfter(ctx, start); err != nil {
+		return err
+	}
+	lp.reorgSubs.notify(start)
+	return nil
+}
+
+// SubscribeToReorgs implements ReorgSubscriber.
+func (lp *logPoller) SubscribeToReorgs() (<-chan int64, func()) {
+	return lp.reorgSubs.subscribe()
 }
 
 func (lp *logPoller) FindLCA(ctx context.Context) (*Block, error) {
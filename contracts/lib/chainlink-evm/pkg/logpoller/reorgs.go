@@ -0,0 +1,50 @@
+package logpoller
+
+import (
+	"sync"
+)
+
+// ReorgSubscriber is implemented by the log pollers that notify of the reorgs they handle.
+type ReorgSubscriber interface {
+	// SubscribeToReorgs returns a channel that receives the number of the first block removed by each reorg, once the
+	// logs and blocks after the last common ancestor are deleted. A subscriber that falls behind receives the lowest
+	// block number of the reorgs it missed. unsubscribe must be called to release the subscription.
+	SubscribeToReorgs() (reorgs <-chan int64, unsubscribe func())
+}
+
+var _ ReorgSubscriber = &logPoller{}
+
+type reorgSubscribers struct {
+	mu   sync.Mutex
+	subs map[chan int64]struct{}
+}
+
+func (s *reorgSubscribers) subscribe() (<-chan int64, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subs == nil {
+		s.subs = make(map[chan int64]struct{})
+	}
+	ch := make(chan int64, 1)
+	s.subs[ch] = struct{}{}
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subs, ch)
+	}
+}
+
+// notify never blocks: a notification still pending for a subscriber is merged with the new one.
+func (s *reorgSubscribers) notify(blockAfterLCA int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subs {
+		n := blockAfterLCA
+		select {
+		case pending := <-ch:
+			n = min(n, pending)
+		default:
+		}
+		ch <- n
+	}
+}
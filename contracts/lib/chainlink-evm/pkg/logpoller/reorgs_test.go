@@ -0,0 +1,30 @@
+package logpoller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReorgSubscribers(t *testing.T) {
+	t.Parallel()
+
+	var subs reorgSubscribers
+	reorgs, unsubscribe := subs.subscribe()
+	other, unsubscribeOther := subs.subscribe()
+	defer unsubscribeOther()
+
+	subs.notify(10)
+	assert.Equal(t, int64(10), <-reorgs)
+
+	subs.notify(12)
+	subs.notify(8)
+	subs.notify(9)
+	assert.Equal(t, int64(8), <-reorgs, "missed reorgs are merged into the lowest block")
+	assert.Equal(t, int64(8), <-other)
+
+	unsubscribe()
+	subs.notify(7)
+	assert.Empty(t, reorgs)
+	assert.Equal(t, int64(7), <-other)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	"google.golang.org/protobuf/proto"
 
@@ -36,6 +37,10 @@ var (
 		Name: "txm_time_until_tx_confirmed",
 		Help: "The amount of time elapsed from a transaction being broadcast to being included in a block.",
 	}, []string{"chainID"})
+	promNumUnstartedTxs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "txm_num_unstarted_transactions",
+		Help: "Number of unstarted transactions queued for broadcasting, per address and priority class.",
+	}, []string{"chainID", "fromAddress", "priority"})
 )
 
 type txmMetrics struct {
@@ -45,6 +50,7 @@ type txmMetrics struct {
 	numConfirmedTxs      metric.Int64Counter
 	numNonceGaps         metric.Int64Counter
 	timeUntilTxConfirmed metric.Float64Histogram
+	numUnstartedTxs      metric.Int64Gauge
 }
 
 func NewTxmMetrics(chainID *big.Int) (*txmMetrics, error) {
@@ -68,6 +74,11 @@ func NewTxmMetrics(chainID *big.Int) (*txmMetrics, error) {
 		return nil, fmt.Errorf("failed to register time until tx confirmed: %w", err)
 	}
 
+	numUnstartedTxs, err := beholder.GetMeter().Int64Gauge("txm_num_unstarted_transactions")
+	if err != nil {
+		return nil, fmt.Errorf("failed to register unstarted txs number: %w", err)
+	}
+
 	return &txmMetrics{
 		chainID:              chainID,
 		Labeler:              metrics.NewLabeler().With("chainID", chainID.String()),
@@ -75,6 +86,7 @@ func NewTxmMetrics(chainID *big.Int) (*txmMetrics, error) {
 		numConfirmedTxs:      numConfirmedTxs,
 		numNonceGaps:         numNonceGaps,
 		timeUntilTxConfirmed: timeUntilTxConfirmed,
+		numUnstartedTxs:      numUnstartedTxs,
 	}, nil
 }
 
@@ -98,6 +110,15 @@ func (m *txmMetrics) RecordTimeUntilTxConfirmed(ctx context.Context, duration fl
 	m.timeUntilTxConfirmed.Record(ctx, duration)
 }
 
+func (m *txmMetrics) RecordNumUnstartedTxs(ctx context.Context, fromAddress common.Address, priority types.Priority, count int) {
+	promNumUnstartedTxs.WithLabelValues(m.chainID.String(), fromAddress.String(), priority.String()).Set(float64(count))
+	m.numUnstartedTxs.Record(ctx, int64(count), metric.WithAttributes(
+		attribute.String("chainID", m.chainID.String()),
+		attribute.String("fromAddress", fromAddress.String()),
+		attribute.String("priority", priority.String()),
+	))
+}
+
 func (m *txmMetrics) EmitTxMessage(ctx context.Context, txHash common.Hash, fromAddress common.Address, tx *types.Transaction) error {
 	meta, err := tx.GetMeta()
 	if err != nil {
@@ -121,6 +121,65 @@ func (_c *mockTxStore_AppendAttemptToTransaction_Call) RunAndReturn(run func(con
 	return _c
 }
 
+// CountUnstartedTransactionsByPriority provides a mock function with given fields: _a0, _a1
+func (_m *mockTxStore) CountUnstartedTransactionsByPriority(_a0 context.Context, _a1 common.Address) (map[types.Priority]int, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUnstartedTransactionsByPriority")
+	}
+
+	var r0 map[types.Priority]int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) (map[types.Priority]int, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) map[types.Priority]int); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[types.Priority]int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// mockTxStore_CountUnstartedTransactionsByPriority_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountUnstartedTransactionsByPriority'
+type mockTxStore_CountUnstartedTransactionsByPriority_Call struct {
+	*mock.Call
+}
+
+// CountUnstartedTransactionsByPriority is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 common.Address
+func (_e *mockTxStore_Expecter) CountUnstartedTransactionsByPriority(_a0 interface{}, _a1 interface{}) *mockTxStore_CountUnstartedTransactionsByPriority_Call {
+	return &mockTxStore_CountUnstartedTransactionsByPriority_Call{Call: _e.mock.On("CountUnstartedTransactionsByPriority", _a0, _a1)}
+}
+
+func (_c *mockTxStore_CountUnstartedTransactionsByPriority_Call) Run(run func(_a0 context.Context, _a1 common.Address)) *mockTxStore_CountUnstartedTransactionsByPriority_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address))
+	})
+	return _c
+}
+
+func (_c *mockTxStore_CountUnstartedTransactionsByPriority_Call) Return(_a0 map[types.Priority]int, _a1 error) *mockTxStore_CountUnstartedTransactionsByPriority_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *mockTxStore_CountUnstartedTransactionsByPriority_Call) RunAndReturn(run func(context.Context, common.Address) (map[types.Priority]int, error)) *mockTxStore_CountUnstartedTransactionsByPriority_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateEmptyUnconfirmedTransaction provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *mockTxStore) CreateEmptyUnconfirmedTransaction(_a0 context.Context, _a1 common.Address, _a2 uint64, _a3 uint64) (*types.Transaction, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
@@ -30,9 +30,11 @@ import (
 )
 
 type OrchestratorTxStore interface {
-	Add(addresses ...common.Address) error
+	Add(ctx context.Context, addresses ...common.Address) error
 	FetchUnconfirmedTransactionAtNonceWithCount(context.Context, uint64, common.Address) (*txmtypes.Transaction, int, error)
 	FindTxWithIdempotencyKey(context.Context, string) (*txmtypes.Transaction, error)
+	FindTxesByMetaFieldAndStates(context.Context, string, string, []txmgrtypes.TxState) ([]*txmtypes.Transaction, error)
+	FindTxesWithMetaFieldByStates(context.Context, string, []txmgrtypes.TxState) ([]*txmtypes.Transaction, error)
 }
 
 type OrchestratorAttemptBuilder[
@@ -93,7 +95,7 @@ func (o *Orchestrator[BLOCK_HASH, HEAD]) Start(ctx context.Context) error {
 			return err
 		}
 		for _, address := range addresses {
-			err := o.txStore.Add(address)
+			err := o.txStore.Add(ctx, address)
 			if err != nil {
 				return err
 			}
@@ -228,6 +230,7 @@ func (o *Orchestrator[BLOCK_HASH, HEAD]) CreateTransaction(ctx context.Context,
 			Value:             &request.Value,
 			Data:              request.EncodedPayload,
 			SpecifiedGasLimit: request.FeeLimit,
+			Priority:          requestPriority(request),
 			Meta:              meta,
 			ForwarderAddress:  request.ForwarderAddress,
 
@@ -243,10 +246,31 @@ func (o *Orchestrator[BLOCK_HASH, HEAD]) CreateTransaction(ctx context.Context,
 		o.txm.Trigger(request.FromAddress)
 	}
 
+	return toTx(wrappedTx)
+}
+
+// requestPriority returns the priority set by the strategy of the request, see txmtypes.WithPriority.
+func requestPriority(request txmgrtypes.TxRequest[common.Address, common.Hash]) txmtypes.Priority {
+	if strategy, ok := request.Strategy.(txmtypes.PriorityStrategy); ok {
+		return strategy.Priority()
+	}
+	return txmtypes.PriorityNormal
+}
+
+func toTx(wrappedTx *txmtypes.Transaction) (tx txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], err error) {
 	if wrappedTx.ID > math.MaxInt64 {
 		return tx, fmt.Errorf("overflow for int64: %d", wrappedTx.ID)
 	}
 
+	var sequence *evmtypes.Nonce
+	if wrappedTx.Nonce != nil {
+		if *wrappedTx.Nonce > math.MaxInt64 {
+			return tx, fmt.Errorf("overflow for int64: %d", *wrappedTx.Nonce)
+		}
+		nonce := evmtypes.Nonce(*wrappedTx.Nonce)
+		sequence = &nonce
+	}
+
 	tx = txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee]{
 		ID:             int64(wrappedTx.ID),
 		IdempotencyKey: wrappedTx.IdempotencyKey,
@@ -255,10 +279,15 @@ func (o *Orchestrator[BLOCK_HASH, HEAD]) CreateTransaction(ctx context.Context,
 		EncodedPayload: wrappedTx.Data,
 		Value:          *wrappedTx.Value,
 		FeeLimit:       wrappedTx.SpecifiedGasLimit,
+		Sequence:       sequence,
 		CreatedAt:      wrappedTx.CreatedAt,
 		Meta:           wrappedTx.Meta,
 		Subject:        wrappedTx.Subject,
 		ChainID:        wrappedTx.ChainID,
+		State:          wrappedTx.State,
+
+		InitialBroadcastAt: wrappedTx.InitialBroadcastAt,
+		BroadcastAt:        wrappedTx.LastBroadcastAt,
 
 		PipelineTaskRunID: wrappedTx.PipelineTaskRunID,
 		MinConfirmations:  wrappedTx.MinConfirmations,
@@ -296,11 +325,31 @@ func (o *Orchestrator[BLOCK_HASH, HEAD]) FindEarliestUnconfirmedTxAttemptBlock(c
 }
 
 func (o *Orchestrator[BLOCK_HASH, HEAD]) FindTxesByMetaFieldAndStates(ctx context.Context, metaField string, metaValue string, states []txmgrtypes.TxState, chainID *big.Int) (txs []*txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], err error) {
-	return
+	wrappedTxs, err := o.txStore.FindTxesByMetaFieldAndStates(ctx, metaField, metaValue, states)
+	if err != nil {
+		return nil, err
+	}
+	return toTxs(wrappedTxs)
 }
 
 func (o *Orchestrator[BLOCK_HASH, HEAD]) FindTxesWithMetaFieldByStates(ctx context.Context, metaField string, states []txmgrtypes.TxState, chainID *big.Int) (txs []*txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], err error) {
-	return
+	wrappedTxs, err := o.txStore.FindTxesWithMetaFieldByStates(ctx, metaField, states)
+	if err != nil {
+		return nil, err
+	}
+	return toTxs(wrappedTxs)
+}
+
+func toTxs(wrappedTxs []*txmtypes.Transaction) ([]*txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], error) {
+	txs := make([]*txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], 0, len(wrappedTxs))
+	for _, wrappedTx := range wrappedTxs {
+		tx, err := toTx(wrappedTx)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, &tx)
+	}
+	return txs, nil
 }
 
 func (o *Orchestrator[BLOCK_HASH, HEAD]) FindTxesWithMetaFieldByReceiptBlockNum(ctx context.Context, metaField string, blockNum int64, chainID *big.Int) (txs []*txmgrtypes.Tx[*big.Int, common.Address, common.Hash, common.Hash, evmtypes.Nonce, gas.EvmFee], err error) {
@@ -0,0 +1,24 @@
+package txm
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	txmtypes "github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
+)
+
+func TestRequestPriority(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, txmtypes.PriorityNormal, requestPriority(txmgrtypes.TxRequest[common.Address, common.Hash]{}))
+	assert.Equal(t, txmtypes.PriorityNormal, requestPriority(txmgrtypes.TxRequest[common.Address, common.Hash]{
+		Strategy: txmgr.NewSendEveryStrategy(),
+	}))
+	assert.Equal(t, txmtypes.PriorityHigh, requestPriority(txmgrtypes.TxRequest[common.Address, common.Hash]{
+		Strategy: txmtypes.WithPriority(txmgr.NewSendEveryStrategy(), txmtypes.PriorityHigh),
+	}))
+}
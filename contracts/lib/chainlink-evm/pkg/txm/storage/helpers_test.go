@@ -0,0 +1,6 @@
+package storage
+
+const (
+	MaxQueuedTransactions = maxQueuedTransactions
+	LegacyHistoryLimit    = legacyHistoryLimit
+)
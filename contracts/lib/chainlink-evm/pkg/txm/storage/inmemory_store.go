@@ -1,9 +1,11 @@
 package storage
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"sort"
 	"sync"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
 	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
 )
 
 const (
@@ -23,6 +26,10 @@ const (
 	pruneSubset = 3
 )
 
+// ErrUnstartedQueueFull is returned when the unstarted transactions queue of an address reached its max limit and
+// the new transaction has a lower priority than the transactions that would have to be dropped to make room for it.
+var ErrUnstartedQueueFull = errors.New("unstarted transactions queue is full of higher priority transactions")
+
 type InMemoryStore struct {
 	sync.RWMutex
 	lggr      logger.Logger
@@ -99,6 +106,20 @@ func (m *InMemoryStore) CountUnstartedTransactions() int {
 	return len(m.UnstartedTransactions)
 }
 
+func (m *InMemoryStore) CountUnstartedTransactionsByPriority() map[types.Priority]int {
+	m.RLock()
+	defer m.RUnlock()
+
+	counts := make(map[types.Priority]int, len(types.Priorities))
+	for _, p := range types.Priorities {
+		counts[p] = 0
+	}
+	for _, tx := range m.UnstartedTransactions {
+		counts[tx.Priority]++
+	}
+	return counts
+}
+
 func (m *InMemoryStore) CreateEmptyUnconfirmedTransaction(nonce uint64, gasLimit uint64) (*types.Transaction, error) {
 	m.Lock()
 	defer m.Unlock()
@@ -130,7 +151,7 @@ func (m *InMemoryStore) CreateEmptyUnconfirmedTransaction(nonce uint64, gasLimit
 	return emptyTx.DeepCopy(), nil
 }
 
-func (m *InMemoryStore) CreateTransaction(txRequest *types.TxRequest) *types.Transaction {
+func (m *InMemoryStore) CreateTransaction(txRequest *types.TxRequest) (*types.Transaction, error) {
 	m.Lock()
 	defer m.Unlock()
 
@@ -143,6 +164,7 @@ func (m *InMemoryStore) CreateTransaction(txRequest *types.TxRequest) *types.Tra
 		Value:             txRequest.Value,
 		Data:              txRequest.Data,
 		SpecifiedGasLimit: txRequest.SpecifiedGasLimit,
+		Priority:          txRequest.Priority,
 		CreatedAt:         time.Now(),
 		State:             txmgr.TxUnstarted,
 		Meta:              txRequest.Meta,
@@ -151,21 +173,31 @@ func (m *InMemoryStore) CreateTransaction(txRequest *types.TxRequest) *types.Tra
 		SignalCallback:    txRequest.SignalCallback,
 	}
 
-	uLen := len(m.UnstartedTransactions)
-	if uLen >= maxQueuedTransactions {
-		m.lggr.Warnw(fmt.Sprintf("Unstarted transactions queue for address: %v reached max limit of: %d. Dropping oldest transactions", m.address, maxQueuedTransactions),
-			"txs", m.UnstartedTransactions[0:uLen-maxQueuedTransactions+1]) // need to make room for the new tx
-		for _, tx := range m.UnstartedTransactions[0 : uLen-maxQueuedTransactions+1] {
-			delete(m.Transactions, tx.ID)
+	if uLen := len(m.UnstartedTransactions); uLen >= maxQueuedTransactions {
+		// need to make room for the new tx, but only at the expense of transactions that don't outrank it
+		toDrop := uLen - maxQueuedTransactions + 1
+		droppable := 0
+		for _, unstartedTx := range m.UnstartedTransactions {
+			if unstartedTx.Priority <= tx.Priority {
+				droppable++
+			}
 		}
-		m.UnstartedTransactions = m.UnstartedTransactions[uLen-maxQueuedTransactions+1:]
+		if droppable < toDrop {
+			return nil, fmt.Errorf("%w: address: %v, limit: %d, priority: %v", ErrUnstartedQueueFull, m.address, maxQueuedTransactions, tx.Priority)
+		}
+		dropped := make([]*types.Transaction, 0, toDrop)
+		for range toDrop {
+			dropped = append(dropped, m.dropUnstartedTransaction())
+		}
+		m.lggr.Warnw(fmt.Sprintf("Unstarted transactions queue for address: %v reached max limit of: %d. Dropping oldest transactions of the lowest priority", m.address, maxQueuedTransactions),
+			"txs", dropped)
 	}
 
 	m.txIDCount++
 	txCopy := tx.DeepCopy()
 	m.Transactions[txCopy.ID] = txCopy
 	m.UnstartedTransactions = append(m.UnstartedTransactions, txCopy)
-	return tx
+	return tx, nil
 }
 
 func (m *InMemoryStore) FetchUnconfirmedTransactionAtNonceWithCount(latestNonce uint64) (txCopy *types.Transaction, unconfirmedCount int) {
@@ -282,16 +314,38 @@ func (m *InMemoryStore) UpdateUnstartedTransactionWithNonce(nonce uint64) (*type
 		return nil, fmt.Errorf("an unconfirmed tx with the same nonce already exists: %v", tx)
 	}
 
-	tx := m.UnstartedTransactions[0]
+	// Pick the oldest transaction of the highest priority
+	i := 0
+	for j, tx := range m.UnstartedTransactions {
+		if tx.Priority > m.UnstartedTransactions[i].Priority {
+			i = j
+		}
+	}
+	tx := m.UnstartedTransactions[i]
 	tx.Nonce = &nonce
 	tx.State = txmgr.TxUnconfirmed
 
-	m.UnstartedTransactions = m.UnstartedTransactions[1:]
+	m.UnstartedTransactions = slices.Delete(m.UnstartedTransactions, i, i+1)
 	m.UnconfirmedTransactions[nonce] = tx
 
 	return tx.DeepCopy(), nil
 }
 
+// dropUnstartedTransaction removes the oldest unstarted transaction of the lowest priority.
+// Shouldn't call lock because it's being called by a method that already has the lock
+func (m *InMemoryStore) dropUnstartedTransaction() *types.Transaction {
+	i := 0
+	for j, tx := range m.UnstartedTransactions {
+		if tx.Priority < m.UnstartedTransactions[i].Priority {
+			i = j
+		}
+	}
+	tx := m.UnstartedTransactions[i]
+	delete(m.Transactions, tx.ID)
+	m.UnstartedTransactions = slices.Delete(m.UnstartedTransactions, i, i+1)
+	return tx
+}
+
 // Shouldn't call lock because it's being called by a method that already has the lock
 func (m *InMemoryStore) pruneConfirmedTransactions() []uint64 {
 	noncesToPrune := make([]uint64, 0, len(m.ConfirmedTransactions))
@@ -354,3 +408,51 @@ func (m *InMemoryStore) FindTxWithIdempotencyKey(idempotencyKey string) *types.T
 
 	return nil
 }
+
+func (m *InMemoryStore) FindTxesByMetaFieldAndStates(metaField string, metaValue string, states []txmgrtypes.TxState) []*types.Transaction {
+	return m.findTxes(func(tx *types.Transaction) bool {
+		value, exists := metaFieldValue(tx, metaField)
+		return exists && value == metaValue && slices.Contains(states, tx.State)
+	})
+}
+
+func (m *InMemoryStore) FindTxesWithMetaFieldByStates(metaField string, states []txmgrtypes.TxState) []*types.Transaction {
+	return m.findTxes(func(tx *types.Transaction) bool {
+		_, exists := metaFieldValue(tx, metaField)
+		return exists && slices.Contains(states, tx.State)
+	})
+}
+
+func (m *InMemoryStore) findTxes(match func(*types.Transaction) bool) []*types.Transaction {
+	m.RLock()
+	defer m.RUnlock()
+
+	var txs []*types.Transaction
+	for _, tx := range m.Transactions {
+		if match(tx) {
+			txs = append(txs, tx.DeepCopy())
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].ID < txs[j].ID })
+	return txs
+}
+
+// metaFieldValue returns the value of a top level field of the tx meta as text, the same way Postgres' ->> operator does.
+func metaFieldValue(tx *types.Transaction, metaField string) (string, bool) {
+	if tx.Meta == nil {
+		return "", false
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(*tx.Meta, &fields); err != nil {
+		return "", false
+	}
+	raw, exists := fields[metaField]
+	if !exists {
+		return "", false
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return string(raw), true
+	}
+	return value, true
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
 )
 
 const StoreNotFoundForAddress string = "InMemoryStore for address: %v not found"
@@ -36,7 +37,7 @@ func (m *InMemoryStoreManager) AbandonPendingTransactions(_ context.Context, fro
 	return fmt.Errorf(StoreNotFoundForAddress, fromAddress)
 }
 
-func (m *InMemoryStoreManager) Add(addresses ...common.Address) (err error) {
+func (m *InMemoryStoreManager) Add(_ context.Context, addresses ...common.Address) (err error) {
 	for _, address := range addresses {
 		if _, exists := m.InMemoryStoreMap[address]; exists {
 			err = errors.Join(err, fmt.Errorf("address %v already exists in store manager", address))
@@ -60,6 +61,13 @@ func (m *InMemoryStoreManager) CountUnstartedTransactions(fromAddress common.Add
 	return 0, fmt.Errorf(StoreNotFoundForAddress, fromAddress)
 }
 
+func (m *InMemoryStoreManager) CountUnstartedTransactionsByPriority(_ context.Context, fromAddress common.Address) (map[types.Priority]int, error) {
+	if store, exists := m.InMemoryStoreMap[fromAddress]; exists {
+		return store.CountUnstartedTransactionsByPriority(), nil
+	}
+	return nil, fmt.Errorf(StoreNotFoundForAddress, fromAddress)
+}
+
 func (m *InMemoryStoreManager) CreateEmptyUnconfirmedTransaction(_ context.Context, fromAddress common.Address, nonce uint64, gasLimit uint64) (*types.Transaction, error) {
 	if store, exists := m.InMemoryStoreMap[fromAddress]; exists {
 		return store.CreateEmptyUnconfirmedTransaction(nonce, gasLimit)
@@ -69,7 +77,7 @@ func (m *InMemoryStoreManager) CreateEmptyUnconfirmedTransaction(_ context.Conte
 
 func (m *InMemoryStoreManager) CreateTransaction(_ context.Context, txRequest *types.TxRequest) (*types.Transaction, error) {
 	if store, exists := m.InMemoryStoreMap[txRequest.FromAddress]; exists {
-		return store.CreateTransaction(txRequest), nil
+		return store.CreateTransaction(txRequest)
 	}
 	return nil, fmt.Errorf(StoreNotFoundForAddress, txRequest.FromAddress)
 }
@@ -134,3 +142,17 @@ func (m *InMemoryStoreManager) FindTxWithIdempotencyKey(_ context.Context, idemp
 	}
 	return nil, nil
 }
+
+func (m *InMemoryStoreManager) FindTxesByMetaFieldAndStates(_ context.Context, metaField string, metaValue string, states []txmgrtypes.TxState) (txs []*types.Transaction, err error) {
+	for _, store := range m.InMemoryStoreMap {
+		txs = append(txs, store.FindTxesByMetaFieldAndStates(metaField, metaValue, states)...)
+	}
+	return
+}
+
+func (m *InMemoryStoreManager) FindTxesWithMetaFieldByStates(_ context.Context, metaField string, states []txmgrtypes.TxState) (txs []*types.Transaction, err error) {
+	for _, store := range m.InMemoryStoreMap {
+		txs = append(txs, store.FindTxesWithMetaFieldByStates(metaField, states)...)
+	}
+	return
+}
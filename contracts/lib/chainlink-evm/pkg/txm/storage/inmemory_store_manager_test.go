@@ -17,19 +17,19 @@ func TestAdd(t *testing.T) {
 	fromAddress := testutils.NewAddress()
 	m := NewInMemoryStoreManager(logger.Test(t), testutils.FixtureChainID)
 	// Adds a new address
-	err := m.Add(fromAddress)
+	err := m.Add(t.Context(), fromAddress)
 	require.NoError(t, err)
 	assert.Len(t, m.InMemoryStoreMap, 1)
 
 	// Fails if address exists
-	err = m.Add(fromAddress)
+	err = m.Add(t.Context(), fromAddress)
 	require.Error(t, err)
 
 	// Adds multiple addresses
 	fromAddress1 := testutils.NewAddress()
 	fromAddress2 := testutils.NewAddress()
 	addresses := []common.Address{fromAddress1, fromAddress2}
-	err = m.Add(addresses...)
+	err = m.Add(t.Context(), addresses...)
 	require.NoError(t, err)
 	assert.Len(t, m.InMemoryStoreMap, 3)
 }
@@ -11,11 +11,13 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
 	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
 
 	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
 	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
 )
 
 func TestAbandonPendingTransactions(t *testing.T) {
@@ -122,6 +124,20 @@ func TestCountUnstartedTransactions(t *testing.T) {
 	assert.Equal(t, 1, m.CountUnstartedTransactions())
 }
 
+func TestCountUnstartedTransactionsByPriority(t *testing.T) {
+	t.Parallel()
+
+	fromAddress := testutils.NewAddress()
+	m := NewInMemoryStore(logger.Test(t), fromAddress, testutils.FixtureChainID)
+
+	assert.Equal(t, map[types.Priority]int{types.PriorityHigh: 0, types.PriorityNormal: 0, types.PriorityLow: 0}, m.CountUnstartedTransactionsByPriority())
+
+	createTransaction(t, m, &types.TxRequest{Priority: types.PriorityHigh})
+	createTransaction(t, m, &types.TxRequest{Priority: types.PriorityLow})
+	createTransaction(t, m, &types.TxRequest{Priority: types.PriorityLow})
+	assert.Equal(t, map[types.Priority]int{types.PriorityHigh: 1, types.PriorityNormal: 0, types.PriorityLow: 2}, m.CountUnstartedTransactionsByPriority())
+}
+
 func TestCreateEmptyUnconfirmedTransaction(t *testing.T) {
 	t.Parallel()
 
@@ -159,11 +175,13 @@ func TestCreateTransaction(t *testing.T) {
 		now := time.Now()
 		txR1 := &types.TxRequest{}
 		txR2 := &types.TxRequest{}
-		tx1 := m.CreateTransaction(txR1)
+		tx1, err := m.CreateTransaction(txR1)
+		require.NoError(t, err)
 		assert.Equal(t, uint64(0), tx1.ID)
 		assert.LessOrEqual(t, now, tx1.CreatedAt)
 
-		tx2 := m.CreateTransaction(txR2)
+		tx2, err := m.CreateTransaction(txR2)
+		require.NoError(t, err)
 		assert.Equal(t, uint64(1), tx2.ID)
 		assert.LessOrEqual(t, now, tx2.CreatedAt)
 
@@ -175,7 +193,8 @@ func TestCreateTransaction(t *testing.T) {
 		overshot := 5
 		for i := 0; i < maxQueuedTransactions+overshot; i++ {
 			r := &types.TxRequest{}
-			tx := m.CreateTransaction(r)
+			tx, err := m.CreateTransaction(r)
+			require.NoError(t, err)
 			//nolint:gosec // this won't overflow
 			assert.Equal(t, uint64(i), tx.ID)
 		}
@@ -187,6 +206,46 @@ func TestCreateTransaction(t *testing.T) {
 		//nolint:gosec // this won't overflow
 		assert.Equal(t, uint64(overshot), tx.ID)
 	})
+
+	t.Run("prunes oldest unstarted transactions of the lowest priority if limit is reached", func(t *testing.T) {
+		m := NewInMemoryStore(logger.Test(t), fromAddress, testutils.FixtureChainID)
+		high := createTransaction(t, m, &types.TxRequest{Priority: types.PriorityHigh})
+		for i := 1; i < maxQueuedTransactions; i++ {
+			createTransaction(t, m, &types.TxRequest{Priority: types.PriorityLow})
+		}
+		normal := createTransaction(t, m, &types.TxRequest{})
+		assert.Equal(t, maxQueuedTransactions, m.CountUnstartedTransactions())
+		// the first low priority tx was dropped instead of the older high priority one
+		assert.NotContains(t, m.Transactions, uint64(1))
+
+		tx, err := m.UpdateUnstartedTransactionWithNonce(0)
+		require.NoError(t, err)
+		assert.Equal(t, high.ID, tx.ID)
+		tx, err = m.UpdateUnstartedTransactionWithNonce(1)
+		require.NoError(t, err)
+		assert.Equal(t, normal.ID, tx.ID)
+		tx, err = m.UpdateUnstartedTransactionWithNonce(2)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(2), tx.ID)
+	})
+
+	t.Run("rejects a new transaction outranked by every unstarted transaction if limit is reached", func(t *testing.T) {
+		m := NewInMemoryStore(logger.Test(t), fromAddress, testutils.FixtureChainID)
+		for range maxQueuedTransactions {
+			createTransaction(t, m, &types.TxRequest{Priority: types.PriorityHigh})
+		}
+
+		_, err := m.CreateTransaction(&types.TxRequest{Priority: types.PriorityLow})
+		require.ErrorIs(t, err, ErrUnstartedQueueFull)
+		assert.Equal(t, map[types.Priority]int{types.PriorityHigh: maxQueuedTransactions, types.PriorityNormal: 0, types.PriorityLow: 0}, m.CountUnstartedTransactionsByPriority())
+		assert.Len(t, m.Transactions, maxQueuedTransactions)
+
+		// a transaction of the same priority still replaces the oldest one
+		tx := createTransaction(t, m, &types.TxRequest{Priority: types.PriorityHigh})
+		assert.Equal(t, maxQueuedTransactions, m.CountUnstartedTransactions())
+		assert.NotContains(t, m.Transactions, uint64(0))
+		assert.Contains(t, m.Transactions, tx.ID)
+	})
 }
 
 func TestFetchUnconfirmedTransactionAtNonceWithCount(t *testing.T) {
@@ -385,6 +444,23 @@ func TestUpdateUnstartedTransactionWithNonce(t *testing.T) {
 		assert.Equal(t, txmgr.TxUnconfirmed, tx.State)
 		assert.Empty(t, m.UnstartedTransactions)
 	})
+
+	t.Run("picks the oldest unstarted transaction of the highest priority", func(t *testing.T) {
+		m := NewInMemoryStore(logger.Test(t), fromAddress, testutils.FixtureChainID)
+		low := createTransaction(t, m, &types.TxRequest{Priority: types.PriorityLow})
+		normal := createTransaction(t, m, &types.TxRequest{})
+		high1 := createTransaction(t, m, &types.TxRequest{Priority: types.PriorityHigh})
+		high2 := createTransaction(t, m, &types.TxRequest{Priority: types.PriorityHigh})
+
+		for nonce, expected := range []*types.Transaction{high1, high2, normal, low} {
+			//nolint:gosec // this won't overflow
+			tx, err := m.UpdateUnstartedTransactionWithNonce(uint64(nonce))
+			require.NoError(t, err)
+			assert.Equal(t, expected.ID, tx.ID)
+			assert.Equal(t, expected.Priority, tx.Priority)
+		}
+		assert.Empty(t, m.UnstartedTransactions)
+	})
 }
 
 func TestDeleteAttemptForUnconfirmedTx(t *testing.T) {
@@ -444,6 +520,32 @@ func TestFindTxWithIdempotencyKey(t *testing.T) {
 	assert.Nil(t, itx)
 }
 
+func TestFindTxesByMeta(t *testing.T) {
+	t.Parallel()
+	fromAddress := testutils.NewAddress()
+	m := NewInMemoryStore(logger.Test(t), fromAddress, testutils.FixtureChainID)
+	tx1, err := insertConfirmedTransaction(m, 0)
+	require.NoError(t, err)
+	meta1 := sqlutil.JSON(`{"RequestID": "0x01", "SubId": 5}`)
+	tx1.Meta = &meta1
+	tx2 := insertUnstartedTransaction(m)
+	meta2 := sqlutil.JSON(`{"RequestID": "0x02"}`)
+	tx2.Meta = &meta2
+	insertUnstartedTransaction(m)
+
+	txs := m.FindTxesByMetaFieldAndStates("RequestID", "0x01", []txmgrtypes.TxState{txmgr.TxConfirmed})
+	require.Len(t, txs, 1)
+	assert.Equal(t, tx1.ID, txs[0].ID)
+	txs = m.FindTxesByMetaFieldAndStates("SubId", "5", []txmgrtypes.TxState{txmgr.TxConfirmed})
+	require.Len(t, txs, 1)
+	assert.Empty(t, m.FindTxesByMetaFieldAndStates("RequestID", "0x01", []txmgrtypes.TxState{txmgr.TxUnstarted}))
+
+	txs = m.FindTxesWithMetaFieldByStates("RequestID", []txmgrtypes.TxState{txmgr.TxUnstarted, txmgr.TxConfirmed})
+	require.Len(t, txs, 2)
+	assert.Equal(t, tx1.ID, txs[0].ID)
+	assert.Equal(t, tx2.ID, txs[1].ID)
+}
+
 func TestPruneConfirmedTransactions(t *testing.T) {
 	t.Parallel()
 	fromAddress := testutils.NewAddress()
@@ -460,6 +562,12 @@ func TestPruneConfirmedTransactions(t *testing.T) {
 	assert.Len(t, prunedTxIDs, total/pruneSubset)
 }
 
+func createTransaction(t *testing.T, m *InMemoryStore, txRequest *types.TxRequest) *types.Transaction {
+	tx, err := m.CreateTransaction(txRequest)
+	require.NoError(t, err)
+	return tx
+}
+
 func insertUnstartedTransaction(m *InMemoryStore) *types.Transaction {
 	m.Lock()
 	defer m.Unlock()
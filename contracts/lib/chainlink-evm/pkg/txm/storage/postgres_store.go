@@ -0,0 +1,654 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	clnull "github.com/smartcontractkit/chainlink-common/pkg/utils/null"
+	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
+	"github.com/smartcontractkit/chainlink-evm/pkg/gas"
+	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
+)
+
+// PostgresStore is a TxStore backed by the evm.txm_transactions and evm.txm_attempts tables. Contrary to the InMemoryStore,
+// it keeps the transaction history across restarts. Transactions created by the legacy TXM are migrated to it when an
+// address is added to the store.
+type PostgresStore struct {
+	ds      sqlutil.DataSource
+	lggr    logger.SugaredLogger
+	chainID *big.Int
+}
+
+func NewPostgresStore(ds sqlutil.DataSource, lggr logger.Logger, chainID *big.Int) *PostgresStore {
+	return &PostgresStore{
+		ds:      ds,
+		lggr:    logger.Sugared(logger.Named(lggr, "PostgresStore")),
+		chainID: chainID,
+	}
+}
+
+// legacyHistoryLimit is the number of final legacy transactions migrated per address, to keep recent idempotency keys
+// and nonces in the history of TXM.
+const legacyHistoryLimit = 100
+
+func (s *PostgresStore) Transact(ctx context.Context, fn func(*PostgresStore) error) error {
+	return sqlutil.Transact(ctx, s.new, s.ds, nil, fn)
+}
+
+// new returns a PostgresStore like s, but backed by q.
+func (s *PostgresStore) new(q sqlutil.DataSource) *PostgresStore {
+	return &PostgresStore{ds: q, lggr: s.lggr, chainID: s.chainID}
+}
+
+type dbTransaction struct {
+	ID                 uint64
+	EVMChainID         ubig.Big
+	IdempotencyKey     *string
+	Nonce              *uint64
+	FromAddress        common.Address
+	ToAddress          common.Address
+	Value              ubig.Big
+	Data               []byte
+	SpecifiedGasLimit  uint64
+	Priority           types.Priority
+	CreatedAt          time.Time
+	InitialBroadcastAt *time.Time
+	LastBroadcastAt    *time.Time
+	State              txmgrtypes.TxState
+	IsPurgeable        bool
+	AttemptCount       uint16
+	Meta               *sqlutil.JSON
+	Subject            uuid.NullUUID
+	PipelineTaskRunID  uuid.NullUUID
+	MinConfirmations   clnull.Uint32
+	SignalCallback     bool
+	CallbackCompleted  bool
+	LegacyTxID         *int64
+}
+
+func (db *dbTransaction) toTransaction() *types.Transaction {
+	return &types.Transaction{
+		ID:                 db.ID,
+		IdempotencyKey:     db.IdempotencyKey,
+		ChainID:            db.EVMChainID.ToInt(),
+		Nonce:              db.Nonce,
+		FromAddress:        db.FromAddress,
+		ToAddress:          db.ToAddress,
+		Value:              db.Value.ToInt(),
+		Data:               db.Data,
+		SpecifiedGasLimit:  db.SpecifiedGasLimit,
+		Priority:           db.Priority,
+		CreatedAt:          db.CreatedAt,
+		InitialBroadcastAt: db.InitialBroadcastAt,
+		LastBroadcastAt:    db.LastBroadcastAt,
+		State:              db.State,
+		IsPurgeable:        db.IsPurgeable,
+		AttemptCount:       db.AttemptCount,
+		Meta:               db.Meta,
+		Subject:            db.Subject,
+		PipelineTaskRunID:  db.PipelineTaskRunID,
+		MinConfirmations:   db.MinConfirmations,
+		SignalCallback:     db.SignalCallback,
+		CallbackCompleted:  db.CallbackCompleted,
+	}
+}
+
+type dbAttempt struct {
+	ID          uint64
+	TxID        uint64
+	Hash        common.Hash
+	GasPrice    *assets.Wei
+	GasTipCap   *assets.Wei
+	GasFeeCap   *assets.Wei
+	GasLimit    uint64
+	TxType      byte
+	SignedRawTx []byte
+	CreatedAt   time.Time
+	BroadcastAt *time.Time
+}
+
+func (db *dbAttempt) toAttempt() (*types.Attempt, error) {
+	attempt := &types.Attempt{
+		ID:   db.ID,
+		TxID: db.TxID,
+		Hash: db.Hash,
+		Fee: gas.EvmFee{
+			GasPrice:   db.GasPrice,
+			DynamicFee: gas.DynamicFee{GasTipCap: db.GasTipCap, GasFeeCap: db.GasFeeCap},
+		},
+		GasLimit:    db.GasLimit,
+		Type:        db.TxType,
+		CreatedAt:   db.CreatedAt,
+		BroadcastAt: db.BroadcastAt,
+	}
+	if len(db.SignedRawTx) > 0 {
+		// Signed transactions are RLP encoded, the same way as in evm.tx_attempts.
+		signedTx := new(gethtypes.Transaction)
+		if err := signedTx.DecodeRLP(rlp.NewStream(bytes.NewReader(db.SignedRawTx), 0)); err != nil {
+			return nil, fmt.Errorf("failed to decode signed transaction of attempt: %d: %w", db.ID, err)
+		}
+		attempt.SignedTransaction = signedTx
+	}
+	return attempt, nil
+}
+
+const selectUnconfirmedTxIDAtNonce = `SELECT id FROM evm.txm_transactions
+WHERE evm_chain_id = $1 AND from_address = $2 AND nonce = $3 AND state = 'unconfirmed'`
+
+func (s *PostgresStore) AbandonPendingTransactions(ctx context.Context, fromAddress common.Address) error {
+	_, err := s.ds.ExecContext(ctx, `UPDATE evm.txm_transactions SET state = 'fatal_error'
+WHERE evm_chain_id = $1 AND from_address = $2 AND state IN ('unstarted', 'unconfirmed')`, s.chainID.String(), fromAddress)
+	return err
+}
+
+// Add migrates the pending and most recent final transactions of the legacy TXM for the given addresses and recovers
+// their in-flight transactions. Adding an address more than once is a no-op.
+func (s *PostgresStore) Add(ctx context.Context, addresses ...common.Address) (err error) {
+	for _, address := range addresses {
+		err = errors.Join(err, s.Transact(ctx, func(store *PostgresStore) error {
+			if mErr := store.migrateLegacyTransactions(ctx, address); mErr != nil {
+				return fmt.Errorf("failed to migrate legacy transactions for address: %v: %w", address, mErr)
+			}
+			if rErr := store.recoverInFlightTransactions(ctx, address); rErr != nil {
+				return fmt.Errorf("failed to recover in-flight transactions for address: %v: %w", address, rErr)
+			}
+			return nil
+		}))
+	}
+	return
+}
+
+// migrateLegacyTransactions copies the transactions of evm.txes that are not final yet, and the last
+// legacyHistoryLimit final ones, along with their attempts. Older history is left behind, so the copy stays small
+// enough for a single database transaction however long the legacy TXM ran. in_progress and
+// confirmed_missing_receipt transactions become unconfirmed, so TXM rebroadcasts or confirms them. Legacy transactions
+// that were already copied, or that collide with an idempotency key or an unconfirmed nonce of TXM, are skipped.
+// The copied transactions that are not final yet are marked as fatal in evm.txes, so the legacy TXM does not send them
+// again if TXM is disabled later.
+func (s *PostgresStore) migrateLegacyTransactions(ctx context.Context, address common.Address) error {
+	var skipped []struct {
+		ID    int64
+		Nonce int64
+	}
+	err := s.ds.SelectContext(ctx, &skipped, `SELECT e.id, e.nonce FROM evm.txes e
+WHERE e.evm_chain_id = $1 AND e.from_address = $2 AND e.state IN ('in_progress', 'unconfirmed', 'confirmed_missing_receipt')
+AND NOT EXISTS (SELECT 1 FROM evm.txm_transactions t WHERE t.legacy_tx_id = e.id)
+AND EXISTS (SELECT 1 FROM evm.txm_transactions t
+	WHERE t.evm_chain_id = e.evm_chain_id AND t.from_address = e.from_address AND t.nonce = e.nonce AND t.state = 'unconfirmed')
+ORDER BY e.id`, s.chainID.String(), address)
+	if err != nil {
+		return err
+	}
+	for _, tx := range skipped {
+		s.lggr.Warnw("Skipped migrating legacy transaction: TXM has an unconfirmed transaction with the same nonce",
+			"address", address, "legacyTxID", tx.ID, "nonce", tx.Nonce)
+	}
+
+	var migrated struct {
+		Transactions int
+		Attempts     int
+		Retired      int
+	}
+	err = s.ds.GetContext(ctx, &migrated, `WITH migrated AS (
+	INSERT INTO evm.txm_transactions (evm_chain_id, idempotency_key, nonce, from_address, to_address, value, data,
+		specified_gas_limit, created_at, initial_broadcast_at, last_broadcast_at, state, meta, subject, pipeline_task_run_id,
+		min_confirmations, signal_callback, callback_completed, legacy_tx_id)
+	SELECT e.evm_chain_id, e.idempotency_key, e.nonce, e.from_address, e.to_address, e.value, e.encoded_payload,
+		e.gas_limit, e.created_at, e.initial_broadcast_at, e.broadcast_at,
+		CASE WHEN e.state IN ('in_progress', 'confirmed_missing_receipt') THEN 'unconfirmed' ELSE e.state END,
+		e.meta, e.subject, e.pipeline_task_run_id, e.min_confirmations, COALESCE(e.signal_callback, false),
+		COALESCE(e.callback_completed, false), e.id
+	FROM evm.txes e
+	WHERE e.evm_chain_id = $1 AND e.from_address = $2
+	AND (e.state IN ('unstarted', 'in_progress', 'unconfirmed', 'confirmed_missing_receipt') OR e.id IN (
+		SELECT h.id FROM evm.txes h
+		WHERE h.evm_chain_id = $1 AND h.from_address = $2 AND h.state IN ('confirmed', 'finalized', 'fatal_error')
+		ORDER BY h.id DESC LIMIT $3))
+	AND NOT EXISTS (SELECT 1 FROM evm.txm_transactions t WHERE t.legacy_tx_id = e.id)
+	AND (e.idempotency_key IS NULL OR NOT EXISTS (
+		SELECT 1 FROM evm.txm_transactions t WHERE t.evm_chain_id = e.evm_chain_id AND t.idempotency_key = e.idempotency_key))
+	AND (e.state NOT IN ('in_progress', 'unconfirmed', 'confirmed_missing_receipt') OR NOT EXISTS (
+		SELECT 1 FROM evm.txm_transactions t
+		WHERE t.evm_chain_id = e.evm_chain_id AND t.from_address = e.from_address AND t.nonce = e.nonce AND t.state = 'unconfirmed'))
+	ORDER BY e.id
+	RETURNING id, legacy_tx_id
+), attempts AS (
+	INSERT INTO evm.txm_attempts (tx_id, hash, gas_price, gas_tip_cap, gas_fee_cap, gas_limit, tx_type, signed_raw_tx,
+		created_at, broadcast_at)
+	SELECT m.id, a.hash, a.gas_price, a.gas_tip_cap, a.gas_fee_cap, a.chain_specific_gas_limit, a.tx_type, a.signed_raw_tx,
+		a.created_at, CASE WHEN a.state = 'broadcast' THEN e.broadcast_at END
+	FROM migrated m
+	JOIN evm.txes e ON e.id = m.legacy_tx_id
+	JOIN evm.tx_attempts a ON a.eth_tx_id = e.id
+	ORDER BY a.id
+	RETURNING id
+), retired AS (
+	UPDATE evm.txes e SET state = 'fatal_error', error = 'migrated to txmv2'
+	WHERE e.evm_chain_id = $1 AND e.from_address = $2
+	AND e.state IN ('unstarted', 'in_progress', 'unconfirmed', 'confirmed_missing_receipt')
+	AND (e.id IN (SELECT legacy_tx_id FROM migrated) OR EXISTS (SELECT 1 FROM evm.txm_transactions t WHERE t.legacy_tx_id = e.id))
+	RETURNING e.id
+)
+SELECT (SELECT count(*) FROM migrated) AS transactions, (SELECT count(*) FROM attempts) AS attempts,
+	(SELECT count(*) FROM retired) AS retired`,
+		s.chainID.String(), address, legacyHistoryLimit)
+	if err != nil {
+		return err
+	}
+	if migrated.Transactions > 0 || migrated.Retired > 0 {
+		s.lggr.Infow("Migrated legacy transactions", "address", address, "transactions", migrated.Transactions, "attempts", migrated.Attempts, "retired", migrated.Retired)
+	}
+	return nil
+}
+
+// recoverInFlightTransactions resets the attempt count of the unconfirmed transactions, the same way a restart does for
+// the InMemoryStore, so TXM can retry them.
+func (s *PostgresStore) recoverInFlightTransactions(ctx context.Context, address common.Address) error {
+	res, err := s.ds.ExecContext(ctx, `UPDATE evm.txm_transactions SET attempt_count = 0
+WHERE evm_chain_id = $1 AND from_address = $2 AND state = 'unconfirmed'`, s.chainID.String(), address)
+	if err != nil {
+		return err
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		s.lggr.Infow("Recovered in-flight transactions", "address", address, "count", count)
+	}
+	return nil
+}
+
+func (s *PostgresStore) AppendAttemptToTransaction(ctx context.Context, txNonce uint64, fromAddress common.Address, attempt *types.Attempt) error {
+	var signedRawTx []byte
+	if attempt.SignedTransaction != nil {
+		buf := new(bytes.Buffer)
+		if err := attempt.SignedTransaction.EncodeRLP(buf); err != nil {
+			return fmt.Errorf("failed to encode signed transaction of txID: %v: %w", attempt.TxID, err)
+		}
+		signedRawTx = buf.Bytes()
+	}
+
+	return s.Transact(ctx, func(store *PostgresStore) error {
+		var txID uint64
+		err := store.ds.GetContext(ctx, &txID, selectUnconfirmedTxIDAtNonce+` FOR UPDATE`, s.chainID.String(), fromAddress, txNonce)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("unconfirmed tx was not found for nonce: %d - txID: %v", txNonce, attempt.TxID)
+		} else if err != nil {
+			return err
+		}
+		if txID != attempt.TxID {
+			return fmt.Errorf("unconfirmed tx with nonce exists but attempt points to a different txID. Found TxID: %v - txID: %v", txID, attempt.TxID)
+		}
+
+		createdAt := time.Now()
+		var attemptID uint64
+		err = store.ds.GetContext(ctx, &attemptID, `INSERT INTO evm.txm_attempts (tx_id, hash, gas_price, gas_tip_cap, gas_fee_cap,
+	gas_limit, tx_type, signed_raw_tx, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id`,
+			txID, attempt.Hash, attempt.Fee.GasPrice, attempt.Fee.GasTipCap, attempt.Fee.GasFeeCap,
+			attempt.GasLimit, attempt.Type, signedRawTx, createdAt)
+		if err != nil {
+			return err
+		}
+		if _, err = store.ds.ExecContext(ctx, `UPDATE evm.txm_transactions SET attempt_count = attempt_count + 1 WHERE id = $1`, txID); err != nil {
+			return err
+		}
+		attempt.ID = attemptID
+		attempt.CreatedAt = createdAt
+		return nil
+	})
+}
+
+func (s *PostgresStore) CountUnstartedTransactionsByPriority(ctx context.Context, fromAddress common.Address) (map[types.Priority]int, error) {
+	var rows []struct {
+		Priority types.Priority
+		Count    int
+	}
+	err := s.ds.SelectContext(ctx, &rows, `SELECT priority, count(*) FROM evm.txm_transactions
+WHERE evm_chain_id = $1 AND from_address = $2 AND state = 'unstarted' GROUP BY priority`, s.chainID.String(), fromAddress)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[types.Priority]int, len(types.Priorities))
+	for _, p := range types.Priorities {
+		counts[p] = 0
+	}
+	for _, row := range rows {
+		counts[row.Priority] = row.Count
+	}
+	return counts, nil
+}
+
+func (s *PostgresStore) CreateEmptyUnconfirmedTransaction(ctx context.Context, fromAddress common.Address, nonce uint64, gasLimit uint64) (tx *types.Transaction, err error) {
+	err = s.Transact(ctx, func(store *PostgresStore) error {
+		existing, err := store.selectTransactions(ctx, `SELECT * FROM evm.txm_transactions
+WHERE evm_chain_id = $1 AND from_address = $2 AND nonce = $3 AND state IN ('unconfirmed', 'confirmed')
+ORDER BY state LIMIT 1`, s.chainID.String(), fromAddress, nonce)
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 && existing[0].State == txmgr.TxUnconfirmed {
+			return fmt.Errorf("an unconfirmed tx with the same nonce already exists: %v", existing[0])
+		} else if len(existing) > 0 {
+			return fmt.Errorf("a confirmed tx with the same nonce already exists: %v", existing[0])
+		}
+
+		tx, err = store.insertTransaction(ctx, &dbTransaction{
+			Nonce:             &nonce,
+			FromAddress:       fromAddress,
+			Value:             *ubig.NewI(0),
+			Data:              []byte{},
+			SpecifiedGasLimit: gasLimit,
+			State:             txmgr.TxUnconfirmed,
+		})
+		return err
+	})
+	return
+}
+
+func (s *PostgresStore) CreateTransaction(ctx context.Context, txRequest *types.TxRequest) (tx *types.Transaction, err error) {
+	value := big.NewInt(0)
+	if txRequest.Value != nil {
+		value = txRequest.Value
+	}
+	data := txRequest.Data
+	if data == nil {
+		data = []byte{}
+	}
+
+	err = s.Transact(ctx, func(store *PostgresStore) error {
+		// Make room for the new transaction by dropping the oldest unstarted transactions of the lowest priority over the
+		// limit, unless the new transaction would be outranked by all of them.
+		var queue struct {
+			Queued    int
+			Droppable int
+		}
+		err := store.ds.GetContext(ctx, &queue, `SELECT count(*) AS queued, count(*) FILTER (WHERE priority <= $3) AS droppable
+FROM evm.txm_transactions WHERE evm_chain_id = $1 AND from_address = $2 AND state = 'unstarted'`, s.chainID.String(), txRequest.FromAddress, txRequest.Priority)
+		if err != nil {
+			return err
+		}
+		if toDrop := queue.Queued - maxQueuedTransactions + 1; toDrop > 0 && queue.Droppable < toDrop {
+			return fmt.Errorf("%w: address: %v, limit: %d, priority: %v", ErrUnstartedQueueFull, txRequest.FromAddress, maxQueuedTransactions, txRequest.Priority)
+		}
+
+		var droppedTxIDs []uint64
+		err = store.ds.SelectContext(ctx, &droppedTxIDs, `UPDATE evm.txm_transactions SET state = 'fatal_error' WHERE id IN (
+	SELECT id FROM evm.txm_transactions WHERE evm_chain_id = $1 AND from_address = $2 AND state = 'unstarted'
+	ORDER BY priority DESC, id DESC OFFSET $3
+) RETURNING id`, s.chainID.String(), txRequest.FromAddress, maxQueuedTransactions-1)
+		if err != nil {
+			return err
+		}
+		if len(droppedTxIDs) > 0 {
+			s.lggr.Warnw(fmt.Sprintf("Unstarted transactions queue for address: %v reached max limit of: %d. Dropping oldest transactions of the lowest priority", txRequest.FromAddress, maxQueuedTransactions),
+				"txIDs", droppedTxIDs)
+		}
+
+		tx, err = store.insertTransaction(ctx, &dbTransaction{
+			IdempotencyKey:    txRequest.IdempotencyKey,
+			FromAddress:       txRequest.FromAddress,
+			ToAddress:         txRequest.ToAddress,
+			Value:             *ubig.New(value),
+			Data:              data,
+			SpecifiedGasLimit: txRequest.SpecifiedGasLimit,
+			Priority:          txRequest.Priority,
+			State:             txmgr.TxUnstarted,
+			Meta:              txRequest.Meta,
+			PipelineTaskRunID: txRequest.PipelineTaskRunID,
+			MinConfirmations:  txRequest.MinConfirmations,
+			SignalCallback:    txRequest.SignalCallback,
+		})
+		return err
+	})
+	return
+}
+
+func (s *PostgresStore) insertTransaction(ctx context.Context, dbTx *dbTransaction) (*types.Transaction, error) {
+	dbTx.EVMChainID = *ubig.New(s.chainID)
+	dbTx.CreatedAt = time.Now()
+	err := s.ds.GetContext(ctx, dbTx, `INSERT INTO evm.txm_transactions (evm_chain_id, idempotency_key, nonce, from_address,
+	to_address, value, data, specified_gas_limit, created_at, state, meta, subject, pipeline_task_run_id, min_confirmations,
+	signal_callback, priority)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16) RETURNING *`,
+		dbTx.EVMChainID, dbTx.IdempotencyKey, dbTx.Nonce, dbTx.FromAddress, dbTx.ToAddress, dbTx.Value, dbTx.Data,
+		dbTx.SpecifiedGasLimit, dbTx.CreatedAt, dbTx.State, dbTx.Meta, dbTx.Subject, dbTx.PipelineTaskRunID,
+		dbTx.MinConfirmations, dbTx.SignalCallback, dbTx.Priority)
+	if err != nil {
+		return nil, err
+	}
+	return dbTx.toTransaction(), nil
+}
+
+// selectTransactions returns the transactions matching the query, along with their attempts.
+func (s *PostgresStore) selectTransactions(ctx context.Context, query string, args ...any) ([]*types.Transaction, error) {
+	var dbTxs []dbTransaction
+	if err := s.ds.SelectContext(ctx, &dbTxs, query, args...); err != nil {
+		return nil, err
+	}
+	if len(dbTxs) == 0 {
+		return nil, nil
+	}
+
+	txs := make([]*types.Transaction, len(dbTxs))
+	txsByID := make(map[uint64]*types.Transaction, len(dbTxs))
+	txIDs := make([]uint64, len(dbTxs))
+	for i := range dbTxs {
+		txs[i] = dbTxs[i].toTransaction()
+		txsByID[txs[i].ID] = txs[i]
+		txIDs[i] = txs[i].ID
+	}
+
+	var dbAttempts []dbAttempt
+	if err := s.ds.SelectContext(ctx, &dbAttempts, `SELECT * FROM evm.txm_attempts WHERE tx_id = ANY($1) ORDER BY id`, pq.Array(txIDs)); err != nil {
+		return nil, err
+	}
+	for i := range dbAttempts {
+		attempt, err := dbAttempts[i].toAttempt()
+		if err != nil {
+			return nil, err
+		}
+		tx := txsByID[attempt.TxID]
+		tx.Attempts = append(tx.Attempts, attempt)
+	}
+	return txs, nil
+}
+
+func (s *PostgresStore) FetchUnconfirmedTransactionAtNonceWithCount(ctx context.Context, nonce uint64, fromAddress common.Address) (tx *types.Transaction, count int, err error) {
+	err = s.Transact(ctx, func(store *PostgresStore) error {
+		if err := store.ds.GetContext(ctx, &count, `SELECT count(*) FROM evm.txm_transactions
+WHERE evm_chain_id = $1 AND from_address = $2 AND state = 'unconfirmed'`, s.chainID.String(), fromAddress); err != nil {
+			return err
+		}
+		txs, err := store.selectTransactions(ctx, `SELECT * FROM evm.txm_transactions
+WHERE evm_chain_id = $1 AND from_address = $2 AND nonce = $3 AND state = 'unconfirmed'`, s.chainID.String(), fromAddress, nonce)
+		if len(txs) > 0 {
+			tx = txs[0]
+		}
+		return err
+	})
+	return
+}
+
+func (s *PostgresStore) MarkConfirmedAndReorgedTransactions(ctx context.Context, latestNonce uint64, fromAddress common.Address) (confirmedTxs []*types.Transaction, unconfirmedTxIDs []uint64, err error) {
+	err = s.Transact(ctx, func(store *PostgresStore) error {
+		var err error
+		confirmedTxs, err = store.selectTransactions(ctx, `UPDATE evm.txm_transactions SET state = 'confirmed'
+WHERE evm_chain_id = $1 AND from_address = $2 AND state = 'unconfirmed' AND nonce < $3 RETURNING *`, s.chainID.String(), fromAddress, latestNonce)
+		if err != nil {
+			return err
+		}
+
+		// Only the latest confirmed transaction of a nonce can be re-orged, and only if TXM hasn't already assigned the
+		// nonce to another unconfirmed transaction.
+		err = store.ds.SelectContext(ctx, &unconfirmedTxIDs, `UPDATE evm.txm_transactions SET state = 'unconfirmed', last_broadcast_at = NULL
+WHERE id IN (
+	SELECT DISTINCT ON (c.nonce) c.id FROM evm.txm_transactions c
+	WHERE c.evm_chain_id = $1 AND c.from_address = $2 AND c.state = 'confirmed' AND c.nonce >= $3
+	AND NOT EXISTS (
+		SELECT 1 FROM evm.txm_transactions u
+		WHERE u.evm_chain_id = c.evm_chain_id AND u.from_address = c.from_address AND u.nonce = c.nonce AND u.state = 'unconfirmed')
+	ORDER BY c.nonce, c.id DESC
+) RETURNING id`, s.chainID.String(), fromAddress, latestNonce)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Slice(confirmedTxs, func(i, j int) bool { return confirmedTxs[i].ID < confirmedTxs[j].ID })
+	sort.Slice(unconfirmedTxIDs, func(i, j int) bool { return unconfirmedTxIDs[i] < unconfirmedTxIDs[j] })
+	return confirmedTxs, unconfirmedTxIDs, nil
+}
+
+func (s *PostgresStore) MarkUnconfirmedTransactionPurgeable(ctx context.Context, nonce uint64, fromAddress common.Address) error {
+	res, err := s.ds.ExecContext(ctx, `UPDATE evm.txm_transactions SET is_purgeable = true
+WHERE evm_chain_id = $1 AND from_address = $2 AND nonce = $3 AND state = 'unconfirmed'`, s.chainID.String(), fromAddress, nonce)
+	if err != nil {
+		return err
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return err
+	} else if count == 0 {
+		return fmt.Errorf("unconfirmed tx with nonce: %d was not found", nonce)
+	}
+	return nil
+}
+
+func (s *PostgresStore) UpdateTransactionBroadcast(ctx context.Context, txID uint64, txNonce uint64, attemptHash common.Hash, fromAddress common.Address) error {
+	return s.Transact(ctx, func(store *PostgresStore) error {
+		// Set the same time for both the tx and its attempt
+		now := time.Now()
+		var unconfirmedTxID uint64
+		err := store.ds.GetContext(ctx, &unconfirmedTxID, `UPDATE evm.txm_transactions
+SET last_broadcast_at = $4, initial_broadcast_at = COALESCE(initial_broadcast_at, $4)
+WHERE evm_chain_id = $1 AND from_address = $2 AND nonce = $3 AND state = 'unconfirmed' RETURNING id`,
+			s.chainID.String(), fromAddress, txNonce, now)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("unconfirmed tx was not found for nonce: %d - txID: %v", txNonce, txID)
+		} else if err != nil {
+			return err
+		}
+
+		res, err := store.ds.ExecContext(ctx, `UPDATE evm.txm_attempts SET broadcast_at = $3 WHERE tx_id = $1 AND hash = $2`,
+			unconfirmedTxID, attemptHash, now)
+		if err != nil {
+			return err
+		}
+		if count, err := res.RowsAffected(); err != nil {
+			return err
+		} else if count == 0 {
+			return fmt.Errorf("UpdateTransactionBroadcast failed to find attempt. %w", fmt.Errorf("attempt with hash: %v was not found", attemptHash))
+		}
+		return nil
+	})
+}
+
+func (s *PostgresStore) UpdateUnstartedTransactionWithNonce(ctx context.Context, fromAddress common.Address, nonce uint64) (tx *types.Transaction, err error) {
+	err = s.Transact(ctx, func(store *PostgresStore) error {
+		var unstartedTxID uint64
+		err := store.ds.GetContext(ctx, &unstartedTxID, `SELECT id FROM evm.txm_transactions
+WHERE evm_chain_id = $1 AND from_address = $2 AND state = 'unstarted' ORDER BY priority DESC, id LIMIT 1 FOR UPDATE`, s.chainID.String(), fromAddress)
+		if errors.Is(err, sql.ErrNoRows) {
+			s.lggr.Debugf("Unstarted transactions queue is empty for address: %v", fromAddress)
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		existing, err := store.selectTransactions(ctx, `SELECT * FROM evm.txm_transactions
+WHERE evm_chain_id = $1 AND from_address = $2 AND nonce = $3 AND state = 'unconfirmed'`, s.chainID.String(), fromAddress, nonce)
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			return fmt.Errorf("an unconfirmed tx with the same nonce already exists: %v", existing[0])
+		}
+
+		var dbTx dbTransaction
+		err = store.ds.GetContext(ctx, &dbTx, `UPDATE evm.txm_transactions SET nonce = $2, state = 'unconfirmed' WHERE id = $1 RETURNING *`,
+			unstartedTxID, nonce)
+		if err != nil {
+			return err
+		}
+		tx = dbTx.toTransaction()
+		return nil
+	})
+	return
+}
+
+// Error Handler
+func (s *PostgresStore) DeleteAttemptForUnconfirmedTx(ctx context.Context, transactionNonce uint64, attempt *types.Attempt, fromAddress common.Address) error {
+	return s.Transact(ctx, func(store *PostgresStore) error {
+		var txID uint64
+		err := store.ds.GetContext(ctx, &txID, selectUnconfirmedTxIDAtNonce, s.chainID.String(), fromAddress, transactionNonce)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("unconfirmed tx was not found for nonce: %d - txID: %v", transactionNonce, attempt.TxID)
+		} else if err != nil {
+			return err
+		}
+
+		res, err := store.ds.ExecContext(ctx, `DELETE FROM evm.txm_attempts WHERE tx_id = $1 AND hash = $2`, txID, attempt.Hash)
+		if err != nil {
+			return err
+		}
+		if count, err := res.RowsAffected(); err != nil {
+			return err
+		} else if count == 0 {
+			return fmt.Errorf("attempt with hash: %v for txID: %v was not found", attempt.Hash, attempt.TxID)
+		}
+		return nil
+	})
+}
+
+func (s *PostgresStore) MarkTxFatal(ctx context.Context, tx *types.Transaction, fromAddress common.Address) error {
+	res, err := s.ds.ExecContext(ctx, `UPDATE evm.txm_transactions SET state = 'fatal_error'
+WHERE id = $1 AND evm_chain_id = $2 AND from_address = $3`, tx.ID, s.chainID.String(), fromAddress)
+	if err != nil {
+		return err
+	}
+	if count, err := res.RowsAffected(); err != nil {
+		return err
+	} else if count == 0 {
+		return fmt.Errorf("tx with txID: %v was not found for address: %v", tx.ID, fromAddress)
+	}
+	tx.State = txmgr.TxFatalError
+	return nil
+}
+
+// Orchestrator
+func (s *PostgresStore) FindTxWithIdempotencyKey(ctx context.Context, idempotencyKey string) (*types.Transaction, error) {
+	txs, err := s.selectTransactions(ctx, `SELECT * FROM evm.txm_transactions WHERE evm_chain_id = $1 AND idempotency_key = $2`,
+		s.chainID.String(), idempotencyKey)
+	if err != nil || len(txs) == 0 {
+		return nil, err
+	}
+	return txs[0], nil
+}
+
+func (s *PostgresStore) FindTxesByMetaFieldAndStates(ctx context.Context, metaField string, metaValue string, states []txmgrtypes.TxState) ([]*types.Transaction, error) {
+	return s.selectTransactions(ctx, `SELECT * FROM evm.txm_transactions
+WHERE evm_chain_id = $1 AND meta->>$2::text = $3 AND state = ANY($4) ORDER BY id`, s.chainID.String(), metaField, metaValue, pq.Array(states))
+}
+
+func (s *PostgresStore) FindTxesWithMetaFieldByStates(ctx context.Context, metaField string, states []txmgrtypes.TxState) ([]*types.Transaction, error) {
+	return s.selectTransactions(ctx, `SELECT * FROM evm.txm_transactions
+WHERE evm_chain_id = $1 AND meta->$2::text IS NOT NULL AND state = ANY($3) ORDER BY id`, s.chainID.String(), metaField, pq.Array(states))
+}
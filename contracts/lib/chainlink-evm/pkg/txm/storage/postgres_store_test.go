@@ -0,0 +1,284 @@
+package storage_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
+	"github.com/smartcontractkit/chainlink-evm/pkg/gas"
+	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	"github.com/smartcontractkit/chainlink-evm/pkg/txm/storage"
+	"github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
+	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr/txmgrtest"
+	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
+)
+
+func TestPostgresStore_TransactionLifecycle(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	s := storage.NewPostgresStore(testutils.NewSqlxDB(t), logger.Test(t), testutils.FixtureChainID)
+	fromAddress := testutils.NewAddress()
+	require.NoError(t, s.Add(ctx, fromAddress))
+
+	tx, err := s.CreateTransaction(ctx, &types.TxRequest{FromAddress: fromAddress, ToAddress: testutils.NewAddress(), Value: big.NewInt(10), SpecifiedGasLimit: 21000})
+	require.NoError(t, err)
+	assert.Equal(t, txmgr.TxUnstarted, tx.State)
+	assert.Equal(t, testutils.FixtureChainID, tx.ChainID)
+
+	tx, err = s.UpdateUnstartedTransactionWithNonce(ctx, fromAddress, 7)
+	require.NoError(t, err)
+	require.NotNil(t, tx)
+	assert.Equal(t, uint64(7), *tx.Nonce)
+	assert.Equal(t, txmgr.TxUnconfirmed, tx.State)
+
+	_, err = s.CreateEmptyUnconfirmedTransaction(ctx, fromAddress, 7, 21000)
+	require.ErrorContains(t, err, "an unconfirmed tx with the same nonce already exists")
+
+	signedTx := testutils.NewLegacyTransaction(7, tx.ToAddress, tx.Value, 21000, big.NewInt(1), nil)
+	attempt := &types.Attempt{TxID: tx.ID, Hash: signedTx.Hash(), Fee: gas.EvmFee{GasPrice: assets.NewWeiI(1)}, GasLimit: 21000, SignedTransaction: signedTx}
+	require.NoError(t, s.AppendAttemptToTransaction(ctx, 7, fromAddress, attempt))
+	require.NoError(t, s.UpdateTransactionBroadcast(ctx, tx.ID, 7, attempt.Hash, fromAddress))
+	require.ErrorContains(t, s.AppendAttemptToTransaction(ctx, 8, fromAddress, attempt), "unconfirmed tx was not found for nonce: 8")
+
+	tx, count, err := s.FetchUnconfirmedTransactionAtNonceWithCount(ctx, 7, fromAddress)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, tx.Attempts, 1)
+	assert.Equal(t, uint16(1), tx.AttemptCount)
+	assert.NotNil(t, tx.LastBroadcastAt)
+	assert.Equal(t, tx.LastBroadcastAt, tx.InitialBroadcastAt)
+	assert.Equal(t, tx.LastBroadcastAt, tx.Attempts[0].BroadcastAt)
+	assert.Equal(t, signedTx.Hash(), tx.Attempts[0].SignedTransaction.Hash())
+
+	confirmedTxs, unconfirmedTxIDs, err := s.MarkConfirmedAndReorgedTransactions(ctx, 8, fromAddress)
+	require.NoError(t, err)
+	require.Len(t, confirmedTxs, 1)
+	assert.Equal(t, tx.ID, confirmedTxs[0].ID)
+	assert.Empty(t, unconfirmedTxIDs)
+
+	// Re-org
+	confirmedTxs, unconfirmedTxIDs, err = s.MarkConfirmedAndReorgedTransactions(ctx, 7, fromAddress)
+	require.NoError(t, err)
+	assert.Empty(t, confirmedTxs)
+	assert.Equal(t, []uint64{tx.ID}, unconfirmedTxIDs)
+	tx, _, err = s.FetchUnconfirmedTransactionAtNonceWithCount(ctx, 7, fromAddress)
+	require.NoError(t, err)
+	assert.Nil(t, tx.LastBroadcastAt)
+
+	require.NoError(t, s.DeleteAttemptForUnconfirmedTx(ctx, 7, attempt, fromAddress))
+	require.ErrorContains(t, s.DeleteAttemptForUnconfirmedTx(ctx, 7, attempt, fromAddress), "was not found")
+
+	require.NoError(t, s.MarkTxFatal(ctx, tx, fromAddress))
+	assert.Equal(t, txmgr.TxFatalError, tx.State)
+	_, count, err = s.FetchUnconfirmedTransactionAtNonceWithCount(ctx, 7, fromAddress)
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
+
+func TestPostgresStore_Priority(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	s := storage.NewPostgresStore(testutils.NewSqlxDB(t), logger.Test(t), testutils.FixtureChainID)
+	fromAddress := testutils.NewAddress()
+	require.NoError(t, s.Add(ctx, fromAddress))
+
+	var created []*types.Transaction
+	for _, priority := range []types.Priority{types.PriorityLow, types.PriorityNormal, types.PriorityHigh, types.PriorityHigh} {
+		tx, err := s.CreateTransaction(ctx, &types.TxRequest{FromAddress: fromAddress, ToAddress: testutils.NewAddress(), Priority: priority})
+		require.NoError(t, err)
+		assert.Equal(t, priority, tx.Priority)
+		created = append(created, tx)
+	}
+
+	counts, err := s.CountUnstartedTransactionsByPriority(ctx, fromAddress)
+	require.NoError(t, err)
+	assert.Equal(t, map[types.Priority]int{types.PriorityHigh: 2, types.PriorityNormal: 1, types.PriorityLow: 1}, counts)
+
+	// The oldest transaction of the highest priority is picked first
+	for nonce, expected := range []*types.Transaction{created[2], created[3], created[1], created[0]} {
+		//nolint:gosec // this won't overflow
+		tx, err := s.UpdateUnstartedTransactionWithNonce(ctx, fromAddress, uint64(nonce))
+		require.NoError(t, err)
+		require.NotNil(t, tx)
+		assert.Equal(t, expected.ID, tx.ID)
+		assert.Equal(t, expected.Priority, tx.Priority)
+	}
+
+	counts, err = s.CountUnstartedTransactionsByPriority(ctx, fromAddress)
+	require.NoError(t, err)
+	assert.Equal(t, map[types.Priority]int{types.PriorityHigh: 0, types.PriorityNormal: 0, types.PriorityLow: 0}, counts)
+}
+
+func TestPostgresStore_CreateTransactionQueueFull(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	s := storage.NewPostgresStore(testutils.NewSqlxDB(t), logger.Test(t), testutils.FixtureChainID)
+	fromAddress := testutils.NewAddress()
+	require.NoError(t, s.Add(ctx, fromAddress))
+
+	var first *types.Transaction
+	for i := range storage.MaxQueuedTransactions {
+		tx, err := s.CreateTransaction(ctx, &types.TxRequest{FromAddress: fromAddress, ToAddress: testutils.NewAddress(), Priority: types.PriorityHigh})
+		require.NoError(t, err)
+		if i == 0 {
+			first = tx
+		}
+	}
+
+	_, err := s.CreateTransaction(ctx, &types.TxRequest{FromAddress: fromAddress, ToAddress: testutils.NewAddress(), Priority: types.PriorityLow})
+	require.ErrorIs(t, err, storage.ErrUnstartedQueueFull)
+	counts, err := s.CountUnstartedTransactionsByPriority(ctx, fromAddress)
+	require.NoError(t, err)
+	assert.Equal(t, map[types.Priority]int{types.PriorityHigh: storage.MaxQueuedTransactions, types.PriorityNormal: 0, types.PriorityLow: 0}, counts)
+
+	// A transaction of the same priority still replaces the oldest one
+	_, err = s.CreateTransaction(ctx, &types.TxRequest{FromAddress: fromAddress, ToAddress: testutils.NewAddress(), Priority: types.PriorityHigh})
+	require.NoError(t, err)
+	tx, err := s.UpdateUnstartedTransactionWithNonce(ctx, fromAddress, 0)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID+1, tx.ID)
+}
+
+func TestPostgresStore_Add(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	db := testutils.NewSqlxDB(t)
+	legacyTxStore := txmgrtest.NewTestTxStore(t, db)
+	fromAddress := testutils.NewAddress()
+
+	legacyUnconfirmed := txmgrtest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, legacyTxStore, 1, fromAddress)
+	legacyConfirmed := txmgrtest.MustInsertConfirmedEthTxWithLegacyAttempt(t, legacyTxStore, 0, 1, fromAddress)
+
+	s := storage.NewPostgresStore(db, logger.Test(t), testutils.FixtureChainID)
+	t.Run("migrates legacy transactions", func(t *testing.T) {
+		require.NoError(t, s.Add(ctx, fromAddress))
+
+		tx, count, err := s.FetchUnconfirmedTransactionAtNonceWithCount(ctx, 1, fromAddress)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		require.NotNil(t, tx)
+		assert.Equal(t, legacyUnconfirmed.EncodedPayload, tx.Data)
+		assert.Equal(t, legacyUnconfirmed.FeeLimit, tx.SpecifiedGasLimit)
+		require.Len(t, tx.Attempts, 1)
+		assert.Equal(t, legacyUnconfirmed.TxAttempts[0].Hash, tx.Attempts[0].Hash)
+		assert.NotNil(t, tx.Attempts[0].BroadcastAt)
+		assert.NotNil(t, tx.Attempts[0].SignedTransaction)
+
+		legacyTx, err := legacyTxStore.FindTxWithAttempts(ctx, legacyUnconfirmed.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.TxFatalError, legacyTx.State)
+		assert.Equal(t, "migrated to txmv2", legacyTx.Error.String)
+		legacyTx, err = legacyTxStore.FindTxWithAttempts(ctx, legacyConfirmed.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgr.TxConfirmed, legacyTx.State)
+
+		confirmedTxs, unconfirmedTxIDs, err := s.MarkConfirmedAndReorgedTransactions(ctx, 2, fromAddress)
+		require.NoError(t, err)
+		require.Len(t, confirmedTxs, 1)
+		assert.Equal(t, uint64(1), *confirmedTxs[0].Nonce)
+		assert.Empty(t, unconfirmedTxIDs)
+
+		_, err = s.CreateEmptyUnconfirmedTransaction(ctx, fromAddress, uint64(*legacyConfirmed.Sequence), 21000)
+		require.ErrorContains(t, err, "a confirmed tx with the same nonce already exists")
+	})
+
+	t.Run("skips transactions that were already migrated", func(t *testing.T) {
+		require.NoError(t, s.Add(ctx, fromAddress))
+
+		var count int
+		require.NoError(t, db.GetContext(ctx, &count, `SELECT count(*) FROM evm.txm_transactions WHERE from_address = $1`, fromAddress))
+		assert.Equal(t, 2, count)
+	})
+
+	t.Run("resets the attempt count of in-flight transactions", func(t *testing.T) {
+		tx, err := s.CreateEmptyUnconfirmedTransaction(ctx, fromAddress, 2, 21000)
+		require.NoError(t, err)
+		attempt := &types.Attempt{TxID: tx.ID, Hash: testutils.NewHash(), Fee: gas.EvmFee{GasPrice: assets.NewWeiI(1)}, GasLimit: 21000}
+		require.NoError(t, s.AppendAttemptToTransaction(ctx, 2, fromAddress, attempt))
+
+		require.NoError(t, s.Add(ctx, fromAddress))
+		tx, _, err = s.FetchUnconfirmedTransactionAtNonceWithCount(ctx, 2, fromAddress)
+		require.NoError(t, err)
+		assert.Zero(t, tx.AttemptCount)
+		assert.Len(t, tx.Attempts, 1)
+	})
+}
+
+func TestPostgresStore_AddMigratesRecentHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	db := testutils.NewSqlxDB(t)
+	legacyTxStore := txmgrtest.NewTestTxStore(t, db)
+	fromAddress := testutils.NewAddress()
+
+	for nonce := int64(0); nonce <= storage.LegacyHistoryLimit; nonce++ {
+		txmgrtest.MustInsertConfirmedEthTxWithLegacyAttempt(t, legacyTxStore, nonce, 1, fromAddress)
+	}
+	txmgrtest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, legacyTxStore, storage.LegacyHistoryLimit+1, fromAddress)
+
+	s := storage.NewPostgresStore(db, logger.Test(t), testutils.FixtureChainID)
+	require.NoError(t, s.Add(ctx, fromAddress))
+
+	var nonces []uint64
+	require.NoError(t, db.SelectContext(ctx, &nonces, `SELECT nonce FROM evm.txm_transactions WHERE from_address = $1 ORDER BY nonce`, fromAddress))
+	require.Len(t, nonces, storage.LegacyHistoryLimit+1)
+	assert.Equal(t, uint64(1), nonces[0], "the oldest confirmed transaction is left behind")
+	assert.Equal(t, uint64(storage.LegacyHistoryLimit+1), nonces[len(nonces)-1])
+}
+
+func TestPostgresStore_FindTxes(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	s := storage.NewPostgresStore(testutils.NewSqlxDB(t), logger.Test(t), testutils.FixtureChainID)
+	fromAddress := testutils.NewAddress()
+	require.NoError(t, s.Add(ctx, fromAddress))
+
+	requestID := testutils.NewHash()
+	meta := sqlutil.JSON(fmt.Sprintf(`{"RequestID": "%s"}`, requestID.Hex()))
+	idempotencyKey := uuid.NewString()
+	tx, err := s.CreateTransaction(ctx, &types.TxRequest{IdempotencyKey: &idempotencyKey, FromAddress: fromAddress, ToAddress: testutils.NewAddress(), Meta: &meta})
+	require.NoError(t, err)
+
+	found, err := s.FindTxWithIdempotencyKey(ctx, idempotencyKey)
+	require.NoError(t, err)
+	assert.Equal(t, tx.ID, found.ID)
+
+	found, err = s.FindTxWithIdempotencyKey(ctx, uuid.NewString())
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	txs, err := s.FindTxesByMetaFieldAndStates(ctx, "RequestID", requestID.Hex(), []txmgrtypes.TxState{txmgr.TxUnstarted})
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, tx.ID, txs[0].ID)
+
+	txs, err = s.FindTxesByMetaFieldAndStates(ctx, "RequestID", requestID.Hex(), []txmgrtypes.TxState{txmgr.TxConfirmed})
+	require.NoError(t, err)
+	assert.Empty(t, txs)
+
+	txs, err = s.FindTxesWithMetaFieldByStates(ctx, "RequestID", []txmgrtypes.TxState{txmgr.TxUnstarted})
+	require.NoError(t, err)
+	assert.Contains(t, txIDs(txs), tx.ID)
+}
+
+func txIDs(txs []*types.Transaction) (ids []uint64) {
+	for _, tx := range txs {
+		ids = append(ids, tx.ID)
+	}
+	return
+}
@@ -35,6 +35,7 @@ type Client interface {
 type TxStore interface {
 	AbandonPendingTransactions(context.Context, common.Address) error
 	AppendAttemptToTransaction(context.Context, uint64, common.Address, *types.Attempt) error
+	CountUnstartedTransactionsByPriority(context.Context, common.Address) (map[types.Priority]int, error)
 	CreateEmptyUnconfirmedTransaction(context.Context, common.Address, uint64, uint64) (*types.Transaction, error)
 	CreateTransaction(context.Context, *types.TxRequest) (*types.Transaction, error)
 	FetchUnconfirmedTransactionAtNonceWithCount(context.Context, uint64, common.Address) (*types.Transaction, int, error)
@@ -152,12 +153,44 @@ func (t *Txm) initializeNonce(ctx context.Context, address common.Address) {
 			}
 			continue
 		}
-		t.setNonce(address, pendingNonce)
-		t.lggr.Debugf("Set initial nonce for address: %v to %d", address, pendingNonce)
+		nonce, err := t.skipInFlightNonces(ctx, address, pendingNonce)
+		if err != nil {
+			t.lggr.Errorw("Error when recovering in-flight transactions", "address", address, "err", err)
+			select {
+			case <-time.After(pendingNonceRecheckInterval):
+			case <-ctx.Done():
+				t.lggr.Errorw("context error", "err", context.Cause(ctx))
+				return
+			}
+			continue
+		}
+		t.setNonce(address, nonce)
+		t.lggr.Debugf("Set initial nonce for address: %v to %d", address, nonce)
 		return
 	}
 }
 
+// skipInFlightNonces returns the first nonce after pendingNonce that isn't held by an unconfirmed transaction
+// of the store. A persistent store can contain transactions that got a nonce but never reached the RPC before
+// a restart, so their nonces must not be reused. The backfill loop rebroadcasts them.
+func (t *Txm) skipInFlightNonces(ctx context.Context, address common.Address, pendingNonce uint64) (uint64, error) {
+	nonce := pendingNonce
+	for {
+		tx, _, err := t.txStore.FetchUnconfirmedTransactionAtNonceWithCount(ctx, nonce, address)
+		if err != nil {
+			return 0, err
+		}
+		if tx == nil {
+			break
+		}
+		nonce++
+	}
+	if nonce != pendingNonce {
+		t.lggr.Infow("Recovered in-flight transactions", "address", address, "pendingNonce", pendingNonce, "nextNonce", nonce)
+	}
+	return nonce, nil
+}
+
 func (t *Txm) Close() error {
 	return t.StopOnce("Txm", func() error {
 		close(t.stopCh)
@@ -233,6 +266,7 @@ func (t *Txm) broadcastLoop(address common.Address, triggerCh chan struct{}) {
 		} else {
 			t.lggr.Debug("Transaction broadcasting time elapsed: ", time.Since(start))
 		}
+		t.recordQueueDepth(ctx, address)
 		if bo {
 			broadcastCh = time.After(broadcastWithBackoff.Duration())
 		} else {
@@ -328,11 +362,25 @@ func (t *Txm) createAndSendAttempt(ctx context.Context, tx *types.Transaction, a
 	if err != nil {
 		return err
 	}
+	return t.appendAndSendAttempt(ctx, tx, attempt, address)
+}
+
+// createAndSendBumpAttempt rebroadcasts tx with a fee bumped over its latest attempt. If the fee can't be bumped,
+// i.e. it already reached the max price, it falls back to a new attempt with a fresh estimation.
+func (t *Txm) createAndSendBumpAttempt(ctx context.Context, tx *types.Transaction, address common.Address) error {
+	attempt, err := t.attemptBuilder.NewBumpAttempt(ctx, t.lggr, tx, *tx.Attempts[len(tx.Attempts)-1])
+	if err != nil {
+		t.lggr.Warnw("Failed to bump attempt, creating a new one instead", "txID", tx.ID, "err", err)
+		return t.createAndSendAttempt(ctx, tx, address)
+	}
+	return t.appendAndSendAttempt(ctx, tx, attempt, address)
+}
 
+func (t *Txm) appendAndSendAttempt(ctx context.Context, tx *types.Transaction, attempt *types.Attempt, address common.Address) error {
 	if tx.Nonce == nil {
 		return fmt.Errorf("nonce for txID: %v is empty", tx.ID)
 	}
-	if err = t.txStore.AppendAttemptToTransaction(ctx, *tx.Nonce, address, attempt); err != nil {
+	if err := t.txStore.AppendAttemptToTransaction(ctx, *tx.Nonce, address, attempt); err != nil {
 		return err
 	}
 
@@ -422,15 +470,39 @@ func (t *Txm) backfillTransactions(ctx context.Context, address common.Address)
 				tx.PrintWithAttempts())
 		}
 
-		if tx.LastBroadcastAt == nil || time.Since(*tx.LastBroadcastAt) > (t.config.BlockTime*time.Duration(t.config.RetryBlockThreshold)) {
-			// TODO: add optional graceful bumping strategy
+		if tx.LastBroadcastAt == nil || time.Since(*tx.LastBroadcastAt) > t.retryThreshold(tx) {
 			t.lggr.Info("Rebroadcasting attempt for txID: ", tx.ID)
+			if tx.Priority == types.PriorityHigh && len(tx.Attempts) > 0 {
+				// TODO: add optional graceful bumping strategy for the rest of the priorities
+				return false, t.createAndSendBumpAttempt(ctx, tx, address)
+			}
 			return false, t.createAndSendAttempt(ctx, tx, address)
 		}
 	}
 	return false, nil
 }
 
+// retryThreshold is the time to wait for tx to be confirmed before rebroadcasting it. High priority transactions
+// are rebroadcasted after half the blocks.
+func (t *Txm) retryThreshold(tx *types.Transaction) time.Duration {
+	blocks := t.config.RetryBlockThreshold
+	if tx.Priority == types.PriorityHigh {
+		blocks = (blocks + 1) / 2
+	}
+	return t.config.BlockTime * time.Duration(blocks)
+}
+
+func (t *Txm) recordQueueDepth(ctx context.Context, address common.Address) {
+	counts, err := t.txStore.CountUnstartedTransactionsByPriority(ctx, address)
+	if err != nil {
+		t.lggr.Errorw("Failed to count unstarted transactions", "address", address, "err", err)
+		return
+	}
+	for priority, count := range counts {
+		t.metrics.RecordNumUnstartedTxs(ctx, address, priority, count)
+	}
+}
+
 func (t *Txm) createAndSendEmptyTx(ctx context.Context, latestNonce uint64, address common.Address) error {
 	tx, err := t.txStore.CreateEmptyUnconfirmedTransaction(ctx, address, latestNonce, t.config.EmptyTxLimitDefault)
 	if err != nil {
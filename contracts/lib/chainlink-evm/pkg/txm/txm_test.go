@@ -37,7 +37,7 @@ func TestLifecycle(t *testing.T) {
 		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
 		config := Config{BlockTime: 1 * time.Minute}
 		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
-		require.NoError(t, txStore.Add(address1))
+		require.NoError(t, txStore.Add(t.Context(), address1))
 		keystore := keystest.Addresses{address1}
 		txm := NewTxm(lggr, testutils.FixtureChainID, client, nil, txStore, nil, config, keystore)
 		client.On("PendingNonceAt", mock.Anything, address1).Return(uint64(0), errors.New("error")).Once()
@@ -47,12 +47,29 @@ func TestLifecycle(t *testing.T) {
 		tests.AssertLogEventually(t, observedLogs, fmt.Sprintf("Set initial nonce for address: %v to %d", address1, 100))
 	})
 
+	t.Run("skips the nonces of in-flight transactions found in the store", func(t *testing.T) {
+		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
+		config := Config{BlockTime: 1 * time.Minute}
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
+		require.NoError(t, txStore.Add(t.Context(), address1))
+		for _, nonce := range []uint64{100, 101} {
+			_, err := txStore.CreateEmptyUnconfirmedTransaction(t.Context(), address1, nonce, 0)
+			require.NoError(t, err)
+		}
+		keystore := keystest.Addresses{address1}
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, nil, txStore, nil, config, keystore)
+		client.On("PendingNonceAt", mock.Anything, address1).Return(uint64(100), nil).Once()
+		servicetest.Run(t, txm)
+		tests.AssertLogEventually(t, observedLogs, "Recovered in-flight transactions")
+		tests.AssertLogEventually(t, observedLogs, fmt.Sprintf("Set initial nonce for address: %v to %d", address1, 102))
+	})
+
 	t.Run("tests lifecycle successfully without any transactions", func(t *testing.T) {
 		config := Config{BlockTime: 200 * time.Millisecond}
 		keystore := keystest.Addresses(addresses)
 		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
 		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
-		require.NoError(t, txStore.Add(addresses...))
+		require.NoError(t, txStore.Add(t.Context(), addresses...))
 		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, config, keystore)
 		var nonce uint64
 		// Start
@@ -82,7 +99,7 @@ func TestTrigger(t *testing.T) {
 	t.Run("executes Trigger", func(t *testing.T) {
 		lggr := logger.Test(t)
 		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
-		require.NoError(t, txStore.Add(address))
+		require.NoError(t, txStore.Add(t.Context(), address))
 		client := newMockClient(t)
 		ab := newMockAttemptBuilder(t)
 		config := Config{BlockTime: 1 * time.Minute, RetryBlockThreshold: 10}
@@ -161,7 +178,7 @@ func TestBroadcastTransaction(t *testing.T) {
 	t.Run("returns if there are no unstarted transactions", func(t *testing.T) {
 		lggr := logger.Test(t)
 		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
-		require.NoError(t, txStore.Add(address))
+		require.NoError(t, txStore.Add(t.Context(), address))
 		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, config, keystore)
 		bo, err := txm.broadcastTransaction(ctx, address)
 		require.NoError(t, err)
@@ -172,7 +189,7 @@ func TestBroadcastTransaction(t *testing.T) {
 	t.Run("picks a new tx and creates a new attempt then sends it and updates the broadcast time", func(t *testing.T) {
 		lggr := logger.Test(t)
 		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
-		require.NoError(t, txStore.Add(address))
+		require.NoError(t, txStore.Add(t.Context(), address))
 		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, config, keystore)
 		txm.setNonce(address, 8)
 		metrics, err := NewTxmMetrics(testutils.FixtureChainID)
@@ -244,7 +261,7 @@ func TestBackfillTransactions(t *testing.T) {
 	t.Run("fills nonce gap", func(t *testing.T) {
 		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
 		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
-		require.NoError(t, txStore.Add(address))
+		require.NoError(t, txStore.Add(t.Context(), address))
 		ab := newMockAttemptBuilder(t)
 		c := Config{EIP1559: false, BlockTime: 10 * time.Minute, RetryBlockThreshold: 10, EmptyTxLimitDefault: 22000}
 		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, c, keystore)
@@ -286,7 +303,7 @@ func TestBackfillTransactions(t *testing.T) {
 	t.Run("retries attempt after threshold", func(t *testing.T) {
 		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
 		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
-		require.NoError(t, txStore.Add(address))
+		require.NoError(t, txStore.Add(t.Context(), address))
 		ab := newMockAttemptBuilder(t)
 		c := Config{EIP1559: false, BlockTime: 1 * time.Second, RetryBlockThreshold: 1, EmptyTxLimitDefault: 22000}
 		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, c, keystore)
@@ -321,4 +338,79 @@ func TestBackfillTransactions(t *testing.T) {
 		require.NoError(t, err)
 		tests.AssertLogEventually(t, observedLogs, fmt.Sprintf("Rebroadcasting attempt for txID: %d", attempt.TxID))
 	})
+
+	t.Run("bumps high priority transaction after half the threshold", func(t *testing.T) {
+		lggr, observedLogs := logger.TestObserved(t, zap.DebugLevel)
+		txStore := storage.NewInMemoryStoreManager(lggr, testutils.FixtureChainID)
+		require.NoError(t, txStore.Add(t.Context(), address))
+		ab := newMockAttemptBuilder(t)
+		c := Config{EIP1559: false, BlockTime: 1 * time.Second, RetryBlockThreshold: 10, EmptyTxLimitDefault: 22000}
+		txm := NewTxm(lggr, testutils.FixtureChainID, client, ab, txStore, nil, c, keystore)
+		emptyMetrics, err := NewTxmMetrics(testutils.FixtureChainID)
+		require.NoError(t, err)
+		txm.metrics = emptyMetrics
+
+		txRequest := &types.TxRequest{
+			ChainID:           testutils.FixtureChainID,
+			FromAddress:       address,
+			ToAddress:         testutils.NewAddress(),
+			SpecifiedGasLimit: 22000,
+			Priority:          types.PriorityHigh,
+		}
+		tx, err := txm.CreateTransaction(t.Context(), txRequest)
+		require.NoError(t, err)
+		_, err = txStore.UpdateUnstartedTransactionWithNonce(t.Context(), address, 0)
+		require.NoError(t, err)
+		attempt := &types.Attempt{
+			TxID:     tx.ID,
+			Hash:     testutils.NewHash(),
+			Fee:      gas.EvmFee{GasPrice: assets.NewWeiI(1)},
+			GasLimit: 22000,
+		}
+		require.NoError(t, txStore.AppendAttemptToTransaction(t.Context(), 0, address, attempt))
+		// Broadcasted 6 blocks ago, which is over the high priority threshold of 5 blocks
+		lastBroadcastAt := time.Now().Add(-6 * time.Second)
+		txStore.InMemoryStoreMap[address].UnconfirmedTransactions[0].LastBroadcastAt = &lastBroadcastAt
+
+		bumpedAttempt := &types.Attempt{
+			TxID:     tx.ID,
+			Hash:     testutils.NewHash(),
+			Fee:      gas.EvmFee{GasPrice: assets.NewWeiI(2)},
+			GasLimit: 22000,
+		}
+		ab.On("NewBumpAttempt", mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(a types.Attempt) bool {
+			return a.Hash == attempt.Hash
+		})).Return(bumpedAttempt, nil).Once()
+		client.On("NonceAt", mock.Anything, address, mock.Anything).Return(uint64(0), nil).Once()
+		client.On("SendTransaction", mock.Anything, mock.Anything, bumpedAttempt).Return(nil).Once()
+		_, err = txm.backfillTransactions(t.Context(), address)
+		require.NoError(t, err)
+		tests.AssertLogEventually(t, observedLogs, fmt.Sprintf("Rebroadcasting attempt for txID: %d", tx.ID))
+		tx, _, err = txStore.FetchUnconfirmedTransactionAtNonceWithCount(t.Context(), 0, address)
+		require.NoError(t, err)
+		assert.Len(t, tx.Attempts, 2)
+	})
+}
+
+func TestRetryThreshold(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		retryBlockThreshold uint16
+		priority            types.Priority
+		expected            time.Duration
+	}{
+		{10, types.PriorityLow, 10 * time.Second},
+		{10, types.PriorityNormal, 10 * time.Second},
+		{10, types.PriorityHigh, 5 * time.Second},
+		{3, types.PriorityHigh, 2 * time.Second},
+		{1, types.PriorityHigh, 1 * time.Second},
+		{0, types.PriorityHigh, 0},
+	} {
+		t.Run(fmt.Sprintf("%d blocks %s priority", tc.retryBlockThreshold, tc.priority), func(t *testing.T) {
+			c := Config{BlockTime: 1 * time.Second, RetryBlockThreshold: tc.retryBlockThreshold}
+			txm := NewTxm(logger.Test(t), testutils.FixtureChainID, nil, nil, nil, nil, c, keystest.Addresses{})
+			assert.Equal(t, tc.expected, txm.retryThreshold(&types.Transaction{Priority: tc.priority}))
+		})
+	}
 }
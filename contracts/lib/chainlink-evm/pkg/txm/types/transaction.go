@@ -30,6 +30,7 @@ type Transaction struct {
 	Value             *big.Int
 	Data              []byte
 	SpecifiedGasLimit uint64
+	Priority          Priority
 
 	CreatedAt          time.Time
 	InitialBroadcastAt *time.Time
@@ -52,10 +53,10 @@ type Transaction struct {
 
 func (t *Transaction) String() string {
 	return fmt.Sprintf(`{txID:%d, IdempotencyKey:%v, ChainID:%v, Nonce:%s, FromAddress:%v, ToAddress:%v, Value:%v, `+
-		`Data:%s, SpecifiedGasLimit:%d, CreatedAt:%v, InitialBroadcastAt:%v, LastBroadcastAt:%v, State:%v, IsPurgeable:%v, AttemptCount:%d, `+
+		`Data:%s, SpecifiedGasLimit:%d, Priority:%v, CreatedAt:%v, InitialBroadcastAt:%v, LastBroadcastAt:%v, State:%v, IsPurgeable:%v, AttemptCount:%d, `+
 		`Meta:%v, Subject:%v}`,
 		t.ID, stringOrNull(t.IdempotencyKey), t.ChainID, stringOrNull(t.Nonce), t.FromAddress, t.ToAddress, t.Value,
-		base64.StdEncoding.EncodeToString(t.Data), t.SpecifiedGasLimit, t.CreatedAt, stringOrNull(t.InitialBroadcastAt), stringOrNull(t.LastBroadcastAt),
+		base64.StdEncoding.EncodeToString(t.Data), t.SpecifiedGasLimit, t.Priority, t.CreatedAt, stringOrNull(t.InitialBroadcastAt), stringOrNull(t.LastBroadcastAt),
 		t.State, t.IsPurgeable, t.AttemptCount, t.Meta, t.Subject)
 }
 
@@ -140,6 +141,8 @@ type TxRequest struct {
 	Value             *big.Int
 	Data              []byte
 	SpecifiedGasLimit uint64
+	// Priority defaults to PriorityNormal.
+	Priority Priority
 
 	Meta             *sqlutil.JSON // TODO: *TxMeta after migration
 	ForwarderAddress common.Address
@@ -151,6 +154,57 @@ type TxRequest struct {
 	SignalCallback    bool
 }
 
+// Priority is the class a transaction is queued in. Unstarted transactions of a higher class are broadcasted
+// before those of a lower one, regardless of when they were created. Transactions of the same class are
+// broadcasted in the order they were created.
+type Priority int8
+
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	// PriorityHigh transactions are also rebroadcasted with a bumped fee sooner than the rest.
+	PriorityHigh Priority = 1
+)
+
+// Priorities lists every priority class, from the highest to the lowest.
+var Priorities = []Priority{PriorityHigh, PriorityNormal, PriorityLow}
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityNormal:
+		return "normal"
+	case PriorityHigh:
+		return "high"
+	default:
+		return fmt.Sprintf("Priority(%d)", int8(p))
+	}
+}
+
+// PriorityStrategy is a TxStrategy that also sets the priority class of the transactions created with it. It lets
+// callers of the TxManager interface, whose TxRequest has no priority of its own, request a priority from TXM.
+type PriorityStrategy interface {
+	commontypes.TxStrategy
+	Priority() Priority
+}
+
+type priorityStrategy struct {
+	commontypes.TxStrategy
+	priority Priority
+}
+
+// WithPriority wraps strategy so the transactions created with it are queued with the given priority. The legacy
+// transaction manager honours it too: its broadcaster sends the highest priority unstarted transaction of a key first
+// and its confirmer bumps high priority transactions sooner. The queue depth limits and metrics are TXMv2 only.
+func WithPriority(strategy commontypes.TxStrategy, priority Priority) PriorityStrategy {
+	return &priorityStrategy{TxStrategy: strategy, priority: priority}
+}
+
+func (s *priorityStrategy) Priority() Priority {
+	return s.priority
+}
+
 type TxMeta struct {
 	// Pipeline
 	JobID        *int32    `json:"JobID,omitempty"`
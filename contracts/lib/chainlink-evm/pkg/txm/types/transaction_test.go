@@ -5,11 +5,13 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
 )
 
 func TestTransaction_GetMeta(t *testing.T) {
@@ -109,3 +111,12 @@ func TestTransaction_GetMeta(t *testing.T) {
 }
 
 func ptr[T any](t T) *T { return &t }
+
+func TestWithPriority(t *testing.T) {
+	t.Parallel()
+
+	subject := uuid.NullUUID{UUID: uuid.New(), Valid: true}
+	strategy := WithPriority(txmgr.NewQueueingTxStrategy(subject.UUID, 3), PriorityHigh)
+	assert.Equal(t, PriorityHigh, strategy.Priority())
+	assert.Equal(t, subject, strategy.Subject())
+}
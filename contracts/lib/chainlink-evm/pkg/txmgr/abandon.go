@@ -0,0 +1,65 @@
+package txmgr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
+)
+
+// TxAbandoner is implemented by the transaction managers that can give up on a single transaction, for callers
+// whose transaction became useless before it was confirmed, e.g. because the event it responds to was reorged out.
+type TxAbandoner interface {
+	// AbandonTx gives up on the transaction. An unstarted transaction is marked as fatal right away. An unconfirmed
+	// transaction already holds its nonce, so it gets a purge attempt instead: an empty transaction reusing its nonce
+	// with a bumped fee, which the Confirmer broadcasts on the next head. The transaction is marked as fatal once the
+	// purge attempt is included. Transactions in any other state are left as they are, and so is a transaction whose
+	// bump the Confirmer is already broadcasting. It reports whether the transaction was abandoned.
+	AbandonTx(ctx context.Context, txID int64) (bool, error)
+}
+
+// evmTxm is the EVM transaction manager, extended with TxAbandoner.
+type evmTxm struct {
+	*Txm
+	txStore          *evmTxStore
+	txAttemptBuilder TxAttemptBuilder
+	lggr             logger.SugaredLogger
+}
+
+var _ TxAbandoner = (*evmTxm)(nil)
+
+func (t *evmTxm) AbandonTx(ctx context.Context, txID int64) (bool, error) {
+	abandoned, err := t.txStore.abandonUnstartedTx(ctx, txID)
+	if err != nil {
+		return false, fmt.Errorf("failed to abandon unstarted tx %d: %w", txID, err)
+	}
+	if abandoned {
+		t.lggr.Infow("Abandoned unstarted tx", "txID", txID)
+		return true, nil
+	}
+
+	etx, err := t.txStore.FindTxWithAttempts(ctx, txID)
+	if err != nil {
+		return false, fmt.Errorf("failed to load tx %d: %w", txID, err)
+	}
+	if etx.State != txmgr.TxUnconfirmed || len(etx.TxAttempts) == 0 || etx.HasPurgeAttempt() {
+		return false, nil
+	}
+	for _, attempt := range etx.TxAttempts {
+		if attempt.State == txmgrtypes.TxAttemptInProgress {
+			return false, nil
+		}
+	}
+	lggr := etx.GetLogger(t.lggr)
+	purgeAttempt, err := t.txAttemptBuilder.NewPurgeTxAttempt(ctx, etx, lggr)
+	if err != nil {
+		return false, fmt.Errorf("failed to create a purge attempt for tx %d: %w", txID, err)
+	}
+	if err = t.txStore.SaveInProgressAttempt(ctx, &purgeAttempt); err != nil {
+		return false, fmt.Errorf("failed to save the purge attempt of tx %d: %w", txID, err)
+	}
+	logger.Sugared(lggr).Infow("Abandoned unconfirmed tx, its nonce will be purged", "purgeAttemptHash", purgeAttempt.Hash)
+	return true, nil
+}
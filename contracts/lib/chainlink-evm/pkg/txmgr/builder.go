@@ -78,7 +78,12 @@ func NewTxm(
 	if txConfig.ResendAfterThreshold() > 0 {
 		evmResender = NewEvmResender(lggr, txStore, txmClient, evmTracker, keyStore, txmgr.DefaultResenderPollInterval, chainConfig, txConfig)
 	}
-	txm = NewEvmTxm(chainID, txmCfg, txConfig, keyStore, lggr, checker, fwdMgr, txAttemptBuilder, txStore, evmBroadcaster, evmConfirmer, evmResender, evmTracker, evmFinalizer, txmv2wrapper)
+	txm = &evmTxm{
+		Txm:              NewEvmTxm(chainID, txmCfg, txConfig, keyStore, lggr, checker, fwdMgr, txAttemptBuilder, txStore, evmBroadcaster, evmConfirmer, evmResender, evmTracker, evmFinalizer, txmv2wrapper),
+		txStore:          txStore,
+		txAttemptBuilder: txAttemptBuilder,
+		lggr:             logger.Sugared(logger.Named(lggr, "TxAbandoner")),
+	}
 	return txm, nil
 }
 
@@ -135,7 +140,15 @@ func NewTxmV2(
 	}
 
 	attemptBuilder := txm.NewAttemptBuilder(fCfg.PriceMaxKey, estimator, keyStore)
-	inMemoryStoreManager := storage.NewInMemoryStoreManager(lggr, chainID)
+	var txStore interface {
+		txm.TxStore
+		txm.OrchestratorTxStore
+	}
+	if txmV2Config.PersistentStore() != nil && *txmV2Config.PersistentStore() {
+		txStore = storage.NewPostgresStore(ds, lggr, chainID)
+	} else {
+		txStore = storage.NewInMemoryStoreManager(lggr, chainID)
+	}
 	config := txm.Config{
 		EIP1559:   fCfg.EIP1559DynamicFees(),
 		BlockTime: *txmV2Config.BlockTime(),
@@ -149,8 +162,8 @@ func NewTxmV2(
 	} else {
 		c = clientwrappers.NewChainClient(client)
 	}
-	t := txm.NewTxm(lggr, chainID, c, attemptBuilder, inMemoryStoreManager, stuckTxDetector, config, keyStore)
-	return txm.NewTxmOrchestrator(lggr, chainID, t, inMemoryStoreManager, fwdMgr, keyStore, attemptBuilder), nil
+	t := txm.NewTxm(lggr, chainID, c, attemptBuilder, txStore, stuckTxDetector, config, keyStore)
+	return txm.NewTxmOrchestrator(lggr, chainID, t, txStore, fwdMgr, keyStore, attemptBuilder), nil
 }
 
 // NewEvmResender creates a new concrete EvmResender
@@ -29,6 +29,7 @@ import (
 	"github.com/smartcontractkit/chainlink-evm/pkg/config/toml"
 	"github.com/smartcontractkit/chainlink-evm/pkg/gas"
 	"github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	txmtypes "github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr"
 	"github.com/smartcontractkit/chainlink-evm/pkg/types"
 	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
@@ -1038,6 +1039,26 @@ func TestORM_FindTxsRequiringGasBump(t *testing.T) {
 		assert.Len(t, etxs, 1)
 		assert.Equal(t, etx.ID, etxs[0].ID)
 	})
+
+	t.Run("bumps high priority txs after half the threshold", func(t *testing.T) {
+		fromAddress := testutils.NewAddress()
+		etx := mustInsertUnconfirmedEthTxWithAttemptState(t, txStore, 1, fromAddress, txmgrtypes.TxAttemptBroadcast)
+		highEtx := mustInsertUnconfirmedEthTxWithAttemptState(t, txStore, 2, fromAddress, txmgrtypes.TxAttemptBroadcast)
+		_, err := db.ExecContext(ctx, `UPDATE evm.txes SET priority = $1 WHERE id = $2`, txmtypes.PriorityHigh, highEtx.ID)
+		require.NoError(t, err)
+		require.NoError(t, txStore.SetBroadcastBeforeBlockNum(ctx, currentBlockNum, ethClient.ConfiguredChainID()))
+
+		etxs, err := txStore.FindTxsRequiringGasBump(ctx, fromAddress, currentBlockNum+2, int64(4), int64(0), ethClient.ConfiguredChainID())
+		require.NoError(t, err)
+		require.Len(t, etxs, 1)
+		assert.Equal(t, highEtx.ID, etxs[0].ID)
+
+		etxs, err = txStore.FindTxsRequiringGasBump(ctx, fromAddress, currentBlockNum+4, int64(4), int64(0), ethClient.ConfiguredChainID())
+		require.NoError(t, err)
+		require.Len(t, etxs, 2)
+		assert.Equal(t, etx.ID, etxs[0].ID)
+		assert.Equal(t, highEtx.ID, etxs[1].ID)
+	})
 }
 
 func TestEthConfirmer_FindTxsRequiringResubmissionDueToInsufficientEth(t *testing.T) {
@@ -1196,6 +1217,17 @@ func TestORM_FindNextUnstartedTransactionFromAddress(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, resultEtx)
 	})
+
+	t.Run("finds the highest priority unstarted tx first", func(t *testing.T) {
+		fromAddress := testutils.NewAddress()
+		mustCreateUnstartedGeneratedTx(t, txStore, fromAddress, testutils.FixtureChainID)
+		high := mustCreateUnstartedGeneratedTx(t, txStore, fromAddress, testutils.FixtureChainID,
+			txRequestWithStrategy(txmtypes.WithPriority(txmgrcommon.NewSendEveryStrategy(), txmtypes.PriorityHigh)))
+
+		resultEtx, err := txStore.FindNextUnstartedTransactionFromAddress(tests.Context(t), fromAddress, ethClient.ConfiguredChainID())
+		require.NoError(t, err)
+		assert.Equal(t, high.ID, resultEtx.ID)
+	})
 }
 
 func TestORM_UpdateTxFatalErrorAndDeleteAttempts(t *testing.T) {
@@ -625,6 +625,72 @@ func TestTxm_Reset(t *testing.T) {
 	})
 }
 
+func TestTxm_AbandonTx(t *testing.T) {
+	t.Parallel()
+
+	ctx := tests.Context(t)
+	db := testutils.NewSqlxDB(t)
+	txStore := txmgrtest.NewTestTxStore(t, db)
+	_, dbConfig, evmConfig := txmgr.MakeTestConfigs(t)
+	memKS := keystest.NewMemoryChainStore()
+	fromAddress := memKS.MustCreate(t)
+	ethKeyStore := keys.NewChainStore(memKS, testutils.FixtureChainID)
+
+	ethClient := clienttest.NewClientWithDefaultChainID(t)
+	estimator, err := gas.NewEstimator(logger.Test(t), ethClient, evmConfig.ChainType(), ethClient.ConfiguredChainID(), evmConfig.GasEstimator(), nil)
+	require.NoError(t, err)
+	txm, err := makeTestEvmTxm(t, db, ethClient, estimator, evmConfig, evmConfig.GasEstimator(), evmConfig.Transactions(), dbConfig, dbConfig.Listener(), ethKeyStore)
+	require.NoError(t, err)
+	abandoner, ok := txm.(txmgr.TxAbandoner)
+	require.True(t, ok)
+
+	t.Run("marks an unstarted tx as fatal", func(t *testing.T) {
+		tx := &txmgr.Tx{
+			FromAddress:    fromAddress,
+			ToAddress:      testutils.NewAddress(),
+			EncodedPayload: []byte{1, 2, 3},
+			FeeLimit:       21000,
+			State:          txmgrcommon.TxUnstarted,
+			ChainID:        testutils.FixtureChainID,
+		}
+		require.NoError(t, txStore.InsertTx(ctx, tx))
+
+		abandoned, err := abandoner.AbandonTx(ctx, tx.ID)
+		require.NoError(t, err)
+		assert.True(t, abandoned)
+
+		etx, err := txStore.FindTxWithAttempts(ctx, tx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgrcommon.TxFatalError, etx.State)
+	})
+
+	t.Run("purges the nonce of an unconfirmed tx", func(t *testing.T) {
+		tx := txmgrtest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, txStore, 0, fromAddress)
+
+		abandoned, err := abandoner.AbandonTx(ctx, tx.ID)
+		require.NoError(t, err)
+		assert.True(t, abandoned)
+
+		etx, err := txStore.FindTxWithAttempts(ctx, tx.ID)
+		require.NoError(t, err)
+		assert.Equal(t, txmgrcommon.TxUnconfirmed, etx.State)
+		require.True(t, etx.HasPurgeAttempt())
+		assert.Equal(t, txmgrtypes.TxAttemptInProgress, etx.TxAttempts[0].State)
+
+		abandoned, err = abandoner.AbandonTx(ctx, tx.ID)
+		require.NoError(t, err)
+		assert.False(t, abandoned, "purge attempt already in progress")
+	})
+
+	t.Run("leaves a confirmed tx", func(t *testing.T) {
+		tx := txmgrtest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 1, 1, fromAddress)
+
+		abandoned, err := abandoner.AbandonTx(ctx, tx.ID)
+		require.NoError(t, err)
+		assert.False(t, abandoned)
+	})
+}
+
 func TestTxm_GetTransactionFee(t *testing.T) {
 	t.Parallel()
 
@@ -291,6 +291,7 @@ func NewApp(s *Shell) *cli.App {
 				initEVMTxSubCmd(s),
 				initCosmosTxSubCmd(s),
 				initSolanaTxSubCmd(s),
+				initTxStatsSubCmd(s),
 			},
 		},
 		{
@@ -308,6 +309,16 @@ func NewApp(s *Shell) *cli.App {
 			Usage:       "Commands for managing forwarder addresses.",
 			Subcommands: initFowardersSubCmds(s),
 		},
+		{
+			Name:        "vrf",
+			Usage:       "Commands for managing VRF requests and billing.",
+			Subcommands: initVRFSubCmds(s),
+		},
+		{
+			Name:        "bhs",
+			Usage:       "Commands for managing the blockhashes stored for VRF requests.",
+			Subcommands: initBHSSubCmds(s),
+		},
 		{
 			Name:  "help-all",
 			Usage: "Shows a list of all commands and sub-commands",
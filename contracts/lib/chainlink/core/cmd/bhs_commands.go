@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	stderrors "errors"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initBHSSubCmds(s *Shell) []cli.Command {
+	return []cli.Command{
+		{
+			Name:   "backfill",
+			Usage:  "Store the blockhashes of unfulfilled VRF requests in a block range that are missing from the blockhash store",
+			Action: s.BackfillBlockhashes,
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "job-id",
+					Usage: "ID of the block header feeder job whose coordinators, blockhash stores and sending keys to use",
+				},
+				cli.Uint64Flag{
+					Name:  "from-block",
+					Usage: "first block to look for unfulfilled requests in",
+				},
+				cli.Uint64Flag{
+					Name:  "to-block",
+					Usage: "last block to look for unfulfilled requests in, defaults to the latest block",
+				},
+				cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "only plan the backfill and estimate its cost",
+				},
+			},
+		},
+	}
+}
+
+type BHSBackfillPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.BHSBackfillResource
+}
+
+var bhsBackfillHeaders = []string{"Job ID", "From Block", "To Block", "Latest Block", "Unfulfilled Requests",
+	"Missing Blocks", "Store Blocks", "Verified Headers", "Transactions", "From Address", "Estimated Gas",
+	"Gas Price (Wei)", "Estimated Cost (Wei)", "Dry Run"}
+
+// ToRow presents the BHSBackfillResource as a slice of strings.
+func (p *BHSBackfillPresenter) ToRow() []string {
+	var verified int
+	for _, batch := range p.VerifyHeaderBatches {
+		verified += len(batch)
+	}
+	var fromAddress, gasPrice, estimatedCost string
+	if p.Transactions > 0 {
+		fromAddress = p.FromAddress.Hex()
+	}
+	if p.GasPriceWei != nil {
+		gasPrice = p.GasPriceWei.String()
+	}
+	if p.EstimatedCostWei != nil {
+		estimatedCost = p.EstimatedCostWei.String()
+	}
+	return []string{
+		strconv.FormatInt(int64(p.JobID), 10),
+		strconv.FormatUint(p.FromBlock, 10),
+		strconv.FormatUint(p.ToBlock, 10),
+		strconv.FormatUint(p.LatestBlock, 10),
+		strconv.Itoa(p.UnfulfilledRequests),
+		formatBlocks(p.MissingBlocks),
+		formatBlocks(p.StoreBlocks),
+		strconv.Itoa(verified),
+		strconv.Itoa(p.Transactions),
+		fromAddress,
+		strconv.FormatUint(p.EstimatedGas, 10),
+		gasPrice,
+		estimatedCost,
+		strconv.FormatBool(p.DryRun),
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *BHSBackfillPresenter) RenderTable(rt RendererTable) error {
+	renderList(bhsBackfillHeaders, [][]string{p.ToRow()}, rt.Writer)
+	return nil
+}
+
+func formatBlocks(blocks []uint64) string {
+	s := make([]string, len(blocks))
+	for i, b := range blocks {
+		s[i] = strconv.FormatUint(b, 10)
+	}
+	return strings.Join(s, ", ")
+}
+
+// BackfillBlockhashes stores the blockhashes missing for the unfulfilled VRF requests in a
+// block range, or only plans it with --dry-run.
+func (s *Shell) BackfillBlockhashes(c *cli.Context) (err error) {
+	if !c.IsSet("job-id") {
+		return s.errorOut(errors.New("must pass the --job-id of a block header feeder job"))
+	}
+	if !c.IsSet("from-block") {
+		return s.errorOut(errors.New("must pass --from-block"))
+	}
+	request := web.BHSBackfillRequest{
+		JobID:     int32(c.Int("job-id")), //nolint:gosec // job IDs are int32
+		FromBlock: c.Uint64("from-block"),
+		DryRun:    c.Bool("dry-run"),
+	}
+	if c.IsSet("to-block") {
+		toBlock := c.Uint64("to-block")
+		request.ToBlock = &toBlock
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/bhs/backfill", bytes.NewReader(body))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = stderrors.Join(err, cerr)
+		}
+	}()
+
+	title := "Blockhash Backfill"
+	if request.DryRun {
+		title = "Blockhash Backfill (dry-run)"
+	}
+	return s.renderAPIResponse(resp, &BHSBackfillPresenter{}, title)
+}
@@ -0,0 +1,51 @@
+package cmd_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+	"github.com/smartcontractkit/chainlink/v2/core/cmd"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func TestBHSBackfillPresenter_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		fromAddress = utils.RandomAddress()
+		buffer      = bytes.NewBufferString("")
+		r           = cmd.RendererTable{Writer: buffer}
+	)
+
+	p := cmd.BHSBackfillPresenter{
+		BHSBackfillResource: presenters.BHSBackfillResource{
+			JAID:                presenters.NewPrefixedJAID("100-1000", "1"),
+			JobID:               1,
+			FromBlock:           100,
+			ToBlock:             1000,
+			LatestBlock:         1000,
+			UnfulfilledRequests: 2,
+			MissingBlocks:       []uint64{150, 155},
+			VerifyHeaderBatches: [][]uint64{{157, 156, 155}, {154, 153, 152}, {151, 150}},
+			Transactions:        3,
+			FromAddress:         fromAddress,
+			EstimatedGas:        423_000,
+			GasPriceWei:         big.NewI(2),
+			EstimatedCostWei:    big.NewI(846_000),
+			DryRun:              true,
+		},
+	}
+
+	require.NoError(t, p.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, "150, 155")
+	assert.Contains(t, output, "423000")
+	assert.Contains(t, output, "846000")
+	assert.Contains(t, output, fromAddress.Hex())
+}
@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net/url"
+	"strconv"
 
 	"github.com/urfave/cli"
 
@@ -65,6 +67,41 @@ func initEVMTxSubCmd(s *Shell) cli.Command {
 	}
 }
 
+func initTxStatsSubCmd(s *Shell) cli.Command {
+	return cli.Command{
+		Name:   "stats",
+		Usage:  "Show the gas spent, bump attempts, time to inclusion and revert rate of EVM transactions per job or key",
+		Action: s.ShowTransactionStats,
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "group-by",
+				Usage: "aggregate transactions per job or per key",
+				Value: "job",
+			},
+			cli.StringFlag{
+				Name:  "evm-chain-id",
+				Usage: "only report on this chain",
+			},
+			cli.StringFlag{
+				Name:  "job-id",
+				Usage: "only report on the transactions of this job",
+			},
+			cli.StringFlag{
+				Name:  "address",
+				Usage: "only report on the transactions sent by this key",
+			},
+			cli.StringFlag{
+				Name:  "from",
+				Usage: "start of the reporting window in RFC3339, defaults to 7 days before --to, at most 31 days before it",
+			},
+			cli.StringFlag{
+				Name:  "to",
+				Usage: "end of the reporting window in RFC3339, defaults to now",
+			},
+		},
+	}
+}
+
 type EthTxPresenter struct {
 	JAID
 	presenters.EthTxResource
@@ -210,3 +247,74 @@ func (s *Shell) SendEther(c *cli.Context) (err error) {
 	err = s.renderAPIResponse(resp, &EthTxPresenter{})
 	return err
 }
+
+type EthTxStatsPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.EthTxStatsResource
+}
+
+var ethTxStatsHeaders = []string{"Chain ID", "Job / Key", "Txs", "Included", "Reverted", "Fatal", "Pending",
+	"Gas Used", "Gas Cost (Wei)", "Avg Gas Price (Wei)", "Bump Attempts", "Avg Inclusion (s)", "Max Inclusion (s)", "Revert Rate"}
+
+// ToRow presents the EthTxStatsResource as a slice of strings.
+func (p *EthTxStatsPresenter) ToRow() []string {
+	group := "none"
+	switch {
+	case p.FromAddress != nil:
+		group = p.FromAddress.Hex()
+	case p.JobID != nil:
+		group = strconv.FormatInt(int64(*p.JobID), 10)
+	}
+	return []string{
+		p.EVMChainID.String(),
+		group,
+		strconv.FormatInt(p.Transactions, 10),
+		strconv.FormatInt(p.Included, 10),
+		strconv.FormatInt(p.Reverted, 10),
+		strconv.FormatInt(p.Fatal, 10),
+		strconv.FormatInt(p.Pending, 10),
+		strconv.FormatUint(p.GasUsed, 10),
+		p.GasCostWei.String(),
+		p.AvgEffectiveGasPrice.String(),
+		strconv.FormatInt(p.BumpAttempts, 10),
+		strconv.FormatFloat(p.AvgTimeToInclusionSeconds, 'f', 1, 64),
+		strconv.FormatFloat(p.MaxTimeToInclusionSeconds, 'f', 1, 64),
+		fmt.Sprintf("%.2f%%", p.RevertRate*100),
+	}
+}
+
+// EthTxStatsPresenters implements TableRenderer for a slice of EthTxStatsPresenter.
+type EthTxStatsPresenters []EthTxStatsPresenter
+
+// RenderTable implements TableRenderer
+func (ps EthTxStatsPresenters) RenderTable(rt RendererTable) error {
+	var rows [][]string
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+	renderList(ethTxStatsHeaders, rows, rt.Writer)
+	return nil
+}
+
+// ShowTransactionStats shows the cost and outcome of EVM transactions per job or key.
+func (s *Shell) ShowTransactionStats(c *cli.Context) (err error) {
+	query := url.Values{}
+	for flag, param := range map[string]string{"group-by": "groupBy", "evm-chain-id": "evmChainID", "job-id": "jobID",
+		"address": "address", "from": "from", "to": "to"} {
+		if v := c.String(flag); v != "" {
+			query.Set(param, v)
+		}
+	}
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/transactions/evm/stats?"+query.Encode(), nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = errors.Join(err, cerr)
+		}
+	}()
+
+	var presenters EthTxStatsPresenters
+	return s.renderAPIResponse(resp, &presenters, "EVM Transaction Stats")
+}
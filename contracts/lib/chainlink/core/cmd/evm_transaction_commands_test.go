@@ -1,6 +1,7 @@
 package cmd_test
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"math/big"
@@ -16,6 +17,7 @@ import (
 	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr/txmgrtest"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
 
 	"github.com/smartcontractkit/chainlink/v2/core/cmd"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
@@ -23,6 +25,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/evmtest"
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
 )
 
 func TestShell_IndexTransactions(t *testing.T) {
@@ -261,3 +264,41 @@ func TestShell_SendEther_From_Txm_WEI(t *testing.T) {
 	require.Len(t, attempts, 1)
 	assert.Equal(t, attempts[0].Hash, output.Hash)
 }
+
+func TestEthTxStatsPresenters_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		jobID  = int32(42)
+		buffer = bytes.NewBufferString("")
+		r      = cmd.RendererTable{Writer: buffer}
+	)
+
+	ps := cmd.EthTxStatsPresenters{{
+		EthTxStatsResource: presenters.EthTxStatsResource{
+			JAID:                      presenters.NewPrefixedJAID("42", "0"),
+			EVMChainID:                ubig.NewI(0),
+			JobID:                     &jobID,
+			Transactions:              4,
+			Included:                  3,
+			Reverted:                  1,
+			Pending:                   1,
+			GasUsed:                   300_000,
+			GasCostWei:                ubig.NewI(987654321),
+			AvgEffectiveGasPrice:      ubig.NewI(3292),
+			BroadcastAttempts:         6,
+			BumpAttempts:              2,
+			AvgTimeToInclusionSeconds: 12.5,
+			MaxTimeToInclusionSeconds: 30,
+			RevertRate:                1.0 / 3,
+		},
+	}}
+
+	require.NoError(t, ps.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, "987654321")
+	assert.Contains(t, output, "3292")
+	assert.Contains(t, output, "12.5")
+	assert.Contains(t, output, "33.33%")
+}
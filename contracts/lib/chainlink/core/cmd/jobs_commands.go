@@ -6,6 +6,8 @@ import (
 	stderrors "errors"
 	"fmt"
 	"io"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -40,6 +42,27 @@ func initJobsSubCmds(s *Shell) []cli.Command {
 			Usage:  "Create a job",
 			Action: s.CreateJob,
 		},
+		{
+			Name:   "update",
+			Usage:  "Update a running VRF job without restarting it",
+			Action: s.UpdateJob,
+		},
+		{
+			Name:   "lint",
+			Usage:  "Check a job spec's pipeline for errors without creating the job",
+			Action: s.LintJob,
+		},
+		{
+			Name:   "dryrun",
+			Usage:  "Run a job spec's pipeline once without creating the job, serving its I/O tasks from fixtures",
+			Action: s.DryRunJob,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "fixtures",
+					Usage: "path to a JSON file with the vars of the run and the responses of its http, bridge, ethcall, ethtx and estimategaslimit tasks",
+				},
+			},
+		},
 		{
 			Name:   "delete",
 			Usage:  "Delete a job",
@@ -50,6 +73,16 @@ func initJobsSubCmds(s *Shell) []cli.Command {
 			Usage:  "Trigger a job run",
 			Action: s.TriggerPipelineRun,
 		},
+		{
+			Name:        "runs",
+			Usage:       "Commands for replaying the pipeline runs of jobs",
+			Subcommands: initJobRunsSubCmds(s),
+		},
+		{
+			Name:        "templates",
+			Usage:       "Commands for managing the pipeline templates expanded by template tasks",
+			Subcommands: initPipelineTemplatesSubCmds(s),
+		},
 	}
 }
 
@@ -280,6 +313,195 @@ func (s *Shell) CreateJob(c *cli.Context) (err error) {
 	return err
 }
 
+// UpdateJob applies a new TOML to a running job, without restarting it.
+// Valid input is the job ID followed by a TOML string or a path to TOML file
+func (s *Shell) UpdateJob(c *cli.Context) (err error) {
+	if c.NArg() != 2 {
+		return s.errorOut(errors.New("must pass the job id and the TOML or filepath"))
+	}
+
+	tomlString, err := getTOMLString(c.Args().Get(1))
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	request, err := json.Marshal(web.UpdateJobRequest{
+		TOML: tomlString,
+	})
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Patch(s.ctx(), "/v2/jobs/"+c.Args().First(), bytes.NewReader(request))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = stderrors.Join(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &JobPresenter{}, "Job updated")
+}
+
+// JobLintPresenter wraps the JSONAPI Job Lint Resource and adds rendering functionality
+type JobLintPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.JobLintResource
+}
+
+// RenderTable implements TableRenderer
+func (p *JobLintPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Severity", "Line", "Task", "Message"})
+	for _, issue := range p.Issues {
+		line := ""
+		if issue.Line > 0 {
+			line = strconv.Itoa(issue.Line)
+		}
+		table.Append([]string{string(issue.Severity), line, issue.Task, issue.Message})
+	}
+
+	render("Lint Issues", table)
+	return nil
+}
+
+// LintJob statically checks the pipeline of a job spec without creating the job,
+// and fails if any errors are found. Warnings alone do not fail.
+// Valid input is a TOML string or a path to TOML file
+func (s *Shell) LintJob(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass in TOML or filepath"))
+	}
+
+	tomlString, err := getTOMLString(c.Args().First())
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	request, err := json.Marshal(web.LintJobRequest{
+		TOML: tomlString,
+	})
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/jobs/lint", bytes.NewReader(request))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = stderrors.Join(err, cerr)
+		}
+	}()
+
+	var p JobLintPresenter
+	if err = s.renderAPIResponse(resp, &p); err != nil {
+		return err
+	}
+	if !p.Valid {
+		return s.errorOut(errors.New("job spec has lint errors"))
+	}
+	return nil
+}
+
+// JobDryRunPresenter wraps the JSONAPI Job Dry Run Resource and adds rendering functionality
+type JobDryRunPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.JobDryRunResource
+}
+
+// RenderTable implements TableRenderer
+func (p *JobDryRunPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Task", "Type", "Inputs", "Output", "Error", "Fixture"})
+	for _, task := range p.Tasks {
+		inputs := make([]string, len(task.Inputs))
+		for i, input := range task.Inputs {
+			inputs[i] = jsonString(&input)
+		}
+		table.Append([]string{
+			task.DotID,
+			string(task.Type),
+			strings.Join(inputs, "\n"),
+			jsonString(&task.Output),
+			task.Error.ValueOrZero(),
+			strconv.FormatBool(task.Fixture),
+		})
+	}
+	render("Dry Run Tasks", table)
+
+	outputs := make([]string, len(p.Outputs))
+	for i, output := range p.Outputs {
+		if output != nil {
+			outputs[i] = *output
+		}
+	}
+	var fatalErrors []string
+	for _, e := range p.FatalErrors {
+		if e != nil {
+			fatalErrors = append(fatalErrors, *e)
+		}
+	}
+	table = rt.newTable([]string{"State", "Outputs", "Fatal Errors"})
+	table.Append([]string{string(p.State), strings.Join(outputs, "\n"), strings.Join(fatalErrors, "\n")})
+	render("Dry Run", table)
+	return nil
+}
+
+func jsonString(v json.Marshaler) string {
+	b, err := v.MarshalJSON()
+	if err != nil {
+		return err.Error()
+	}
+	return string(b)
+}
+
+// DryRunJob runs the pipeline of a job spec once without creating the job, with its http,
+// bridge, ethcall, ethtx and estimategaslimit tasks served from the --fixtures file.
+// Valid input is a TOML string or a path to TOML file
+func (s *Shell) DryRunJob(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass in TOML or filepath"))
+	}
+
+	tomlString, err := getTOMLString(c.Args().First())
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	var fixtures pipeline.DryRunFixtures
+	if path := c.String("fixtures"); path != "" {
+		b, rerr := os.ReadFile(path)
+		if rerr != nil {
+			return s.errorOut(rerr)
+		}
+		if err = json.Unmarshal(b, &fixtures); err != nil {
+			return s.errorOut(errors.Wrapf(err, "invalid fixtures file %s", path))
+		}
+	}
+
+	request, err := json.Marshal(web.DryRunJobRequest{
+		TOML:     tomlString,
+		Fixtures: fixtures,
+	})
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/jobs/dryrun", bytes.NewReader(request))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = stderrors.Join(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &JobDryRunPresenter{})
+}
+
 // DeleteJob deletes a job
 func (s *Shell) DeleteJob(c *cli.Context) error {
 	if !c.Args().Present() {
@@ -5,6 +5,7 @@ import (
 	_ "embed"
 	"flag"
 	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
@@ -453,6 +454,41 @@ func TestShell_DeleteJob(t *testing.T) {
 	requireJobsCount(t, app.JobORM(), 0)
 }
 
+func TestShell_UpdateJob(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplicationV2(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.Database.Listener.FallbackPollInterval = commonconfig.MustNewDuration(100 * time.Millisecond)
+		c.EVM[0].Enabled = ptr(true)
+		c.EVM[0].NonceAutoSync = ptr(false)
+		c.EVM[0].BalanceMonitor.Enabled = ptr(false)
+		c.EVM[0].GasEstimator.Mode = ptr("FixedPrice")
+	})
+	client, _ := app.NewShellAndRenderer()
+
+	fs := flag.NewFlagSet("", flag.ExitOnError)
+	flagSetApplyFromAction(client.CreateJob, fs, "")
+	require.NoError(t, fs.Parse([]string{getDirectRequestSpec()}))
+	require.NoError(t, client.CreateJob(cli.NewContext(nil, fs, nil)))
+
+	ctx := testutils.Context(t)
+	jobs, _, err := app.JobORM().FindJobs(ctx, 0, 1000)
+	require.NoError(t, err)
+	jobID := strconv.Itoa(int(jobs[0].ID))
+
+	// Must supply job id and TOML
+	set := flag.NewFlagSet("test", 0)
+	flagSetApplyFromAction(client.UpdateJob, set, "")
+	require.NoError(t, set.Parse([]string{jobID}))
+	require.Equal(t, "must pass the job id and the TOML or filepath", client.UpdateJob(cli.NewContext(nil, set, nil)).Error())
+
+	// Only VRF jobs can be updated in place
+	set = flag.NewFlagSet("test", 0)
+	flagSetApplyFromAction(client.UpdateJob, set, "")
+	require.NoError(t, set.Parse([]string{jobID, getDirectRequestSpec()}))
+	require.ErrorContains(t, client.UpdateJob(cli.NewContext(nil, set, nil)), "is not a VRF job")
+}
+
 func requireJobsCount(t *testing.T, orm job.ORM, expected int) {
 	ctx := testutils.Context(t)
 	jobs, _, err := orm.FindJobs(ctx, 0, 1000)
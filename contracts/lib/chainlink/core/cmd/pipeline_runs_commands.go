@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initJobRunsSubCmds(s *Shell) []cli.Command {
+	return []cli.Command{
+		{
+			Name:   "replay",
+			Usage:  "Run a finished pipeline run again with the same inputs, reusing the recorded results of the tasks before --from-task",
+			Action: s.ReplayPipelineRun,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "from-task",
+					Usage: "dot ID of the task to replay the run from, it and its descendants are executed again",
+				},
+			},
+		},
+	}
+}
+
+// PipelineRunReplayPresenter wraps the JSONAPI Pipeline Run Replay Resource and adds rendering functionality
+type PipelineRunReplayPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.PipelineRunReplayResource
+}
+
+// RenderTable implements TableRenderer
+func (p *PipelineRunReplayPresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Task", "Replayed", "Old", "New", "Changed"})
+	for _, task := range p.Tasks {
+		oldResult := taskResultString(task.OldOutput, task.OldError)
+		newResult := taskResultString(task.NewOutput, task.NewError)
+		table.Append([]string{
+			task.DotID,
+			strconv.FormatBool(task.Replayed),
+			oldResult,
+			newResult,
+			strconv.FormatBool(oldResult != newResult),
+		})
+	}
+	render("Replayed Tasks", table)
+
+	outputs := make([]string, len(p.Outputs))
+	for i, output := range p.Outputs {
+		if output != nil {
+			outputs[i] = *output
+		}
+	}
+	var fatalErrors []string
+	for _, e := range p.FatalErrors {
+		if e != nil {
+			fatalErrors = append(fatalErrors, *e)
+		}
+	}
+	table = rt.newTable([]string{"ID", "Replayed Run ID", "State", "Outputs", "Fatal Errors"})
+	table.Append([]string{p.GetID(), p.ReplayedRunID, string(p.State), strings.Join(outputs, "\n"), strings.Join(fatalErrors, "\n")})
+	render("Pipeline Run Replay", table)
+	return nil
+}
+
+// taskResultString presents the result of a task run as its output, or its error.
+func taskResultString(output, err *string) string {
+	if err != nil {
+		return "error: " + *err
+	}
+	if output != nil {
+		return *output
+	}
+	return ""
+}
+
+// ReplayPipelineRun runs a finished pipeline run again from the --from-task task and shows how
+// the results of its tasks changed.
+func (s *Shell) ReplayPipelineRun(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the ID of the pipeline run to be replayed"))
+	}
+	if c.String("from-task") == "" {
+		return s.errorOut(errors.New("must pass the --from-task to replay the run from"))
+	}
+	runID := c.Args().First()
+	if _, err = strconv.ParseInt(runID, 10, 64); err != nil {
+		return s.errorOut(fmt.Errorf("invalid pipeline run ID %q: %w", runID, err))
+	}
+
+	request, err := json.Marshal(web.ReplayPipelineRunRequest{FromTask: c.String("from-task")})
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/pipeline/runs/"+runID+"/replay", bytes.NewReader(request))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = errors.Join(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &PipelineRunReplayPresenter{}, "Pipeline run replayed")
+}
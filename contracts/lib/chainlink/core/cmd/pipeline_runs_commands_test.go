@@ -0,0 +1,59 @@
+package cmd_test
+
+import (
+	"flag"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/jsonserializable"
+	"github.com/smartcontractkit/chainlink/v2/core/cmd"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
+)
+
+func TestShell_ReplayPipelineRun(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	app := startNewApplicationV2(t, nil)
+	client, r := app.NewShellAndRenderer()
+
+	jb, err := webhook.ValidatedWebhookSpec(ctx, `
+type = "webhook"
+schemaVersion = 1
+externalJobID = "`+uuid.New().String()+`"
+observationSource = """
+double [type=multiply input="$(jobRun.requestBody)" times=2]
+triple [type=multiply input="$(double)" times=3]
+double -> triple
+"""
+`, app.GetExternalInitiatorManager())
+	require.NoError(t, err)
+	require.NoError(t, app.AddJobV2(ctx, &jb))
+	runID, err := app.RunWebhookJobV2(ctx, jb.ExternalJobID, "5", jsonserializable.JSONSerializable{})
+	require.NoError(t, err)
+
+	set := flag.NewFlagSet("test", 0)
+	flagSetApplyFromAction(client.ReplayPipelineRun, set, "runs")
+	require.NoError(t, set.Set("from-task", "triple"))
+	require.NoError(t, set.Parse([]string{strconv.FormatInt(runID, 10)}))
+	require.NoError(t, client.ReplayPipelineRun(cli.NewContext(nil, set, nil)))
+
+	require.Len(t, r.Renders, 1)
+	replay := r.Renders[0].(*cmd.PipelineRunReplayPresenter)
+	assert.Equal(t, strconv.FormatInt(runID, 10), replay.ReplayedRunID)
+	require.Len(t, replay.Tasks, 2)
+	assert.Equal(t, "triple", replay.Tasks[1].DotID)
+	assert.True(t, replay.Tasks[1].Replayed)
+	assert.Equal(t, replay.Tasks[1].OldOutput, replay.Tasks[1].NewOutput)
+
+	set = flag.NewFlagSet("test", 0)
+	flagSetApplyFromAction(client.ReplayPipelineRun, set, "runs")
+	require.NoError(t, set.Parse([]string{strconv.FormatInt(runID, 10)}))
+	require.ErrorContains(t, client.ReplayPipelineRun(cli.NewContext(nil, set, nil)), "must pass the --from-task")
+}
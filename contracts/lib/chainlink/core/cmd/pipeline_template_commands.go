@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/urfave/cli"
+
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initPipelineTemplatesSubCmds(s *Shell) []cli.Command {
+	return []cli.Command{
+		{
+			Name:   "list",
+			Usage:  "List all versions of all pipeline templates",
+			Action: s.ListPipelineTemplates,
+		},
+		{
+			Name:   "show",
+			Usage:  "Show a pipeline template",
+			Action: s.ShowPipelineTemplate,
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "version",
+					Usage: "version of the template to show, the latest one if not set",
+				},
+			},
+		},
+		{
+			Name:   "create",
+			Usage:  "Create a pipeline template, or a new version of an existing one, from a DOT file",
+			Action: s.CreatePipelineTemplate,
+		},
+		{
+			Name:   "delete",
+			Usage:  "Delete all versions of a pipeline template which no job uses",
+			Action: s.DeletePipelineTemplate,
+		},
+	}
+}
+
+// PipelineTemplatePresenter wraps the JSONAPI Pipeline Template Resource and adds rendering functionality
+type PipelineTemplatePresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.PipelineTemplateResource
+}
+
+// ToRow presents the PipelineTemplatePresenter as a slice of strings.
+func (p *PipelineTemplatePresenter) ToRow() []string {
+	return []string{
+		p.Name,
+		strconv.FormatInt(int64(p.Version), 10),
+		p.CreatedAt.String(),
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *PipelineTemplatePresenter) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Name", "Version", "Created"})
+	table.Append(p.ToRow())
+	render("Pipeline Template", table)
+
+	fmt.Fprintln(rt)
+	fmt.Fprintln(rt, p.DotDagSource)
+	return nil
+}
+
+// PipelineTemplatePresenters implements TableRenderer for a slice of PipelineTemplatePresenter.
+type PipelineTemplatePresenters []PipelineTemplatePresenter
+
+// RenderTable implements TableRenderer
+func (ps PipelineTemplatePresenters) RenderTable(rt RendererTable) error {
+	table := rt.newTable([]string{"Name", "Version", "Created"})
+	for _, p := range ps {
+		table.Append(p.ToRow())
+	}
+	render("Pipeline Templates", table)
+	return nil
+}
+
+// ListPipelineTemplates lists all versions of all pipeline templates.
+func (s *Shell) ListPipelineTemplates(_ *cli.Context) (err error) {
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/pipeline/templates")
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = errors.Join(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &PipelineTemplatePresenters{})
+}
+
+// ShowPipelineTemplate shows a version of a pipeline template.
+func (s *Shell) ShowPipelineTemplate(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the name of the pipeline template to be shown"))
+	}
+	path := "/v2/pipeline/templates/" + url.PathEscape(c.Args().First())
+	if c.IsSet("version") {
+		path += "?version=" + strconv.Itoa(c.Int("version"))
+	}
+	resp, err := s.HTTP.Get(s.ctx(), path)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = errors.Join(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &PipelineTemplatePresenter{})
+}
+
+// CreatePipelineTemplate stores the DOT in the given file as a new version of
+// the named pipeline template.
+func (s *Shell) CreatePipelineTemplate(c *cli.Context) (err error) {
+	if c.NArg() != 2 {
+		return s.errorOut(errors.New("must pass the name of the pipeline template and the path of its DOT file"))
+	}
+	buf, err := fromFile(c.Args().Get(1))
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	request, err := json.Marshal(web.CreatePipelineTemplateRequest{
+		Name:         c.Args().First(),
+		DotDagSource: buf.String(),
+	})
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/pipeline/templates", bytes.NewReader(request))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = errors.Join(err, cerr)
+		}
+	}()
+
+	return s.renderAPIResponse(resp, &PipelineTemplatePresenter{}, "Pipeline template created")
+}
+
+// DeletePipelineTemplate deletes all versions of a pipeline template.
+func (s *Shell) DeletePipelineTemplate(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the name of the pipeline template to be deleted"))
+	}
+	resp, err := s.HTTP.Delete(s.ctx(), "/v2/pipeline/templates/"+url.PathEscape(c.Args().First()))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = errors.Join(err, cerr)
+		}
+	}()
+	if _, err = s.parseResponse(resp); err != nil {
+		return s.errorOut(err)
+	}
+
+	fmt.Printf("Pipeline template %v deleted\n", c.Args().First())
+	return nil
+}
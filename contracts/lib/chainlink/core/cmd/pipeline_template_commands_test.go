@@ -0,0 +1,58 @@
+package cmd_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+
+	"github.com/smartcontractkit/chainlink/v2/core/cmd"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+)
+
+func TestShell_PipelineTemplates(t *testing.T) {
+	t.Parallel()
+
+	app := startNewApplicationV2(t, nil)
+	client, r := app.NewShellAndRenderer()
+
+	path := filepath.Join(t.TempDir(), "scale.dot")
+	require.NoError(t, os.WriteFile(path, []byte(`scale [type=multiply input="{{input}}" times=100]`), 0600))
+
+	// Create two versions
+	for range 2 {
+		set := flag.NewFlagSet("test", 0)
+		flagSetApplyFromAction(client.CreatePipelineTemplate, set, "templates")
+		require.NoError(t, set.Parse([]string{"cliScale", path}))
+		require.NoError(t, client.CreatePipelineTemplate(cli.NewContext(nil, set, nil)))
+	}
+	require.Len(t, r.Renders, 2)
+	created := r.Renders[1].(*cmd.PipelineTemplatePresenter)
+	assert.Equal(t, "cliScale", created.Name)
+	assert.Equal(t, int32(2), created.Version)
+
+	require.NoError(t, client.ListPipelineTemplates(cltest.EmptyCLIContext()))
+	templates := *r.Renders[2].(*cmd.PipelineTemplatePresenters)
+	require.Len(t, templates, 2)
+
+	set := flag.NewFlagSet("test", 0)
+	flagSetApplyFromAction(client.ShowPipelineTemplate, set, "templates")
+	require.NoError(t, set.Set("version", "1"))
+	require.NoError(t, set.Parse([]string{"cliScale"}))
+	require.NoError(t, client.ShowPipelineTemplate(cli.NewContext(nil, set, nil)))
+	shown := r.Renders[3].(*cmd.PipelineTemplatePresenter)
+	assert.Equal(t, int32(1), shown.Version)
+	assert.Contains(t, shown.DotDagSource, "times=100")
+
+	set = flag.NewFlagSet("test", 0)
+	flagSetApplyFromAction(client.DeletePipelineTemplate, set, "templates")
+	require.NoError(t, set.Parse([]string{"cliScale"}))
+	require.NoError(t, client.DeletePipelineTemplate(cli.NewContext(nil, set, nil)))
+
+	require.NoError(t, client.ListPipelineTemplates(cltest.EmptyCLIContext()))
+	assert.Empty(t, *r.Renders[4].(*cmd.PipelineTemplatePresenters))
+}
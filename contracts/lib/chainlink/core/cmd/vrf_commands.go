@@ -0,0 +1,625 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/manyminds/api2go/jsonapi"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/prover"
+	v2 "github.com/smartcontractkit/chainlink/v2/core/services/vrf/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func initVRFSubCmds(s *Shell) []cli.Command {
+	return []cli.Command{
+		{
+			Name:  "requests",
+			Usage: "Commands for managing VRF requests",
+			Subcommands: cli.Commands{
+				{
+					Name:   "show",
+					Usage:  "Show the lifecycle of a VRF request for every job that observed it",
+					Action: s.ShowVRFRequest,
+				},
+				{
+					Name:   "provenance",
+					Usage:  "Show the provenance bundle of a fulfilled VRF request, or export it for 'vrf verify-bundle'",
+					Action: s.ShowVRFProvenance,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "output, o",
+							Usage: "Path where the bundle will be saved as JSON",
+						},
+					},
+				},
+				{
+					Name:   "refulfill",
+					Usage:  "Re-fulfill a VRF request through the running job that serves it",
+					Action: s.RefulfillVRFRequest,
+					Flags: []cli.Flag{
+						cli.StringFlag{
+							Name:  "coordinator-address, c",
+							Usage: "The address of the VRF coordinator the request was made to",
+						},
+						cli.Uint64Flag{
+							Name:  "block-number, b",
+							Usage: "The block the request was made in, only required if the node never observed the request",
+						},
+						cli.BoolFlag{
+							Name:  "dry-run",
+							Usage: "only simulate the fulfillment and report its gas limit and payment",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name:  "billing",
+			Usage: "Commands for reporting what fulfilling VRF requests cost the node",
+			Subcommands: cli.Commands{
+				{
+					Name:   "summary",
+					Usage:  "Show the requests fulfilled, gas spent and payment collected per subscription and currency",
+					Action: s.ShowVRFBilling,
+					Flags:  vrfBillingFlags,
+				},
+				{
+					Name:   "export",
+					Usage:  "Export the requests fulfilled by the node, with their gas and payment, as CSV",
+					Action: s.ExportVRFBillingLedger,
+					Flags: append([]cli.Flag{
+						cli.StringFlag{
+							Name:  "output, o",
+							Usage: "Path where the CSV file will be saved",
+						},
+					}, vrfBillingFlags...),
+				},
+			},
+		},
+		{
+			Name:   "simulate",
+			Usage:  "Simulate the fulfillment of a VRF request against an RPC, without a job or a transaction",
+			Action: s.SimulateVRFRequest,
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "rpc-url",
+					Usage: "HTTP or WebSocket URL of the RPC to simulate against, e.g. a local anvil fork",
+				},
+				cli.StringFlag{
+					Name:  "tx-hash",
+					Usage: "hash of the transaction that made the request",
+				},
+				cli.UintFlag{
+					Name:  "log-index",
+					Usage: "block log index of the RandomWordsRequested log in the transaction",
+				},
+				cli.StringFlag{
+					Name:  "vrf-key",
+					Usage: "path to the VRF key to prove with, as exported by 'keys vrf export'",
+				},
+				cli.StringFlag{
+					Name:  "password, p",
+					Usage: "file containing the password of the VRF key",
+				},
+			},
+		},
+		{
+			Name:   "verify-bundle",
+			Usage:  "Verify the randomness of a VRF provenance bundle offline, without a node or an RPC",
+			Action: s.VerifyVRFBundle,
+		},
+	}
+}
+
+var vrfBillingFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "sub-id",
+		Usage: "only report on this subscription",
+	},
+	cli.StringFlag{
+		Name:  "from",
+		Usage: "start of the billing window in RFC3339, defaults to 7 days before --to",
+	},
+	cli.StringFlag{
+		Name:  "to",
+		Usage: "end of the billing window in RFC3339, defaults to now",
+	},
+}
+
+type VRFRequestLifecyclePresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.VRFRequestLifecycleResource
+}
+
+var vrfRequestLifecycleHeaders = []string{"Job ID", "Request ID", "Sub ID", "Stage", "Observed At", "Confirmed At",
+	"Simulations", "Last Simulation Error", "Enqueued At", "Eth Tx ID", "Fulfilled At", "Fulfillment Tx Hash", "Dropped At", "Drop Reason"}
+
+// ToRow presents the VRFRequestLifecycleResource as a slice of strings.
+func (p *VRFRequestLifecyclePresenter) ToRow() []string {
+	var ethTxID, fulfillmentTxHash string
+	if p.EthTxID != nil {
+		ethTxID = strconv.FormatInt(*p.EthTxID, 10)
+	}
+	if p.FulfillmentTxHash != nil {
+		fulfillmentTxHash = p.FulfillmentTxHash.Hex()
+	}
+	return []string{
+		strconv.FormatInt(int64(p.JobID), 10),
+		p.RequestID.String(),
+		p.SubID.String(),
+		p.Stage,
+		p.ObservedAt.Format(time.RFC3339),
+		formatOptionalTime(p.ConfirmedAt),
+		strconv.FormatInt(int64(p.SimulationAttempts), 10),
+		formatOptionalString(p.LastSimulationError),
+		formatOptionalTime(p.EnqueuedAt),
+		ethTxID,
+		formatOptionalTime(p.FulfilledAt),
+		fulfillmentTxHash,
+		formatOptionalTime(p.DroppedAt),
+		formatOptionalString(p.DropReason),
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *VRFRequestLifecyclePresenter) RenderTable(rt RendererTable) error {
+	renderList(vrfRequestLifecycleHeaders, [][]string{p.ToRow()}, rt.Writer)
+	return nil
+}
+
+// VRFRequestLifecyclePresenters implements TableRenderer for a slice of VRFRequestLifecyclePresenter.
+type VRFRequestLifecyclePresenters []VRFRequestLifecyclePresenter
+
+// RenderTable implements TableRenderer
+func (ps VRFRequestLifecyclePresenters) RenderTable(rt RendererTable) error {
+	var rows [][]string
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+	renderList(vrfRequestLifecycleHeaders, rows, rt.Writer)
+	return nil
+}
+
+// ShowVRFRequest shows the lifecycle of a VRF request for every job that observed it.
+func (s *Shell) ShowVRFRequest(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the request ID"))
+	}
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/vrf/requests/"+c.Args().First(), nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = stderrors.Join(err, cerr)
+		}
+	}()
+
+	var presenters VRFRequestLifecyclePresenters
+	return s.renderAPIResponse(resp, &presenters, "VRF Request Lifecycle")
+}
+
+type VRFProvenancePresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.VRFProvenanceResource
+}
+
+// The proof and the raw logs and transaction are only rendered as JSON.
+var vrfProvenanceHeaders = []string{"Version", "Chain ID", "Coordinator", "Request ID", "Key Hash", "PreSeed",
+	"Block Hash", "Seed", "Output", "Fulfillment Tx Hash", "Random Words"}
+
+// ToRow presents the VRFProvenanceResource as a slice of strings.
+func (p *VRFProvenancePresenter) ToRow() []string {
+	words := make([]string, len(p.RandomWords))
+	for i, word := range p.RandomWords {
+		words[i] = word.String()
+	}
+	return []string{
+		string(p.Version),
+		p.ChainID.String(),
+		p.Coordinator.Hex(),
+		p.RequestID.String(),
+		p.KeyHash.Hex(),
+		p.PreSeed.String(),
+		p.BlockHash.Hex(),
+		p.Proof.Seed.String(),
+		p.Proof.Output.String(),
+		p.FulfillmentTxHash.Hex(),
+		strings.Join(words, " "),
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *VRFProvenancePresenter) RenderTable(rt RendererTable) error {
+	renderList(vrfProvenanceHeaders, [][]string{p.ToRow()}, rt.Writer)
+	return nil
+}
+
+// ShowVRFProvenance shows the provenance bundle of a fulfilled VRF request, or saves it to
+// --output for it to be verified offline with VerifyVRFBundle.
+func (s *Shell) ShowVRFProvenance(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the request ID"))
+	}
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/vrf/requests/"+c.Args().First()+"/provenance", nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = stderrors.Join(err, cerr)
+		}
+	}()
+
+	filepath := c.String("output")
+	if len(filepath) == 0 {
+		return s.renderAPIResponse(resp, &VRFProvenancePresenter{}, "VRF Provenance")
+	}
+
+	var p VRFProvenancePresenter
+	if err = s.deserializeAPIResponse(resp, &p, &jsonapi.Links{}); err != nil {
+		return s.errorOut(err)
+	}
+	bundle, err := json.MarshalIndent(p.ProvenanceBundle, "", "  ")
+	if err != nil {
+		return s.errorOut(err)
+	}
+	if err = utils.WriteFileWithMaxPerms(filepath, bundle, 0o644); err != nil {
+		return s.errorOut(errors.Wrapf(err, "Could not write %v", filepath))
+	}
+	_, err = os.Stderr.WriteString("Exported VRF provenance bundle to " + filepath + "\n")
+	return s.errorOut(err)
+}
+
+// VerifyVRFBundle verifies a provenance bundle exported by ShowVRFProvenance. It only uses the
+// bundle, so anyone can run it without a node, an RPC or trusting the node operator.
+func (s *Shell) VerifyVRFBundle(c *cli.Context) error {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the path of the bundle"))
+	}
+	b, err := os.ReadFile(c.Args().First())
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "failed to read bundle"))
+	}
+	var bundle v2.ProvenanceBundle
+	if err = json.Unmarshal(b, &bundle); err != nil {
+		return s.errorOut(errors.Wrap(err, "failed to parse bundle"))
+	}
+	if err = v2.VerifyProvenanceBundle(bundle); err != nil {
+		return s.errorOut(errors.Wrap(err, "bundle does not verify"))
+	}
+	p := &VRFProvenancePresenter{VRFProvenanceResource: presenters.NewVRFProvenanceResource(bundle)}
+	return s.errorOut(s.Render(p, "VRF Provenance Verified"))
+}
+
+type VRFRefulfillmentPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.VRFRefulfillmentResource
+}
+
+var vrfRefulfillmentHeaders = []string{"Job ID", "Request ID", "Sub ID", "Native Payment", "Gas Limit", "Max Gas Price (Wei)",
+	"Max Fee", "Dry Run", "From Address", "Eth Tx ID"}
+
+// ToRow presents the VRFRefulfillmentResource as a slice of strings.
+func (p *VRFRefulfillmentPresenter) ToRow() []string {
+	var fromAddress, ethTxID string
+	if p.EthTxID != nil {
+		fromAddress = p.FromAddress.Hex()
+		ethTxID = strconv.FormatInt(*p.EthTxID, 10)
+	}
+	return []string{
+		strconv.FormatInt(int64(p.JobID), 10),
+		p.RequestID.String(),
+		p.SubID.String(),
+		strconv.FormatBool(p.NativePayment),
+		strconv.FormatUint(p.GasLimit, 10),
+		p.MaxGasPriceWei.String(),
+		p.MaxFee.String(),
+		strconv.FormatBool(p.DryRun),
+		fromAddress,
+		ethTxID,
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *VRFRefulfillmentPresenter) RenderTable(rt RendererTable) error {
+	renderList(vrfRefulfillmentHeaders, [][]string{p.ToRow()}, rt.Writer)
+	return nil
+}
+
+// RefulfillVRFRequest re-fulfills a VRF request, or only simulates it with --dry-run.
+func (s *Shell) RefulfillVRFRequest(c *cli.Context) (err error) {
+	if !c.Args().Present() {
+		return s.errorOut(errors.New("must pass the request ID"))
+	}
+	if !common.IsHexAddress(c.String("coordinator-address")) {
+		return s.errorOut(errors.New("must pass a valid --coordinator-address"))
+	}
+	request := web.RefulfillVRFRequest{
+		CoordinatorAddress: common.HexToAddress(c.String("coordinator-address")),
+		DryRun:             c.Bool("dry-run"),
+	}
+	if c.IsSet("block-number") {
+		bn := c.Uint64("block-number")
+		request.BlockNumber = &bn
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	resp, err := s.HTTP.Post(s.ctx(), "/v2/vrf/requests/"+c.Args().First()+"/refulfill", bytes.NewReader(body))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = stderrors.Join(err, cerr)
+		}
+	}()
+
+	title := "VRF Request Refulfilled"
+	if request.DryRun {
+		title = "VRF Request Refulfillment (dry-run)"
+	}
+	return s.renderAPIResponse(resp, &VRFRefulfillmentPresenter{}, title)
+}
+
+type VRFBillingSummaryPresenter struct {
+	JAID // This is needed to render the id for a JSONAPI Resource as normal JSON
+	presenters.VRFBillingSummaryResource
+}
+
+var vrfBillingSummaryHeaders = []string{"Chain ID", "Coordinator", "Sub ID", "Currency", "Requests Fulfilled",
+	"Fulfillment Txs", "Gas Used", "Gas Cost (Wei)", "Payment", "Last Fulfilled At"}
+
+// ToRow presents the VRFBillingSummaryResource as a slice of strings.
+func (p *VRFBillingSummaryPresenter) ToRow() []string {
+	currency := "LINK (juels)"
+	if p.NativePayment {
+		currency = "native (wei)"
+	}
+	return []string{
+		p.EVMChainID.String(),
+		p.CoordinatorAddress.Hex(),
+		p.SubID.String(),
+		currency,
+		strconv.FormatInt(p.RequestsFulfilled, 10),
+		strconv.FormatInt(p.FulfillmentTxs, 10),
+		strconv.FormatUint(p.GasUsed, 10),
+		p.GasCostWei.String(),
+		p.Payment.String(),
+		p.LastFulfilledAt.Format(time.RFC3339),
+	}
+}
+
+// VRFBillingSummaryPresenters implements TableRenderer for a slice of VRFBillingSummaryPresenter.
+type VRFBillingSummaryPresenters []VRFBillingSummaryPresenter
+
+// RenderTable implements TableRenderer
+func (ps VRFBillingSummaryPresenters) RenderTable(rt RendererTable) error {
+	var rows [][]string
+	for _, p := range ps {
+		rows = append(rows, p.ToRow())
+	}
+	renderList(vrfBillingSummaryHeaders, rows, rt.Writer)
+	return nil
+}
+
+func vrfBillingQuery(c *cli.Context) url.Values {
+	query := url.Values{}
+	for flag, param := range map[string]string{"sub-id": "subID", "from": "from", "to": "to"} {
+		if v := c.String(flag); v != "" {
+			query.Set(param, v)
+		}
+	}
+	return query
+}
+
+// ShowVRFBilling shows the billing summary of VRF subscriptions.
+func (s *Shell) ShowVRFBilling(c *cli.Context) (err error) {
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/vrf/billing?"+vrfBillingQuery(c).Encode(), nil)
+	if err != nil {
+		return s.errorOut(err)
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = stderrors.Join(err, cerr)
+		}
+	}()
+
+	var presenters VRFBillingSummaryPresenters
+	return s.renderAPIResponse(resp, &presenters, "VRF Billing")
+}
+
+// ExportVRFBillingLedger saves the VRF billing ledger to a CSV file.
+func (s *Shell) ExportVRFBillingLedger(c *cli.Context) (err error) {
+	filepath := c.String("output")
+	if len(filepath) == 0 {
+		return s.errorOut(errors.New("Must specify --output/-o flag"))
+	}
+
+	query := vrfBillingQuery(c)
+	query.Set("format", "csv")
+	resp, err := s.HTTP.Get(s.ctx(), "/v2/vrf/billing/ledger?"+query.Encode(), nil)
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "Could not make HTTP request"))
+	}
+	defer func() {
+		if cerr := resp.Body.Close(); cerr != nil {
+			err = stderrors.Join(err, cerr)
+		}
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return s.errorOut(fmt.Errorf("error exporting: %w", httpError(resp)))
+	}
+
+	ledger, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "Could not read response body"))
+	}
+
+	err = utils.WriteFileWithMaxPerms(filepath, ledger, 0o600)
+	if err != nil {
+		return s.errorOut(errors.Wrapf(err, "Could not write %v", filepath))
+	}
+
+	_, err = os.Stderr.WriteString("Exported VRF billing ledger to " + filepath + "\n")
+	if err != nil {
+		return s.errorOut(err)
+	}
+
+	return nil
+}
+
+// VRFSimulationPresenter presents the outcome of a local fulfillment simulation.
+type VRFSimulationPresenter struct {
+	Version                 string `json:"version"`
+	Coordinator             string `json:"coordinator"`
+	RequestID               string `json:"requestID"`
+	SubID                   string `json:"subID"`
+	Sender                  string `json:"sender"`
+	NativePayment           bool   `json:"nativePayment"`
+	CallbackGasLimit        uint32 `json:"callbackGasLimit"`
+	FulfillmentGas          uint64 `json:"fulfillmentGas"`
+	Payment                 string `json:"payment"`
+	FulfillmentRevertReason string `json:"fulfillmentRevertReason"`
+	FulfillmentFailure      string `json:"fulfillmentFailure"`
+	CallbackGas             uint64 `json:"callbackGas"`
+	CallbackRevertReason    string `json:"callbackRevertReason"`
+	CallbackPayload         string `json:"callbackPayload"`
+	FulfillmentPayload      string `json:"fulfillmentPayload"`
+}
+
+// NewVRFSimulationPresenter presents a SimulationResult.
+func NewVRFSimulationPresenter(res v2.SimulationResult) *VRFSimulationPresenter {
+	var payment string
+	if res.Payment != nil {
+		payment = res.Payment.String()
+	}
+	return &VRFSimulationPresenter{
+		Version:                 string(res.Version),
+		Coordinator:             res.Coordinator.Hex(),
+		RequestID:               res.RequestID.String(),
+		SubID:                   res.SubID.String(),
+		Sender:                  res.Sender.Hex(),
+		NativePayment:           res.NativePayment,
+		CallbackGasLimit:        res.CallbackGasLimit,
+		FulfillmentGas:          res.FulfillmentGas,
+		Payment:                 payment,
+		FulfillmentRevertReason: res.FulfillmentRevertReason,
+		FulfillmentFailure:      res.FulfillmentFailure,
+		CallbackGas:             res.CallbackGas,
+		CallbackRevertReason:    res.CallbackRevertReason,
+		CallbackPayload:         hexutil.Encode(res.CallbackPayload),
+		FulfillmentPayload:      hexutil.Encode(res.FulfillmentPayload),
+	}
+}
+
+// The fulfillment payload holds the whole proof, so it is only rendered as JSON.
+var vrfSimulationHeaders = []string{"Version", "Coordinator", "Request ID", "Sub ID", "Sender", "Native Payment",
+	"Callback Gas Limit", "Fulfillment Gas", "Payment", "Fulfillment Revert Reason", "Fulfillment Failure",
+	"Callback Gas", "Callback Revert Reason", "Callback Payload"}
+
+// ToRow presents the VRFSimulationPresenter as a slice of strings.
+func (p *VRFSimulationPresenter) ToRow() []string {
+	return []string{
+		p.Version,
+		p.Coordinator,
+		p.RequestID,
+		p.SubID,
+		p.Sender,
+		strconv.FormatBool(p.NativePayment),
+		strconv.FormatUint(uint64(p.CallbackGasLimit), 10),
+		strconv.FormatUint(p.FulfillmentGas, 10),
+		p.Payment,
+		p.FulfillmentRevertReason,
+		p.FulfillmentFailure,
+		strconv.FormatUint(p.CallbackGas, 10),
+		p.CallbackRevertReason,
+		p.CallbackPayload,
+	}
+}
+
+// RenderTable implements TableRenderer
+func (p *VRFSimulationPresenter) RenderTable(rt RendererTable) error {
+	renderList(vrfSimulationHeaders, [][]string{p.ToRow()}, rt.Writer)
+	return nil
+}
+
+// SimulateVRFRequest proves a VRF request with a local key and simulates its fulfillment
+// against an RPC. It doesn't need a running node, a job or a funded key.
+func (s *Shell) SimulateVRFRequest(c *cli.Context) (err error) {
+	for _, flag := range []string{"rpc-url", "tx-hash", "vrf-key", "password"} {
+		if c.String(flag) == "" {
+			return s.errorOut(errors.Errorf("must pass --%s", flag))
+		}
+	}
+	if !c.IsSet("log-index") {
+		return s.errorOut(errors.New("must pass --log-index"))
+	}
+	txHash, err := hexutil.Decode(c.String("tx-hash"))
+	if err != nil || len(txHash) != common.HashLength {
+		return s.errorOut(errors.New("must pass a valid --tx-hash"))
+	}
+
+	keyJSON, err := os.ReadFile(c.String("vrf-key"))
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "failed to read VRF key"))
+	}
+	password, err := utils.PasswordFromFile(c.String("password"))
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "failed to read password"))
+	}
+	key, err := vrfkey.FromEncryptedJSON(keyJSON, password)
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "failed to decrypt VRF key"))
+	}
+
+	ctx := s.ctx()
+	client, err := ethclient.DialContext(ctx, c.String("rpc-url"))
+	if err != nil {
+		return s.errorOut(errors.Wrap(err, "failed to dial RPC"))
+	}
+	defer client.Close()
+
+	res, err := v2.SimulateRequest(ctx, client, prover.NewInMemoryProver(key), key.PublicKey,
+		common.BytesToHash(txHash), c.Uint("log-index"))
+	if err != nil {
+		return s.errorOut(err)
+	}
+	return s.errorOut(s.Render(NewVRFSimulationPresenter(res), "VRF Simulation"))
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatOptionalString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
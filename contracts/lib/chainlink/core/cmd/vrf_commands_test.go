@@ -0,0 +1,198 @@
+package cmd_test
+
+import (
+	"bytes"
+	gobig "math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+	"github.com/smartcontractkit/chainlink/v2/core/cmd"
+	v2 "github.com/smartcontractkit/chainlink/v2/core/services/vrf/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func TestVRFRequestLifecyclePresenter_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		observedAt = time.Now()
+		ethTxID    = int64(7)
+		txHash     = utils.RandomHash()
+		buffer     = bytes.NewBufferString("")
+		r          = cmd.RendererTable{Writer: buffer}
+	)
+
+	p := cmd.VRFRequestLifecyclePresenter{
+		VRFRequestLifecycleResource: presenters.VRFRequestLifecycleResource{
+			JAID:              presenters.NewPrefixedJAID("1234", "1"),
+			JobID:             1,
+			RequestID:         big.NewI(1234),
+			SubID:             big.NewI(5),
+			Stage:             string(vrfcommon.StageFulfilled),
+			ObservedAt:        observedAt,
+			EthTxID:           &ethTxID,
+			FulfilledAt:       &observedAt,
+			FulfillmentTxHash: &txHash,
+		},
+	}
+
+	// Render a single resource
+	require.NoError(t, p.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, "1234")
+	assert.Contains(t, output, string(vrfcommon.StageFulfilled))
+	assert.Contains(t, output, observedAt.Format(time.RFC3339))
+	assert.Contains(t, output, txHash.Hex())
+
+	// Render many resources
+	buffer.Reset()
+	ps := cmd.VRFRequestLifecyclePresenters{p}
+	require.NoError(t, ps.RenderTable(r))
+
+	output = buffer.String()
+	assert.Contains(t, output, "1234")
+	assert.Contains(t, output, txHash.Hex())
+}
+
+func TestVRFProvenancePresenter_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		coordinator       = utils.RandomAddress()
+		fulfillmentTxHash = utils.RandomHash()
+		buffer            = bytes.NewBufferString("")
+		r                 = cmd.RendererTable{Writer: buffer}
+	)
+
+	p := cmd.VRFProvenancePresenter{
+		VRFProvenanceResource: presenters.NewVRFProvenanceResource(v2.ProvenanceBundle{
+			Version:           vrfcommon.V2Plus,
+			ChainID:           big.NewI(1337),
+			Coordinator:       coordinator,
+			RequestID:         big.NewI(1234),
+			PreSeed:           big.NewI(42),
+			Proof:             v2.ProvenanceProof{Seed: big.NewI(987654321), Output: big.NewI(123456789)},
+			FulfillmentTxHash: fulfillmentTxHash,
+			RandomWords:       []*big.Big{big.NewI(11), big.NewI(22)},
+		}),
+	}
+
+	require.NoError(t, p.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, coordinator.Hex())
+	assert.Contains(t, output, "1234")
+	assert.Contains(t, output, "987654321")
+	assert.Contains(t, output, fulfillmentTxHash.Hex())
+	assert.Contains(t, output, "11 22")
+}
+
+func TestVRFRefulfillmentPresenter_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ethTxID     = int64(9)
+		fromAddress = utils.RandomAddress()
+		buffer      = bytes.NewBufferString("")
+		r           = cmd.RendererTable{Writer: buffer}
+	)
+
+	p := cmd.VRFRefulfillmentPresenter{
+		VRFRefulfillmentResource: presenters.VRFRefulfillmentResource{
+			JAID:           presenters.NewPrefixedJAID("1234", "1"),
+			JobID:          1,
+			RequestID:      big.NewI(1234),
+			SubID:          big.NewI(5),
+			GasLimit:       500_000,
+			MaxGasPriceWei: big.NewI(1_000_000_000),
+			MaxFee:         big.NewI(123456789),
+			FromAddress:    fromAddress,
+			EthTxID:        &ethTxID,
+		},
+	}
+
+	require.NoError(t, p.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, "1234")
+	assert.Contains(t, output, "500000")
+	assert.Contains(t, output, "123456789")
+	assert.Contains(t, output, fromAddress.Hex())
+}
+
+func TestVRFBillingSummaryPresenters_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		lastFulfilledAt = time.Now()
+		coordinator     = utils.RandomAddress()
+		buffer          = bytes.NewBufferString("")
+		r               = cmd.RendererTable{Writer: buffer}
+	)
+
+	ps := cmd.VRFBillingSummaryPresenters{{
+		VRFBillingSummaryResource: presenters.VRFBillingSummaryResource{
+			JAID:               presenters.NewPrefixedJAID("5/native", "0"),
+			EVMChainID:         big.NewI(0),
+			CoordinatorAddress: coordinator,
+			SubID:              big.NewI(5),
+			NativePayment:      true,
+			RequestsFulfilled:  3,
+			FulfillmentTxs:     2,
+			GasUsed:            400_000,
+			GasCostWei:         big.NewI(987654321),
+			Payment:            big.NewI(123456789),
+			LastFulfilledAt:    lastFulfilledAt,
+		},
+	}}
+
+	require.NoError(t, ps.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, coordinator.Hex())
+	assert.Contains(t, output, "native (wei)")
+	assert.Contains(t, output, "987654321")
+	assert.Contains(t, output, "123456789")
+	assert.Contains(t, output, lastFulfilledAt.Format(time.RFC3339))
+}
+
+func TestVRFSimulationPresenter_RenderTable(t *testing.T) {
+	t.Parallel()
+
+	var (
+		coordinator = utils.RandomAddress()
+		sender      = utils.RandomAddress()
+		buffer      = bytes.NewBufferString("")
+		r           = cmd.RendererTable{Writer: buffer}
+	)
+
+	p := cmd.NewVRFSimulationPresenter(v2.SimulationResult{
+		Version:              vrfcommon.V2Plus,
+		Coordinator:          coordinator,
+		RequestID:            gobig.NewInt(7),
+		SubID:                gobig.NewInt(5),
+		Sender:               sender,
+		CallbackGasLimit:     200_000,
+		FulfillmentGas:       123_456,
+		Payment:              gobig.NewInt(987654321),
+		CallbackPayload:      []byte{0xde, 0xad, 0xbe, 0xef},
+		FulfillmentPayload:   []byte{0x01},
+		CallbackRevertReason: "not enough tickets",
+	})
+	require.NoError(t, p.RenderTable(r))
+
+	output := buffer.String()
+	assert.Contains(t, output, coordinator.Hex())
+	assert.Contains(t, output, sender.Hex())
+	assert.Contains(t, output, "123456")
+	assert.Contains(t, output, "987654321")
+	assert.Contains(t, output, "not enough tickets")
+	assert.Contains(t, output, "0xdeadbeef")
+}
@@ -64,6 +64,7 @@ type AppConfig interface {
 	CRE() CRE
 	Billing() Billing
 	BridgeStatusReporter() BridgeStatusReporter
+	VRFProver() VRFProver
 }
 
 type DatabaseBackupMode string
@@ -64,6 +64,7 @@ type Core struct {
 	CRE                  CreConfig            `toml:",omitempty"`
 	Billing              Billing              `toml:",omitempty"`
 	BridgeStatusReporter BridgeStatusReporter `toml:",omitempty"`
+	VRFProver            VRFProver            `toml:",omitempty"`
 }
 
 // SetFrom updates c with any non-nil values from f. (currently TOML field only!)
@@ -109,6 +110,7 @@ func (c *Core) SetFrom(f *Core) {
 	c.CRE.setFrom(&f.CRE)
 	c.Billing.setFrom(&f.Billing)
 	c.BridgeStatusReporter.setFrom(&f.BridgeStatusReporter)
+	c.VRFProver.setFrom(&f.VRFProver)
 }
 
 func (c *Core) ValidateConfig() (err error) {
@@ -2622,6 +2624,21 @@ func (e *BridgeStatusReporter) ValidateConfig() error {
 	return nil
 }
 
+type VRFProver struct {
+	URL        *string
+	TLSEnabled *bool
+}
+
+func (v *VRFProver) setFrom(f *VRFProver) {
+	if f.URL != nil {
+		v.URL = f.URL
+	}
+
+	if f.TLSEnabled != nil {
+		v.TLSEnabled = f.TLSEnabled
+	}
+}
+
 type JobDistributor struct {
 	DisplayName *string
 }
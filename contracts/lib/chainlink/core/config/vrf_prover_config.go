@@ -0,0 +1,6 @@
+package config
+
+type VRFProver interface {
+	URL() string
+	TLSEnabled() bool
+}
@@ -7,10 +7,14 @@ import (
 
 	audit "github.com/smartcontractkit/chainlink/v2/core/logger/audit"
 
+	blockheaderfeeder "github.com/smartcontractkit/chainlink/v2/core/services/blockheaderfeeder"
+
 	bridges "github.com/smartcontractkit/chainlink/v2/core/bridges"
 
 	chainlink "github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 
+	common "github.com/ethereum/go-ethereum/common"
+
 	context "context"
 
 	feeds "github.com/smartcontractkit/chainlink/v2/core/services/feeds"
@@ -41,6 +45,8 @@ import (
 
 	uuid "github.com/google/uuid"
 
+	vrfcommon "github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+
 	webhook "github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 
 	zapcore "go.uber.org/zap/zapcore"
@@ -153,6 +159,64 @@ func (_c *Application_AuthenticationProvider_Call) RunAndReturn(run func() sessi
 	return _c
 }
 
+// BackfillBlockhashes provides a mock function with given fields: ctx, jobID, opts
+func (_m *Application) BackfillBlockhashes(ctx context.Context, jobID int32, opts blockheaderfeeder.BackfillOpts) (blockheaderfeeder.BackfillPlan, error) {
+	ret := _m.Called(ctx, jobID, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BackfillBlockhashes")
+	}
+
+	var r0 blockheaderfeeder.BackfillPlan
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, blockheaderfeeder.BackfillOpts) (blockheaderfeeder.BackfillPlan, error)); ok {
+		return rf(ctx, jobID, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, blockheaderfeeder.BackfillOpts) blockheaderfeeder.BackfillPlan); ok {
+		r0 = rf(ctx, jobID, opts)
+	} else {
+		r0 = ret.Get(0).(blockheaderfeeder.BackfillPlan)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, blockheaderfeeder.BackfillOpts) error); ok {
+		r1 = rf(ctx, jobID, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_BackfillBlockhashes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BackfillBlockhashes'
+type Application_BackfillBlockhashes_Call struct {
+	*mock.Call
+}
+
+// BackfillBlockhashes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID int32
+//   - opts blockheaderfeeder.BackfillOpts
+func (_e *Application_Expecter) BackfillBlockhashes(ctx interface{}, jobID interface{}, opts interface{}) *Application_BackfillBlockhashes_Call {
+	return &Application_BackfillBlockhashes_Call{Call: _e.mock.On("BackfillBlockhashes", ctx, jobID, opts)}
+}
+
+func (_c *Application_BackfillBlockhashes_Call) Run(run func(ctx context.Context, jobID int32, opts blockheaderfeeder.BackfillOpts)) *Application_BackfillBlockhashes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(blockheaderfeeder.BackfillOpts))
+	})
+	return _c
+}
+
+func (_c *Application_BackfillBlockhashes_Call) Return(_a0 blockheaderfeeder.BackfillPlan, _a1 error) *Application_BackfillBlockhashes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_BackfillBlockhashes_Call) RunAndReturn(run func(context.Context, int32, blockheaderfeeder.BackfillOpts) (blockheaderfeeder.BackfillPlan, error)) *Application_BackfillBlockhashes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // BasicAdminUsersORM provides a mock function with no fields
 func (_m *Application) BasicAdminUsersORM() sessions.BasicAdminUsersORM {
 	ret := _m.Called()
@@ -342,6 +406,75 @@ func (_c *Application_DeleteLogPollerDataAfter_Call) RunAndReturn(run func(conte
 	return _c
 }
 
+// DryRunJobV2 provides a mock function with given fields: ctx, tomlString, fixtures
+func (_m *Application) DryRunJobV2(ctx context.Context, tomlString string, fixtures pipeline.DryRunFixtures) (*pipeline.Run, pipeline.TaskRunResults, error) {
+	ret := _m.Called(ctx, tomlString, fixtures)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DryRunJobV2")
+	}
+
+	var r0 *pipeline.Run
+	var r1 pipeline.TaskRunResults
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, pipeline.DryRunFixtures) (*pipeline.Run, pipeline.TaskRunResults, error)); ok {
+		return rf(ctx, tomlString, fixtures)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, pipeline.DryRunFixtures) *pipeline.Run); ok {
+		r0 = rf(ctx, tomlString, fixtures)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipeline.Run)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, pipeline.DryRunFixtures) pipeline.TaskRunResults); ok {
+		r1 = rf(ctx, tomlString, fixtures)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(pipeline.TaskRunResults)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, pipeline.DryRunFixtures) error); ok {
+		r2 = rf(ctx, tomlString, fixtures)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Application_DryRunJobV2_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DryRunJobV2'
+type Application_DryRunJobV2_Call struct {
+	*mock.Call
+}
+
+// DryRunJobV2 is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tomlString string
+//   - fixtures pipeline.DryRunFixtures
+func (_e *Application_Expecter) DryRunJobV2(ctx interface{}, tomlString interface{}, fixtures interface{}) *Application_DryRunJobV2_Call {
+	return &Application_DryRunJobV2_Call{Call: _e.mock.On("DryRunJobV2", ctx, tomlString, fixtures)}
+}
+
+func (_c *Application_DryRunJobV2_Call) Run(run func(ctx context.Context, tomlString string, fixtures pipeline.DryRunFixtures)) *Application_DryRunJobV2_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(pipeline.DryRunFixtures))
+	})
+	return _c
+}
+
+func (_c *Application_DryRunJobV2_Call) Return(run *pipeline.Run, trrs pipeline.TaskRunResults, err error) *Application_DryRunJobV2_Call {
+	_c.Call.Return(run, trrs, err)
+	return _c
+}
+
+func (_c *Application_DryRunJobV2_Call) RunAndReturn(run func(context.Context, string, pipeline.DryRunFixtures) (*pipeline.Run, pipeline.TaskRunResults, error)) *Application_DryRunJobV2_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindLCA provides a mock function with given fields: ctx, chainID
 func (_m *Application) FindLCA(ctx context.Context, chainID *big.Int) (*logpoller.Block, error) {
 	ret := _m.Called(ctx, chainID)
@@ -1151,6 +1284,170 @@ func (_c *Application_PipelineORM_Call) RunAndReturn(run func() pipeline.ORM) *A
 	return _c
 }
 
+// PipelineTemplateORM provides a mock function with no fields
+func (_m *Application) PipelineTemplateORM() pipeline.TemplateORM {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PipelineTemplateORM")
+	}
+
+	var r0 pipeline.TemplateORM
+	if rf, ok := ret.Get(0).(func() pipeline.TemplateORM); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(pipeline.TemplateORM)
+		}
+	}
+
+	return r0
+}
+
+// Application_PipelineTemplateORM_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PipelineTemplateORM'
+type Application_PipelineTemplateORM_Call struct {
+	*mock.Call
+}
+
+// PipelineTemplateORM is a helper method to define mock.On call
+func (_e *Application_Expecter) PipelineTemplateORM() *Application_PipelineTemplateORM_Call {
+	return &Application_PipelineTemplateORM_Call{Call: _e.mock.On("PipelineTemplateORM")}
+}
+
+func (_c *Application_PipelineTemplateORM_Call) Run(run func()) *Application_PipelineTemplateORM_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Application_PipelineTemplateORM_Call) Return(_a0 pipeline.TemplateORM) *Application_PipelineTemplateORM_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Application_PipelineTemplateORM_Call) RunAndReturn(run func() pipeline.TemplateORM) *Application_PipelineTemplateORM_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReconfigureVRFJob provides a mock function with given fields: ctx, jobID, tomlString
+func (_m *Application) ReconfigureVRFJob(ctx context.Context, jobID int32, tomlString string) (job.Job, error) {
+	ret := _m.Called(ctx, jobID, tomlString)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReconfigureVRFJob")
+	}
+
+	var r0 job.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string) (job.Job, error)); ok {
+		return rf(ctx, jobID, tomlString)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32, string) job.Job); ok {
+		r0 = rf(ctx, jobID, tomlString)
+	} else {
+		r0 = ret.Get(0).(job.Job)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32, string) error); ok {
+		r1 = rf(ctx, jobID, tomlString)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_ReconfigureVRFJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReconfigureVRFJob'
+type Application_ReconfigureVRFJob_Call struct {
+	*mock.Call
+}
+
+// ReconfigureVRFJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - jobID int32
+//   - tomlString string
+func (_e *Application_Expecter) ReconfigureVRFJob(ctx interface{}, jobID interface{}, tomlString interface{}) *Application_ReconfigureVRFJob_Call {
+	return &Application_ReconfigureVRFJob_Call{Call: _e.mock.On("ReconfigureVRFJob", ctx, jobID, tomlString)}
+}
+
+func (_c *Application_ReconfigureVRFJob_Call) Run(run func(ctx context.Context, jobID int32, tomlString string)) *Application_ReconfigureVRFJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int32), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Application_ReconfigureVRFJob_Call) Return(_a0 job.Job, _a1 error) *Application_ReconfigureVRFJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_ReconfigureVRFJob_Call) RunAndReturn(run func(context.Context, int32, string) (job.Job, error)) *Application_ReconfigureVRFJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefulfillVRFRequest provides a mock function with given fields: ctx, coordinator, requestID, opts
+func (_m *Application) RefulfillVRFRequest(ctx context.Context, coordinator common.Address, requestID *big.Int, opts vrfcommon.RefulfillOpts) (vrfcommon.RefulfillResult, error) {
+	ret := _m.Called(ctx, coordinator, requestID, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefulfillVRFRequest")
+	}
+
+	var r0 vrfcommon.RefulfillResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, *big.Int, vrfcommon.RefulfillOpts) (vrfcommon.RefulfillResult, error)); ok {
+		return rf(ctx, coordinator, requestID, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, *big.Int, vrfcommon.RefulfillOpts) vrfcommon.RefulfillResult); ok {
+		r0 = rf(ctx, coordinator, requestID, opts)
+	} else {
+		r0 = ret.Get(0).(vrfcommon.RefulfillResult)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, *big.Int, vrfcommon.RefulfillOpts) error); ok {
+		r1 = rf(ctx, coordinator, requestID, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Application_RefulfillVRFRequest_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefulfillVRFRequest'
+type Application_RefulfillVRFRequest_Call struct {
+	*mock.Call
+}
+
+// RefulfillVRFRequest is a helper method to define mock.On call
+//   - ctx context.Context
+//   - coordinator common.Address
+//   - requestID *big.Int
+//   - opts vrfcommon.RefulfillOpts
+func (_e *Application_Expecter) RefulfillVRFRequest(ctx interface{}, coordinator interface{}, requestID interface{}, opts interface{}) *Application_RefulfillVRFRequest_Call {
+	return &Application_RefulfillVRFRequest_Call{Call: _e.mock.On("RefulfillVRFRequest", ctx, coordinator, requestID, opts)}
+}
+
+func (_c *Application_RefulfillVRFRequest_Call) Run(run func(ctx context.Context, coordinator common.Address, requestID *big.Int, opts vrfcommon.RefulfillOpts)) *Application_RefulfillVRFRequest_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(common.Address), args[2].(*big.Int), args[3].(vrfcommon.RefulfillOpts))
+	})
+	return _c
+}
+
+func (_c *Application_RefulfillVRFRequest_Call) Return(_a0 vrfcommon.RefulfillResult, _a1 error) *Application_RefulfillVRFRequest_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Application_RefulfillVRFRequest_Call) RunAndReturn(run func(context.Context, common.Address, *big.Int, vrfcommon.RefulfillOpts) (vrfcommon.RefulfillResult, error)) *Application_RefulfillVRFRequest_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ReplayFromBlock provides a mock function with given fields: ctx, chainFamily, chainID, number, forceBroadcast
 func (_m *Application) ReplayFromBlock(ctx context.Context, chainFamily string, chainID string, number uint64, forceBroadcast bool) error {
 	ret := _m.Called(ctx, chainFamily, chainID, number, forceBroadcast)
@@ -1201,6 +1498,75 @@ func (_c *Application_ReplayFromBlock_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// ReplayJobRunV2 provides a mock function with given fields: ctx, original, fromTask
+func (_m *Application) ReplayJobRunV2(ctx context.Context, original pipeline.Run, fromTask string) (*pipeline.Run, []string, error) {
+	ret := _m.Called(ctx, original, fromTask)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplayJobRunV2")
+	}
+
+	var r0 *pipeline.Run
+	var r1 []string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, pipeline.Run, string) (*pipeline.Run, []string, error)); ok {
+		return rf(ctx, original, fromTask)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pipeline.Run, string) *pipeline.Run); ok {
+		r0 = rf(ctx, original, fromTask)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipeline.Run)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pipeline.Run, string) []string); ok {
+		r1 = rf(ctx, original, fromTask)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, pipeline.Run, string) error); ok {
+		r2 = rf(ctx, original, fromTask)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Application_ReplayJobRunV2_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplayJobRunV2'
+type Application_ReplayJobRunV2_Call struct {
+	*mock.Call
+}
+
+// ReplayJobRunV2 is a helper method to define mock.On call
+//   - ctx context.Context
+//   - original pipeline.Run
+//   - fromTask string
+func (_e *Application_Expecter) ReplayJobRunV2(ctx interface{}, original interface{}, fromTask interface{}) *Application_ReplayJobRunV2_Call {
+	return &Application_ReplayJobRunV2_Call{Call: _e.mock.On("ReplayJobRunV2", ctx, original, fromTask)}
+}
+
+func (_c *Application_ReplayJobRunV2_Call) Run(run func(ctx context.Context, original pipeline.Run, fromTask string)) *Application_ReplayJobRunV2_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pipeline.Run), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *Application_ReplayJobRunV2_Call) Return(_a0 *pipeline.Run, _a1 []string, _a2 error) *Application_ReplayJobRunV2_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Application_ReplayJobRunV2_Call) RunAndReturn(run func(context.Context, pipeline.Run, string) (*pipeline.Run, []string, error)) *Application_ReplayJobRunV2_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ResumeJobV2 provides a mock function with given fields: ctx, taskID, result
 func (_m *Application) ResumeJobV2(ctx context.Context, taskID uuid.UUID, result pipeline.Result) error {
 	ret := _m.Called(ctx, taskID, result)
@@ -55,8 +55,9 @@ const (
 	CosmosTransactionCreated EventID = "COSMOS_TRANSACTION_CREATED"
 	SolanaTransactionCreated EventID = "SOLANA_TRANSACTION_CREATED"
 
-	JobCreated EventID = "JOB_CREATED"
-	JobDeleted EventID = "JOB_DELETED"
+	JobCreated     EventID = "JOB_CREATED"
+	JobDeleted     EventID = "JOB_DELETED"
+	JobSpecUpdated EventID = "JOB_SPEC_UPDATED"
 
 	ChainAdded       EventID = "CHAIN_ADDED"
 	ChainSpecUpdated EventID = "CHAIN_SPEC_UPDATED"
@@ -69,9 +70,16 @@ const (
 	BridgeUpdated EventID = "BRIDGE_UPDATED"
 	BridgeDeleted EventID = "BRIDGE_DELETED"
 
+	PipelineTemplateCreated EventID = "PIPELINE_TEMPLATE_CREATED"
+	PipelineTemplateDeleted EventID = "PIPELINE_TEMPLATE_DELETED"
+
 	ForwarderCreated EventID = "FORWARDER_CREATED"
 	ForwarderDeleted EventID = "FORWARDER_DELETED"
 
+	VRFRequestRefulfilled EventID = "VRF_REQUEST_REFULFILLED"
+
+	BlockhashesBackfilled EventID = "BLOCKHASHES_BACKFILLED"
+
 	ExternalInitiatorCreated EventID = "EXTERNAL_INITIATOR_CREATED"
 	ExternalInitiatorDeleted EventID = "EXTERNAL_INITIATOR_DELETED"
 
@@ -87,6 +95,7 @@ const (
 
 	JobErrorDismissed EventID = "JOB_ERROR_DISMISSED"
 	JobRunSet         EventID = "JOB_RUN_SET"
+	JobRunReplayed    EventID = "JOB_RUN_REPLAYED"
 
 	EnvNoncriticalEnvDumped EventID = "ENV_NONCRITICAL_ENV_DUMPED"
 
@@ -50,6 +50,29 @@ func (b *BatchBlockhashStore) GetBlockhashes(ctx context.Context, blockNumbers [
 	return blockhashes, nil
 }
 
+// Store stores the blockhashes of blockNumbers that are still available through the blockhash
+// instruction when the transaction is mined, the others are skipped.
+func (b *BatchBlockhashStore) Store(ctx context.Context, blockNumbers []*big.Int, fromAddress common.Address) error {
+	payload, err := b.abi.Pack("store", blockNumbers)
+	if err != nil {
+		return errors.Wrap(err, "packing args")
+	}
+
+	_, err = b.txm.CreateTransaction(ctx, txmgr.TxRequest{
+		FromAddress:    fromAddress,
+		ToAddress:      b.batchbhs.Address(),
+		EncodedPayload: payload,
+		FeeLimit:       b.config.LimitDefault(),
+		Strategy:       txmgrcommon.NewSendEveryStrategy(),
+	})
+
+	if err != nil {
+		return errors.Wrap(err, "creating transaction")
+	}
+
+	return nil
+}
+
 func (b *BatchBlockhashStore) StoreVerifyHeader(ctx context.Context, blockNumbers []*big.Int, blockHeaders [][]byte, fromAddress common.Address) error {
 	payload, err := b.abi.Pack("storeVerifyHeader", blockNumbers, blockHeaders)
 	if err != nil {
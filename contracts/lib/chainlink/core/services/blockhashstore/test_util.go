@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"math/big"
+	"slices"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
@@ -95,6 +96,7 @@ type TestBatchBHS struct {
 	Stored                       []uint64
 	GetBlockhashesCallCounter    uint16
 	StoreVerifyHeaderCallCounter uint16
+	StoreCallCounter             uint16
 	GetBlockhashesError          error
 	StoreVerifyHeadersError      error
 }
@@ -106,20 +108,26 @@ func (t *TestBatchBHS) GetBlockhashes(_ context.Context, blockNumbers []*big.Int
 	}
 	var blockhashes [][32]byte
 	for _, b := range blockNumbers {
-		for _, stored := range t.Stored {
-			var randomBlockhash [32]byte
-			if stored == b.Uint64() {
-				_, err := rand.Read(randomBlockhash[:])
-				if err != nil {
-					return nil, err
-				}
+		var randomBlockhash [32]byte
+		if slices.Contains(t.Stored, b.Uint64()) {
+			_, err := rand.Read(randomBlockhash[:])
+			if err != nil {
+				return nil, err
 			}
-			blockhashes = append(blockhashes, randomBlockhash)
 		}
+		blockhashes = append(blockhashes, randomBlockhash)
 	}
 	return blockhashes, nil
 }
 
+func (t *TestBatchBHS) Store(_ context.Context, blockNumbers []*big.Int, fromAddress common.Address) error {
+	t.StoreCallCounter++
+	for _, blockNumber := range blockNumbers {
+		t.Stored = append(t.Stored, blockNumber.Uint64())
+	}
+	return nil
+}
+
 func (t *TestBatchBHS) StoreVerifyHeader(ctx context.Context, blockNumbers []*big.Int, blockHeaders [][]byte, fromAddress common.Address) error {
 	t.StoreVerifyHeaderCallCounter++
 	if t.StoreVerifyHeadersError != nil {
@@ -40,19 +40,31 @@ func ValidatedSpec(tomlString string) (job.Job, error) {
 		return jb, errors.Wrap(err, "unmarshalling toml job")
 	}
 
+	if spec.EVMChainID == nil {
+		return jb, notSet("evmChainID")
+	}
+	if err = ValidateSpec(&spec); err != nil {
+		return jb, err
+	}
+
+	jb.BlockhashStoreSpec = &spec
+
+	return jb, nil
+}
+
+// ValidateSpec checks the feeder settings of spec and sets the defaults of those it omits. It is
+// shared by blockhashstore jobs and the blockhash store feeders of VRF jobs.
+func ValidateSpec(spec *job.BlockhashStoreSpec) error {
 	// Required fields
 	if spec.CoordinatorV1Address == nil && spec.CoordinatorV2Address == nil && spec.CoordinatorV2PlusAddress == nil {
-		return jb, errors.New(
+		return errors.New(
 			`at least one of "coordinatorV1Address", "coordinatorV2Address" and "coordinatorV2PlusAddress" must be set`)
 	}
 	if spec.BlockhashStoreAddress == "" {
-		return jb, notSet("blockhashStoreAddress")
-	}
-	if spec.EVMChainID == nil {
-		return jb, notSet("evmChainID")
+		return notSet("blockhashStoreAddress")
 	}
 	if spec.TrustedBlockhashStoreAddress != nil && spec.TrustedBlockhashStoreAddress.Hex() != EmptyAddress && spec.TrustedBlockhashStoreBatchSize == 0 {
-		return jb, notSet("trustedBlockhashStoreBatchSize")
+		return notSet("trustedBlockhashStoreBatchSize")
 	}
 
 	// Defaults
@@ -69,24 +81,21 @@ func ValidatedSpec(tomlString string) (job.Job, error) {
 		spec.RunTimeout = 30 * time.Second
 	}
 	if spec.HeartbeatPeriod < 0 {
-		return jb, errors.New(`"heartbeatPeriod" must be greater than 0`)
+		return errors.New(`"heartbeatPeriod" must be greater than 0`)
 	}
 	// spec.HeartbeatPeriodTime == 0, default is heartbeat disabled
 
 	// Validation
 	if spec.WaitBlocks >= spec.LookbackBlocks {
-		return jb, errors.New(`"waitBlocks" must be less than "lookbackBlocks"`)
+		return errors.New(`"waitBlocks" must be less than "lookbackBlocks"`)
 	}
 	if (spec.TrustedBlockhashStoreAddress == nil || spec.TrustedBlockhashStoreAddress.Hex() == EmptyAddress) && spec.WaitBlocks >= 256 {
-		return jb, errors.New(`"waitBlocks" must be less than 256`)
+		return errors.New(`"waitBlocks" must be less than 256`)
 	}
 	if (spec.TrustedBlockhashStoreAddress == nil || spec.TrustedBlockhashStoreAddress.Hex() == EmptyAddress) && spec.LookbackBlocks >= 256 {
-		return jb, errors.New(`"lookbackBlocks" must be less than 256`)
+		return errors.New(`"lookbackBlocks" must be less than 256`)
 	}
-
-	jb.BlockhashStoreSpec = &spec
-
-	return jb, nil
+	return nil
 }
 
 func notSet(field string) error {
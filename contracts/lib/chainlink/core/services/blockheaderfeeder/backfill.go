@@ -0,0 +1,295 @@
+package blockheaderfeeder
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/errors"
+
+	evmkeystore "github.com/smartcontractkit/chainlink-evm/pkg/keys"
+	"github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/blockhashstore"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+)
+
+const (
+	// backfillAnchorDepth is how far below the latest block the backfill anchors the headers it
+	// verifies, when there is no stored blockhash above them. The anchor is stored with the
+	// blockhash instruction, so its transaction has to be mined within 256 - backfillAnchorDepth blocks.
+	backfillAnchorDepth = 128
+
+	// backfillMaxRange is the most blocks a backfill looks for unfulfilled requests in, and
+	// backfillMaxHeaders the most block headers it verifies. They bound the time a backfill
+	// takes, as it runs within an API request.
+	backfillMaxRange   = 100_000
+	backfillMaxHeaders = 10_000
+
+	// Rough gas costs of the backfill transactions, for the dry-run estimate.
+	backfillTxGas                   = 21_000
+	backfillStoreGasPerBlock        = 30_000
+	backfillVerifyHeaderGasPerBlock = 45_000
+)
+
+// Backfiller stores the blockhashes of unfulfilled VRF requests that fell out of the feeders'
+// lookback window, using the coordinators, blockhash stores and sending keys of a block header
+// feeder job.
+type Backfiller interface {
+	Backfill(ctx context.Context, jb job.Job, opts BackfillOpts) (BackfillPlan, error)
+}
+
+// BackfillOpts configures a blockhash backfill.
+type BackfillOpts struct {
+	// FromBlock and ToBlock bound the blocks of the requests to backfill, both inclusive.
+	// ToBlock defaults to the latest block.
+	FromBlock uint64
+	ToBlock   *uint64
+	// DryRun only plans the backfill and estimates its cost, without submitting transactions.
+	DryRun bool
+}
+
+// BackfillPlan is the minimal set of blockhashes to store so that every unfulfilled request
+// in a block range can be proven.
+type BackfillPlan struct {
+	JobID       int32
+	FromBlock   uint64
+	ToBlock     uint64
+	LatestBlock uint64
+	// UnfulfilledRequests is the number of requests in the range that are not fulfilled yet.
+	UnfulfilledRequests int
+	// MissingBlocks are the blocks of unfulfilled requests whose blockhash is not stored yet.
+	MissingBlocks []uint64
+	// StoreBlocks are stored with the blockhash instruction, in a single transaction sent first.
+	// They are recent missing blocks and the anchors of the headers verified below them.
+	StoreBlocks []uint64
+	// VerifyHeaderBatches are stored with storeVerifyHeader, one transaction per batch, in
+	// decreasing block order so that the blockhash of each block's child is stored first.
+	VerifyHeaderBatches [][]uint64
+	FromAddress         common.Address
+	EstimatedGas        uint64
+	GasPriceWei         *big.Int
+	EstimatedCostWei    *big.Int
+	DryRun              bool
+}
+
+// Transactions returns the number of transactions the plan is submitted in.
+func (p BackfillPlan) Transactions() int {
+	n := len(p.VerifyHeaderBatches)
+	if len(p.StoreBlocks) > 0 {
+		n++
+	}
+	return n
+}
+
+// BackfillBHS defines the BatchBlockhashStore calls a backfill is submitted with.
+type BackfillBHS interface {
+	BatchBHS
+
+	// Store stores blockhashes on-chain with the blockhash instruction
+	Store(ctx context.Context, blockNumbers []*big.Int, fromAddress common.Address) error
+}
+
+// backfiller plans and submits a backfill for a single block header feeder job.
+type backfiller struct {
+	lggr                      logger.Logger
+	coordinator               blockhashstore.Coordinator
+	batchBHS                  BackfillBHS
+	blockHeaderProvider       BlockHeaderProvider
+	latestBlock               func(ctx context.Context) (uint64, error)
+	gasPrice                  func(ctx context.Context) (*big.Int, error)
+	gethks                    evmkeystore.RoundRobin
+	getBlockhashesBatchSize   uint16
+	storeBlockhashesBatchSize uint16
+	fromAddresses             []types.EIP55Address
+	// maxHeaders is the most block headers a backfill verifies.
+	maxHeaders int
+}
+
+// backfill plans the backfill of opts' block range and, unless it is a dry-run, submits it.
+// All its transactions are sent from a single key, so that they are mined in order.
+func (b *backfiller) backfill(ctx context.Context, opts BackfillOpts) (BackfillPlan, error) {
+	plan, err := b.plan(ctx, opts)
+	if err != nil {
+		return BackfillPlan{}, err
+	}
+	if plan.Transactions() == 0 {
+		return plan, nil
+	}
+
+	plan.FromAddress, err = b.gethks.GetNextAddress(ctx, blockhashstore.SendingKeys(b.fromAddresses)...)
+	if err != nil {
+		return BackfillPlan{}, errors.Wrap(err, "getting round robin address")
+	}
+	plan.GasPriceWei, err = b.gasPrice(ctx)
+	if err != nil {
+		return BackfillPlan{}, errors.Wrap(err, "getting gas price")
+	}
+	plan.EstimatedCostWei = new(big.Int).Mul(new(big.Int).SetUint64(plan.EstimatedGas), plan.GasPriceWei)
+	if plan.DryRun {
+		return plan, nil
+	}
+
+	lggr := b.lggr.With("fromBlock", plan.FromBlock, "toBlock", plan.ToBlock, "fromAddress", plan.FromAddress)
+	if len(plan.StoreBlocks) > 0 {
+		lggr.Debugw("storing blockhashes", "blocks", plan.StoreBlocks)
+		if err = b.batchBHS.Store(ctx, toBigs(plan.StoreBlocks), plan.FromAddress); err != nil {
+			return BackfillPlan{}, errors.Wrap(err, "store blockhashes")
+		}
+	}
+	for _, batch := range plan.VerifyHeaderBatches {
+		blockRange := toBigs(batch)
+		blockHeaders, err := b.blockHeaderProvider.RlpHeadersBatch(ctx, blockRange)
+		if err != nil {
+			return BackfillPlan{}, errors.Wrap(err, "fetching block headers")
+		}
+		lggr.Debugw("storing block headers", "blockRange", batch)
+		if err = b.batchBHS.StoreVerifyHeader(ctx, blockRange, blockHeaders, plan.FromAddress); err != nil {
+			return BackfillPlan{}, errors.Wrap(err, "store block headers")
+		}
+	}
+	lggr.Infow("Submitted blockhash backfill",
+		"missingBlocks", len(plan.MissingBlocks),
+		"transactions", plan.Transactions(),
+		"estimatedGas", plan.EstimatedGas)
+	return plan, nil
+}
+
+// plan finds the blocks of unfulfilled requests in opts' range whose blockhash is missing, and
+// walks up from each of them to the closest block whose blockhash is, or will be, stored. Every
+// block on the way is stored by verifying its child's header. Walks that reach the blocks still
+// available to the blockhash instruction end at an anchor stored with it.
+func (b *backfiller) plan(ctx context.Context, opts BackfillOpts) (BackfillPlan, error) {
+	latest, err := b.latestBlock(ctx)
+	if err != nil {
+		return BackfillPlan{}, errors.Wrap(err, "fetching block number")
+	}
+	plan := BackfillPlan{FromBlock: opts.FromBlock, ToBlock: latest, LatestBlock: latest, DryRun: opts.DryRun}
+	if opts.ToBlock != nil && *opts.ToBlock < latest {
+		plan.ToBlock = *opts.ToBlock
+	}
+	if plan.FromBlock > plan.ToBlock {
+		return BackfillPlan{}, errors.Errorf("fromBlock (%d) must not be greater than toBlock (%d)", plan.FromBlock, plan.ToBlock)
+	}
+	if plan.ToBlock-plan.FromBlock >= backfillMaxRange {
+		return BackfillPlan{}, errors.Errorf("cannot backfill more than %d blocks at once, got %d to %d", backfillMaxRange, plan.FromBlock, plan.ToBlock)
+	}
+
+	lggr := b.lggr.With("latestBlock", latest, "fromBlock", plan.FromBlock, "toBlock", plan.ToBlock)
+	blockToRequests, err := blockhashstore.GetUnfulfilledBlocksAndRequests(ctx, lggr, b.coordinator, plan.FromBlock, plan.ToBlock)
+	if err != nil {
+		return BackfillPlan{}, err
+	}
+	var requestBlocks []uint64
+	for block, unfulfilledReqs := range blockToRequests {
+		if len(unfulfilledReqs) > 0 {
+			requestBlocks = append(requestBlocks, block)
+			plan.UnfulfilledRequests += len(unfulfilledReqs)
+		}
+	}
+	// Walk down from the highest block, so that lower walks can end on blocks planned by higher ones.
+	slices.Sort(requestBlocks)
+	slices.Reverse(requestBlocks)
+
+	var anchorLimit uint64
+	if latest > backfillAnchorDepth {
+		anchorLimit = latest - backfillAnchorDepth
+	}
+	stored := &storedBlocks{batchBHS: b.batchBHS, batchSize: uint64(max(b.getBlockhashesBatchSize, 1)), stored: make(map[uint64]bool)}
+	planned := make(map[uint64]struct{})
+	var verify []uint64
+	for _, block := range requestBlocks {
+		isStored, err := stored.isStored(ctx, block)
+		if err != nil {
+			return BackfillPlan{}, err
+		}
+		if _, ok := planned[block]; ok || isStored {
+			continue
+		}
+		plan.MissingBlocks = append(plan.MissingBlocks, block)
+		if block >= anchorLimit {
+			plan.StoreBlocks = append(plan.StoreBlocks, block)
+			planned[block] = struct{}{}
+			continue
+		}
+
+		walk := []uint64{block}
+		for n := block + 1; ; n++ {
+			if len(verify)+len(walk) > b.maxHeaders {
+				return BackfillPlan{}, errors.Errorf("the blockhash of block %d is more than %d block headers away from a stored one, "+
+					"backfill the requests of more recent blocks first", block, b.maxHeaders)
+			}
+			if _, ok := planned[n]; ok {
+				break
+			}
+			if isStored, err = stored.isStored(ctx, n); err != nil {
+				return BackfillPlan{}, err
+			} else if isStored {
+				break
+			}
+			if n >= anchorLimit {
+				plan.StoreBlocks = append(plan.StoreBlocks, n)
+				planned[n] = struct{}{}
+				break
+			}
+			walk = append(walk, n)
+		}
+		for _, n := range walk {
+			planned[n] = struct{}{}
+		}
+		verify = append(verify, walk...)
+	}
+	slices.Sort(plan.MissingBlocks)
+	slices.Sort(plan.StoreBlocks)
+	slices.Sort(verify)
+	slices.Reverse(verify)
+
+	batchSize := int(max(b.storeBlockhashesBatchSize, 1))
+	for i := 0; i < len(verify); i += batchSize {
+		plan.VerifyHeaderBatches = append(plan.VerifyHeaderBatches, verify[i:min(i+batchSize, len(verify))])
+	}
+	plan.EstimatedGas = uint64(plan.Transactions())*backfillTxGas +
+		uint64(len(plan.StoreBlocks))*backfillStoreGasPerBlock +
+		uint64(len(verify))*backfillVerifyHeaderGasPerBlock
+	return plan, nil
+}
+
+// storedBlocks looks up which blockhashes are stored, a batch of consecutive blocks at a time.
+type storedBlocks struct {
+	batchBHS  BatchBHS
+	batchSize uint64
+	stored    map[uint64]bool
+}
+
+func (s *storedBlocks) isStored(ctx context.Context, block uint64) (bool, error) {
+	if isStored, ok := s.stored[block]; ok {
+		return isStored, nil
+	}
+	var blocks []*big.Int
+	for n := block; n < block+s.batchSize; n++ {
+		if _, ok := s.stored[n]; !ok {
+			blocks = append(blocks, new(big.Int).SetUint64(n))
+		}
+	}
+	blockhashes, err := s.batchBHS.GetBlockhashes(ctx, blocks)
+	if err != nil {
+		return false, errors.Wrap(err, "fetching blockhashes")
+	}
+	if len(blockhashes) != len(blocks) {
+		return false, errors.Errorf("fetching blockhashes: got %d blockhashes for %d blocks", len(blockhashes), len(blocks))
+	}
+	for i, bh := range blockhashes {
+		s.stored[blocks[i].Uint64()] = !bytes.Equal(bh[:], zeroHash[:])
+	}
+	return s.stored[block], nil
+}
+
+func toBigs(blocks []uint64) []*big.Int {
+	bigs := make([]*big.Int, len(blocks))
+	for i, b := range blocks {
+		bigs[i] = new(big.Int).SetUint64(b)
+	}
+	return bigs
+}
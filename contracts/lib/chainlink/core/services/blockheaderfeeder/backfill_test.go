@@ -0,0 +1,135 @@
+package blockheaderfeeder
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/keys/keystest"
+	"github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/blockhashstore"
+)
+
+func newTestBackfiller(t *testing.T, requests, fulfillments []blockhashstore.Event, batchBHS *blockhashstore.TestBatchBHS, latest uint64) *backfiller {
+	fromAddress := "0x469aA2CD13e037DC5236320783dCfd0e641c0559"
+	return &backfiller{
+		lggr: logger.TestLogger(t),
+		coordinator: &blockhashstore.TestCoordinator{
+			RequestEvents:     requests,
+			FulfillmentEvents: fulfillments,
+		},
+		batchBHS:            batchBHS,
+		blockHeaderProvider: &blockhashstore.TestBlockHeaderProvider{},
+		latestBlock: func(ctx context.Context) (uint64, error) {
+			return latest, nil
+		},
+		gasPrice: func(ctx context.Context) (*big.Int, error) {
+			return big.NewInt(2), nil
+		},
+		gethks:                    keystest.Addresses{common.HexToAddress(fromAddress)},
+		getBlockhashesBatchSize:   4,
+		storeBlockhashesBatchSize: 3,
+		fromAddresses:             []types.EIP55Address{types.EIP55Address(fromAddress)},
+		maxHeaders:                backfillMaxHeaders,
+	}
+}
+
+func TestBackfiller(t *testing.T) {
+	t.Parallel()
+
+	t.Run("verifies headers down from a stored blockhash", func(t *testing.T) {
+		batchBHS := &blockhashstore.TestBatchBHS{Stored: []uint64{155}}
+		b := newTestBackfiller(t, []blockhashstore.Event{{Block: 150, ID: "request"}}, nil, batchBHS, 1000)
+
+		plan, err := b.backfill(testutils.Context(t), BackfillOpts{FromBlock: 100})
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1000), plan.ToBlock)
+		assert.Equal(t, 1, plan.UnfulfilledRequests)
+		assert.Equal(t, []uint64{150}, plan.MissingBlocks)
+		assert.Empty(t, plan.StoreBlocks)
+		assert.Equal(t, [][]uint64{{154, 153, 152}, {151, 150}}, plan.VerifyHeaderBatches)
+		assert.Equal(t, 2, plan.Transactions())
+		assert.Equal(t, uint64(2*backfillTxGas+5*backfillVerifyHeaderGasPerBlock), plan.EstimatedGas)
+		assert.Equal(t, new(big.Int).SetUint64(2*plan.EstimatedGas), plan.EstimatedCostWei)
+
+		assert.ElementsMatch(t, []uint64{150, 151, 152, 153, 154, 155}, batchBHS.Stored)
+		assert.Equal(t, uint16(2), batchBHS.StoreVerifyHeaderCallCounter)
+		assert.Zero(t, batchBHS.StoreCallCounter)
+	})
+
+	t.Run("shares the headers verified for a higher block", func(t *testing.T) {
+		batchBHS := &blockhashstore.TestBatchBHS{Stored: []uint64{158}}
+		b := newTestBackfiller(t, []blockhashstore.Event{{Block: 150, ID: "request1"}, {Block: 155, ID: "request2"}}, nil, batchBHS, 1000)
+
+		plan, err := b.backfill(testutils.Context(t), BackfillOpts{FromBlock: 100, DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{150, 155}, plan.MissingBlocks)
+		assert.Equal(t, [][]uint64{{157, 156, 155}, {154, 153, 152}, {151, 150}}, plan.VerifyHeaderBatches)
+		assert.True(t, plan.DryRun)
+		assert.Equal(t, []uint64{158}, batchBHS.Stored, "dry-run submits nothing")
+	})
+
+	t.Run("anchors headers to a recent blockhash", func(t *testing.T) {
+		batchBHS := &blockhashstore.TestBatchBHS{}
+		b := newTestBackfiller(t, []blockhashstore.Event{{Block: 125, ID: "request1"}, {Block: 290, ID: "request2"}}, nil, batchBHS, 300)
+
+		plan, err := b.backfill(testutils.Context(t), BackfillOpts{FromBlock: 100})
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{125, 290}, plan.MissingBlocks)
+		assert.Equal(t, []uint64{172, 290}, plan.StoreBlocks)
+		var verified []uint64
+		for _, batch := range plan.VerifyHeaderBatches {
+			verified = append(verified, batch...)
+		}
+		require.Len(t, verified, 172-125)
+		assert.Equal(t, uint64(171), verified[0])
+		assert.Equal(t, uint64(125), verified[len(verified)-1])
+		assert.Equal(t, uint16(1), batchBHS.StoreCallCounter)
+		assert.Contains(t, batchBHS.Stored, uint64(125))
+	})
+
+	t.Run("nothing to backfill", func(t *testing.T) {
+		batchBHS := &blockhashstore.TestBatchBHS{Stored: []uint64{150}}
+		b := newTestBackfiller(t,
+			[]blockhashstore.Event{{Block: 150, ID: "request1"}, {Block: 160, ID: "request2"}},
+			[]blockhashstore.Event{{Block: 170, ID: "request2"}},
+			batchBHS, 1000)
+
+		plan, err := b.backfill(testutils.Context(t), BackfillOpts{FromBlock: 100})
+		require.NoError(t, err)
+		assert.Equal(t, 1, plan.UnfulfilledRequests)
+		assert.Empty(t, plan.MissingBlocks)
+		assert.Zero(t, plan.Transactions())
+		assert.Zero(t, plan.EstimatedGas)
+		assert.Nil(t, plan.EstimatedCostWei)
+	})
+
+	t.Run("too many headers to verify", func(t *testing.T) {
+		batchBHS := &blockhashstore.TestBatchBHS{Stored: []uint64{155}}
+		b := newTestBackfiller(t, []blockhashstore.Event{{Block: 150, ID: "request"}}, nil, batchBHS, 1000)
+		b.maxHeaders = 4
+
+		_, err := b.backfill(testutils.Context(t), BackfillOpts{FromBlock: 100})
+		require.ErrorContains(t, err, "the blockhash of block 150 is more than 4 block headers away from a stored one")
+		assert.Equal(t, []uint64{155}, batchBHS.Stored)
+	})
+
+	t.Run("range too large", func(t *testing.T) {
+		b := newTestBackfiller(t, nil, nil, &blockhashstore.TestBatchBHS{}, 1000+backfillMaxRange)
+		_, err := b.backfill(testutils.Context(t), BackfillOpts{FromBlock: 1000})
+		require.ErrorContains(t, err, "cannot backfill more than")
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		toBlock := uint64(100)
+		b := newTestBackfiller(t, nil, nil, &blockhashstore.TestBatchBHS{}, 1000)
+		_, err := b.backfill(testutils.Context(t), BackfillOpts{FromBlock: 200, ToBlock: &toBlock})
+		require.EqualError(t, err, "fromBlock (200) must not be greater than toBlock (100)")
+	})
+}
@@ -70,6 +70,51 @@ func (d *Delegate) ServicesForSpec(ctx context.Context, jb job.Job) ([]job.Servi
 	}
 	d.logger.Debugw("Creating services for job spec", "job", string(marshalledJob))
 
+	c, err := d.newComponents(ctx, jb)
+	if err != nil {
+		return nil, err
+	}
+
+	feeder := NewBlockHeaderFeeder(
+		c.log,
+		c.coordinator,
+		c.bhs,
+		c.batchBHS,
+		c.blockHeaderProvider,
+		int(jb.BlockHeaderFeederSpec.WaitBlocks),
+		int(jb.BlockHeaderFeederSpec.LookbackBlocks),
+		c.latestBlock,
+		c.ks,
+		jb.BlockHeaderFeederSpec.GetBlockhashesBatchSize,
+		jb.BlockHeaderFeederSpec.StoreBlockhashesBatchSize,
+		jb.BlockHeaderFeederSpec.FromAddresses,
+	)
+
+	services := []job.ServiceCtx{&service{
+		feeder:     feeder,
+		pollPeriod: jb.BlockHeaderFeederSpec.PollPeriod,
+		runTimeout: jb.BlockHeaderFeederSpec.RunTimeout,
+		logger:     c.log,
+		done:       make(chan struct{}),
+	}}
+
+	return services, nil
+}
+
+// components are the chain clients, contracts and keys a block header feeder job stores
+// blockhashes with.
+type components struct {
+	chain               legacyevm.Chain
+	log                 logger.Logger
+	coordinator         blockhashstore.Coordinator
+	bhs                 *blockhashstore.BulletproofBHS
+	batchBHS            *blockhashstore.BatchBlockhashStore
+	blockHeaderProvider *GethBlockHeaderProvider
+	ks                  keys.ChainStore
+	latestBlock         func(ctx context.Context) (uint64, error)
+}
+
+func (d *Delegate) newComponents(ctx context.Context, jb job.Job) (*components, error) {
 	cid := jb.BlockHeaderFeederSpec.EVMChainID.ToInt()
 	chainService, err := d.legacyChains.Get(cid.String())
 	if err != nil {
@@ -187,38 +232,50 @@ func (d *Delegate) ServicesForSpec(ctx context.Context, jb job.Job) ([]job.Servi
 		"batchBHSAddress", batchBlockhashStore.Address(),
 	)
 
-	blockHeaderProvider := NewGethBlockHeaderProvider(chain.Client())
-
-	feeder := NewBlockHeaderFeeder(
-		log,
-		blockhashstore.NewMultiCoordinator(coordinators...),
-		bpBHS,
-		batchBHS,
-		blockHeaderProvider,
-		int(jb.BlockHeaderFeederSpec.WaitBlocks),
-		int(jb.BlockHeaderFeederSpec.LookbackBlocks),
-		func(ctx context.Context) (uint64, error) {
+	return &components{
+		chain:               chain,
+		log:                 log,
+		coordinator:         blockhashstore.NewMultiCoordinator(coordinators...),
+		bhs:                 bpBHS,
+		batchBHS:            batchBHS,
+		blockHeaderProvider: NewGethBlockHeaderProvider(chain.Client()),
+		ks:                  ks,
+		latestBlock: func(ctx context.Context) (uint64, error) {
 			head, err := chain.Client().HeadByNumber(ctx, nil)
 			if err != nil {
 				return 0, errors.Wrap(err, "getting chain head")
 			}
 			return uint64(head.Number), nil
 		},
-		ks,
-		jb.BlockHeaderFeederSpec.GetBlockhashesBatchSize,
-		jb.BlockHeaderFeederSpec.StoreBlockhashesBatchSize,
-		fromAddresses,
-	)
-
-	services := []job.ServiceCtx{&service{
-		feeder:     feeder,
-		pollPeriod: jb.BlockHeaderFeederSpec.PollPeriod,
-		runTimeout: jb.BlockHeaderFeederSpec.RunTimeout,
-		logger:     log,
-		done:       make(chan struct{}),
-	}}
+	}, nil
+}
 
-	return services, nil
+// Backfill satisfies the Backfiller interface. It plans the backfill with the job's
+// coordinators, blockhash stores and batch sizes, and submits it from one of its fromAddresses.
+func (d *Delegate) Backfill(ctx context.Context, jb job.Job, opts BackfillOpts) (BackfillPlan, error) {
+	if jb.BlockHeaderFeederSpec == nil {
+		return BackfillPlan{}, errors.Errorf("job %d is not a block header feeder job", jb.ID)
+	}
+	c, err := d.newComponents(ctx, jb)
+	if err != nil {
+		return BackfillPlan{}, err
+	}
+	b := &backfiller{
+		lggr:                      c.log.Named("Backfill"),
+		coordinator:               c.coordinator,
+		batchBHS:                  c.batchBHS,
+		blockHeaderProvider:       c.blockHeaderProvider,
+		latestBlock:               c.latestBlock,
+		gasPrice:                  c.chain.Client().SuggestGasPrice,
+		gethks:                    c.ks,
+		getBlockhashesBatchSize:   jb.BlockHeaderFeederSpec.GetBlockhashesBatchSize,
+		storeBlockhashesBatchSize: jb.BlockHeaderFeederSpec.StoreBlockhashesBatchSize,
+		fromAddresses:             jb.BlockHeaderFeederSpec.FromAddresses,
+		maxHeaders:                backfillMaxHeaders,
+	}
+	plan, err := b.backfill(ctx, opts)
+	plan.JobID = jb.ID
+	return plan, err
 }
 
 // AfterJobCreated satisfies the job.Delegate interface.
@@ -37,24 +37,36 @@ func ValidatedSpec(tomlString string) (job.Job, error) {
 		return jb, errors.Wrap(err, "unmarshalling toml job")
 	}
 
+	if spec.EVMChainID == nil {
+		return jb, notSet("evmChainID")
+	}
+	if err = ValidateSpec(&spec); err != nil {
+		return jb, err
+	}
+
+	jb.BlockHeaderFeederSpec = &spec
+
+	return jb, nil
+}
+
+// ValidateSpec checks the feeder settings of spec and sets the defaults of those it omits. It is
+// shared by blockheaderfeeder jobs and the block header feeders of VRF jobs.
+func ValidateSpec(spec *job.BlockHeaderFeederSpec) error {
 	// Required fields
 	if spec.CoordinatorV1Address == nil && spec.CoordinatorV2Address == nil && spec.CoordinatorV2PlusAddress == nil {
-		return jb, errors.New(
+		return errors.New(
 			`at least one of "coordinatorV1Address", "coordinatorV2Address" and "coordinatorV2PlusAddress" must be set`)
 	}
 	if spec.BlockhashStoreAddress == "" {
-		return jb, notSet("blockhashStoreAddress")
+		return notSet("blockhashStoreAddress")
 	}
 	if spec.BatchBlockhashStoreAddress == "" {
-		return jb, notSet("batchBlockhashStoreAddress")
+		return notSet("batchBlockhashStoreAddress")
 	}
-	if spec.EVMChainID == nil {
-		return jb, notSet("evmChainID")
-	}
-
-	err = validateChainID(spec.EVMChainID.Int64())
-	if err != nil {
-		return jb, err
+	if spec.EVMChainID != nil {
+		if err := validateChainID(spec.EVMChainID.Int64()); err != nil {
+			return err
+		}
 	}
 
 	// Defaults
@@ -78,18 +90,15 @@ func ValidatedSpec(tomlString string) (job.Job, error) {
 	}
 
 	if spec.WaitBlocks < 256 {
-		return jb, errors.New(`"waitBlocks" must be greater than or equal to 256`)
+		return errors.New(`"waitBlocks" must be greater than or equal to 256`)
 	}
 	if spec.LookbackBlocks <= 256 {
-		return jb, errors.New(`"lookbackBlocks" must be greater than 256`)
+		return errors.New(`"lookbackBlocks" must be greater than 256`)
 	}
 	if spec.WaitBlocks >= spec.LookbackBlocks {
-		return jb, errors.New(`"lookbackBlocks" must be greater than "waitBlocks"`)
+		return errors.New(`"lookbackBlocks" must be greater than "waitBlocks"`)
 	}
-
-	jb.BlockHeaderFeederSpec = &spec
-
-	return jb, nil
+	return nil
 }
 
 func notSet(field string) error {
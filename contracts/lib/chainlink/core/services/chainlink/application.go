@@ -92,6 +92,8 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/streams"
 	"github.com/smartcontractkit/chainlink/v2/core/services/telemetry"
 	"github.com/smartcontractkit/chainlink/v2/core/services/vrf"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/prover"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
 	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows"
 	artifactsV1 "github.com/smartcontractkit/chainlink/v2/core/services/workflows/artifacts"
@@ -137,6 +139,7 @@ type Application interface {
 	JobSpawner() job.Spawner
 	JobORM() job.ORM
 	PipelineORM() pipeline.ORM
+	PipelineTemplateORM() pipeline.TemplateORM
 	BridgeORM() bridges.ORM
 	BasicAdminUsersORM() sessions.BasicAdminUsersORM
 	AuthenticationProvider() sessions.AuthenticationProvider
@@ -145,6 +148,20 @@ type Application interface {
 	DeleteJob(ctx context.Context, jobID int32) error
 	RunWebhookJobV2(ctx context.Context, jobUUID uuid.UUID, requestBody string, meta jsonserializable.JSONSerializable) (int64, error)
 	ResumeJobV2(ctx context.Context, taskID uuid.UUID, result pipeline.Result) error
+	// RefulfillVRFRequest re-fulfills a VRF v2 or v2plus request through the running job that serves it.
+	RefulfillVRFRequest(ctx context.Context, coordinator common.Address, requestID *big.Int, opts vrfcommon.RefulfillOpts) (vrfcommon.RefulfillResult, error)
+	// BackfillBlockhashes stores the blockhashes of the unfulfilled VRF requests in a block range
+	// through a block header feeder job.
+	BackfillBlockhashes(ctx context.Context, jobID int32, opts blockheaderfeeder.BackfillOpts) (blockheaderfeeder.BackfillPlan, error)
+	// ReconfigureVRFJob updates the mutable fields of a VRF v2 or v2plus job, without restarting it.
+	ReconfigureVRFJob(ctx context.Context, jobID int32, tomlString string) (job.Job, error)
+	// DryRunJobV2 runs the pipeline of a job spec once without creating the job, with its http, bridge,
+	// ethcall, ethtx and estimategaslimit tasks served from fixtures.
+	DryRunJobV2(ctx context.Context, tomlString string, fixtures pipeline.DryRunFixtures) (*pipeline.Run, pipeline.TaskRunResults, error)
+	// ReplayJobRunV2 runs the pipeline of a job again with the inputs of the finished run original,
+	// reusing its recorded results for every task but fromTask and its descendants. It returns the
+	// new run and the dot IDs of the tasks which were executed again.
+	ReplayJobRunV2(ctx context.Context, original pipeline.Run, fromTask string) (*pipeline.Run, []string, error)
 	// Testing only
 	RunJobV2(ctx context.Context, jobID int32, meta map[string]interface{}) (int64, error)
 
@@ -174,6 +191,7 @@ type ChainlinkApplication struct {
 	jobORM                   job.ORM
 	jobSpawner               job.Spawner
 	pipelineORM              pipeline.ORM
+	pipelineTemplateORM      pipeline.TemplateORM
 	pipelineRunner           pipeline.Runner
 	bridgeORM                bridges.ORM
 	localAdminUsersORM       sessions.BasicAdminUsersORM
@@ -181,6 +199,9 @@ type ChainlinkApplication struct {
 	txmStorageService        txmgr.EvmTxStore
 	FeedsService             feeds.Service
 	webhookJobRunner         webhook.JobRunner
+	vrfRefulfiller           vrf.Refulfiller
+	vrfReconfigurer          vrf.Reconfigurer
+	bhsBackfiller            blockheaderfeeder.Backfiller
 	Config                   GeneralConfig
 	KeyStore                 keystore.Master
 	ExternalInitiatorManager webhook.ExternalInitiatorManager
@@ -499,15 +520,26 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 		return nil, errors.Errorf("NewApplication: Unexpected 'AuthenticationMethod': %s supported values: %s, %s", authMethod, sessions.LocalAuth, sessions.LDAPAuth)
 	}
 
+	var vrfProver pipeline.VRFProver = prover.NewKeystoreProver(keyStore.VRF())
+	if cfg.VRFProver().URL() != "" {
+		remoteProver, err := prover.NewRemoteProver(cfg.VRFProver().URL(), cfg.VRFProver().TLSEnabled())
+		if err != nil {
+			return nil, errors.Wrap(err, "NewApplication: failed to initialize remote VRF prover")
+		}
+		srvcs = append(srvcs, remoteProver)
+		vrfProver = remoteProver
+	}
+
 	var (
-		pipelineORM    = pipeline.NewORM(opts.DS, globalLogger, cfg.JobPipeline().MaxSuccessfulRuns())
-		bridgeORM      = bridges.NewORM(opts.DS)
-		mercuryORM     = mercury.NewORM(opts.DS)
-		pipelineRunner = pipeline.NewRunner(pipelineORM, bridgeORM, cfg.JobPipeline(), cfg.WebServer(), legacyEVMChains, keyStore.Eth(), keyStore.VRF(), globalLogger, restrictedHTTPClient, unrestrictedHTTPClient)
-		jobORM         = job.NewORM(opts.DS, pipelineORM, bridgeORM, keyStore, globalLogger)
-		txmORM         = txmgr.NewTxStore(opts.DS, globalLogger)
-		streamRegistry = streams.NewRegistry(globalLogger, pipelineRunner)
-		workflowORM    = workflowstore.NewInMemoryStore(globalLogger, clockwork.NewRealClock())
+		pipelineORM         = pipeline.NewORM(opts.DS, globalLogger, cfg.JobPipeline().MaxSuccessfulRuns())
+		pipelineTemplateORM = pipeline.NewTemplateORM(opts.DS, globalLogger)
+		bridgeORM           = bridges.NewORM(opts.DS)
+		mercuryORM          = mercury.NewORM(opts.DS)
+		pipelineRunner      = pipeline.NewRunner(pipelineORM, bridgeORM, cfg.JobPipeline(), cfg.WebServer(), legacyEVMChains, keyStore.Eth(), vrfProver, globalLogger, restrictedHTTPClient, unrestrictedHTTPClient)
+		jobORM              = job.NewORM(opts.DS, pipelineORM, bridgeORM, keyStore, globalLogger)
+		txmORM              = txmgr.NewTxStore(opts.DS, globalLogger)
+		streamRegistry      = streams.NewRegistry(globalLogger, pipelineRunner)
+		workflowORM         = workflowstore.NewInMemoryStore(globalLogger, clockwork.NewRealClock())
 	)
 	srvcs = append(srvcs, workflowORM)
 
@@ -531,10 +563,23 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 	}
 
 	srvcs = append(srvcs, pipelineORM)
+	// Templates are loaded before the job spawner parses the pipelines of existing jobs.
+	srvcs = append(srvcs, pipelineTemplateORM)
+	pipeline.SetTemplateSource(pipelineTemplateORM)
 
 	loopRegistrarConfig := plugins.NewRegistrarConfig(opts.GRPCOpts, loopRegistry.Register, loopRegistry.Unregister)
 
 	var (
+		bhsDelegate = blockhashstore.NewDelegate(
+			cfg,
+			globalLogger,
+			legacyEVMChains,
+			keyStore.Eth())
+		bhfDelegate = blockheaderfeeder.NewDelegate(
+			cfg,
+			globalLogger,
+			legacyEVMChains,
+			keyStore.Eth())
 		delegates = map[job.Type]job.Delegate{
 			job.DirectRequest: directrequest.NewDelegate(
 				globalLogger,
@@ -557,7 +602,12 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 				pipelineORM,
 				legacyEVMChains,
 				globalLogger,
-				mailMon),
+				mailMon,
+				bhsDelegate,
+				bhfDelegate,
+				cfg.VRFProver().URL() != "",
+				restrictedHTTPClient,
+				cfg.JobPipeline().DefaultHTTPTimeout().Duration()),
 			job.Webhook: webhook.NewDelegate(
 				pipelineRunner,
 				externalInitiatorManager,
@@ -565,16 +615,8 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 			job.Cron: cron.NewDelegate(
 				pipelineRunner,
 				globalLogger),
-			job.BlockhashStore: blockhashstore.NewDelegate(
-				cfg,
-				globalLogger,
-				legacyEVMChains,
-				keyStore.Eth()),
-			job.BlockHeaderFeeder: blockheaderfeeder.NewDelegate(
-				cfg,
-				globalLogger,
-				legacyEVMChains,
-				keyStore.Eth()),
+			job.BlockhashStore:    bhsDelegate,
+			job.BlockHeaderFeeder: bhfDelegate,
 			job.Gateway: gateway.NewDelegate(
 				legacyEVMChains,
 				keyStore.Eth(),
@@ -590,6 +632,9 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 			),
 		}
 		webhookJobRunner = delegates[job.Webhook].(*webhook.Delegate).WebhookJobRunner()
+		vrfRefulfiller   = delegates[job.VRF].(*vrf.Delegate).Refulfiller()
+		vrfReconfigurer  = delegates[job.VRF].(*vrf.Delegate).Reconfigurer()
+		bhsBackfiller    = bhfDelegate
 	)
 
 	delegates[job.Workflow] = workflows.NewDelegate(
@@ -780,6 +825,7 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 		jobSpawner:               jobSpawner,
 		pipelineRunner:           pipelineRunner,
 		pipelineORM:              pipelineORM,
+		pipelineTemplateORM:      pipelineTemplateORM,
 		bridgeORM:                bridgeORM,
 		localAdminUsersORM:       localAdminUsersORM,
 		authenticationProvider:   authenticationProvider,
@@ -787,6 +833,9 @@ func NewApplication(ctx context.Context, opts ApplicationOpts) (Application, err
 		FeedsService:             feedsService,
 		Config:                   cfg,
 		webhookJobRunner:         webhookJobRunner,
+		vrfRefulfiller:           vrfRefulfiller,
+		vrfReconfigurer:          vrfReconfigurer,
+		bhsBackfiller:            bhsBackfiller,
 		KeyStore:                 keyStore,
 		SessionReaper:            sessionReaper,
 		ExternalInitiatorManager: externalInitiatorManager,
@@ -1419,6 +1468,10 @@ func (app *ChainlinkApplication) PipelineORM() pipeline.ORM {
 	return app.pipelineORM
 }
 
+func (app *ChainlinkApplication) PipelineTemplateORM() pipeline.TemplateORM {
+	return app.pipelineTemplateORM
+}
+
 func (app *ChainlinkApplication) TxmStorageService() txmgr.EvmTxStore {
 	return app.txmStorageService
 }
@@ -1458,6 +1511,44 @@ func (app *ChainlinkApplication) RunWebhookJobV2(ctx context.Context, jobUUID uu
 	return app.webhookJobRunner.RunJob(ctx, jobUUID, requestBody, meta)
 }
 
+// RefulfillVRFRequest implements the Application interface.
+func (app *ChainlinkApplication) RefulfillVRFRequest(ctx context.Context, coordinator common.Address, requestID *big.Int, opts vrfcommon.RefulfillOpts) (vrfcommon.RefulfillResult, error) {
+	return app.vrfRefulfiller.Refulfill(ctx, coordinator, requestID, opts)
+}
+
+// BackfillBlockhashes implements the Application interface.
+func (app *ChainlinkApplication) BackfillBlockhashes(ctx context.Context, jobID int32, opts blockheaderfeeder.BackfillOpts) (blockheaderfeeder.BackfillPlan, error) {
+	jb, err := app.jobORM.FindJob(ctx, jobID)
+	if err != nil {
+		return blockheaderfeeder.BackfillPlan{}, err
+	}
+	return app.bhsBackfiller.Backfill(ctx, jb, opts)
+}
+
+// ReconfigureVRFJob implements the Application interface.
+func (app *ChainlinkApplication) ReconfigureVRFJob(ctx context.Context, jobID int32, tomlString string) (job.Job, error) {
+	// Do not allow the job to be updated if it is managed by the Feeds Manager
+	isManaged, err := app.FeedsService.IsJobManaged(ctx, int64(jobID))
+	if err != nil {
+		return job.Job{}, err
+	}
+
+	if isManaged {
+		return job.Job{}, errors.New("job must be updated in the feeds manager")
+	}
+
+	return app.vrfReconfigurer.Reconfigure(ctx, app.jobORM, jobID, tomlString)
+}
+
+// DryRunJobV2 implements the Application interface.
+func (app *ChainlinkApplication) DryRunJobV2(ctx context.Context, tomlString string, fixtures pipeline.DryRunFixtures) (*pipeline.Run, pipeline.TaskRunResults, error) {
+	spec, err := job.DryRunSpec(tomlString)
+	if err != nil {
+		return nil, nil, err
+	}
+	return app.pipelineRunner.ExecuteDryRun(ctx, spec, fixtures)
+}
+
 // Only used for local testing, not supported by the UI.
 func (app *ChainlinkApplication) RunJobV2(
 	ctx context.Context,
@@ -1523,6 +1614,35 @@ func (app *ChainlinkApplication) RunJobV2(
 	return runID, err
 }
 
+// ReplayJobRunV2 implements the Application interface.
+func (app *ChainlinkApplication) ReplayJobRunV2(ctx context.Context, original pipeline.Run, fromTask string) (*pipeline.Run, []string, error) {
+	if original.PipelineSpec.JobID == 0 {
+		return nil, nil, fmt.Errorf("%w: run %d does not belong to a job", pipeline.ErrCannotReplay, original.ID)
+	}
+	jb, err := app.jobORM.FindJob(ctx, original.PipelineSpec.JobID)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "job ID %v", original.PipelineSpec.JobID)
+	}
+	// the spec is set up the way the job spawner does it for running jobs
+	spec := *jb.PipelineSpec
+	spec.JobName = jb.Name.ValueOrZero()
+	spec.JobType = string(jb.Type)
+	spec.ForwardingAllowed = jb.ForwardingAllowed
+	if jb.GasLimit.Valid {
+		spec.GasLimit = &jb.GasLimit.Uint32
+	}
+
+	run, replayed, err := pipeline.NewReplayRun(spec, original, fromTask)
+	if err != nil {
+		return nil, nil, err
+	}
+	// successful task runs are kept so that the replay can be compared with the original run
+	if _, err = app.pipelineRunner.Run(ctx, run, true, nil); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to replay run %d", original.ID)
+	}
+	return run, replayed, nil
+}
+
 func (app *ChainlinkApplication) ResumeJobV2(
 	ctx context.Context,
 	taskID uuid.UUID,
@@ -592,4 +592,8 @@ func (g *generalConfig) BridgeStatusReporter() coreconfig.BridgeStatusReporter {
 	return &bridgeStatusReporterConfig{c: g.c.BridgeStatusReporter}
 }
 
+func (g *generalConfig) VRFProver() coreconfig.VRFProver {
+	return &vrfProverConfig{t: g.c.VRFProver}
+}
+
 var zeroSha256Hash = models.Sha256Hash{}
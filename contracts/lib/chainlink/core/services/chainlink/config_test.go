@@ -639,6 +639,10 @@ func TestConfig_Marshal(t *testing.T) {
 		IgnoreInvalidBridges: ptr(true),
 		IgnoreJoblessBridges: ptr(false),
 	}
+	full.VRFProver = toml.VRFProver{
+		URL:        ptr("localhost:50051"),
+		TLSEnabled: ptr(true),
+	}
 	full.JobDistributor = toml.JobDistributor{
 		DisplayName: ptr("test-node"),
 	}
@@ -0,0 +1,20 @@
+package chainlink
+
+import (
+	"github.com/smartcontractkit/chainlink/v2/core/config"
+	"github.com/smartcontractkit/chainlink/v2/core/config/toml"
+)
+
+var _ config.VRFProver = (*vrfProverConfig)(nil)
+
+type vrfProverConfig struct {
+	t toml.VRFProver
+}
+
+func (c *vrfProverConfig) URL() string {
+	return *c.t.URL
+}
+
+func (c *vrfProverConfig) TLSEnabled() bool {
+	return *c.t.TLSEnabled
+}
@@ -2607,6 +2607,53 @@ func (_c *GeneralConfig_TronEnabled_Call) RunAndReturn(run func() bool) *General
 	return _c
 }
 
+// VRFProver provides a mock function with no fields
+func (_m *GeneralConfig) VRFProver() config.VRFProver {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for VRFProver")
+	}
+
+	var r0 config.VRFProver
+	if rf, ok := ret.Get(0).(func() config.VRFProver); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(config.VRFProver)
+		}
+	}
+
+	return r0
+}
+
+// GeneralConfig_VRFProver_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VRFProver'
+type GeneralConfig_VRFProver_Call struct {
+	*mock.Call
+}
+
+// VRFProver is a helper method to define mock.On call
+func (_e *GeneralConfig_Expecter) VRFProver() *GeneralConfig_VRFProver_Call {
+	return &GeneralConfig_VRFProver_Call{Call: _e.mock.On("VRFProver")}
+}
+
+func (_c *GeneralConfig_VRFProver_Call) Run(run func()) *GeneralConfig_VRFProver_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *GeneralConfig_VRFProver_Call) Return(_a0 config.VRFProver) *GeneralConfig_VRFProver_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *GeneralConfig_VRFProver_Call) RunAndReturn(run func() config.VRFProver) *GeneralConfig_VRFProver_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Validate provides a mock function with no fields
 func (_m *GeneralConfig) Validate() error {
 	ret := _m.Called()
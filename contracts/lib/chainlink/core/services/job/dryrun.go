@@ -0,0 +1,37 @@
+package job
+
+import (
+	"github.com/pelletier/go-toml"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+// DryRunSpec returns the pipeline spec of a job spec TOML, for it to be dry run
+// with pipeline.Runner.ExecuteDryRun without creating the job.
+func DryRunSpec(ts string) (pipeline.Spec, error) {
+	jobType, err := ValidateSpec(ts)
+	if err != nil {
+		return pipeline.Spec{}, err
+	}
+	var jb Job
+	if err = toml.Unmarshal([]byte(ts), &jb); err != nil {
+		return pipeline.Spec{}, err
+	}
+	if jb.Pipeline.Source == "" {
+		return pipeline.Spec{}, errors.Errorf("%v jobs have no pipeline to dry run", jobType)
+	}
+
+	spec := pipeline.Spec{
+		DotDagSource:      jb.Pipeline.Source,
+		MaxTaskDuration:   jb.MaxTaskDuration,
+		ForwardingAllowed: jb.ForwardingAllowed,
+		JobName:           jb.Name.ValueOrZero(),
+		JobType:           string(jobType),
+		Pipeline:          &jb.Pipeline,
+	}
+	if jb.GasLimit.Valid {
+		spec.GasLimit = &jb.GasLimit.Uint32
+	}
+	return spec, nil
+}
@@ -0,0 +1,72 @@
+package job
+
+import (
+	"strings"
+
+	"github.com/pelletier/go-toml"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+// pipelineVars are the variables each job type passes into its pipeline runs.
+var pipelineVars = map[Type][]string{
+	Cron:               {"jobSpec", "jobRun"},
+	DirectRequest:      {"jobSpec", "jobRun"},
+	FluxMonitor:        {"jobSpec", "jobRun"},
+	Keeper:             {"jobSpec"},
+	OffchainReporting:  {"jb", "jobRun"},
+	OffchainReporting2: {"jb", "jobRun"},
+	Stream:             {"jb"},
+	VRF:                {"jobSpec", "jobRun"},
+	Webhook:            {"jobSpec", "jobRun"},
+}
+
+// LintSpec statically checks the pipeline of a job spec, see pipeline.Lint.
+// The lines of the returned issues are lines of the TOML, not of the
+// observationSource. Specs without a pipeline have nothing to lint.
+func LintSpec(ts string) (Type, []pipeline.LintIssue, error) {
+	tree, err := toml.Load(ts)
+	if err != nil {
+		return "", nil, err
+	}
+	jobType, _ := tree.Get("type").(string)
+	if _, ok := jobTypes[Type(jobType)]; !ok {
+		return "", nil, ErrInvalidJobType
+	}
+	source, _ := tree.Get("observationSource").(string)
+	if strings.TrimSpace(source) == "" {
+		if Type(jobType).RequiresPipelineSpec() {
+			return "", nil, ErrNoPipelineSpec
+		}
+		return Type(jobType), nil, nil
+	}
+
+	offset := sourceLineOffset(ts, tree.GetPosition("observationSource").Line)
+	issues := pipeline.Lint(source, pipelineVars[Type(jobType)]...)
+	for i := range issues {
+		if issues[i].Line > 0 {
+			issues[i].Line += offset
+		}
+	}
+	return Type(jobType), issues, nil
+}
+
+// sourceLineOffset returns the number of TOML lines before the first line of
+// the observationSource declared on line keyLine. TOML drops the newline
+// directly after the opening delimiter of a multi-line string.
+func sourceLineOffset(ts string, keyLine int) int {
+	lines := strings.Split(ts, "\n")
+	if keyLine < 1 || keyLine > len(lines) {
+		return 0
+	}
+	offset := keyLine - 1
+	for _, delim := range []string{`"""`, `'''`} {
+		if i := strings.Index(lines[keyLine-1], delim); i >= 0 {
+			if strings.TrimSpace(lines[keyLine-1][i+len(delim):]) == "" {
+				offset++
+			}
+			break
+		}
+	}
+	return offset
+}
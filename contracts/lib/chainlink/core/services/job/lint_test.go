@@ -0,0 +1,66 @@
+package job_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func TestLintSpec(t *testing.T) {
+	t.Parallel()
+
+	t.Run("reports TOML lines", func(t *testing.T) {
+		jobType, issues, err := job.LintSpec(`
+type = "webhook"
+schemaVersion = 1
+observationSource = """
+ds    [type=http method=GET url="$(jobSpec.url)"]
+parse [type=jsonparse path="data" data="$(dss)"]
+ds -> parse
+"""
+`)
+		require.NoError(t, err)
+		assert.Equal(t, job.Webhook, jobType)
+		require.Len(t, issues, 1)
+		assert.Equal(t, pipeline.LintError, issues[0].Severity)
+		assert.Equal(t, 6, issues[0].Line)
+		assert.Equal(t, "parse", issues[0].Task)
+	})
+
+	t.Run("variables are per job type", func(t *testing.T) {
+		_, issues, err := job.LintSpec(`
+type = "stream"
+schemaVersion = 1
+observationSource = """ds [type=memo value="$(jobRun.meta)"]"""
+`)
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		// stream jobs don't pass jobRun into their runs
+		assert.Equal(t, pipeline.LintWarning, issues[0].Severity)
+		assert.Equal(t, 4, issues[0].Line)
+	})
+
+	t.Run("no pipeline", func(t *testing.T) {
+		_, issues, err := job.LintSpec(`
+type = "bootstrap"
+schemaVersion = 1
+`)
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+
+		_, _, err = job.LintSpec(`
+type = "cron"
+schemaVersion = 1
+`)
+		require.ErrorIs(t, err, job.ErrNoPipelineSpec)
+	})
+
+	t.Run("invalid job type", func(t *testing.T) {
+		_, _, err := job.LintSpec(`type = "foo"`)
+		require.ErrorIs(t, err, job.ErrInvalidJobType)
+	})
+}
@@ -831,6 +831,65 @@ func (_c *ORM_FindJobIDsWithBridge_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// FindJobIDsWithTemplate provides a mock function with given fields: ctx, name
+func (_m *ORM) FindJobIDsWithTemplate(ctx context.Context, name string) ([]int32, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindJobIDsWithTemplate")
+	}
+
+	var r0 []int32
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]int32, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []int32); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]int32)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ORM_FindJobIDsWithTemplate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindJobIDsWithTemplate'
+type ORM_FindJobIDsWithTemplate_Call struct {
+	*mock.Call
+}
+
+// FindJobIDsWithTemplate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *ORM_Expecter) FindJobIDsWithTemplate(ctx interface{}, name interface{}) *ORM_FindJobIDsWithTemplate_Call {
+	return &ORM_FindJobIDsWithTemplate_Call{Call: _e.mock.On("FindJobIDsWithTemplate", ctx, name)}
+}
+
+func (_c *ORM_FindJobIDsWithTemplate_Call) Run(run func(ctx context.Context, name string)) *ORM_FindJobIDsWithTemplate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *ORM_FindJobIDsWithTemplate_Call) Return(_a0 []int32, _a1 error) *ORM_FindJobIDsWithTemplate_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *ORM_FindJobIDsWithTemplate_Call) RunAndReturn(run func(context.Context, string) ([]int32, error)) *ORM_FindJobIDsWithTemplate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // FindJobWithoutSpecErrors provides a mock function with given fields: ctx, id
 func (_m *ORM) FindJobWithoutSpecErrors(ctx context.Context, id int32) (job.Job, error) {
 	ret := _m.Called(ctx, id)
@@ -1729,6 +1788,53 @@ func (_c *ORM_TryRecordError_Call) RunAndReturn(run func(context.Context, int32,
 	return _c
 }
 
+// UpdateVRFSpec provides a mock function with given fields: ctx, spec
+func (_m *ORM) UpdateVRFSpec(ctx context.Context, spec *job.VRFSpec) error {
+	ret := _m.Called(ctx, spec)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVRFSpec")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *job.VRFSpec) error); ok {
+		r0 = rf(ctx, spec)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ORM_UpdateVRFSpec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateVRFSpec'
+type ORM_UpdateVRFSpec_Call struct {
+	*mock.Call
+}
+
+// UpdateVRFSpec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - spec *job.VRFSpec
+func (_e *ORM_Expecter) UpdateVRFSpec(ctx interface{}, spec interface{}) *ORM_UpdateVRFSpec_Call {
+	return &ORM_UpdateVRFSpec_Call{Call: _e.mock.On("UpdateVRFSpec", ctx, spec)}
+}
+
+func (_c *ORM_UpdateVRFSpec_Call) Run(run func(ctx context.Context, spec *job.VRFSpec)) *ORM_UpdateVRFSpec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*job.VRFSpec))
+	})
+	return _c
+}
+
+func (_c *ORM_UpdateVRFSpec_Call) Return(_a0 error) *ORM_UpdateVRFSpec_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *ORM_UpdateVRFSpec_Call) RunAndReturn(run func(context.Context, *job.VRFSpec) error) *ORM_UpdateVRFSpec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // WithDataSource provides a mock function with given fields: source
 func (_m *ORM) WithDataSource(source sqlutil.DataSource) job.ORM {
 	ret := _m.Called(source)
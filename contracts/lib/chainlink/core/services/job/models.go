@@ -600,10 +600,255 @@ type VRFSpec struct {
 	// only.
 	BackoffMaxDelay time.Duration `toml:"backoffMaxDelay"`
 
+	// ConsumerRateLimit is the number of requests per second that are processed for a single
+	// consumer contract. Requests over the limit stay pending until a later round. Optional,
+	// 0 disables the limit. V2 only.
+	ConsumerRateLimit tomlutils.Float64 `toml:"consumerRateLimit"`
+
+	// ConsumerRateLimitBurst is the number of requests of a single consumer contract that can
+	// be processed at once. Optional, defaults to ChunkSize. V2 only.
+	ConsumerRateLimitBurst uint32 `toml:"consumerRateLimitBurst"`
+
+	// SubscriptionRateLimit is the number of requests per second that are processed for a
+	// single subscription. Optional, 0 disables the limit. V2 only.
+	SubscriptionRateLimit tomlutils.Float64 `toml:"subscriptionRateLimit"`
+
+	// SubscriptionRateLimitBurst is the number of requests of a single subscription that can
+	// be processed at once. Optional, defaults to ChunkSize. V2 only.
+	SubscriptionRateLimitBurst uint32 `toml:"subscriptionRateLimitBurst"`
+
+	// SubscriptionWeights enables weighted round-robin scheduling across subscriptions. Each
+	// round, a subscription gets up to its weight times ChunkSize of its requests processed.
+	// Subscriptions that are not listed have a weight of 1. Optional, V2 only.
+	SubscriptionWeights VRFSubscriptionWeights `toml:"subscriptionWeights"`
+
+	// FulfillmentSLABlocks is the number of blocks after its confirmation within which a
+	// request is expected to be fulfilled. Optional, 0 disables the check. V2 only.
+	FulfillmentSLABlocks uint32 `toml:"fulfillmentSLABlocks"`
+
+	// FulfillmentSLA is the time after its confirmation within which a request is expected to
+	// be fulfilled. Optional, 0 disables the check. V2 only.
+	FulfillmentSLA time.Duration `toml:"fulfillmentSLA"`
+
+	// SLAWebhookURL is notified with a JSON payload for every request that breaches
+	// FulfillmentSLABlocks or FulfillmentSLA. Optional, V2 only.
+	SLAWebhookURL string `toml:"slaWebhookURL"`
+
+	// GasLanes are the key hashes and gas lanes served by the job besides the one of PublicKey,
+	// GasLanePrice and FromAddresses. A single listener serves them all, routing each request to
+	// the lane of its key hash. Optional, V2 only.
+	GasLanes VRFGasLanes `toml:"gasLanes"`
+
+	// LowFundsWarningHorizons enables subscription balance forecasting: the listener projects
+	// when each subscription it serves runs out of funds at its recent burn rate, and warns once
+	// per horizon when that is within it. Optional, V2 only.
+	LowFundsWarningHorizons VRFForecastHorizons `toml:"lowFundsWarningHorizons"`
+
+	// BalanceForecastWindow is how far back the fulfillments of a subscription are taken into
+	// account for its burn rate. Defaults to 24h when LowFundsWarningHorizons is set.
+	BalanceForecastWindow time.Duration `toml:"balanceForecastWindow"`
+
+	// BlockhashStore runs a blockhash store feeder for the job's coordinator as part of the job,
+	// in place of a separate blockhashstore job. Optional, V2 only.
+	BlockhashStore *VRFBlockhashStoreConfig `toml:"blockhashStore"`
+
+	// BlockHeaderFeeder runs a block header feeder for the job's coordinator as part of the job,
+	// in place of a separate blockheaderfeeder job. Optional, V2 only.
+	BlockHeaderFeeder *VRFBlockHeaderFeederConfig `toml:"blockHeaderFeeder"`
+
 	CreatedAt time.Time `toml:"-"`
 	UpdatedAt time.Time `toml:"-"`
 }
 
+// VRFBlockhashStoreConfig configures the blockhash store feeder of a VRF job. The fields are
+// those of a BlockhashStoreSpec, the coordinator and chain are the job's.
+type VRFBlockhashStoreConfig struct {
+	BlockhashStoreAddress          evmtypes.EIP55Address  `toml:"blockhashStoreAddress" json:"blockhashStoreAddress"`
+	TrustedBlockhashStoreAddress   *evmtypes.EIP55Address `toml:"trustedBlockhashStoreAddress" json:"trustedBlockhashStoreAddress,omitempty"`
+	TrustedBlockhashStoreBatchSize int32                  `toml:"trustedBlockhashStoreBatchSize" json:"trustedBlockhashStoreBatchSize"`
+	WaitBlocks                     int32                  `toml:"waitBlocks" json:"waitBlocks"`
+	LookbackBlocks                 int32                  `toml:"lookbackBlocks" json:"lookbackBlocks"`
+	HeartbeatPeriod                time.Duration          `toml:"heartbeatPeriod" json:"heartbeatPeriod"`
+	PollPeriod                     time.Duration          `toml:"pollPeriod" json:"pollPeriod"`
+	RunTimeout                     time.Duration          `toml:"runTimeout" json:"runTimeout"`
+	// FromAddresses are the keys that store the blockhashes, they default to the job's.
+	FromAddresses []evmtypes.EIP55Address `toml:"fromAddresses" json:"fromAddresses,omitempty"`
+}
+
+// Value returns this instance serialized for database storage.
+func (c VRFBlockhashStoreConfig) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+// Scan reads the database value and returns an instance.
+func (c *VRFBlockhashStoreConfig) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("expected bytes got %T", b)
+	}
+	return json.Unmarshal(b, c)
+}
+
+// VRFBlockHeaderFeederConfig configures the block header feeder of a VRF job. The fields are
+// those of a BlockHeaderFeederSpec, the coordinator and chain are the job's.
+type VRFBlockHeaderFeederConfig struct {
+	BlockhashStoreAddress      evmtypes.EIP55Address `toml:"blockhashStoreAddress" json:"blockhashStoreAddress"`
+	BatchBlockhashStoreAddress evmtypes.EIP55Address `toml:"batchBlockhashStoreAddress" json:"batchBlockhashStoreAddress"`
+	WaitBlocks                 int32                 `toml:"waitBlocks" json:"waitBlocks"`
+	LookbackBlocks             int32                 `toml:"lookbackBlocks" json:"lookbackBlocks"`
+	PollPeriod                 time.Duration         `toml:"pollPeriod" json:"pollPeriod"`
+	RunTimeout                 time.Duration         `toml:"runTimeout" json:"runTimeout"`
+	GetBlockhashesBatchSize    uint16                `toml:"getBlockhashesBatchSize" json:"getBlockhashesBatchSize"`
+	StoreBlockhashesBatchSize  uint16                `toml:"storeBlockhashesBatchSize" json:"storeBlockhashesBatchSize"`
+	// FromAddresses are the keys that store the block headers, they default to the job's.
+	FromAddresses []evmtypes.EIP55Address `toml:"fromAddresses" json:"fromAddresses,omitempty"`
+}
+
+// Value returns this instance serialized for database storage.
+func (c VRFBlockHeaderFeederConfig) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+// Scan reads the database value and returns an instance.
+func (c *VRFBlockHeaderFeederConfig) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("expected bytes got %T", b)
+	}
+	return json.Unmarshal(b, c)
+}
+
+// VRFSubscriptionWeights maps subscription IDs, in decimal, to their scheduling weight.
+type VRFSubscriptionWeights map[string]uint32
+
+// Weight returns the weight of the subscription, 1 if it has none.
+func (w VRFSubscriptionWeights) Weight(subID string) uint32 {
+	if weight, ok := w[subID]; ok {
+		return weight
+	}
+	return 1
+}
+
+// Value returns this instance serialized for database storage.
+func (w VRFSubscriptionWeights) Value() (driver.Value, error) {
+	if w == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(w)
+}
+
+// Scan reads the database value and returns an instance.
+func (w *VRFSubscriptionWeights) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("expected bytes got %T", b)
+	}
+	return json.Unmarshal(b, w)
+}
+
+// VRFGasLane is a key hash and gas lane served by a VRF job, in addition to its main one.
+type VRFGasLane struct {
+	// PublicKey is the proving key of the lane, requests with its key hash are routed to the lane.
+	PublicKey secp256k1.PublicKey `toml:"publicKey" json:"publicKey"`
+	// GasLanePrice is the gas lane price of the lane, it must match the key-specific max gas
+	// price of its FromAddresses. Optional.
+	GasLanePrice *assets.Wei `toml:"gasLanePrice" json:"gasLanePrice,omitempty"`
+	// FromAddresses are the keys that send the fulfillments of the lane.
+	FromAddresses []evmtypes.EIP55Address `toml:"fromAddresses" json:"fromAddresses"`
+}
+
+// VRFGasLanes are the additional gas lanes of a VRF job.
+type VRFGasLanes []VRFGasLane
+
+// Value returns this instance serialized for database storage.
+func (l VRFGasLanes) Value() (driver.Value, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(l)
+}
+
+// Scan reads the database value and returns an instance. No lanes are read as nil, as when
+// the TOML has none.
+func (l *VRFGasLanes) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("expected bytes got %T", b)
+	}
+	if err := json.Unmarshal(b, l); err != nil {
+		return err
+	}
+	if len(*l) == 0 {
+		*l = nil
+	}
+	return nil
+}
+
+// VRFForecastHorizons are the lead times before a subscription runs out of funds at which a
+// VRF job warns about it, e.g. ["72h", "24h", "1h"].
+type VRFForecastHorizons []time.Duration
+
+// UnmarshalTOML parses the horizons from a TOML array of duration strings.
+func (h *VRFForecastHorizons) UnmarshalTOML(val interface{}) error {
+	values, ok := val.([]interface{})
+	if !ok {
+		return errors.Errorf("expected an array of durations, got %T", val)
+	}
+	horizons := make(VRFForecastHorizons, len(values))
+	for i, v := range values {
+		s, ok := v.(string)
+		if !ok {
+			return errors.Errorf("expected a duration string, got %T", v)
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return errors.Wrapf(err, "invalid horizon %q", s)
+		}
+		horizons[i] = d
+	}
+	*h = horizons
+	return nil
+}
+
+// MarshalJSON encodes the horizons as duration strings.
+func (h VRFForecastHorizons) MarshalJSON() ([]byte, error) {
+	strs := make([]string, len(h))
+	for i, d := range h {
+		strs[i] = d.String()
+	}
+	return json.Marshal(strs)
+}
+
+// UnmarshalJSON decodes the horizons from duration strings.
+func (h *VRFForecastHorizons) UnmarshalJSON(b []byte) error {
+	var strs []interface{}
+	if err := json.Unmarshal(b, &strs); err != nil {
+		return err
+	}
+	return h.UnmarshalTOML(strs)
+}
+
+// Value returns this instance serialized for database storage.
+func (h VRFForecastHorizons) Value() (driver.Value, error) {
+	return h.MarshalJSON()
+}
+
+// Scan reads the database value and returns an instance. No horizons are read as nil, as
+// when the TOML has none.
+func (h *VRFForecastHorizons) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("expected bytes got %T", b)
+	}
+	if err := h.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	if len(*h) == 0 {
+		*h = nil
+	}
+	return nil
+}
+
 // BlockhashStoreSpec defines the job spec for the blockhash store feeder.
 type BlockhashStoreSpec struct {
 	ID int32
@@ -51,7 +51,11 @@ type ORM interface {
 	FindJobIDByAddress(ctx context.Context, address evmtypes.EIP55Address, evmChainID *big.Big) (int32, error)
 	FindOCR2JobIDByAddress(ctx context.Context, relay string, chainID int64, contractID string, feedID *common.Hash) (int32, error)
 	FindJobIDsWithBridge(ctx context.Context, name string) ([]int32, error)
+	// FindJobIDsWithTemplate returns the jobs whose pipelines expand the named pipeline template.
+	FindJobIDsWithTemplate(ctx context.Context, name string) ([]int32, error)
 	DeleteJob(ctx context.Context, id int32, jobType Type) error
+	// UpdateVRFSpec saves the fields of a VRF spec that can change without recreating its job.
+	UpdateVRFSpec(ctx context.Context, spec *VRFSpec) error
 	RecordError(ctx context.Context, jobID int32, description string) error
 	// TryRecordError is a helper which calls RecordError and logs the returned error if present.
 	TryRecordError(ctx context.Context, jobID int32, description string)
@@ -593,6 +597,10 @@ func (o *orm) insertVRFSpec(ctx context.Context, spec *VRFSpec) (specID int32, e
 				request_timeout, chunk_size, batch_coordinator_address, batch_fulfillment_enabled,
 				batch_fulfillment_gas_multiplier, backoff_initial_delay, backoff_max_delay, gas_lane_price,
                 vrf_owner_address, custom_reverts_pipeline_enabled,
+				consumer_rate_limit, consumer_rate_limit_burst, subscription_rate_limit,
+				subscription_rate_limit_burst, subscription_weights,
+				fulfillment_sla_blocks, fulfillment_sla, sla_webhook_url, gas_lanes,
+				low_funds_warning_horizons, balance_forecast_window, blockhash_store, block_header_feeder,
 				created_at, updated_at)
 			VALUES (
 				:coordinator_address, :public_key, :min_incoming_confirmations,
@@ -600,10 +608,37 @@ func (o *orm) insertVRFSpec(ctx context.Context, spec *VRFSpec) (specID int32, e
 				:request_timeout, :chunk_size, :batch_coordinator_address, :batch_fulfillment_enabled,
 				:batch_fulfillment_gas_multiplier, :backoff_initial_delay, :backoff_max_delay, :gas_lane_price,
 			    :vrf_owner_address, :custom_reverts_pipeline_enabled,
+				:consumer_rate_limit, :consumer_rate_limit_burst, :subscription_rate_limit,
+				:subscription_rate_limit_burst, :subscription_weights,
+				:fulfillment_sla_blocks, :fulfillment_sla, :sla_webhook_url, :gas_lanes,
+				:low_funds_warning_horizons, :balance_forecast_window, :blockhash_store, :block_header_feeder,
 				NOW(), NOW())
 			RETURNING id;`, toVRFSpecRow(spec))
 }
 
+// UpdateVRFSpec saves the fields of a VRF spec that a running listener can pick up without a
+// restart. The other fields are left untouched.
+func (o *orm) UpdateVRFSpec(ctx context.Context, spec *VRFSpec) error {
+	stmt, err := o.ds.PrepareNamedContext(ctx, `UPDATE vrf_specs SET
+				chunk_size = :chunk_size, backoff_initial_delay = :backoff_initial_delay,
+				backoff_max_delay = :backoff_max_delay, gas_lane_price = :gas_lane_price,
+				from_addresses = :from_addresses, batch_fulfillment_gas_multiplier = :batch_fulfillment_gas_multiplier,
+				consumer_rate_limit = :consumer_rate_limit, consumer_rate_limit_burst = :consumer_rate_limit_burst,
+				subscription_rate_limit = :subscription_rate_limit, subscription_rate_limit_burst = :subscription_rate_limit_burst,
+				subscription_weights = :subscription_weights, updated_at = NOW()
+			WHERE id = :id
+			RETURNING updated_at;`)
+	if err != nil {
+		return errors.Wrap(err, "failed to update VRFSpec")
+	}
+	defer stmt.Close()
+	err = stmt.QueryRowxContext(ctx, toVRFSpecRow(spec)).Scan(&spec.UpdatedAt)
+	if err != nil {
+		return errors.Wrap(err, "failed to update VRFSpec")
+	}
+	return nil
+}
+
 func (o *orm) insertBlockhashStoreSpec(ctx context.Context, spec *BlockhashStoreSpec) (specID int32, err error) {
 	return o.prepareQuerySpecID(ctx, `INSERT INTO blockhash_store_specs (coordinator_v1_address, coordinator_v2_address, coordinator_v2_plus_address, trusted_blockhash_store_address, trusted_blockhash_store_batch_size, wait_blocks, lookback_blocks, heartbeat_period, blockhash_store_address, poll_period, run_timeout, evm_chain_id, from_addresses, created_at, updated_at)
 			VALUES (:coordinator_v1_address, :coordinator_v2_address, :coordinator_v2_plus_address, :trusted_blockhash_store_address, :trusted_blockhash_store_batch_size, :wait_blocks, :lookback_blocks, :heartbeat_period, :blockhash_store_address, :poll_period, :run_timeout, :evm_chain_id, :from_addresses, NOW(), NOW())
@@ -1168,6 +1203,33 @@ func (o *orm) FindJobIDsWithBridge(ctx context.Context, name string) (jids []int
 	return
 }
 
+func (o *orm) FindJobIDsWithTemplate(ctx context.Context, name string) (jids []int32, err error) {
+	query := `SELECT
+			jobs.id, pipeline_specs.dot_dag_source
+		FROM jobs
+		    JOIN job_pipeline_specs ON job_pipeline_specs.job_id = jobs.id
+		    JOIN pipeline_specs ON pipeline_specs.id = job_pipeline_specs.pipeline_spec_id
+		WHERE pipeline_specs.dot_dag_source ILIKE '%' || $1 || '%' ORDER BY id`
+	var rows []struct {
+		ID           int32
+		DotDagSource string
+	}
+	if err = o.ds.SelectContext(ctx, &rows, query, name); err != nil {
+		return nil, errors.Wrap(err, "FindJobIDsWithTemplate failed")
+	}
+
+	for _, row := range rows {
+		var names []string
+		if names, err = pipeline.TemplateNames(row.DotDagSource); err != nil {
+			return nil, errors.Wrapf(err, "could not parse dag for job %d", row.ID)
+		}
+		if slices.Contains(names, name) {
+			jids = append(jids, row.ID)
+		}
+	}
+	return jids, nil
+}
+
 func (o *orm) FindJobIDByWorkflow(ctx context.Context, spec WorkflowSpec) (jobID int32, err error) {
 	stmt := `
 SELECT jobs.id FROM jobs
@@ -60,6 +60,8 @@ type (
 		TaskRetries() uint32
 		TaskMinBackoff() time.Duration
 		TaskMaxBackoff() time.Duration
+		TaskRetryOn() []RetryCondition
+		TaskRetryJitter() RetryJitter
 		TaskTags() string
 		TaskStreamID() *uint32
 		GetDescendantTasks() []Task
@@ -109,6 +111,10 @@ const (
 type RunInfo struct {
 	IsRetryable bool
 	IsPending   bool
+	// StatusCode is the HTTP status code of a failed http or bridge request.
+	StatusCode int
+	// TimedOut is set when the task failed because it ran out of time.
+	TimedOut bool
 }
 
 // retryableMeta should be returned if the error is non-deterministic; i.e. a
@@ -209,6 +215,8 @@ type TaskRunResult struct {
 	FinishedAt null.Time
 	// runInfo is never persisted
 	runInfo RunInfo
+	// history holds every finished attempt of the task, see TaskRun.Attempts
+	history TaskRunAttempts
 }
 
 func (result *TaskRunResult) IsPending() bool {
@@ -320,9 +328,11 @@ const (
 	TaskTypeETHCall          TaskType = "ethcall"
 	TaskTypeETHTx            TaskType = "ethtx"
 	TaskTypeEstimateGasLimit TaskType = "estimategaslimit"
+	TaskTypeForEach          TaskType = "foreach"
 	TaskTypeHTTP             TaskType = "http"
 	TaskTypeHexDecode        TaskType = "hexdecode"
 	TaskTypeHexEncode        TaskType = "hexencode"
+	TaskTypeJQ               TaskType = "jq"
 	TaskTypeJSONParse        TaskType = "jsonparse"
 	TaskTypeLength           TaskType = "length"
 	TaskTypeLessThan         TaskType = "lessthan"
@@ -334,6 +344,7 @@ const (
 	TaskTypeMode             TaskType = "mode"
 	TaskTypeMultiply         TaskType = "multiply"
 	TaskTypeSum              TaskType = "sum"
+	TaskTypeTemplate         TaskType = "template"
 	TaskTypeUppercase        TaskType = "uppercase"
 	TaskTypeVRF              TaskType = "vrf"
 	TaskTypeVRFV2            TaskType = "vrfv2"
@@ -384,6 +395,8 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 		task = &AnyTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeJSONParse:
 		task = &JSONParseTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeJQ:
+		task = &JQTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeMemo:
 		task = &MemoTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeMultiply:
@@ -414,6 +427,8 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 		task = &CBORParseTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeFail:
 		task = &FailTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
+	case TaskTypeForEach:
+		task = &ForEachTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeMerge:
 		task = &MergeTask{BaseTask: BaseTask{id: ID, dotID: dotID}}
 	case TaskTypeLength:
@@ -485,6 +500,14 @@ func UnmarshalTaskFromMap(taskType TaskType, taskMap interface{}, ID int, dotID
 		}
 	}
 
+	base := task.Base()
+	if base.retryOn, err = parseRetryConditions(base.RetryOn); err != nil {
+		return nil, pkgerrors.Wrapf(err, "task %s", dotID)
+	}
+	if base.retryJitter, err = parseRetryJitter(base.RetryJitter); err != nil {
+		return nil, pkgerrors.Wrapf(err, "task %s", dotID)
+	}
+
 	return task, nil
 }
 
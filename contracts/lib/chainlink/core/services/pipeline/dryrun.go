@@ -0,0 +1,126 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/jsonserializable"
+)
+
+// DryRunFixture is the canned response of a task in a dry run. The task
+// fails with Error if it is set, and returns Value otherwise.
+type DryRunFixture struct {
+	Value interface{} `json:"value"`
+	Error string      `json:"error,omitempty"`
+}
+
+// DryRunFixtures are what a dry run is executed with: the vars passed into
+// the run, e.g. "jobSpec" and "jobRun", and the canned responses of its
+// http, bridge, ethcall, ethtx and estimategaslimit tasks by task name.
+type DryRunFixtures struct {
+	Vars  map[string]interface{}   `json:"vars"`
+	Tasks map[string]DryRunFixture `json:"tasks"`
+}
+
+// DryRunTaskResult is the inputs and output of a single task of a dry run.
+type DryRunTaskResult struct {
+	DotID   string                              `json:"dotId"`
+	Type    TaskType                            `json:"type"`
+	Inputs  []jsonserializable.JSONSerializable `json:"inputs"`
+	Output  jsonserializable.JSONSerializable   `json:"output"`
+	Error   null.String                         `json:"error"`
+	Fixture bool                                `json:"fixture"`
+}
+
+// isDryRunTask returns true for the tasks served from fixtures in a dry run.
+func isDryRunTask(taskType TaskType) bool {
+	switch taskType {
+	case TaskTypeHTTP, TaskTypeBridge, TaskTypeETHCall, TaskTypeETHTx, TaskTypeEstimateGasLimit:
+		return true
+	default:
+		return false
+	}
+}
+
+// result returns the canned result of task, converted to the type the task
+// itself would return so that downstream tasks see the same values.
+func (f DryRunFixtures) result(task Task) Result {
+	fixture, ok := f.Tasks[task.DotID()]
+	if !ok {
+		return Result{Error: errors.Errorf("dry run: no fixture for %s task %s", task.Type(), task.DotID())}
+	}
+	if fixture.Error != "" {
+		return Result{Error: errors.New(fixture.Error)}
+	}
+
+	switch task.Type() {
+	case TaskTypeHTTP, TaskTypeBridge:
+		// Response bodies are strings; JSON bodies may be given as JSON.
+		if s, ok := fixture.Value.(string); ok {
+			return Result{Value: s}
+		}
+		b, err := json.Marshal(fixture.Value)
+		if err != nil {
+			return Result{Error: errors.Wrapf(err, "dry run: fixture for %s", task.DotID())}
+		}
+		return Result{Value: string(b)}
+	case TaskTypeETHCall:
+		if s, ok := fixture.Value.(string); ok && strings.HasPrefix(s, "0x") {
+			b, err := hexutil.Decode(s)
+			if err != nil {
+				return Result{Error: errors.Wrapf(err, "dry run: fixture for %s", task.DotID())}
+			}
+			return Result{Value: b}
+		}
+	case TaskTypeEstimateGasLimit:
+		var gasLimit Uint64Param
+		if err := gasLimit.UnmarshalPipelineParam(fixture.Value); err != nil {
+			return Result{Error: errors.Wrapf(err, "dry run: fixture for %s", task.DotID())}
+		}
+		return Result{Value: uint64(gasLimit)}
+	default:
+	}
+	return Result{Value: fixture.Value}
+}
+
+// NewDryRunTaskResults returns the inputs and output of each task of a dry
+// run, in the order the tasks were executed in.
+func NewDryRunTaskResults(trrs TaskRunResults) []DryRunTaskResult {
+	byDotID := make(map[string]TaskRunResult, len(trrs))
+	for _, trr := range trrs {
+		byDotID[trr.Task.DotID()] = trr
+	}
+
+	sorted := make(TaskRunResults, len(trrs))
+	copy(sorted, trrs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Task.ID() < sorted[j].Task.ID()
+	})
+
+	results := make([]DryRunTaskResult, 0, len(sorted))
+	for _, trr := range sorted {
+		inputs := []jsonserializable.JSONSerializable{}
+		for _, dep := range trr.Task.Inputs() {
+			if !dep.PropagateResult {
+				continue
+			}
+			if in, ok := byDotID[dep.InputTask.DotID()]; ok {
+				inputs = append(inputs, in.Result.OutputDB())
+			}
+		}
+		results = append(results, DryRunTaskResult{
+			DotID:   trr.Task.DotID(),
+			Type:    trr.Task.Type(),
+			Inputs:  inputs,
+			Output:  trr.Result.OutputDB(),
+			Error:   trr.Result.ErrorDB(),
+			Fixture: isDryRunTask(trr.Task.Type()),
+		})
+	}
+	return results
+}
@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"bytes"
 	"fmt"
 	"regexp"
 	"sort"
@@ -11,14 +12,23 @@ import (
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/encoding"
 	"gonum.org/v1/gonum/graph/encoding/dot"
+	dotparser "gonum.org/v1/gonum/graph/formats/dot"
+	"gonum.org/v1/gonum/graph/formats/dot/ast"
 	"gonum.org/v1/gonum/graph/simple"
 	"gonum.org/v1/gonum/graph/topo"
+
+	cnull "github.com/smartcontractkit/chainlink/v2/core/null"
 )
 
 // tree fulfills the graph.DirectedGraph interface, which makes it possible
 // for us to `dot.Unmarshal(...)` a DOT string directly into it.
 type Graph struct {
 	*simple.DirectedGraph
+	attrs graphAttributes
+	// subgraphs holds the sources of the named subgraphs run by foreach tasks
+	subgraphs map[string]string
+	// templates are expanded by the template tasks
+	templates TemplateSource
 }
 
 func NewGraph() *Graph {
@@ -33,6 +43,29 @@ func (g *Graph) NewEdge(from, to graph.Node) graph.Edge {
 	return &GraphEdge{Edge: g.DirectedGraph.NewEdge(from, to)}
 }
 
+// DOTAttributeSetters makes graph level attributes, e.g.
+// `graph [retryBudget=10]`, available to Parse. Default node and edge
+// attributes are ignored.
+func (g *Graph) DOTAttributeSetters() (graph, node, edge encoding.AttributeSetter) {
+	if g.attrs == nil {
+		g.attrs = make(graphAttributes)
+	}
+	return g.attrs, ignoredAttributes{}, ignoredAttributes{}
+}
+
+type graphAttributes map[string]string
+
+func (a graphAttributes) SetAttribute(attr encoding.Attribute) error {
+	a[attr.Key] = attr.Value
+	return nil
+}
+
+type ignoredAttributes struct{}
+
+func (ignoredAttributes) SetAttribute(encoding.Attribute) error {
+	return nil
+}
+
 func (g *Graph) UnmarshalText(bs []byte) (err error) {
 	if g.DirectedGraph == nil {
 		g.DirectedGraph = simple.NewDirectedGraph()
@@ -44,6 +77,10 @@ func (g *Graph) UnmarshalText(bs []byte) (err error) {
 	}()
 	bs = append([]byte("digraph {\n"), bs...)
 	bs = append(bs, []byte("\n}")...)
+	bs, err = g.rewrite(bs)
+	if err != nil {
+		return errors.Wrap(err, "could not unmarshal DOT into a pipeline.Graph")
+	}
 	err = dot.Unmarshal(bs, g)
 	if err != nil {
 		return errors.Wrap(err, "could not unmarshal DOT into a pipeline.Graph")
@@ -52,6 +89,98 @@ func (g *Graph) UnmarshalText(bs []byte) (err error) {
 	return nil
 }
 
+// rewrite extracts the subgraphs run by foreach tasks from the DOT and
+// expands its template tasks. DOT without either is returned as is.
+func (g *Graph) rewrite(bs []byte) ([]byte, error) {
+	lower := bytes.ToLower(bs)
+	if !bytes.Contains(lower, []byte(TaskTypeForEach)) && !bytes.Contains(lower, []byte(TaskTypeTemplate)) {
+		return bs, nil
+	}
+	file, err := dotparser.ParseBytes(bs)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Graphs) != 1 {
+		return bs, nil
+	}
+	root := file.Graphs[0]
+
+	g.extractSubgraphs(root)
+	if root.Stmts, err = expandTemplates(g.templates, root.Stmts); err != nil {
+		return nil, err
+	}
+	return []byte(root.String()), nil
+}
+
+// extractSubgraphs removes the top-level subgraphs run by foreach tasks, e.g.
+// `subgraph perWinner { ... }`, from the graph and keeps their statements as
+// the sources of sub-pipelines. Any other subgraph is flattened into the
+// graph, as before.
+func (g *Graph) extractSubgraphs(root *ast.Graph) {
+	referenced := make(map[string]bool)
+	var walk func(stmts []ast.Stmt)
+	walk = func(stmts []ast.Stmt) {
+		for _, stmt := range stmts {
+			switch stmt := stmt.(type) {
+			case *ast.NodeStmt:
+				if nodeStmtType(stmt) == TaskTypeForEach {
+					if name := nodeStmtAttr(stmt, "pipeline"); name != "" {
+						referenced[name] = true
+					}
+				}
+			case *ast.Subgraph:
+				walk(stmt.Stmts)
+			}
+		}
+	}
+	walk(root.Stmts)
+	if len(referenced) == 0 {
+		return
+	}
+
+	stmts := make([]ast.Stmt, 0, len(root.Stmts))
+	for _, stmt := range root.Stmts {
+		sub, ok := stmt.(*ast.Subgraph)
+		if !ok || !referenced[unquoteDOTID(sub.ID)] {
+			stmts = append(stmts, stmt)
+			continue
+		}
+		if g.subgraphs == nil {
+			g.subgraphs = make(map[string]string)
+		}
+		var source strings.Builder
+		for _, s := range sub.Stmts {
+			source.WriteString(s.String())
+			source.WriteString("\n")
+		}
+		g.subgraphs[unquoteDOTID(sub.ID)] = source.String()
+	}
+	root.Stmts = stmts
+}
+
+// nodeStmtAttr returns the unquoted value of the attribute key of a node
+// statement, or "" if it is not set.
+func nodeStmtAttr(stmt *ast.NodeStmt, key string) string {
+	var val string
+	for _, attr := range stmt.Attrs {
+		if unquoteDOTID(attr.Key) == key {
+			val = unquoteDOTID(attr.Val)
+		}
+	}
+	return val
+}
+
+func nodeStmtType(stmt *ast.NodeStmt) TaskType {
+	return TaskType(strings.ToLower(nodeStmtAttr(stmt, "type")))
+}
+
+func unquoteDOTID(id string) string {
+	if len(id) >= 2 && strings.HasPrefix(id, `"`) && strings.HasSuffix(id, `"`) {
+		return strings.ReplaceAll(id[1:len(id)-1], `\"`, `"`)
+	}
+	return id
+}
+
 // Looks at node attributes and searches for implicit dependencies on other nodes
 // expressed as attribute values. Adds those dependencies as implicit edges in the graph.
 func (g *Graph) AddImplicitDependenciesAsEdges() {
@@ -160,6 +289,10 @@ type Pipeline struct {
 	Tasks  []Task
 	tree   *Graph
 	Source string
+	// RetryBudget limits the number of retries across all tasks of a run.
+	RetryBudget cnull.Uint32
+	// subpipelines are the named subgraphs run by foreach tasks
+	subpipelines map[string]*Pipeline
 }
 
 func (p *Pipeline) UnmarshalText(bs []byte) (err error) {
@@ -208,11 +341,19 @@ func (p *Pipeline) ByDotID(id string) Task {
 	return nil
 }
 
+// Parse parses a pipeline, expanding its template tasks from the
+// TemplateSource set by SetTemplateSource.
 func Parse(text string) (*Pipeline, error) {
+	return ParseWithTemplates(text, getTemplateSource())
+}
+
+// ParseWithTemplates parses a pipeline, expanding its template tasks from ts.
+func ParseWithTemplates(text string, ts TemplateSource) (*Pipeline, error) {
 	if strings.TrimSpace(text) == "" {
 		return nil, errors.New("empty pipeline")
 	}
 	g := NewGraph()
+	g.templates = ts
 	err := g.UnmarshalText([]byte(text))
 
 	if err != nil {
@@ -225,6 +366,21 @@ func Parse(text string) (*Pipeline, error) {
 		Source: text,
 	}
 
+	if budget, ok := g.attrs[RetryBudgetAttribute]; ok {
+		if p.RetryBudget, err = parseRetryBudget(budget); err != nil {
+			return nil, err
+		}
+	}
+
+	for name, source := range g.subgraphs {
+		if p.subpipelines == nil {
+			p.subpipelines = make(map[string]*Pipeline)
+		}
+		if p.subpipelines[name], err = ParseWithTemplates(source, ts); err != nil {
+			return nil, errors.Wrapf(err, "subgraph %s", name)
+		}
+	}
+
 	// toposort all the nodes: dependencies ordered before outputs. This also does cycle checking for us.
 	nodes, err := topo.SortStabilized(g, nil)
 
@@ -282,5 +438,13 @@ func Parse(text string) (*Pipeline, error) {
 		ids[node.ID()] = id
 	}
 
+	for _, task := range p.Tasks {
+		if t, is := task.(*ForEachTask); is {
+			if err = t.resolveSubpipeline(p.subpipelines); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	return p, nil
 }
@@ -251,6 +251,28 @@ func TestGraph_ImplicitDependencies(t *testing.T) {
 	require.True(t, g.HasEdgeFromTo(nodes["c"], nodes["d"]))
 }
 
+func TestGraph_Subgraphs(t *testing.T) {
+	t.Parallel()
+
+	p, err := pipeline.Parse(`
+		fanout [type=foreach input="$(jobRun.winners)" pipeline="perWinner"];
+		subgraph perWinner {
+			mul [type=multiply input="$(foreach.element)" times=2];
+		}
+		subgraph grouped {
+			a [type=memo value=1];
+		}
+	`)
+	require.NoError(t, err)
+
+	// subgraphs run by foreach tasks are not part of the pipeline, any other
+	// subgraph is flattened into it
+	require.Len(t, p.Tasks, 2)
+	require.NotNil(t, p.ByDotID("fanout"))
+	require.NotNil(t, p.ByDotID("a"))
+	require.Nil(t, p.ByDotID("mul"))
+}
+
 func TestParse(t *testing.T) {
 	for _, s := range []struct {
 		name     string
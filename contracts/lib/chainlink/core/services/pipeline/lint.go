@@ -0,0 +1,363 @@
+package pipeline
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+type LintSeverity string
+
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+)
+
+// LintIssue is a problem found in a pipeline before it is ever run. Line is
+// 1-based within the pipeline source, or 0 if the issue has no single line.
+type LintIssue struct {
+	Severity LintSeverity `json:"severity"`
+	Line     int          `json:"line,omitempty"`
+	Task     string       `json:"task,omitempty"`
+	Message  string       `json:"message"`
+}
+
+// Lint statically checks a pipeline for problems which would otherwise only
+// surface at run time: undefined or misspelled $(var) references, keys that
+// an ethabidecode/ethabidecodelog ABI does not produce, final outputs that
+// nothing consumes, and tasks that can never run. vars are the variables the
+// job passes into every run, e.g. "jobSpec" and "jobRun".
+func Lint(source string, vars ...string) []LintIssue {
+	p, err := Parse(source)
+	if err != nil {
+		return []LintIssue{{Severity: LintError, Message: err.Error()}}
+	}
+
+	l := &linter{
+		p:       p,
+		source:  source,
+		vars:    make(map[string]struct{}, len(vars)),
+		nodes:   make(map[string]*GraphNode, len(p.Tasks)),
+		decoded: make(map[string][]string),
+		flagged: make(map[string]struct{}),
+	}
+	for _, v := range vars {
+		l.vars[v] = struct{}{}
+	}
+	for it := p.tree.Nodes(); it.Next(); {
+		n := it.Node().(*GraphNode)
+		l.nodes[n.dotID] = n
+	}
+
+	l.checkABIs()
+	l.checkVariables()
+	l.checkReachability()
+	l.checkOutputs()
+
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		return l.issues[i].Line < l.issues[j].Line
+	})
+	return l.issues
+}
+
+// HasErrors returns true if any of the issues is an error rather than a warning.
+func HasErrors(issues []LintIssue) bool {
+	for _, i := range issues {
+		if i.Severity == LintError {
+			return true
+		}
+	}
+	return false
+}
+
+type linter struct {
+	p      *Pipeline
+	source string
+	vars   map[string]struct{}
+	nodes  map[string]*GraphNode
+	// decoded holds the keys produced by each ethabidecode(log) task with a static ABI.
+	decoded map[string][]string
+	// flagged holds tasks already reported as unreachable.
+	flagged map[string]struct{}
+	issues  []LintIssue
+}
+
+func (l *linter) report(severity LintSeverity, line int, task Task, format string, args ...interface{}) {
+	l.issues = append(l.issues, LintIssue{
+		Severity: severity,
+		Line:     line,
+		Task:     task.DotID(),
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) checkABIs() {
+	for _, task := range l.p.Tasks {
+		var (
+			theABI string
+			isLog  bool
+		)
+		switch t := task.(type) {
+		case *ETHABIDecodeTask:
+			theABI = t.ABI
+		case *ETHABIDecodeLogTask:
+			theABI, isLog = t.ABI, true
+		default:
+			continue
+		}
+		if variableRegexp.MatchString(theABI) {
+			continue
+		}
+
+		var (
+			args abi.Arguments
+			err  error
+		)
+		if isLog {
+			_, args, _, err = parseETHABIString([]byte(theABI), true)
+		} else {
+			args, _, err = ParseETHABIArgsString([]byte(theABI), false)
+		}
+		if err != nil {
+			l.report(LintError, l.declarationLine(task), task, "invalid abi: %v", err)
+			continue
+		}
+		keys := make([]string, 0, len(args))
+		for _, arg := range args {
+			keys = append(keys, arg.Name)
+		}
+		l.decoded[task.DotID()] = keys
+	}
+}
+
+func (l *linter) checkVariables() {
+	for _, task := range l.p.Tasks {
+		seen := make(map[string]struct{})
+		for _, attr := range l.nodes[task.DotID()].Attributes() {
+			for _, match := range variableRegexp.FindAllStringSubmatch(attr.Value, -1) {
+				expr := strings.TrimSpace(match[1])
+				if _, ok := seen[expr]; ok {
+					continue
+				}
+				seen[expr] = struct{}{}
+				l.checkVariable(task, match[0], expr)
+			}
+		}
+	}
+}
+
+func (l *linter) checkVariable(task Task, raw, expr string) {
+	parts := strings.Split(expr, KeypathSeparator)
+	root := parts[0]
+	line := l.referenceLine(task, raw)
+
+	if root == task.DotID() {
+		l.report(LintError, line, task, "$(%s) references the task's own output", expr)
+		return
+	}
+	if _, ok := l.nodes[root]; ok {
+		keys, decoded := l.decoded[root]
+		if !decoded || len(parts) < 2 || slices.Contains(keys, parts[1]) {
+			return
+		}
+		msg := fmt.Sprintf("$(%s): task %s does not decode a key named %q", expr, root, parts[1])
+		if s := suggest(parts[1], keys); s != "" {
+			msg += fmt.Sprintf("; did you mean %q?", s)
+		}
+		l.report(LintError, line, task, "%s (decoded keys: %s)", msg, strings.Join(keys, ", "))
+		return
+	}
+	if _, ok := l.vars[root]; ok {
+		return
+	}
+
+	candidates := make([]string, 0, len(l.nodes)+len(l.vars))
+	for id := range l.nodes {
+		candidates = append(candidates, id)
+	}
+	for v := range l.vars {
+		candidates = append(candidates, v)
+	}
+	sort.Strings(candidates)
+	if s := suggest(root, candidates); s != "" {
+		l.report(LintError, line, task, "$(%s) references undefined %q; did you mean %q?", expr, root, s)
+		return
+	}
+	l.report(LintWarning, line, task, "$(%s) references %q, which is neither a task nor set by the job; the run fails unless it is provided", expr, root)
+}
+
+func (l *linter) checkReachability() {
+	// A task whose inputs all come from fail tasks, or from tasks which are
+	// themselves dead, can never receive a successful input.
+	dead := make(map[string]struct{})
+	for _, task := range l.p.Tasks {
+		inputs := task.Inputs()
+		if len(inputs) == 0 {
+			continue
+		}
+		allDead := true
+		for _, in := range inputs {
+			_, isDead := dead[in.InputTask.DotID()]
+			if in.InputTask.Type() != TaskTypeFail && !isDead {
+				allDead = false
+				break
+			}
+		}
+		if !allDead {
+			continue
+		}
+		dead[task.DotID()] = struct{}{}
+		if task.Type() != TaskTypeFail {
+			l.flagged[task.DotID()] = struct{}{}
+			l.report(LintError, l.declarationLine(task), task, "task is unreachable: all of its inputs come from fail tasks")
+		}
+	}
+
+	// Tasks which are not connected to the part of the pipeline producing the
+	// results are never consumed. Components holding an indexed task are the
+	// results; without indexes the largest component is.
+	component := make(map[string]int)
+	var sizes []int
+	var indexed []bool
+	for _, task := range l.p.Tasks {
+		if _, ok := component[task.DotID()]; ok {
+			continue
+		}
+		c := len(sizes)
+		sizes = append(sizes, 0)
+		indexed = append(indexed, false)
+		stack := []Task{task}
+		for len(stack) > 0 {
+			t := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if _, ok := component[t.DotID()]; ok {
+				continue
+			}
+			component[t.DotID()] = c
+			sizes[c]++
+			indexed[c] = indexed[c] || t.OutputIndex() > 0
+			stack = append(stack, t.Outputs()...)
+			for _, in := range t.Inputs() {
+				stack = append(stack, in.InputTask)
+			}
+		}
+	}
+	if len(sizes) < 2 {
+		return
+	}
+
+	results := make(map[int]bool)
+	for c := range indexed {
+		if indexed[c] {
+			results[c] = true
+		}
+	}
+	if len(results) == 0 {
+		largest := 0
+		for c := range sizes {
+			if sizes[c] > sizes[largest] {
+				largest = c
+			}
+		}
+		results[largest] = true
+	}
+	for _, task := range l.p.Tasks {
+		if results[component[task.DotID()]] {
+			continue
+		}
+		if _, ok := l.flagged[task.DotID()]; ok {
+			continue
+		}
+		l.flagged[task.DotID()] = struct{}{}
+		l.report(LintWarning, l.declarationLine(task), task, "task is not connected to the rest of the pipeline")
+	}
+}
+
+func (l *linter) checkOutputs() {
+	var final []Task
+	for _, task := range l.p.Tasks {
+		if len(task.Outputs()) == 0 && task.Type() != TaskTypeFail {
+			final = append(final, task)
+		}
+	}
+	if len(final) < 2 {
+		return
+	}
+	for _, task := range final {
+		if _, ok := l.flagged[task.DotID()]; ok || task.OutputIndex() > 0 {
+			continue
+		}
+		l.report(LintWarning, l.declarationLine(task), task, "output is not used by any task and has no index, so its position in the run results is undefined")
+	}
+}
+
+// declarationOffset returns the offset in the source at which task is declared.
+func (l *linter) declarationOffset(task Task) (int, bool) {
+	re := regexp.MustCompile(`(?m)^[ \t]*"?` + regexp.QuoteMeta(task.DotID()) + `"?\s*\[`)
+	loc := re.FindStringIndex(l.source)
+	if loc == nil {
+		return 0, false
+	}
+	return loc[0], true
+}
+
+// declarationLine returns the line on which task is declared, or 0.
+func (l *linter) declarationLine(task Task) int {
+	offset, ok := l.declarationOffset(task)
+	if !ok {
+		return 0
+	}
+	return lineAt(l.source, offset)
+}
+
+// referenceLine returns the line of the first occurrence of raw after the
+// declaration of task, falling back to the declaration line itself.
+func (l *linter) referenceLine(task Task, raw string) int {
+	offset, ok := l.declarationOffset(task)
+	if !ok {
+		return 0
+	}
+	if idx := strings.Index(l.source[offset:], raw); idx >= 0 {
+		offset += idx
+	}
+	return lineAt(l.source, offset)
+}
+
+func lineAt(s string, offset int) int {
+	return strings.Count(s[:offset], "\n") + 1
+}
+
+// suggest returns the candidate closest to name if it is a likely typo of it.
+func suggest(name string, candidates []string) string {
+	best, bestDist := "", 3
+	for _, c := range candidates {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(c)); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
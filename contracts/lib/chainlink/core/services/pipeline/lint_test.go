@@ -0,0 +1,144 @@
+package pipeline_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		source   string
+		expected []pipeline.LintIssue
+	}{
+		{
+			name: "clean",
+			source: `
+ds    [type=http method=GET url="$(jobSpec.url)"]
+parse [type=jsonparse path="data,price"]
+ds -> parse
+`,
+		},
+		{
+			name:   "parse error",
+			source: `ds [type=http`,
+			expected: []pipeline.LintIssue{
+				{Severity: pipeline.LintError, Message: "could not unmarshal DOT into a pipeline.Graph"},
+			},
+		},
+		{
+			name: "misspelled reference",
+			source: `
+ds    [type=http method=GET url="https://example.com"]
+parse [type=jsonparse path="data,price" data="$(dss)"]
+ds -> parse
+`,
+			expected: []pipeline.LintIssue{
+				{Severity: pipeline.LintError, Line: 3, Task: "parse", Message: `$(dss) references undefined "dss"; did you mean "ds"?`},
+			},
+		},
+		{
+			name: "variable not set by the job",
+			source: `
+ds [type=http method=GET url="$(settings.url)"]
+`,
+			expected: []pipeline.LintIssue{
+				{Severity: pipeline.LintWarning, Line: 2, Task: "ds", Message: `$(settings.url) references "settings", which is neither a task nor set by the job; the run fails unless it is provided`},
+			},
+		},
+		{
+			name: "decoded key does not exist",
+			source: `
+decode [type=ethabidecode abi="uint256 price, bytes32 id" data="$(jobRun.data)"]
+submit [type=multiply input="$(decode.prices)" times=100]
+decode -> submit
+`,
+			expected: []pipeline.LintIssue{
+				{Severity: pipeline.LintError, Line: 3, Task: "submit", Message: `$(decode.prices): task decode does not decode a key named "prices"; did you mean "price"? (decoded keys: price, id)`},
+			},
+		},
+		{
+			name: "decoded log key",
+			source: `
+decode [type=ethabidecodelog abi="Request(bytes32 indexed id, uint256 amount)" data="$(jobRun.logData)" topics="$(jobRun.logTopics)"]
+submit [type=multiply input="$(decode.amount)" times=100]
+decode -> submit
+`,
+		},
+		{
+			name: "invalid abi",
+			source: `
+decode [type=ethabidecode abi="uint256" data="$(jobRun.data)"]
+`,
+			expected: []pipeline.LintIssue{
+				{Severity: pipeline.LintError, Line: 2, Task: "decode", Message: "invalid abi: bad ABI specification, missing argument name: uint256"},
+			},
+		},
+		{
+			name: "disconnected tasks",
+			source: `
+a [type=memo value="1"]
+b [type=memo value="2" index=1]
+c [type=memo value="3"]
+`,
+			expected: []pipeline.LintIssue{
+				{Severity: pipeline.LintWarning, Line: 2, Task: "a", Message: "task is not connected to the rest of the pipeline"},
+				{Severity: pipeline.LintWarning, Line: 4, Task: "c", Message: "task is not connected to the rest of the pipeline"},
+			},
+		},
+		{
+			name: "several unindexed final outputs",
+			source: `
+ds [type=memo value="1"]
+a  [type=memo value="$(ds)"]
+b  [type=memo value="$(ds)"]
+ds -> a
+ds -> b
+`,
+			expected: []pipeline.LintIssue{
+				{Severity: pipeline.LintWarning, Line: 3, Task: "a", Message: "output is not used by any task and has no index, so its position in the run results is undefined"},
+				{Severity: pipeline.LintWarning, Line: 4, Task: "b", Message: "output is not used by any task and has no index, so its position in the run results is undefined"},
+			},
+		},
+		{
+			name: "downstream of fail",
+			source: `
+fail   [type=fail msg="always"]
+submit [type=memo value="1"]
+fail -> submit
+`,
+			expected: []pipeline.LintIssue{
+				{Severity: pipeline.LintError, Line: 3, Task: "submit", Message: "task is unreachable: all of its inputs come from fail tasks"},
+			},
+		},
+		{
+			name: "own output",
+			source: `
+ds [type=memo value="$(ds)"]
+`,
+			expected: []pipeline.LintIssue{
+				{Severity: pipeline.LintError, Line: 2, Task: "ds", Message: "$(ds) references the task's own output"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := pipeline.Lint(tt.source, "jobSpec", "jobRun")
+			require.Len(t, issues, len(tt.expected), "%v", issues)
+			for i, expected := range tt.expected {
+				assert.Equal(t, expected.Severity, issues[i].Severity)
+				assert.Equal(t, expected.Line, issues[i].Line)
+				assert.Equal(t, expected.Task, issues[i].Task)
+				assert.Contains(t, issues[i].Message, expected.Message)
+			}
+			assert.Equal(t, len(tt.expected) > 0 && tt.expected[0].Severity == pipeline.LintError, pipeline.HasErrors(issues))
+		})
+	}
+}
@@ -139,6 +139,75 @@ func (_c *Runner_ExecuteAndInsertFinishedRun_Call) RunAndReturn(run func(context
 	return _c
 }
 
+// ExecuteDryRun provides a mock function with given fields: ctx, spec, fixtures
+func (_m *Runner) ExecuteDryRun(ctx context.Context, spec pipeline.Spec, fixtures pipeline.DryRunFixtures) (*pipeline.Run, pipeline.TaskRunResults, error) {
+	ret := _m.Called(ctx, spec, fixtures)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteDryRun")
+	}
+
+	var r0 *pipeline.Run
+	var r1 pipeline.TaskRunResults
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, pipeline.Spec, pipeline.DryRunFixtures) (*pipeline.Run, pipeline.TaskRunResults, error)); ok {
+		return rf(ctx, spec, fixtures)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pipeline.Spec, pipeline.DryRunFixtures) *pipeline.Run); ok {
+		r0 = rf(ctx, spec, fixtures)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pipeline.Run)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pipeline.Spec, pipeline.DryRunFixtures) pipeline.TaskRunResults); ok {
+		r1 = rf(ctx, spec, fixtures)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(pipeline.TaskRunResults)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, pipeline.Spec, pipeline.DryRunFixtures) error); ok {
+		r2 = rf(ctx, spec, fixtures)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Runner_ExecuteDryRun_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteDryRun'
+type Runner_ExecuteDryRun_Call struct {
+	*mock.Call
+}
+
+// ExecuteDryRun is a helper method to define mock.On call
+//   - ctx context.Context
+//   - spec pipeline.Spec
+//   - fixtures pipeline.DryRunFixtures
+func (_e *Runner_Expecter) ExecuteDryRun(ctx interface{}, spec interface{}, fixtures interface{}) *Runner_ExecuteDryRun_Call {
+	return &Runner_ExecuteDryRun_Call{Call: _e.mock.On("ExecuteDryRun", ctx, spec, fixtures)}
+}
+
+func (_c *Runner_ExecuteDryRun_Call) Run(run func(ctx context.Context, spec pipeline.Spec, fixtures pipeline.DryRunFixtures)) *Runner_ExecuteDryRun_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(pipeline.Spec), args[2].(pipeline.DryRunFixtures))
+	})
+	return _c
+}
+
+func (_c *Runner_ExecuteDryRun_Call) Return(run *pipeline.Run, trrs pipeline.TaskRunResults, err error) *Runner_ExecuteDryRun_Call {
+	_c.Call.Return(run, trrs, err)
+	return _c
+}
+
+func (_c *Runner_ExecuteDryRun_Call) RunAndReturn(run func(context.Context, pipeline.Spec, pipeline.DryRunFixtures) (*pipeline.Run, pipeline.TaskRunResults, error)) *Runner_ExecuteDryRun_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ExecuteRun provides a mock function with given fields: ctx, spec, vars
 func (_m *Runner) ExecuteRun(ctx context.Context, spec pipeline.Spec, vars pipeline.Vars) (*pipeline.Run, pipeline.TaskRunResults, error) {
 	ret := _m.Called(ctx, spec, vars)
@@ -68,6 +68,9 @@ type Run struct {
 	Pending bool
 	// FailSilently is used to signal that a task with the failEarly flag has failed, and we want to not put this in the db
 	FailSilently bool
+
+	// fixtures is set on dry runs, see Runner.ExecuteDryRun
+	fixtures *DryRunFixtures
 }
 
 func (r Run) GetID() string {
@@ -275,6 +278,7 @@ type TaskRun struct {
 	FinishedAt    null.Time                         `json:"finishedAt"`
 	Index         int32                             `json:"index"`
 	DotID         string                            `json:"dotId"`
+	Attempts      TaskRunAttempts                   `json:"attempts"` // only set for tasks which were retried
 
 	// Used internally for sorting completed results
 	task Task
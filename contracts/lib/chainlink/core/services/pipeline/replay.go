@@ -0,0 +1,63 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+// ErrCannotReplay is returned by NewReplayRun when a run cannot be replayed as requested.
+var ErrCannotReplay = errors.New("cannot replay run")
+
+// NewReplayRun returns a new run of spec which replays the finished run original from the
+// task fromTask: the new run has the inputs of original, and copies of the task runs original
+// recorded for every task which is neither fromTask nor one of its descendants. Once the
+// scheduler has reconstructed their results from those copies, only fromTask and its
+// descendants are executed again, e.g. only the ethtx task of a run whose transaction failed.
+//
+// spec must be the pipeline spec original was made with. The dot IDs of the tasks which will be
+// executed again are returned in the order of the pipeline.
+func NewReplayRun(spec Spec, original Run, fromTask string) (*Run, []string, error) {
+	if !original.State.Finished() {
+		return nil, nil, fmt.Errorf("%w: run %d is %s, only finished runs can be replayed", ErrCannotReplay, original.ID, original.State)
+	}
+	if spec.ID != original.PipelineSpecID {
+		return nil, nil, fmt.Errorf("%w: run %d was made with pipeline spec %d, not %d", ErrCannotReplay, original.ID, original.PipelineSpecID, spec.ID)
+	}
+	vars, ok := original.Inputs.Val.(map[string]interface{})
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: run %d has no recorded inputs", ErrCannotReplay, original.ID)
+	}
+
+	p, err := Parse(spec.DotDagSource)
+	if err != nil {
+		return nil, nil, err
+	}
+	from := p.ByDotID(fromTask)
+	if from == nil {
+		return nil, nil, fmt.Errorf("%w: pipeline has no task %q", ErrCannotReplay, fromTask)
+	}
+	replayed := map[string]struct{}{fromTask: {}}
+	for _, task := range from.Base().GetDescendantTasks() {
+		replayed[task.DotID()] = struct{}{}
+	}
+
+	run := NewRun(spec, NewVarsFrom(vars))
+	var dotIDs []string
+	for _, task := range p.Tasks {
+		if _, ok := replayed[task.DotID()]; ok {
+			dotIDs = append(dotIDs, task.DotID())
+			continue
+		}
+		taskRun := original.ByDotID(task.DotID())
+		if taskRun == nil || taskRun.IsPending() {
+			return nil, nil, fmt.Errorf("%w: run %d has no recorded result for task %s", ErrCannotReplay, original.ID, task.DotID())
+		}
+		recorded := *taskRun
+		recorded.ID = uuid.New()
+		recorded.PipelineRunID = 0
+		run.PipelineTaskRuns = append(run.PipelineTaskRuns, recorded)
+	}
+	return run, dotIDs, nil
+}
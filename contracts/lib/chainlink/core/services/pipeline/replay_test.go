@@ -0,0 +1,87 @@
+package pipeline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/guregu/null.v4"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/jsonserializable"
+)
+
+func TestNewReplayRun(t *testing.T) {
+	t.Parallel()
+
+	spec := Spec{ID: 7, DotDagSource: `
+fetch  [type=memo value=10]
+double [type=multiply input="$(fetch)" times=2]
+submit [type=memo value="$(double)"]
+log    [type=memo value="$(fetch)"]
+fetch -> double -> submit
+fetch -> log
+`}
+	taskRun := func(dotID string, output interface{}) TaskRun {
+		return TaskRun{
+			ID:            uuid.New(),
+			PipelineRunID: 1,
+			DotID:         dotID,
+			Type:          TaskTypeMemo,
+			Output:        jsonserializable.JSONSerializable{Val: output, Valid: true},
+			CreatedAt:     time.Now(),
+			FinishedAt:    null.TimeFrom(time.Now()),
+		}
+	}
+	original := Run{
+		ID:             1,
+		State:          RunStatusErrored,
+		PipelineSpecID: spec.ID,
+		Inputs:         jsonserializable.JSONSerializable{Val: map[string]interface{}{"jobRun": map[string]interface{}{"meta": "x"}}, Valid: true},
+		PipelineTaskRuns: []TaskRun{
+			taskRun("fetch", "10"),
+			taskRun("double", "20"),
+			taskRun("log", "10"),
+			{ID: uuid.New(), PipelineRunID: 1, DotID: "submit", Error: null.StringFrom("tx reverted"), FinishedAt: null.TimeFrom(time.Now())},
+		},
+	}
+
+	t.Run("reuses the results of the tasks before fromTask", func(t *testing.T) {
+		run, replayed, err := NewReplayRun(spec, original, "double")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"double", "submit"}, replayed)
+		assert.Equal(t, original.Inputs.Val, run.Inputs.Val)
+		assert.Equal(t, spec.ID, run.PipelineSpecID)
+
+		require.Len(t, run.PipelineTaskRuns, 2)
+		for _, tr := range run.PipelineTaskRuns {
+			recorded := original.ByDotID(tr.DotID)
+			assert.NotEqual(t, recorded.ID, tr.ID)
+			assert.Zero(t, tr.PipelineRunID)
+			assert.Equal(t, recorded.Output, tr.Output)
+		}
+		assert.NotNil(t, run.ByDotID("fetch"))
+		assert.NotNil(t, run.ByDotID("log"))
+	})
+
+	for _, tt := range []struct {
+		name     string
+		mutate   func(spec *Spec, run *Run)
+		fromTask string
+		err      string
+	}{
+		{"unknown task", func(*Spec, *Run) {}, "sign", `pipeline has no task "sign"`},
+		{"unfinished run", func(_ *Spec, run *Run) { run.State = RunStatusSuspended }, "submit", "only finished runs can be replayed"},
+		{"other spec", func(spec *Spec, _ *Run) { spec.ID = 8 }, "submit", "run 1 was made with pipeline spec 7, not 8"},
+		{"missing result", func(_ *Spec, run *Run) { run.PipelineTaskRuns = run.PipelineTaskRuns[1:] }, "submit", "run 1 has no recorded result for task fetch"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, run := spec, original
+			tt.mutate(&spec, &run)
+			_, _, err := NewReplayRun(spec, run, tt.fromTask)
+			require.ErrorIs(t, err, ErrCannotReplay)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
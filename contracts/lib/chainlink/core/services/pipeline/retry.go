@@ -0,0 +1,190 @@
+package pipeline
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/guregu/null.v4"
+
+	evmclient "github.com/smartcontractkit/chainlink-evm/pkg/client"
+
+	cnull "github.com/smartcontractkit/chainlink/v2/core/null"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
+)
+
+// RetryCondition is a class of task errors which a task may be retried on, set
+// with e.g. retryOn="http5xx,timeout".
+type RetryCondition string
+
+const (
+	// RetryOnRetryable matches the errors the task itself considers transient.
+	RetryOnRetryable RetryCondition = "retryable"
+	// RetryOnHTTP4xx matches http and bridge responses with a 4xx status code.
+	RetryOnHTTP4xx RetryCondition = "http4xx"
+	// RetryOnHTTP429 matches http and bridge responses which were rate limited.
+	RetryOnHTTP429 RetryCondition = "http429"
+	// RetryOnHTTP5xx matches http and bridge responses with a 5xx status code.
+	RetryOnHTTP5xx RetryCondition = "http5xx"
+	// RetryOnTimeout matches tasks which ran out of time.
+	RetryOnTimeout RetryCondition = "timeout"
+	// RetryOnRPCUnavailable matches RPC errors classified as service unavailable.
+	RetryOnRPCUnavailable RetryCondition = "rpcUnavailable"
+	// RetryOnRPCTimeout matches RPC errors classified as service timeouts.
+	RetryOnRPCTimeout RetryCondition = "rpcTimeout"
+)
+
+var retryConditions = map[RetryCondition]struct{}{
+	RetryOnRetryable:      {},
+	RetryOnHTTP4xx:        {},
+	RetryOnHTTP429:        {},
+	RetryOnHTTP5xx:        {},
+	RetryOnTimeout:        {},
+	RetryOnRPCUnavailable: {},
+	RetryOnRPCTimeout:     {},
+}
+
+// RetryJitter randomises the backoff between attempts, so that tasks failing
+// together do not retry together.
+type RetryJitter string
+
+const (
+	// RetryJitterNone waits exactly the backoff.
+	RetryJitterNone RetryJitter = "none"
+	// RetryJitterFull waits a random duration between 0 and the backoff.
+	RetryJitterFull RetryJitter = "full"
+	// RetryJitterEqual waits half the backoff plus a random duration up to the other half.
+	RetryJitterEqual RetryJitter = "equal"
+)
+
+// RetryBudgetAttribute is the graph attribute limiting the number of retries
+// across all tasks of a run, e.g. `graph [retryBudget=10]`.
+const RetryBudgetAttribute = "retryBudget"
+
+// parseRetryConditions parses a comma separated list of retry conditions.
+func parseRetryConditions(s string) ([]RetryCondition, error) {
+	var conditions []RetryCondition
+	for _, c := range strings.Split(s, ",") {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		if _, ok := retryConditions[RetryCondition(c)]; !ok {
+			return nil, errors.Errorf("unknown retry condition %q", c)
+		}
+		conditions = append(conditions, RetryCondition(c))
+	}
+	return conditions, nil
+}
+
+func parseRetryJitter(s string) (RetryJitter, error) {
+	switch j := RetryJitter(strings.TrimSpace(s)); j {
+	case "", RetryJitterNone:
+		return RetryJitterNone, nil
+	case RetryJitterFull, RetryJitterEqual:
+		return j, nil
+	default:
+		return "", errors.Errorf("unknown retry jitter %q", s)
+	}
+}
+
+func parseRetryBudget(s string) (cnull.Uint32, error) {
+	budget, err := strconv.ParseUint(strings.TrimSpace(s), 10, 32)
+	if err != nil {
+		return cnull.Uint32{}, errors.Wrapf(err, "invalid %s", RetryBudgetAttribute)
+	}
+	return cnull.Uint32From(uint32(budget)), nil
+}
+
+// matches returns true if the failed result belongs to the condition.
+func (c RetryCondition) matches(result Result, runInfo RunInfo) bool {
+	switch c {
+	case RetryOnRetryable:
+		return runInfo.IsRetryable
+	case RetryOnHTTP4xx:
+		return runInfo.StatusCode >= 400 && runInfo.StatusCode < 500
+	case RetryOnHTTP429:
+		return runInfo.StatusCode == 429
+	case RetryOnHTTP5xx:
+		return runInfo.StatusCode >= 500 && runInfo.StatusCode < 600
+	case RetryOnTimeout:
+		return runInfo.TimedOut || errors.Is(result.Error, context.DeadlineExceeded)
+	case RetryOnRPCUnavailable:
+		return evmclient.NewSendError(result.Error).IsServiceUnavailable(nil)
+	case RetryOnRPCTimeout:
+		return evmclient.NewSendError(result.Error).IsServiceTimeout(nil)
+	default:
+		return false
+	}
+}
+
+// matchesRetryPolicy returns true if a failed attempt of task may be retried given
+// its retry conditions. Tasks without conditions retry on any error.
+func matchesRetryPolicy(task Task, result Result, runInfo RunInfo) bool {
+	if result.Error == nil {
+		return false
+	}
+	conditions := task.TaskRetryOn()
+	if len(conditions) == 0 {
+		return true
+	}
+	for _, c := range conditions {
+		if c.matches(result, runInfo) {
+			return true
+		}
+	}
+	return false
+}
+
+// apply returns the duration to wait before the next attempt.
+func (j RetryJitter) apply(backoff time.Duration) time.Duration {
+	if backoff <= 0 {
+		return backoff
+	}
+	switch j {
+	case RetryJitterFull:
+		return time.Duration(rand.Int63n(int64(backoff))) //nolint:gosec // jitter does not need a secure source
+	case RetryJitterEqual:
+		half := backoff / 2
+		return half + time.Duration(rand.Int63n(int64(backoff-half))) //nolint:gosec // jitter does not need a secure source
+	default:
+		return backoff
+	}
+}
+
+// TaskRunAttempt is a single attempt of a task which was retried. Backoff is
+// how long the scheduler waited before the next attempt, and is zero for the
+// last one.
+type TaskRunAttempt struct {
+	Error      null.String     `json:"error"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	FinishedAt null.Time       `json:"finishedAt"`
+	Backoff    models.Interval `json:"backoff"`
+}
+
+// TaskRunAttempts is the attempt history of a task run, stored as JSON.
+type TaskRunAttempts []TaskRunAttempt
+
+func (a *TaskRunAttempts) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.Errorf("TaskRunAttempts#Scan received a value of type %T", value)
+	}
+	return json.Unmarshal(bytes, a)
+}
+
+func (a TaskRunAttempts) Value() (driver.Value, error) {
+	if len(a) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(a)
+}
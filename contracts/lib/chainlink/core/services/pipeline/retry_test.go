@@ -0,0 +1,104 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryCondition_matches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		condition RetryCondition
+		result    Result
+		runInfo   RunInfo
+		expected  bool
+	}{
+		{"retryable", RetryOnRetryable, Result{Error: ErrTaskRunFailed}, RunInfo{IsRetryable: true}, true},
+		{"not retryable", RetryOnRetryable, Result{Error: ErrTaskRunFailed}, RunInfo{}, false},
+		{"4xx", RetryOnHTTP4xx, Result{Error: ErrTaskRunFailed}, RunInfo{StatusCode: 404}, true},
+		{"4xx on a 5xx", RetryOnHTTP4xx, Result{Error: ErrTaskRunFailed}, RunInfo{StatusCode: 500}, false},
+		{"429", RetryOnHTTP429, Result{Error: ErrTaskRunFailed}, RunInfo{StatusCode: 429}, true},
+		{"429 on another 4xx", RetryOnHTTP429, Result{Error: ErrTaskRunFailed}, RunInfo{StatusCode: 400}, false},
+		{"5xx", RetryOnHTTP5xx, Result{Error: ErrTaskRunFailed}, RunInfo{StatusCode: 503}, true},
+		{"5xx without a response", RetryOnHTTP5xx, Result{Error: ErrTaskRunFailed}, RunInfo{}, false},
+		{"timed out", RetryOnTimeout, Result{Error: ErrTaskRunFailed}, RunInfo{TimedOut: true}, true},
+		{"deadline exceeded", RetryOnTimeout, Result{Error: errors.Wrap(context.DeadlineExceeded, "call failed")}, RunInfo{}, true},
+		{"not timed out", RetryOnTimeout, Result{Error: ErrTaskRunFailed}, RunInfo{}, false},
+		{"rpc unavailable", RetryOnRPCUnavailable, Result{Error: errors.New("dial tcp: network is unreachable")}, RunInfo{}, true},
+		{"rpc available", RetryOnRPCUnavailable, Result{Error: errors.New("execution reverted")}, RunInfo{}, false},
+		{"rpc timeout", RetryOnRPCTimeout, Result{Error: errors.New("408 Request Timeout")}, RunInfo{}, true},
+		{"rpc no timeout", RetryOnRPCTimeout, Result{Error: errors.New("execution reverted")}, RunInfo{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.condition.matches(tt.result, tt.runInfo))
+		})
+	}
+}
+
+func TestRetryJitter_apply(t *testing.T) {
+	t.Parallel()
+
+	backoff := 10 * time.Second
+	assert.Equal(t, backoff, RetryJitterNone.apply(backoff))
+	for i := 0; i < 100; i++ {
+		full := RetryJitterFull.apply(backoff)
+		assert.GreaterOrEqual(t, full, time.Duration(0))
+		assert.Less(t, full, backoff)
+
+		equal := RetryJitterEqual.apply(backoff)
+		assert.GreaterOrEqual(t, equal, backoff/2)
+		assert.Less(t, equal, backoff)
+	}
+	assert.Equal(t, time.Duration(0), RetryJitterFull.apply(0))
+}
+
+func TestParse_RetryPolicies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parses retry policies and the retry budget", func(t *testing.T) {
+		p, err := Parse(`
+graph [retryBudget=5]
+ds [type=http method=GET url="https://example.com" retries=3 retryOn="http5xx, http429,timeout" retryJitter=equal]
+`)
+		require.NoError(t, err)
+		require.True(t, p.RetryBudget.Valid)
+		assert.Equal(t, uint32(5), p.RetryBudget.Uint32)
+		task := p.ByDotID("ds")
+		assert.Equal(t, []RetryCondition{RetryOnHTTP5xx, RetryOnHTTP429, RetryOnTimeout}, task.TaskRetryOn())
+		assert.Equal(t, RetryJitterEqual, task.TaskRetryJitter())
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		p, err := Parse(`ds [type=http method=GET url="https://example.com" retries=3]`)
+		require.NoError(t, err)
+		assert.False(t, p.RetryBudget.Valid)
+		task := p.ByDotID("ds")
+		assert.Empty(t, task.TaskRetryOn())
+		assert.Equal(t, RetryJitterNone, task.TaskRetryJitter())
+		assert.True(t, matchesRetryPolicy(task, Result{Error: ErrTaskRunFailed}, RunInfo{}))
+	})
+
+	for _, tt := range []struct {
+		name   string
+		source string
+		err    string
+	}{
+		{"unknown retry condition", `ds [type=http method=GET url="https://example.com" retryOn="http5xx,http3xx"]`, `unknown retry condition "http3xx"`},
+		{"unknown jitter", `ds [type=http method=GET url="https://example.com" retryJitter=half]`, `unknown retry jitter "half"`},
+		{"invalid retry budget", "graph [retryBudget=lots]\nds [type=http method=GET url=\"https://example.com\"]", "invalid retryBudget"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.source)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
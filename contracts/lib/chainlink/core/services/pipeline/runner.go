@@ -42,6 +42,9 @@ type Runner interface {
 	// ExecuteRun executes a new run in-memory according to a spec and returns the results.
 	// We expect spec.JobID and spec.JobName to be set for logging/prometheus.
 	ExecuteRun(ctx context.Context, spec Spec, vars Vars) (run *Run, trrs TaskRunResults, err error)
+	// ExecuteDryRun executes a new run in-memory like ExecuteRun, but its http, bridge, ethcall, ethtx
+	// and estimategaslimit tasks are served from fixtures instead of talking to the outside world.
+	ExecuteDryRun(ctx context.Context, spec Spec, fixtures DryRunFixtures) (run *Run, trrs TaskRunResults, err error)
 	// InsertFinishedRun saves the run results in the database.
 	// ds is an optional override, for example when executing a transaction.
 	InsertFinishedRun(ctx context.Context, ds sqlutil.DataSource, run *Run, saveSuccessfulTaskRuns bool) error
@@ -65,7 +68,7 @@ type runner struct {
 	bridgeConfig           BridgeConfig
 	legacyEVMChains        legacyevm.LegacyChainContainer
 	ethKeyStore            ETHKeyStore
-	vrfKeyStore            VRFKeyStore
+	vrfProver              VRFProver
 	runReaperWorker        *commonutils.SleeperTask
 	lggr                   logger.Logger
 	httpClient             *http.Client
@@ -115,7 +118,7 @@ func NewRunner(
 	bridgeCfg BridgeConfig,
 	legacyChains legacyevm.LegacyChainContainer,
 	ethks ETHKeyStore,
-	vrfks VRFKeyStore,
+	vrfProver VRFProver,
 	lggr logger.Logger,
 	httpClient, unrestrictedHTTPClient *http.Client,
 ) *runner {
@@ -128,7 +131,7 @@ func NewRunner(
 		bridgeConfig:           bridgeCfg,
 		legacyEVMChains:        legacyChains,
 		ethKeyStore:            ethks,
-		vrfKeyStore:            vrfks,
+		vrfProver:              vrfProver,
 		chStop:                 make(chan struct{}),
 		wgDone:                 sync.WaitGroup{},
 		runFinished:            func(*Run) {},
@@ -324,14 +327,38 @@ func (r *runner) ExecuteRun(ctx context.Context, spec Spec, vars Vars) (*Run, Ta
 	return run, taskRunResults, nil
 }
 
+func (r *runner) ExecuteDryRun(ctx context.Context, spec Spec, fixtures DryRunFixtures) (*Run, TaskRunResults, error) {
+	pipeline, err := r.initializePipeline(spec, &fixtures)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	vars := NewVarsFrom(fixtures.Vars)
+	run := NewRun(spec, vars)
+	run.fixtures = &fixtures
+	return run, r.run(ctx, pipeline, run, vars), nil
+}
+
 func (r *runner) InitializePipeline(spec Spec) (pipeline *Pipeline, err error) {
+	return r.initializePipeline(spec, nil)
+}
+
+func (r *runner) initializePipeline(spec Spec, fixtures *DryRunFixtures) (pipeline *Pipeline, err error) {
 	pipeline, err = spec.GetOrParsePipeline()
 	if err != nil {
 		return
 	}
 
+	r.initializeTasks(spec, fixtures, pipeline.Tasks)
+	return pipeline, nil
+}
+
+// initializeTasks sets the task params which come from the node rather than
+// from the spec. The sub-pipelines of foreach tasks are served from the same
+// fixtures as the rest of a dry run.
+func (r *runner) initializeTasks(spec Spec, fixtures *DryRunFixtures, tasks []Task) {
 	// initialize certain task params
-	for _, task := range pipeline.Tasks {
+	for _, task := range tasks {
 		task.Base().uuid = uuid.New()
 
 		switch task.Type() {
@@ -355,11 +382,11 @@ func (r *runner) InitializePipeline(spec Spec) (pipeline *Pipeline, err error) {
 			task.(*ETHCallTask).specGasLimit = spec.GasLimit
 			task.(*ETHCallTask).jobType = spec.JobType
 		case TaskTypeVRF:
-			task.(*VRFTask).keyStore = r.vrfKeyStore
+			task.(*VRFTask).prover = r.vrfProver
 		case TaskTypeVRFV2:
-			task.(*VRFTaskV2).keyStore = r.vrfKeyStore
+			task.(*VRFTaskV2).prover = r.vrfProver
 		case TaskTypeVRFV2Plus:
-			task.(*VRFTaskV2Plus).keyStore = r.vrfKeyStore
+			task.(*VRFTaskV2Plus).prover = r.vrfProver
 		case TaskTypeEstimateGasLimit:
 			task.(*EstimateGasLimitTask).legacyChains = r.legacyEVMChains
 			task.(*EstimateGasLimitTask).specGasLimit = spec.GasLimit
@@ -370,11 +397,15 @@ func (r *runner) InitializePipeline(spec Spec) (pipeline *Pipeline, err error) {
 			task.(*ETHTxTask).specGasLimit = spec.GasLimit
 			task.(*ETHTxTask).jobType = spec.JobType
 			task.(*ETHTxTask).forwardingAllowed = spec.ForwardingAllowed
+		case TaskTypeForEach:
+			t := task.(*ForEachTask)
+			r.initializeTasks(spec, fixtures, t.subpipeline.Tasks)
+			t.runSubpipeline = func(ctx context.Context, vars Vars) TaskRunResults {
+				return r.runSubpipeline(ctx, spec, fixtures, t.subpipeline, vars)
+			}
 		default:
 		}
 	}
-
-	return pipeline, nil
 }
 
 func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Vars) TaskRunResults {
@@ -383,39 +414,7 @@ func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Var
 		l.Debug("Initiating tasks for pipeline run of spec")
 	}
 
-	scheduler := newScheduler(pipeline, run, vars, l)
-	go scheduler.Run()
-
-	// This is "just in case" for cleaning up any stray reports.
-	// Normally the scheduler loop doesn't stop until all in progress runs report back
-	reportCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
-	defer cancel()
-
-	if pipelineTimeout := r.config.MaxRunDuration(); pipelineTimeout != 0 {
-		ctx, cancel = context.WithTimeout(ctx, pipelineTimeout)
-		defer cancel()
-	}
-
-	for taskRun := range scheduler.taskCh {
-		taskRun := taskRun
-		// execute
-		go recovery.WrapRecoverHandle(l, func() {
-			result := r.executeTaskRun(ctx, run.PipelineSpec, taskRun, l)
-
-			logTaskRunToPrometheus(result, run.PipelineSpec)
-
-			scheduler.report(reportCtx, result)
-		}, func(err interface{}) {
-			t := time.Now()
-			scheduler.report(reportCtx, TaskRunResult{
-				ID:         uuid.New(),
-				Task:       taskRun.task,
-				Result:     Result{Error: ErrRunPanicked{err}},
-				FinishedAt: null.TimeFrom(t),
-				CreatedAt:  t, // TODO: more accurate start time
-			})
-		})
-	}
+	scheduler := r.execute(ctx, pipeline, run, vars, l)
 
 	// if the run is suspended, awaiting resumption
 	run.Pending = scheduler.pending
@@ -436,6 +435,11 @@ func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Var
 	run.PipelineTaskRuns = nil
 	for _, result := range scheduler.results {
 		output := result.Result.OutputDB()
+		// the attempt history is only kept for tasks which were retried
+		var attempts TaskRunAttempts
+		if len(result.history) > 1 {
+			attempts = result.history
+		}
 		run.PipelineTaskRuns = append(run.PipelineTaskRuns, TaskRun{
 			ID:            result.ID,
 			PipelineRunID: run.ID,
@@ -446,6 +450,7 @@ func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Var
 			DotID:         result.Task.DotID(),
 			CreatedAt:     result.CreatedAt,
 			FinishedAt:    result.FinishedAt,
+			Attempts:      attempts,
 			task:          result.Task,
 		})
 
@@ -537,7 +542,60 @@ func (r *runner) run(ctx context.Context, pipeline *Pipeline, run *Run, vars Var
 	return taskRunResults
 }
 
-func (r *runner) executeTaskRun(ctx context.Context, spec Spec, taskRun *memoryTaskRun, l logger.Logger) TaskRunResult {
+// execute runs the tasks of pipeline until the scheduler has no more work.
+func (r *runner) execute(ctx context.Context, pipeline *Pipeline, run *Run, vars Vars, l logger.Logger) *scheduler {
+	scheduler := newScheduler(pipeline, run, vars, l)
+	go scheduler.Run()
+
+	// This is "just in case" for cleaning up any stray reports.
+	// Normally the scheduler loop doesn't stop until all in progress runs report back
+	reportCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
+	if pipelineTimeout := r.config.MaxRunDuration(); pipelineTimeout != 0 {
+		ctx, cancel = context.WithTimeout(ctx, pipelineTimeout)
+		defer cancel()
+	}
+
+	for taskRun := range scheduler.taskCh {
+		taskRun := taskRun
+		// execute
+		go recovery.WrapRecoverHandle(l, func() {
+			result := r.executeTaskRun(ctx, run.PipelineSpec, run.fixtures, taskRun, l)
+
+			logTaskRunToPrometheus(result, run.PipelineSpec)
+
+			scheduler.report(reportCtx, result)
+		}, func(err interface{}) {
+			t := time.Now()
+			scheduler.report(reportCtx, TaskRunResult{
+				ID:         uuid.New(),
+				Task:       taskRun.task,
+				Result:     Result{Error: ErrRunPanicked{err}},
+				FinishedAt: null.TimeFrom(t),
+				CreatedAt:  t, // TODO: more accurate start time
+			})
+		})
+	}
+
+	return scheduler
+}
+
+// runSubpipeline runs a sub-pipeline in memory, e.g. for one element of a
+// foreach task. Its task runs are not persisted.
+func (r *runner) runSubpipeline(ctx context.Context, spec Spec, fixtures *DryRunFixtures, p *Pipeline, vars Vars) TaskRunResults {
+	l := r.lggr.With("executionID", uuid.New(), "specID", spec.ID, "jobID", spec.JobID, "jobName", spec.JobName)
+	run := &Run{PipelineSpec: spec, PipelineSpecID: spec.ID, fixtures: fixtures}
+
+	scheduler := r.execute(ctx, p, run, vars, l)
+	trrs := make(TaskRunResults, 0, len(scheduler.results))
+	for _, result := range scheduler.results {
+		trrs = append(trrs, result)
+	}
+	return trrs
+}
+
+func (r *runner) executeTaskRun(ctx context.Context, spec Spec, fixtures *DryRunFixtures, taskRun *memoryTaskRun, l logger.Logger) TaskRunResult {
 	start := time.Now()
 	l = l.With("taskName", taskRun.task.DotID(),
 		"taskType", taskRun.task.Type(),
@@ -564,7 +622,18 @@ func (r *runner) executeTaskRun(ctx context.Context, spec Spec, taskRun *memoryT
 		defer cancel()
 	}
 
-	result, runInfo := taskRun.task.Run(ctx, l, taskRun.vars, taskRun.inputs)
+	var (
+		result  Result
+		runInfo RunInfo
+	)
+	if fixtures != nil && isDryRunTask(taskRun.task.Type()) {
+		result = fixtures.result(taskRun.task)
+	} else {
+		result, runInfo = taskRun.task.Run(ctx, l, taskRun.vars, taskRun.inputs)
+	}
+	if result.Error != nil && pkgerrors.Is(ctx.Err(), context.DeadlineExceeded) {
+		runInfo.TimedOut = true
+	}
 	loggerFields := []interface{}{"runInfo", runInfo,
 		"resultValue", result.Value,
 		"resultError", result.Error,
@@ -660,6 +729,10 @@ func (r *runner) Run(ctx context.Context, run *Run, saveSuccessfulTaskRuns bool,
 			now := time.Now()
 			// initialize certain task params
 			for _, task := range pipeline.Tasks {
+				// replayed runs come with the task runs they reuse
+				if run.ByDotID(task.DotID()) != nil {
+					continue
+				}
 				switch task.Type() {
 				case TaskTypeETHTx:
 					run.PipelineTaskRuns = append(run.PipelineTaskRuns, TaskRun{
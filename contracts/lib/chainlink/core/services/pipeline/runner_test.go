@@ -1077,3 +1077,148 @@ succeed;
 		assert.Equal(t, "1", trrs[0].Result.Value.(pipeline.ObjectParam).DecimalValue.Decimal().String())
 	})
 }
+
+func Test_PipelineRunner_ExecuteDryRun(t *testing.T) {
+	t.Parallel()
+
+	cfg := configtest.NewTestGeneralConfig(t)
+	r := pipeline.NewRunner(nil, nil, cfg.JobPipeline(), cfg.WebServer(), nil, nil, nil, logger.TestLogger(t), nil, nil)
+
+	spec := pipeline.Spec{DotDagSource: `
+ds          [type=http method=GET url="https://example.com/price"]
+ds_parse    [type=jsonparse path="data,price"]
+ds_multiply [type=multiply times="$(jobRun.meta.multiplier)" index=0]
+call        [type=ethcall contract="0x0000000000000000000000000000000000000001" data="0x01" evmChainID=0]
+decode      [type=ethabidecode abi="uint256 value" index=1]
+
+ds -> ds_parse -> ds_multiply
+call -> decode
+`}
+
+	t.Run("serves I/O tasks from fixtures", func(t *testing.T) {
+		run, trrs, err := r.ExecuteDryRun(testutils.Context(t), spec, pipeline.DryRunFixtures{
+			Vars: map[string]interface{}{
+				"jobRun": map[string]interface{}{"meta": map[string]interface{}{"multiplier": 100}},
+			},
+			Tasks: map[string]pipeline.DryRunFixture{
+				"ds":   {Value: map[string]interface{}{"data": map[string]interface{}{"price": 12.5}}},
+				"call": {Value: hexutil.Encode(common.LeftPadBytes([]byte{42}, 32))},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, pipeline.RunStatusCompleted, run.State)
+
+		results := make(map[string]pipeline.DryRunTaskResult)
+		for _, result := range pipeline.NewDryRunTaskResults(trrs) {
+			results[result.DotID] = result
+		}
+		require.Len(t, results, 5)
+
+		assert.True(t, results["ds"].Fixture)
+		assert.Equal(t, `{"data":{"price":12.5}}`, results["ds"].Output.Val)
+		assert.False(t, results["ds_parse"].Fixture)
+		require.Len(t, results["ds_parse"].Inputs, 1)
+		assert.Equal(t, `{"data":{"price":12.5}}`, results["ds_parse"].Inputs[0].Val)
+		assert.Equal(t, "1250", results["ds_multiply"].Output.Val.(decimal.Decimal).String())
+		assert.Equal(t, "42", results["decode"].Output.Val.(map[string]interface{})["value"].(fmt.Stringer).String())
+	})
+
+	t.Run("fails tasks without fixtures", func(t *testing.T) {
+		run, trrs, err := r.ExecuteDryRun(testutils.Context(t), spec, pipeline.DryRunFixtures{
+			Tasks: map[string]pipeline.DryRunFixture{
+				"ds": {Error: "connection refused"},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, pipeline.RunStatusErrored, run.State)
+
+		results := make(map[string]pipeline.DryRunTaskResult)
+		for _, result := range pipeline.NewDryRunTaskResults(trrs) {
+			results[result.DotID] = result
+		}
+		assert.Equal(t, "connection refused", results["ds"].Error.ValueOrZero())
+		assert.Contains(t, results["call"].Error.ValueOrZero(), "dry run: no fixture for ethcall task call")
+	})
+}
+
+func Test_PipelineRunner_ForEach(t *testing.T) {
+	t.Parallel()
+
+	cfg := configtest.NewTestGeneralConfig(t)
+	r := pipeline.NewRunner(nil, nil, cfg.JobPipeline(), cfg.WebServer(), nil, nil, nil, logger.TestLogger(t), nil, nil)
+	vars := map[string]interface{}{
+		"jobRun": map[string]interface{}{"winners": []interface{}{1, 5, 2}},
+	}
+	source := func(failOn string) string {
+		return fmt.Sprintf(`
+fanout [type=foreach input="$(jobRun.winners)" pipeline=belowThree parallelism=2 failOn=%q]
+
+subgraph belowThree {
+	lt   [type=lessthan left="$(foreach.element)" right=3]
+	cond [type=conditional data="$(lt)"]
+	mul  [type=multiply input="$(foreach.element)" times="$(foreach.index)"]
+	lt -> cond -> mul
+}
+`, failOn)
+	}
+
+	t.Run("fails when any element fails", func(t *testing.T) {
+		run, trrs, err := r.ExecuteRun(testutils.Context(t), pipeline.Spec{DotDagSource: source("any")}, pipeline.NewVarsFrom(vars))
+		require.NoError(t, err)
+		assert.Equal(t, pipeline.RunStatusErrored, run.State)
+		require.Len(t, trrs, 1)
+		require.Error(t, trrs[0].Result.Error)
+		assert.Contains(t, trrs[0].Result.Error.Error(), "sub-pipeline belowThree failed for 1 of 3 elements")
+		assert.Contains(t, trrs[0].Result.Error.Error(), "element 1")
+	})
+
+	t.Run("collects the results of the elements which succeeded", func(t *testing.T) {
+		run, trrs, err := r.ExecuteRun(testutils.Context(t), pipeline.Spec{DotDagSource: source("all")}, pipeline.NewVarsFrom(vars))
+		require.NoError(t, err)
+		assert.Equal(t, pipeline.RunStatusCompleted, run.State)
+		require.Len(t, trrs, 1)
+		values := trrs[0].Result.Value.([]interface{})
+		require.Len(t, values, 3)
+		assert.Equal(t, "0", values[0].(decimal.Decimal).String())
+		assert.Nil(t, values[1])
+		assert.Equal(t, "4", values[2].(decimal.Decimal).String())
+	})
+
+	t.Run("dry runs serve sub-pipelines from fixtures", func(t *testing.T) {
+		spec := pipeline.Spec{DotDagSource: `
+fanout [type=foreach input="[\"a\", \"b\"]" pipeline=fetch]
+
+subgraph fetch {
+	ds    [type=http method=GET url="https://example.com/$(foreach.element)"]
+	parse [type=jsonparse path="price"]
+	ds -> parse
+}
+`}
+		run, trrs, err := r.ExecuteDryRun(testutils.Context(t), spec, pipeline.DryRunFixtures{
+			Tasks: map[string]pipeline.DryRunFixture{
+				"ds": {Value: map[string]interface{}{"price": 7.5}},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, pipeline.RunStatusCompleted, run.State)
+		require.Len(t, trrs, 1)
+		assert.Equal(t, []interface{}{7.5, 7.5}, trrs[0].Result.Value)
+	})
+
+	t.Run("rejects unknown subgraphs", func(t *testing.T) {
+		_, err := pipeline.Parse(`fanout [type=foreach input="$(jobRun.winners)" pipeline=missing]`)
+		require.ErrorContains(t, err, `foreach task fanout: no subgraph named "missing"`)
+	})
+
+	t.Run("rejects subgraphs with several final tasks", func(t *testing.T) {
+		_, err := pipeline.Parse(`
+fanout [type=foreach input="$(jobRun.winners)" pipeline=split]
+
+subgraph split {
+	a [type=multiply input="$(foreach.element)" times=2]
+	b [type=multiply input="$(foreach.element)" times=3]
+}
+`)
+		require.ErrorContains(t, err, "subgraph split must have exactly one final task, got 2")
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/guregu/null.v4"
 
 	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/store/models"
 )
 
 func (s *scheduler) newMemoryTaskRun(task Task, vars Vars) *memoryTaskRun {
@@ -59,6 +60,8 @@ type scheduler struct {
 
 	pending bool
 	exiting bool
+	// retries counts the retries scheduled so far, see Pipeline.RetryBudget
+	retries uint32
 
 	taskCh   chan *memoryTaskRun
 	resultCh chan TaskRunResult
@@ -135,10 +138,12 @@ func (s *scheduler) reconstructResults() {
 		}
 
 		s.results[task.ID()] = TaskRunResult{
+			ID:         r.ID,
 			Task:       task,
 			Result:     result,
 			CreatedAt:  r.CreatedAt,
 			FinishedAt: r.FinishedAt,
+			history:    r.Attempts,
 		}
 
 		// store the result in vars
@@ -174,12 +179,21 @@ func (s *scheduler) Run() {
 
 		s.waiting--
 
-		// retrieve previous attempt count
-		result.Attempts = s.results[result.Task.ID()].Attempts
+		// retrieve previous attempt count and history
+		previous := s.results[result.Task.ID()]
+		result.Attempts = previous.Attempts
+		result.history = previous.history
 
 		// only count as an attempt if the job actually ran. If we're exiting then it got cancelled
 		if !s.exiting {
 			result.Attempts++
+			if !result.runInfo.IsPending {
+				result.history = append(result.history, TaskRunAttempt{
+					Error:      result.Result.ErrorDB(),
+					CreatedAt:  result.CreatedAt,
+					FinishedAt: result.FinishedAt,
+				})
+			}
 		}
 
 		// store task run
@@ -220,16 +234,20 @@ func (s *scheduler) Run() {
 		}
 
 		// if task hasn't reached it's max retry count yet, we schedule it again
-		if result.Attempts < uint(result.Task.TaskRetries()) && result.Result.Error != nil {
+		if s.shouldRetry(result) {
 			// we immediately increase the in-flight counter so the pipeline doesn't terminate
 			// while we wait for the next retry
 			s.waiting++
+			s.retries++
 
 			backoff := backoff.Backoff{
 				Factor: 2,
 				Min:    result.Task.TaskMinBackoff(),
 				Max:    result.Task.TaskMaxBackoff(),
 			}
+			delay := result.Task.TaskRetryJitter().apply(backoff.ForAttempt(float64(result.Attempts - 1))) // we subtract 1 because backoff 0-indexes
+			result.history[len(result.history)-1].Backoff = models.Interval(delay)
+			s.results[result.Task.ID()] = result
 
 			go func(vars Vars) {
 				select {
@@ -242,7 +260,7 @@ func (s *scheduler) Run() {
 						CreatedAt:  now, // TODO: more accurate start time
 						FinishedAt: null.TimeFrom(now),
 					})
-				case <-time.After(backoff.ForAttempt(float64(result.Attempts - 1))): // we subtract 1 because backoff 0-indexes
+				case <-time.After(delay):
 					// schedule a new attempt
 					run := s.newMemoryTaskRun(result.Task, vars)
 					run.attempts = result.Attempts
@@ -274,6 +292,20 @@ func (s *scheduler) Run() {
 	close(s.taskCh)
 }
 
+// shouldRetry returns true if the failed result should be attempted again:
+// the task has retries left, the error matches its retry conditions, and the
+// run has not spent its retry budget.
+func (s *scheduler) shouldRetry(result TaskRunResult) bool {
+	if result.Attempts >= uint(result.Task.TaskRetries()) || !matchesRetryPolicy(result.Task, result.Result, result.runInfo) {
+		return false
+	}
+	if budget := s.pipeline.RetryBudget; budget.Valid && s.retries >= budget.Uint32 {
+		s.logger.Debugw("not retrying task: run retry budget exhausted", "dot_id", result.Task.DotID(), "attempts", result.Attempts, "retryBudget", budget.Uint32)
+		return false
+	}
+	return true
+}
+
 func (s *scheduler) markRemaining(err error) {
 	now := time.Now()
 	for _, task := range s.pipeline.Tasks {
@@ -15,6 +15,7 @@ import (
 type event struct {
 	expected string
 	result   Result
+	runInfo  RunInfo
 }
 
 func TestScheduler(t *testing.T) {
@@ -128,6 +129,102 @@ func TestScheduler(t *testing.T) {
 				require.Equal(t, ErrCancelled, result.Result.Error)
 			},
 		},
+		{
+			name: "retry on: do not retry errors the policy does not match",
+			spec: `
+			a [type=median retries=3 retryOn="http5xx,timeout" minBackoff="1us" maxBackoff="1us"]
+			b [type=median index=0]
+			a -> b`,
+			events: []event{
+				{
+					expected: "a",
+					result:   Result{Error: ErrTaskRunFailed},
+					runInfo:  RunInfo{StatusCode: 404},
+				},
+				{
+					expected: "b",
+					result:   Result{Value: 1},
+				},
+			},
+			assertion: func(t *testing.T, p Pipeline, results map[int]TaskRunResult) {
+				result := results[p.ByDotID("a").ID()]
+				require.Equal(t, uint(1), result.Attempts)
+				require.Equal(t, ErrTaskRunFailed, result.Result.Error)
+				require.Len(t, result.history, 1)
+			},
+		},
+		{
+			name: "retry on: retry errors the policy matches",
+			spec: `
+			a [type=median retries=3 retryOn="http5xx,timeout" retryJitter=full minBackoff="1us" maxBackoff="1us"]
+			b [type=median index=0]
+			a -> b`,
+			events: []event{
+				{
+					expected: "a",
+					result:   Result{Error: ErrTaskRunFailed},
+					runInfo:  RunInfo{StatusCode: 503},
+				},
+				{
+					expected: "a",
+					result:   Result{Error: ErrTimeout},
+					runInfo:  RunInfo{TimedOut: true},
+				},
+				{
+					expected: "a",
+					result:   Result{Value: 1},
+				},
+				{
+					expected: "b",
+					result:   Result{Value: 1},
+				},
+			},
+			assertion: func(t *testing.T, p Pipeline, results map[int]TaskRunResult) {
+				result := results[p.ByDotID("a").ID()]
+				require.NoError(t, result.Result.Error)
+				require.Equal(t, uint(3), result.Attempts)
+				require.Len(t, result.history, 3)
+				require.Equal(t, ErrTaskRunFailed.Error(), result.history[0].Error.String)
+				require.Equal(t, ErrTimeout.Error(), result.history[1].Error.String)
+				require.False(t, result.history[2].Error.Valid)
+				require.Zero(t, result.history[2].Backoff)
+			},
+		},
+		{
+			name: "retry budget: stop retrying once the run has spent it",
+			spec: `
+			graph [retryBudget=2]
+			a [type=median retries=3 minBackoff="1us" maxBackoff="1us" index=0]
+			b [type=median retries=3 minBackoff="1us" maxBackoff="1us" index=1]
+			a -> b`,
+			events: []event{
+				{
+					expected: "a",
+					result:   Result{Error: ErrTaskRunFailed},
+				},
+				{
+					expected: "a",
+					result:   Result{Value: 1},
+				},
+				{
+					expected: "b",
+					result:   Result{Error: ErrTaskRunFailed},
+				},
+				{
+					expected: "b",
+					result:   Result{Error: ErrTaskRunFailed},
+				},
+				// the budget is spent, so b is not retried a third time
+			},
+			assertion: func(t *testing.T, p Pipeline, results map[int]TaskRunResult) {
+				require.Equal(t, uint(2), results[p.ByDotID("a").ID()].Attempts)
+				result := results[p.ByDotID("b").ID()]
+				require.Equal(t, uint(2), result.Attempts)
+				require.Equal(t, ErrTaskRunFailed, result.Result.Error)
+				require.Len(t, result.history, 2)
+				require.Equal(t, time.Microsecond, result.history[0].Backoff.Duration())
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -150,6 +247,7 @@ func TestScheduler(t *testing.T) {
 					Result:     event.result,
 					FinishedAt: null.TimeFrom(now),
 					CreatedAt:  now,
+					runInfo:    event.runInfo,
 				})
 			case <-time.After(time.Second):
 				t.Fatal("timed out waiting for task run")
@@ -22,11 +22,19 @@ type BaseTask struct {
 	MinBackoff time.Duration `mapstructure:"minBackoff"`
 	MaxBackoff time.Duration `mapstructure:"maxBackoff"`
 
+	// RetryOn is a comma separated list of RetryConditions; if set, failed
+	// attempts are only retried on matching errors.
+	RetryOn     string `mapstructure:"retryOn" json:"-"`
+	RetryJitter string `mapstructure:"retryJitter" json:"-"`
+
 	Tags string `mapstructure:"tags" json:"-"`
 
 	StreamID null.Uint32 `mapstructure:"streamID"`
 
 	uuid uuid.UUID
+
+	retryOn     []RetryCondition
+	retryJitter RetryJitter
 }
 
 func NewBaseTask(id int, dotID string, inputs []TaskDependency, outputs []Task, index int32) BaseTask {
@@ -82,6 +90,14 @@ func (t BaseTask) TaskMaxBackoff() time.Duration {
 	return time.Minute
 }
 
+func (t BaseTask) TaskRetryOn() []RetryCondition {
+	return t.retryOn
+}
+
+func (t BaseTask) TaskRetryJitter() RetryJitter {
+	return t.retryJitter
+}
+
 func (t BaseTask) TaskTags() string {
 	return t.Tags
 }
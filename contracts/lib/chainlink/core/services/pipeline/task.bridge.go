@@ -227,7 +227,7 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 				"status_code", statusCode,
 				"error", err,
 			)
-			return Result{Error: err}, RunInfo{IsRetryable: isRetryableHTTPError(statusCode, err)}
+			return Result{Error: err}, RunInfo{IsRetryable: isRetryableHTTPError(statusCode, err), StatusCode: statusCode}
 		}
 
 		var cacheErr error
@@ -240,7 +240,7 @@ func (t *BridgeTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inp
 					"url", url.String(),
 				)
 			}
-			return Result{Error: err}, RunInfo{IsRetryable: isRetryableHTTPError(statusCode, err)}
+			return Result{Error: err}, RunInfo{IsRetryable: isRetryableHTTPError(statusCode, err), StatusCode: statusCode}
 		}
 		promBridgeCacheHits.WithLabelValues(t.Name).Inc()
 		lggr.Debugw("Bridge task: request failed, falling back to cache",
@@ -0,0 +1,163 @@
+package pipeline
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+)
+
+// ForEachVarsKey is the variable holding the element a sub-pipeline of a
+// foreach task is run for, as $(foreach.element), and its position in the
+// array, as $(foreach.index).
+const ForEachVarsKey = "foreach"
+
+const defaultForEachParallelism = 10
+
+const (
+	// ForEachFailOnAny fails the task if the sub-pipeline fails for any element.
+	ForEachFailOnAny = "any"
+	// ForEachFailOnAll fails the task only if the sub-pipeline fails for all
+	// elements; the results of the failed elements are nil.
+	ForEachFailOnAll = "all"
+)
+
+// ForEachTask runs a sub-pipeline, declared in the same spec as a named
+// subgraph, once per element of an array and collects the final result of
+// each run, in the order of the elements:
+//
+//	decode  [type=ethabidecode abi="address[] winners" data="$(jobRun.data)"]
+//	fanout  [type=foreach input="$(decode.winners)" pipeline=perWinner parallelism=5]
+//	decode -> fanout
+//
+//	subgraph perWinner {
+//		fetch [type=http method=GET url="https://example.com/$(foreach.element)"]
+//		parse [type=jsonparse path="score"]
+//		fetch -> parse
+//	}
+//
+// Return types:
+//
+//	[]interface{}
+type ForEachTask struct {
+	BaseTask    `mapstructure:",squash"`
+	Input       string `json:"input"`
+	Pipeline    string `json:"pipeline"`
+	Parallelism string `json:"parallelism"`
+	FailOn      string `json:"failOn"`
+
+	subpipeline    *Pipeline
+	runSubpipeline func(ctx context.Context, vars Vars) TaskRunResults
+}
+
+var _ Task = (*ForEachTask)(nil)
+
+func (t *ForEachTask) Type() TaskType {
+	return TaskTypeForEach
+}
+
+// resolveSubpipeline links the task to the sub-pipeline it runs.
+func (t *ForEachTask) resolveSubpipeline(subpipelines map[string]*Pipeline) error {
+	switch t.FailOn {
+	case "", ForEachFailOnAny, ForEachFailOnAll:
+	default:
+		return errors.Errorf("foreach task %s: failOn must be %q or %q, got %q", t.DotID(), ForEachFailOnAny, ForEachFailOnAll, t.FailOn)
+	}
+
+	sub, ok := subpipelines[t.Pipeline]
+	if !ok {
+		return errors.Errorf("foreach task %s: no subgraph named %q", t.DotID(), t.Pipeline)
+	}
+	var final int
+	for _, task := range sub.Tasks {
+		if task.Type() == TaskTypeETHTx {
+			return errors.Errorf("foreach task %s: subgraph %s cannot contain ethtx tasks", t.DotID(), t.Pipeline)
+		}
+		if len(task.Outputs()) == 0 {
+			final++
+		}
+	}
+	if final != 1 {
+		return errors.Errorf("foreach task %s: subgraph %s must have exactly one final task, got %d", t.DotID(), t.Pipeline, final)
+	}
+	t.subpipeline = sub
+	return nil
+}
+
+func (t *ForEachTask) Run(ctx context.Context, lggr logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, 0, 1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+
+	var (
+		elements    SliceParam
+		parallelism MaybeUint64Param
+	)
+	err = stderrors.Join(
+		errors.Wrap(ResolveParam(&elements, From(VarExpr(t.Input, vars), JSONWithVarExprs(t.Input, vars, false), Input(inputs, 0))), "input"),
+		errors.Wrap(ResolveParam(&parallelism, From(t.Parallelism)), "parallelism"),
+	)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	if t.runSubpipeline == nil {
+		return Result{Error: errors.Errorf("foreach task %s was not initialized", t.DotID())}, runInfo
+	}
+
+	limit := defaultForEachParallelism
+	if p, isSet := parallelism.Uint64(); isSet {
+		if p == 0 {
+			return Result{Error: errors.Wrap(ErrBadInput, "parallelism must be greater than 0")}, runInfo
+		}
+		limit = int(p)
+	}
+
+	values := make([]interface{}, len(elements))
+	errs := make([]error, len(elements))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, element := range elements {
+		elementVars := vars.Copy()
+		if err = elementVars.Set(ForEachVarsKey, map[string]interface{}{"element": element, "index": i}); err != nil {
+			return Result{Error: err}, runInfo
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			final, err := t.runSubpipeline(ctx, elementVars).FinalResult().SingularResult()
+			if err == nil {
+				err = final.Error
+			}
+			if err != nil {
+				errs[i] = errors.Wrapf(err, "element %d", i)
+				return
+			}
+			values[i] = final.Value
+		}()
+	}
+	wg.Wait()
+
+	var failed []error
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err)
+		}
+	}
+	switch {
+	case len(failed) == 0:
+	case t.FailOn == ForEachFailOnAll && len(failed) < len(elements):
+		lggr.Debugw("foreach task: sub-pipeline failed for some elements", "failed", len(failed), "elements", len(elements), "err", stderrors.Join(failed...))
+	default:
+		return Result{Error: errors.Wrapf(stderrors.Join(failed...), "sub-pipeline %s failed for %d of %d elements", t.Pipeline, len(failed), len(elements))}, runInfo
+	}
+
+	return Result{Value: values}, runInfo
+}
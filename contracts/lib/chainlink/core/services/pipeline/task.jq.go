@@ -0,0 +1,264 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"math/big"
+	"regexp"
+
+	"github.com/itchyny/gojq"
+	"github.com/pkg/errors"
+	"github.com/shopspring/decimal"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/jsonserializable"
+)
+
+// JQTask evaluates a jq query over JSON data, e.g. to extract, filter and reshape an API response
+// in one task instead of a chain of jsonparse, lookup and merge tasks:
+//
+//	prices [type=jq query=<[.data.prices[] | select(.volume > $threshold) | .price] | max>]
+//
+// Queries are evaluated by gojq (https://github.com/itchyny/gojq), see the jq manual for the
+// language. data is the input of the task by default. It can be a JSON string or byte array, or
+// a value such as the result of another task. The variables of the pipeline are available to the
+// query as jq variables, e.g. $jobRun.meta. The environment of the node is not.
+//
+// The query must produce exactly one value; wrap it in [...] to collect several values in an
+// array. Lax when enabled returns nil instead of an error if the query produces no value.
+//
+// Return types:
+//
+//	int64, uint64 or *big.Int for integers, float64 otherwise
+//	string
+//	bool
+//	map[string]interface{}
+//	[]interface{}
+//	nil
+type JQTask struct {
+	BaseTask `mapstructure:",squash"`
+	Query    string `json:"query"`
+	Data     string `json:"data"`
+	Lax      string `json:"lax"`
+}
+
+// ErrJQ is returned when a jq query cannot be parsed or evaluated.
+var ErrJQ = errors.New("jq")
+
+var jqVariableRegexp = regexp.MustCompile(`\$([a-zA-Z_][a-zA-Z0-9_]*)`)
+
+var _ Task = (*JQTask)(nil)
+
+func (t *JQTask) Type() TaskType {
+	return TaskTypeJQ
+}
+
+func (t *JQTask) Run(ctx context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+	_, err := CheckInputs(inputs, 0, 1, 0)
+	if err != nil {
+		return Result{Error: errors.Wrap(err, "task inputs")}, runInfo
+	}
+
+	var (
+		query StringParam
+		data  jqDataParam
+		lax   BoolParam
+	)
+	err = stderrors.Join(
+		errors.Wrap(ResolveParam(&query, From(VarExpr(t.Query, vars), NonemptyString(t.Query))), "query"),
+		errors.Wrap(ResolveParam(&data, From(VarExpr(t.Data, vars), Input(inputs, 0))), "data"),
+		errors.Wrap(ResolveParam(&lax, From(NonemptyString(t.Lax), false)), "lax"),
+	)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+
+	parsed, err := gojq.Parse(string(query))
+	if err != nil {
+		return Result{Error: errors.Wrap(jqError(err), "query")}, runInfo
+	}
+	names, values, err := jqVariables(string(query), vars)
+	if err != nil {
+		return Result{Error: err}, runInfo
+	}
+	code, err := gojq.Compile(parsed, gojq.WithVariables(names))
+	if err != nil {
+		return Result{Error: errors.Wrap(jqError(err), "query")}, runInfo
+	}
+
+	// Only the first two values are needed to tell whether the query produced exactly one.
+	var outputs []interface{}
+	iter := code.RunWithContext(ctx, data.value, values...)
+	for len(outputs) < 2 {
+		output, ok := iter.Next()
+		if !ok {
+			break
+		}
+		if outputErr, ok := output.(error); ok {
+			return Result{Error: jqError(outputErr)}, runInfo
+		}
+		outputs = append(outputs, output)
+	}
+
+	switch {
+	case len(outputs) == 0 && bool(lax):
+		return Result{Value: nil}, runInfo
+	case len(outputs) == 0:
+		return Result{Error: fmt.Errorf("%w: query %q produced no value", ErrJQ, string(query))}, runInfo
+	case len(outputs) > 1:
+		return Result{Error: fmt.Errorf("%w: query %q produced more than one value, wrap it in [...] to return them as an array", ErrJQ, string(query))}, runInfo
+	}
+
+	// The values are converted through JSON, for numbers to get the types of the jsonparse task.
+	b, err := json.Marshal(outputs[0])
+	if err != nil {
+		return Result{Error: jqError(err)}, runInfo
+	}
+	value, err := jqParseJSON(b)
+	if err == nil {
+		value, err = jsonserializable.ReinterpretJSONNumbers(value)
+	}
+	if err != nil {
+		return Result{Error: stderrors.Join(ErrBadInput, err)}, runInfo
+	}
+	return Result{Value: value}, runInfo
+}
+
+func jqError(err error) error {
+	return fmt.Errorf("%w: %w", ErrJQ, err)
+}
+
+// jqVariables returns the names and values of the pipeline variables the query refers to. The
+// other references are left undefined, for the query to fail to compile.
+func jqVariables(query string, vars Vars) (names []string, values []interface{}, err error) {
+	seen := make(map[string]bool)
+	for _, match := range jqVariableRegexp.FindAllStringSubmatch(query, -1) {
+		name := match[1]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		val, ok := vars.vars[name]
+		if !ok {
+			continue
+		}
+		if valErr, ok := val.(error); ok {
+			return nil, nil, errors.Wrapf(valErr, "$%s", name)
+		}
+		var value interface{}
+		if value, err = jqValue(val); err != nil {
+			return nil, nil, errors.Wrapf(err, "$%s", name)
+		}
+		names = append(names, "$"+name)
+		values = append(values, value)
+	}
+	return names, values, nil
+}
+
+// jqDataParam is the data a jq query is evaluated over. Strings and byte arrays are parsed as
+// JSON, other values are converted to JSON values.
+type jqDataParam struct {
+	value interface{}
+}
+
+func (p *jqDataParam) UnmarshalPipelineParam(val interface{}) error {
+	var err error
+	switch v := val.(type) {
+	case string:
+		p.value, err = jqParseJSON([]byte(v))
+	case []byte:
+		p.value, err = jqParseJSON(v)
+	default:
+		p.value, err = jqValue(v)
+	}
+	if err != nil {
+		return stderrors.Join(ErrBadInput, err)
+	}
+	return nil
+}
+
+func jqParseJSON(data []byte) (interface{}, error) {
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if d.More() {
+		return nil, errors.New("invalid JSON: unexpected data after the top-level value")
+	}
+	return v, nil
+}
+
+// jqValue converts v to a value gojq works with. Maps and slices are copied, as gojq normalizes
+// the numbers of its inputs in place.
+func jqValue(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case nil, bool, string, json.Number, float64, float32,
+		int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return v, nil
+	case *big.Int:
+		if v == nil {
+			return nil, nil
+		}
+		return new(big.Int).Set(v), nil
+	case decimal.Decimal:
+		return json.Number(v.String()), nil
+	case *decimal.Decimal:
+		if v == nil {
+			return nil, nil
+		}
+		return json.Number(v.String()), nil
+	case ObjectParam:
+		return jqObjectParamValue(v)
+	case *ObjectParam:
+		if v == nil {
+			return nil, nil
+		}
+		return jqObjectParamValue(*v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			var err error
+			if out[i], err = jqValue(e); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			var err error
+			if out[k], err = jqValue(e); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert %T to JSON: %w", v, err)
+	}
+	return jqParseJSON(b)
+}
+
+func jqObjectParamValue(o ObjectParam) (interface{}, error) {
+	switch o.Type {
+	case NilType:
+		return nil, nil
+	case BoolType:
+		return bool(o.BoolValue), nil
+	case DecimalType:
+		return json.Number(o.DecimalValue.Decimal().String()), nil
+	case StringType:
+		return string(o.StringValue), nil
+	case SliceType:
+		return jqValue([]interface{}(o.SliceValue))
+	case MapType:
+		return jqValue(map[string]interface{}(o.MapValue))
+	}
+	return nil, fmt.Errorf("invalid object type %v", o.Type)
+}
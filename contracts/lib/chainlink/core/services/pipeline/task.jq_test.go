@@ -0,0 +1,291 @@
+package pipeline_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+)
+
+func TestJQTask(t *testing.T) {
+	t.Parallel()
+
+	response := `{"data": {"prices": [
+		{"symbol": "ETH", "price": 3021.55, "volume": 10},
+		{"symbol": "BTC", "price": 61000.1, "volume": 2},
+		{"symbol": "LINK", "price": 14.2, "volume": 120, "supply": 1000000000000000000000000000}
+	]}}`
+
+	tests := []struct {
+		name              string
+		query             string
+		data              string
+		lax               string
+		vars              pipeline.Vars
+		inputs            []pipeline.Result
+		wantData          interface{}
+		wantErrorCause    error
+		wantErrorContains string
+	}{
+		{
+			"path",
+			".data.prices[0].symbol",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: response}},
+			"ETH",
+			nil,
+			"",
+		},
+		{
+			"filter and reshape",
+			`[.data.prices[] | select(.volume > 5) | {(.symbol): .price}] | add`,
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: []byte(response)}},
+			map[string]interface{}{"ETH": 3021.55, "LINK": 14.2},
+			nil,
+			"",
+		},
+		{
+			"integer result",
+			".data.prices | map(.volume) | add",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: response}},
+			int64(132),
+			nil,
+			"",
+		},
+		{
+			"large integer result",
+			`.data.prices[] | select(.symbol == "LINK") | .supply`,
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: response}},
+			new(big.Int).Exp(big.NewInt(10), big.NewInt(27), nil),
+			nil,
+			"",
+		},
+		{
+			"pipeline variables",
+			`.data.prices[] | select(.symbol == $jobRun.meta.symbol) | .price * $scale`,
+			"",
+			"",
+			pipeline.NewVarsFrom(map[string]interface{}{
+				"jobRun": map[string]interface{}{"meta": map[string]interface{}{"symbol": "BTC"}},
+				"scale":  100,
+			}),
+			[]pipeline.Result{{Value: response}},
+			int64(6100010),
+			nil,
+			"",
+		},
+		{
+			"data from a variable",
+			"map(. * 2)",
+			"$(foo.values)",
+			"",
+			pipeline.NewVarsFrom(map[string]interface{}{"foo": map[string]interface{}{"values": []interface{}{1, 2.5, 3}}}),
+			nil,
+			[]interface{}{int64(2), int64(5), int64(6)},
+			nil,
+			"",
+		},
+		{
+			"query from a variable",
+			"$(query)",
+			"$(foo)",
+			"",
+			pipeline.NewVarsFrom(map[string]interface{}{"query": ".a", "foo": map[string]interface{}{"a": []interface{}{true, nil}}}),
+			nil,
+			[]interface{}{true, nil},
+			nil,
+			"",
+		},
+		{
+			"no value",
+			".data.prices[] | select(.volume > 1000)",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: response}},
+			nil,
+			pipeline.ErrJQ,
+			"produced no value",
+		},
+		{
+			"no value lax",
+			".data.prices[] | select(.volume > 1000)",
+			"",
+			"true",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: response}},
+			nil,
+			nil,
+			"",
+		},
+		{
+			"several values",
+			".data.prices[].symbol",
+			"",
+			"true",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: response}},
+			nil,
+			pipeline.ErrJQ,
+			"produced more than one value, wrap it in [...]",
+		},
+		{
+			"invalid query",
+			".data.prices[",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: response}},
+			nil,
+			pipeline.ErrJQ,
+			"query: jq: unexpected EOF",
+		},
+		{
+			"undefined variable",
+			".data.prices[] | select(.symbol == $symbol)",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: response}},
+			nil,
+			pipeline.ErrJQ,
+			"query: jq: variable not defined: $symbol",
+		},
+		{
+			"errored variable",
+			".price * $ds1",
+			"",
+			"",
+			pipeline.NewVarsFrom(map[string]interface{}{"ds1": pipeline.ErrTooManyErrors}),
+			[]pipeline.Result{{Value: response}},
+			nil,
+			pipeline.ErrTooManyErrors,
+			"$ds1",
+		},
+		{
+			"evaluation error",
+			".data.prices[0].symbol.name",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: response}},
+			nil,
+			pipeline.ErrJQ,
+			"expected an object",
+		},
+		{
+			"node environment is not available",
+			"$ENV",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: response}},
+			map[string]interface{}{},
+			nil,
+			"",
+		},
+		{
+			"missing query",
+			"",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: response}},
+			nil,
+			pipeline.ErrParameterEmpty,
+			"query",
+		},
+		{
+			"invalid JSON",
+			".",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Value: `{"a": `}},
+			nil,
+			pipeline.ErrBadInput,
+			"data",
+		},
+		{
+			"input error",
+			".",
+			"",
+			"",
+			pipeline.NewVarsFrom(nil),
+			[]pipeline.Result{{Error: pipeline.ErrTooManyErrors}},
+			nil,
+			pipeline.ErrTooManyErrors,
+			"task inputs",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			task := pipeline.JQTask{
+				BaseTask: pipeline.NewBaseTask(0, "jq", nil, nil, 0),
+				Query:    test.query,
+				Data:     test.data,
+				Lax:      test.lax,
+			}
+			result, runInfo := task.Run(testutils.Context(t), logger.TestLogger(t), test.vars, test.inputs)
+			assert.False(t, runInfo.IsPending)
+			assert.False(t, runInfo.IsRetryable)
+
+			if test.wantErrorCause != nil {
+				require.ErrorIs(t, result.Error, test.wantErrorCause)
+				require.ErrorContains(t, result.Error, test.wantErrorContains)
+				require.Nil(t, result.Value)
+			} else {
+				require.NoError(t, result.Error)
+				require.Equal(t, test.wantData, result.Value)
+			}
+		})
+	}
+}
+
+func TestJQTask_ETHABIEncode(t *testing.T) {
+	t.Parallel()
+
+	jq := pipeline.JQTask{
+		BaseTask: pipeline.NewBaseTask(0, "jq", nil, nil, 0),
+		Query:    `{symbol: .data.symbol, supply: .data.supply, live: (.data.status == "live")}`,
+	}
+	result, _ := jq.Run(testutils.Context(t), logger.TestLogger(t), pipeline.NewVarsFrom(nil), []pipeline.Result{
+		{Value: `{"data": {"symbol": "LINK", "supply": 1000000000000000000000000000, "status": "live"}}`},
+	})
+	require.NoError(t, result.Error)
+
+	encode := func(vars pipeline.Vars) interface{} {
+		task := pipeline.ETHABIEncodeTask{
+			BaseTask: pipeline.NewBaseTask(1, "encode", nil, nil, 0),
+			ABI:      "report(string symbol, uint256 supply, bool live)",
+			Data:     `{ "symbol": $(report.symbol), "supply": $(report.supply), "live": $(report.live) }`,
+		}
+		encoded, _ := task.Run(testutils.Context(t), logger.TestLogger(t), vars, nil)
+		require.NoError(t, encoded.Error)
+		return encoded.Value
+	}
+
+	expected := encode(pipeline.NewVarsFrom(map[string]interface{}{"report": map[string]interface{}{
+		"symbol": "LINK",
+		"supply": new(big.Int).Exp(big.NewInt(10), big.NewInt(27), nil),
+		"live":   true,
+	}}))
+	assert.Equal(t, expected, encode(pipeline.NewVarsFrom(map[string]interface{}{"report": result.Value})))
+}
@@ -26,11 +26,14 @@ type VRFTask struct {
 	RequestBlockNumber string `json:"requestBlockNumber"`
 	Topics             string `json:"topics"`
 
-	keyStore VRFKeyStore
+	prover VRFProver
 }
 
-type VRFKeyStore interface {
-	GenerateProof(id string, seed *big.Int) (vrfkey.Proof, error)
+// VRFProver generates VRF proofs with the secret key of a VRF public key. The node's
+// keystore is the default implementation, but the secret keys may also be held by a
+// remote prover, see the vrf/prover package.
+type VRFProver interface {
+	GenerateProof(ctx context.Context, pubKey secp256k1.PublicKey, seed *big.Int) (vrfkey.Proof, error)
 }
 
 var _ Task = (*VRFTask)(nil)
@@ -39,7 +42,7 @@ func (t *VRFTask) Type() TaskType {
 	return TaskTypeVRF
 }
 
-func (t *VRFTask) Run(_ context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+func (t *VRFTask) Run(ctx context.Context, _ logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
 	if len(inputs) != 1 {
 		return Result{Error: ErrWrongInputCardinality}, runInfo
 	}
@@ -103,7 +106,7 @@ func (t *VRFTask) Run(_ context.Context, _ logger.Logger, vars Vars, inputs []Re
 		BlockNum:  uint64(requestBlockNumber),
 	}
 	finalSeed := proof.FinalSeed(preSeedData)
-	p, err := t.keyStore.GenerateProof(pk.String(), finalSeed)
+	p, err := t.prover.GenerateProof(ctx, pk, finalSeed)
 	if err != nil {
 		return Result{Error: err}, runInfo
 	}
@@ -31,7 +31,7 @@ type VRFTaskV2 struct {
 	RequestBlockNumber string `json:"requestBlockNumber"`
 	Topics             string `json:"topics"`
 
-	keyStore VRFKeyStore
+	prover VRFProver
 }
 
 var _ Task = (*VRFTaskV2)(nil)
@@ -40,7 +40,7 @@ func (t *VRFTaskV2) Type() TaskType {
 	return TaskTypeVRFV2
 }
 
-func (t *VRFTaskV2) Run(_ context.Context, lggr logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+func (t *VRFTaskV2) Run(ctx context.Context, lggr logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
 	if len(inputs) != 1 {
 		return Result{Error: ErrWrongInputCardinality}, runInfo
 	}
@@ -121,8 +121,7 @@ func (t *VRFTaskV2) Run(_ context.Context, lggr logger.Logger, vars Vars, inputs
 		Sender:           sender,
 	}
 	finalSeed := proof.FinalSeedV2(preSeedData)
-	id := hexutil.Encode(pk[:])
-	p, err := t.keyStore.GenerateProof(id, finalSeed)
+	p, err := t.prover.GenerateProof(ctx, pk, finalSeed)
 	if err != nil {
 		return Result{Error: err}, retryableRunInfo()
 	}
@@ -34,7 +34,7 @@ type VRFTaskV2Plus struct {
 	RequestBlockNumber string `json:"requestBlockNumber"`
 	Topics             string `json:"topics"`
 
-	keyStore VRFKeyStore
+	prover VRFProver
 }
 
 var _ Task = (*VRFTaskV2Plus)(nil)
@@ -43,7 +43,7 @@ func (t *VRFTaskV2Plus) Type() TaskType {
 	return TaskTypeVRFV2Plus
 }
 
-func (t *VRFTaskV2Plus) Run(_ context.Context, lggr logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
+func (t *VRFTaskV2Plus) Run(ctx context.Context, lggr logger.Logger, vars Vars, inputs []Result) (result Result, runInfo RunInfo) {
 	if len(inputs) != 1 {
 		return Result{Error: ErrWrongInputCardinality}, runInfo
 	}
@@ -129,8 +129,7 @@ func (t *VRFTaskV2Plus) Run(_ context.Context, lggr logger.Logger, vars Vars, in
 		ExtraArgs:        extraArgs,
 	}
 	finalSeed := proof.FinalSeedV2Plus(preSeedData)
-	id := hexutil.Encode(pk[:])
-	p, err := t.keyStore.GenerateProof(id, finalSeed)
+	p, err := t.prover.GenerateProof(ctx, pk, finalSeed)
 	if err != nil {
 		return Result{Error: err}, retryableRunInfo()
 	}
@@ -0,0 +1,526 @@
+package pipeline
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	dotparser "gonum.org/v1/gonum/graph/formats/dot"
+	"gonum.org/v1/gonum/graph/formats/dot/ast"
+)
+
+// Template is a named, versioned pipeline fragment stored in the database,
+// which template tasks expand inline when a pipeline is parsed:
+//
+//	price [type=template template=fetchPrice url="https://example.com/eth" path="data,price"]
+//	price -> median
+//
+// where fetchPrice is e.g.:
+//
+//	fetch [type=http method=GET url="{{url}}"]
+//	parse [type=jsonparse path="{{path}}"]
+//	fetch -> parse
+//
+// The attributes of a template task, except for type, template, version and
+// index, are its parameters and replace their {{name}} placeholders. The
+// final task of the template takes the name of the template task, so that
+// other tasks depend on it as on any other task, and the other tasks are
+// prefixed with it, e.g. price_fetch. Edges into the template task lead to
+// the tasks of the template without inputs. Without a version, the latest
+// version of the template is expanded whenever the pipeline is parsed, so a
+// fix to a template applies to every job which uses it.
+type Template struct {
+	ID           int32     `json:"-"`
+	Name         string    `json:"name"`
+	Version      int32     `json:"version"`
+	DotDagSource string    `json:"dotDagSource"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+const (
+	templateNameAttribute    = "template"
+	templateVersionAttribute = "version"
+)
+
+var (
+	templateNameRegexp  = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_\-]*$`)
+	templateParamRegexp = regexp.MustCompile(`\{\{\s*([a-zA-Z0-9_]+)\s*\}\}`)
+	plainDOTIDRegexp    = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+// TemplateSource provides the templates that template tasks expand.
+type TemplateSource interface {
+	// Template returns the given version of the named template, or its
+	// latest version if version is 0.
+	Template(name string, version int32) (Template, error)
+}
+
+// templateSource is the TemplateSource of Parse, see SetTemplateSource.
+var templateSource struct {
+	mu sync.RWMutex
+	ts TemplateSource
+}
+
+// SetTemplateSource sets the TemplateSource that Parse expands template tasks
+// from. The application sets it to its TemplateORM before any job is started,
+// as pipelines are parsed wherever job specs are decoded, e.g. from TOML, and
+// not only by the runner. Jobs which must not change when a template does pin
+// its version, e.g. template=fetchPrice version=2.
+func SetTemplateSource(ts TemplateSource) {
+	templateSource.mu.Lock()
+	defer templateSource.mu.Unlock()
+	templateSource.ts = ts
+}
+
+func getTemplateSource() TemplateSource {
+	templateSource.mu.RLock()
+	defer templateSource.mu.RUnlock()
+	return templateSource.ts
+}
+
+// templateVersions is a TemplateSource holding the versions of each template
+// in memory.
+type templateVersions struct {
+	mu sync.RWMutex
+	// versions holds the versions of each template, in ascending order
+	versions map[string][]Template
+}
+
+func newTemplateVersions() *templateVersions {
+	return &templateVersions{versions: make(map[string][]Template)}
+}
+
+func (r *templateVersions) Template(name string, version int32) (Template, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	versions := r.versions[name]
+	if len(versions) == 0 {
+		return Template{}, errors.Errorf("no pipeline template named %q", name)
+	}
+	if version == 0 {
+		return versions[len(versions)-1], nil
+	}
+	for _, t := range versions {
+		if t.Version == version {
+			return t, nil
+		}
+	}
+	return Template{}, errors.Errorf("pipeline template %q has no version %d", name, version)
+}
+
+// put adds a version of a template, replacing it if it is already known.
+func (r *templateVersions) put(t Template) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	vs := r.versions[t.Name]
+	i := sort.Search(len(vs), func(i int) bool { return vs[i].Version >= t.Version })
+	if i < len(vs) && vs[i].Version == t.Version {
+		vs[i] = t
+		return
+	}
+	r.versions[t.Name] = slices.Insert(vs, i, t)
+}
+
+func (r *templateVersions) remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.versions, name)
+}
+
+// ValidateTemplate checks that a template is well formed, by expanding it
+// with placeholder parameters.
+func ValidateTemplate(name, dotDagSource string) error {
+	if !templateNameRegexp.MatchString(name) {
+		return errors.Errorf("invalid template name %q: must start with a letter and contain only letters, digits, _ and -", name)
+	}
+	t := Template{Name: name, DotDagSource: dotDagSource}
+	params := make(map[string]string)
+	for _, m := range templateParamRegexp.FindAllStringSubmatch(dotDagSource, -1) {
+		params[m[1]] = "0"
+	}
+	_, err := t.expand(name, params, nil)
+	return err
+}
+
+// TemplateNames returns the names of the templates used by the template tasks
+// of a pipeline, without expanding them.
+func TemplateNames(dotDagSource string) ([]string, error) {
+	file, err := dotparser.ParseBytes([]byte("digraph {\n" + dotDagSource + "\n}"))
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var names []string
+	var walk func(stmts []ast.Stmt)
+	walk = func(stmts []ast.Stmt) {
+		for _, stmt := range stmts {
+			switch stmt := stmt.(type) {
+			case *ast.NodeStmt:
+				name := nodeStmtAttr(stmt, templateNameAttribute)
+				if nodeStmtType(stmt) == TaskTypeTemplate && name != "" && !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			case *ast.Subgraph:
+				walk(stmt.Stmts)
+			}
+		}
+	}
+	for _, g := range file.Graphs {
+		walk(g.Stmts)
+	}
+	return names, nil
+}
+
+// templateExpansion is a template task replaced by the tasks of its template.
+type templateExpansion struct {
+	stmts []ast.Stmt
+	// entries are the tasks without inputs, which edges into the template
+	// task lead to
+	entries []string
+}
+
+// expandTemplates replaces the template tasks among stmts, including those in
+// subgraphs, with the tasks of their templates.
+func expandTemplates(ts TemplateSource, stmts []ast.Stmt) ([]ast.Stmt, error) {
+	declared := make(map[string]bool)
+	collectNodeIDs(stmts, declared)
+
+	expansions := make(map[string]templateExpansion)
+	stmts, err := expandTemplateTasks(ts, stmts, expansions)
+	if err != nil || len(expansions) == 0 {
+		return stmts, err
+	}
+
+	generated := make(map[string]string)
+	for id, exp := range expansions {
+		names := make(map[string]bool)
+		collectNodeIDs(exp.stmts, names)
+		for name := range names {
+			if name == id {
+				continue
+			}
+			if declared[name] {
+				return nil, errors.Errorf("template task %s: task %s already exists", id, name)
+			}
+			if other, ok := generated[name]; ok {
+				return nil, errors.Errorf("template tasks %s and %s both expand to task %s", other, id, name)
+			}
+			generated[name] = id
+		}
+	}
+	return redirectTemplateEdges(stmts, expansions)
+}
+
+func expandTemplateTasks(ts TemplateSource, stmts []ast.Stmt, expansions map[string]templateExpansion) ([]ast.Stmt, error) {
+	out := make([]ast.Stmt, 0, len(stmts))
+	for _, stmt := range stmts {
+		switch stmt := stmt.(type) {
+		case *ast.NodeStmt:
+			if nodeStmtType(stmt) != TaskTypeTemplate {
+				out = append(out, stmt)
+				continue
+			}
+			id := unquoteDOTID(stmt.Node.ID)
+			if _, exists := expansions[id]; exists {
+				return nil, errors.Errorf("template task %s is declared more than once", id)
+			}
+			exp, err := expandTemplateTask(ts, id, stmt.Attrs)
+			if err != nil {
+				return nil, errors.Wrapf(err, "template task %s", id)
+			}
+			expansions[id] = exp
+			out = append(out, exp.stmts...)
+		case *ast.Subgraph:
+			var err error
+			if stmt.Stmts, err = expandTemplateTasks(ts, stmt.Stmts, expansions); err != nil {
+				return nil, err
+			}
+			out = append(out, stmt)
+		default:
+			out = append(out, stmt)
+		}
+	}
+	return out, nil
+}
+
+func expandTemplateTask(ts TemplateSource, id string, attrs []*ast.Attr) (templateExpansion, error) {
+	var (
+		name, version string
+		index         *ast.Attr
+	)
+	params := make(map[string]string)
+	for _, attr := range attrs {
+		switch key := unquoteDOTID(attr.Key); key {
+		case "type":
+		case templateNameAttribute:
+			name = unquoteDOTID(attr.Val)
+		case templateVersionAttribute:
+			version = unquoteDOTID(attr.Val)
+		case "index":
+			index = attr
+		default:
+			params[key] = templateParamValue(attr.Val)
+		}
+	}
+	if name == "" {
+		return templateExpansion{}, errors.Errorf("%s is required", templateNameAttribute)
+	}
+	var v int64
+	if version != "" {
+		var err error
+		if v, err = strconv.ParseInt(version, 10, 32); err != nil || v <= 0 {
+			return templateExpansion{}, errors.Errorf("invalid %s %q", templateVersionAttribute, version)
+		}
+	}
+	if ts == nil {
+		return templateExpansion{}, errors.New("no pipeline templates are available")
+	}
+	t, err := ts.Template(name, int32(v))
+	if err != nil {
+		return templateExpansion{}, err
+	}
+	return t.expand(id, params, index)
+}
+
+// templateParamValue returns a parameter as it is substituted into the
+// template. Quoted values keep their escaping, and HTML-like values, e.g.
+// data=<{"id": $(jobRun.id)}>, are escaped, as the placeholders they replace
+// are usually quoted.
+func templateParamValue(val string) string {
+	switch {
+	case len(val) >= 2 && strings.HasPrefix(val, `"`) && strings.HasSuffix(val, `"`):
+		return val[1 : len(val)-1]
+	case len(val) >= 2 && strings.HasPrefix(val, "<") && strings.HasSuffix(val, ">"):
+		quoted := strconv.Quote(val[1 : len(val)-1])
+		return quoted[1 : len(quoted)-1]
+	default:
+		return val
+	}
+}
+
+// expand returns the tasks of the template for the template task id, with
+// params substituted for their placeholders.
+func (t Template) expand(id string, params map[string]string, index *ast.Attr) (templateExpansion, error) {
+	var missing []string
+	used := make(map[string]bool)
+	source := templateParamRegexp.ReplaceAllStringFunc(t.DotDagSource, func(m string) string {
+		param := templateParamRegexp.FindStringSubmatch(m)[1]
+		val, ok := params[param]
+		if !ok {
+			if !used[param] {
+				missing = append(missing, param)
+			}
+			used[param] = true
+			return m
+		}
+		used[param] = true
+		return val
+	})
+	if len(missing) > 0 {
+		return templateExpansion{}, errors.Errorf("template %s: missing parameters %s", t.Name, strings.Join(missing, ", "))
+	}
+	var unknown []string
+	for param := range params {
+		if !used[param] {
+			unknown = append(unknown, param)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return templateExpansion{}, errors.Errorf("template %s: unknown parameters %s", t.Name, strings.Join(unknown, ", "))
+	}
+
+	file, err := dotparser.ParseBytes([]byte("digraph {\n" + source + "\n}"))
+	if err != nil {
+		return templateExpansion{}, errors.Wrapf(err, "template %s", t.Name)
+	}
+	stmts := file.Graphs[0].Stmts
+	for _, stmt := range stmts {
+		switch stmt := stmt.(type) {
+		case *ast.Subgraph:
+			return templateExpansion{}, errors.Errorf("template %s cannot contain subgraphs", t.Name)
+		case *ast.NodeStmt:
+			if nodeStmtType(stmt) == TaskTypeTemplate {
+				return templateExpansion{}, errors.Errorf("template %s cannot contain template tasks", t.Name)
+			}
+		}
+	}
+
+	p, err := Parse(source)
+	if err != nil {
+		return templateExpansion{}, errors.Wrapf(err, "template %s", t.Name)
+	}
+	var finals []string
+	for _, task := range p.Tasks {
+		if len(task.Outputs()) == 0 {
+			finals = append(finals, task.DotID())
+		}
+	}
+	if len(finals) != 1 {
+		return templateExpansion{}, errors.Errorf("template %s must have exactly one final task, got %d", t.Name, len(finals))
+	}
+
+	names := make(map[string]string, len(p.Tasks))
+	for _, task := range p.Tasks {
+		names[task.DotID()] = id + "_" + task.DotID()
+	}
+	names[finals[0]] = id
+	renameTemplateNodes(stmts, names)
+
+	exp := templateExpansion{stmts: stmts}
+	for _, task := range p.Tasks {
+		if len(task.Inputs()) == 0 {
+			exp.entries = append(exp.entries, names[task.DotID()])
+		}
+	}
+	if index != nil {
+		for _, stmt := range stmts {
+			if node, ok := stmt.(*ast.NodeStmt); ok && unquoteDOTID(node.Node.ID) == id {
+				node.Attrs = append(node.Attrs, index)
+			}
+		}
+	}
+	return exp, nil
+}
+
+// renameTemplateNodes renames the tasks of a template, and the references to
+// them in the attributes of its tasks.
+func renameTemplateNodes(stmts []ast.Stmt, names map[string]string) {
+	for _, stmt := range stmts {
+		switch stmt := stmt.(type) {
+		case *ast.NodeStmt:
+			renameTemplateNode(stmt.Node, names)
+			for _, attr := range stmt.Attrs {
+				attr.Val = variableRegexp.ReplaceAllStringFunc(attr.Val, func(m string) string {
+					parts := strings.SplitN(strings.TrimSpace(m[2:len(m)-1]), ".", 2)
+					name, ok := names[parts[0]]
+					if !ok {
+						return m
+					}
+					parts[0] = name
+					return "$(" + strings.Join(parts, ".") + ")"
+				})
+			}
+		case *ast.EdgeStmt:
+			renameTemplateVertex(stmt.From, names)
+			for e := stmt.To; e != nil; e = e.To {
+				renameTemplateVertex(e.Vertex, names)
+			}
+		}
+	}
+}
+
+func renameTemplateVertex(v ast.Vertex, names map[string]string) {
+	switch v := v.(type) {
+	case *ast.Node:
+		renameTemplateNode(v, names)
+	case *ast.Subgraph:
+		renameTemplateNodes(v.Stmts, names)
+	}
+}
+
+func renameTemplateNode(n *ast.Node, names map[string]string) {
+	if name, ok := names[unquoteDOTID(n.ID)]; ok {
+		n.ID = quoteDOTID(name)
+	}
+}
+
+// redirectTemplateEdges makes the edges into template tasks lead to the tasks
+// of their templates without inputs.
+func redirectTemplateEdges(stmts []ast.Stmt, expansions map[string]templateExpansion) ([]ast.Stmt, error) {
+	out := make([]ast.Stmt, 0, len(stmts))
+	for _, stmt := range stmts {
+		switch stmt := stmt.(type) {
+		case *ast.EdgeStmt:
+			edges, err := redirectTemplateEdge(stmt, expansions)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, edges...)
+		case *ast.Subgraph:
+			var err error
+			if stmt.Stmts, err = redirectTemplateEdges(stmt.Stmts, expansions); err != nil {
+				return nil, err
+			}
+			out = append(out, stmt)
+		default:
+			out = append(out, stmt)
+		}
+	}
+	return out, nil
+}
+
+func redirectTemplateEdge(stmt *ast.EdgeStmt, expansions map[string]templateExpansion) ([]ast.Stmt, error) {
+	var redirect bool
+	for e := stmt.To; e != nil; e = e.To {
+		switch v := e.Vertex.(type) {
+		case *ast.Node:
+			if _, ok := expansions[unquoteDOTID(v.ID)]; ok {
+				redirect = true
+			}
+		case *ast.Subgraph:
+			ids := make(map[string]bool)
+			collectNodeIDs(v.Stmts, ids)
+			for id := range ids {
+				if _, ok := expansions[id]; ok {
+					return nil, errors.Errorf("template task %s cannot be the target of an edge to a subgraph", id)
+				}
+			}
+		}
+	}
+	if !redirect {
+		return []ast.Stmt{stmt}, nil
+	}
+
+	// Split the chain of edges, so that only the edges into template tasks
+	// are redirected.
+	var out []ast.Stmt
+	from := stmt.From
+	for e := stmt.To; e != nil; e = e.To {
+		to := e.Vertex
+		if v, ok := to.(*ast.Node); ok {
+			if exp, ok := expansions[unquoteDOTID(v.ID)]; ok {
+				to = templateEntries(exp)
+			}
+		}
+		out = append(out, &ast.EdgeStmt{From: from, To: &ast.Edge{Directed: e.Directed, Vertex: to}, Attrs: stmt.Attrs})
+		from = e.Vertex
+	}
+	return out, nil
+}
+
+func templateEntries(exp templateExpansion) ast.Vertex {
+	if len(exp.entries) == 1 {
+		return &ast.Node{ID: quoteDOTID(exp.entries[0])}
+	}
+	sub := &ast.Subgraph{}
+	for _, entry := range exp.entries {
+		sub.Stmts = append(sub.Stmts, &ast.NodeStmt{Node: &ast.Node{ID: quoteDOTID(entry)}})
+	}
+	return sub
+}
+
+// collectNodeIDs adds the IDs of the nodes declared by stmts to ids.
+func collectNodeIDs(stmts []ast.Stmt, ids map[string]bool) {
+	for _, stmt := range stmts {
+		switch stmt := stmt.(type) {
+		case *ast.NodeStmt:
+			ids[unquoteDOTID(stmt.Node.ID)] = true
+		case *ast.Subgraph:
+			collectNodeIDs(stmt.Stmts, ids)
+		}
+	}
+}
+
+func quoteDOTID(id string) string {
+	if plainDOTIDRegexp.MatchString(id) {
+		return id
+	}
+	return `"` + strings.ReplaceAll(id, `"`, `\"`) + `"`
+}
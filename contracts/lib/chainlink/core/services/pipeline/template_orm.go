@@ -0,0 +1,122 @@
+package pipeline
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+)
+
+// TemplateORM stores pipeline templates. It is the TemplateSource of Parse:
+// it loads the templates when it starts and keeps them in memory, so that
+// pipelines are parsed without querying the database, and updates them as
+// they are created and deleted.
+type TemplateORM interface {
+	services.Service
+	TemplateSource
+
+	// CreateTemplate stores a new version of the named template.
+	CreateTemplate(ctx context.Context, name string, dotDagSource string) (Template, error)
+	FindTemplates(ctx context.Context) ([]Template, error)
+	// FindTemplate returns the given version of the named template, or its
+	// latest version if version is 0.
+	FindTemplate(ctx context.Context, name string, version int32) (Template, error)
+	// DeleteTemplate deletes all versions of the named template.
+	DeleteTemplate(ctx context.Context, name string) error
+}
+
+type templateORM struct {
+	services.StateMachine
+	ds        sqlutil.DataSource
+	lggr      logger.Logger
+	templates *templateVersions
+}
+
+var _ TemplateORM = (*templateORM)(nil)
+
+func NewTemplateORM(ds sqlutil.DataSource, lggr logger.Logger) *templateORM {
+	return &templateORM{
+		ds:        ds,
+		lggr:      lggr.Named("PipelineTemplateORM"),
+		templates: newTemplateVersions(),
+	}
+}
+
+func (o *templateORM) Start(ctx context.Context) error {
+	return o.StartOnce("PipelineTemplateORM", func() error {
+		ts, err := o.FindTemplates(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to load pipeline templates")
+		}
+		for _, t := range ts {
+			o.templates.put(t)
+		}
+		o.lggr.Debugw("Loaded pipeline templates", "count", len(ts))
+		return nil
+	})
+}
+
+func (o *templateORM) Close() error {
+	return o.StopOnce("PipelineTemplateORM", func() error { return nil })
+}
+
+func (o *templateORM) Name() string {
+	return o.lggr.Name()
+}
+
+func (o *templateORM) HealthReport() map[string]error {
+	return map[string]error{o.Name(): o.Healthy()}
+}
+
+func (o *templateORM) Template(name string, version int32) (Template, error) {
+	return o.templates.Template(name, version)
+}
+
+func (o *templateORM) CreateTemplate(ctx context.Context, name string, dotDagSource string) (t Template, err error) {
+	if err = ValidateTemplate(name, dotDagSource); err != nil {
+		return t, err
+	}
+	query := `INSERT INTO pipeline_templates (name, version, dot_dag_source, created_at)
+	SELECT $1, COALESCE(MAX(version), 0) + 1, $2, NOW() FROM pipeline_templates WHERE name = $1
+	RETURNING *;`
+	if err = o.ds.GetContext(ctx, &t, query, name, dotDagSource); err != nil {
+		return t, errors.Wrap(err, "failed to create pipeline template")
+	}
+	o.templates.put(t)
+	return t, nil
+}
+
+func (o *templateORM) FindTemplates(ctx context.Context) (ts []Template, err error) {
+	err = o.ds.SelectContext(ctx, &ts, `SELECT * FROM pipeline_templates ORDER BY name, version`)
+	return ts, errors.Wrap(err, "failed to find pipeline templates")
+}
+
+func (o *templateORM) FindTemplate(ctx context.Context, name string, version int32) (t Template, err error) {
+	if version == 0 {
+		err = o.ds.GetContext(ctx, &t, `SELECT * FROM pipeline_templates WHERE name = $1 ORDER BY version DESC LIMIT 1`, name)
+	} else {
+		err = o.ds.GetContext(ctx, &t, `SELECT * FROM pipeline_templates WHERE name = $1 AND version = $2`, name, version)
+	}
+	return t, errors.Wrap(err, "failed to find pipeline template")
+}
+
+func (o *templateORM) DeleteTemplate(ctx context.Context, name string) error {
+	result, err := o.ds.ExecContext(ctx, `DELETE FROM pipeline_templates WHERE name = $1`, name)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete pipeline template")
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to delete pipeline template")
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	o.templates.remove(name)
+	return nil
+}
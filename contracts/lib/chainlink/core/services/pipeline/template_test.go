@@ -0,0 +1,131 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func inputDotIDs(task Task) []string {
+	var ids []string
+	for _, input := range task.Inputs() {
+		ids = append(ids, input.InputTask.DotID())
+	}
+	return ids
+}
+
+func TestParse_Templates(t *testing.T) {
+	t.Parallel()
+
+	templates := newTemplateVersions()
+	templates.put(Template{Name: "parseFetchPrice", Version: 1, DotDagSource: `
+fetch [type=http method=GET url="{{url}}"]
+parse [type=jsonparse path="{{path}}" data="$(fetch)"]
+`})
+	templates.put(Template{Name: "parseScale", Version: 1, DotDagSource: `scale [type=multiply times=10]`})
+	templates.put(Template{Name: "parseScale", Version: 2, DotDagSource: `scale [type=multiply times={{times}}]`})
+
+	t.Run("expands templates with their parameters", func(t *testing.T) {
+		p, err := ParseWithTemplates(`
+eth [type=template template=parseFetchPrice url="https://example.com/eth" path="data,price"]
+btc [type=template template=parseFetchPrice url="https://example.com/btc?q=\"x\"" path="price"]
+median [type=median values=<[ $(eth), $(btc) ]>]
+`, templates)
+		require.NoError(t, err)
+		require.Len(t, p.Tasks, 5)
+
+		ethFetch := p.ByDotID("eth_fetch").(*HTTPTask)
+		assert.Equal(t, "https://example.com/eth", ethFetch.URL)
+		btcFetch := p.ByDotID("btc_fetch").(*HTTPTask)
+		assert.Equal(t, `https://example.com/btc?q="x"`, btcFetch.URL)
+
+		eth := p.ByDotID("eth").(*JSONParseTask)
+		assert.Equal(t, "data,price", eth.Path)
+		assert.Equal(t, "$(eth_fetch)", eth.Data)
+		assert.Equal(t, []string{"eth_fetch"}, inputDotIDs(eth))
+		assert.ElementsMatch(t, []string{"eth", "btc"}, inputDotIDs(p.ByDotID("median")))
+	})
+
+	t.Run("leads edges into a template task to its first tasks", func(t *testing.T) {
+		p, err := ParseWithTemplates(`
+start  [type=memo value=2]
+scaled [type=template template=parseScale version=1]
+start -> scaled -> end
+end    [type=memo]
+`, templates)
+		require.NoError(t, err)
+		scaled := p.ByDotID("scaled").(*MultiplyTask)
+		assert.Equal(t, "10", scaled.Times)
+		assert.Equal(t, []string{"start"}, inputDotIDs(scaled))
+		assert.Equal(t, []string{"scaled"}, inputDotIDs(p.ByDotID("end")))
+	})
+
+	t.Run("expands the latest version by default", func(t *testing.T) {
+		p, err := ParseWithTemplates(`scaled [type=template template=parseScale times=3 index=2]`, templates)
+		require.NoError(t, err)
+		scaled := p.ByDotID("scaled").(*MultiplyTask)
+		assert.Equal(t, "3", scaled.Times)
+		assert.Equal(t, int32(2), scaled.Base().Index)
+	})
+
+	t.Run("pinned versions do not change with the template", func(t *testing.T) {
+		source := "pinned [type=template template=parseDouble version=1]\nlatest [type=template template=parseDouble]"
+		templates.put(Template{Name: "parseDouble", Version: 1, DotDagSource: `double [type=multiply times=2]`})
+		templates.put(Template{Name: "parseDouble", Version: 2, DotDagSource: `double [type=multiply times=4]`})
+
+		p, err := ParseWithTemplates(source, templates)
+		require.NoError(t, err)
+		assert.Equal(t, "2", p.ByDotID("pinned").(*MultiplyTask).Times)
+		assert.Equal(t, "4", p.ByDotID("latest").(*MultiplyTask).Times)
+	})
+
+	t.Run("without templates", func(t *testing.T) {
+		_, err := ParseWithTemplates(`x [type=template template=parseScale]`, nil)
+		require.ErrorContains(t, err, "no pipeline templates are available")
+	})
+
+	for _, tt := range []struct {
+		name   string
+		source string
+		err    string
+	}{
+		{"unknown template", `x [type=template template=parseUnknown]`, `no pipeline template named "parseUnknown"`},
+		{"unknown version", `x [type=template template=parseScale version=3]`, `pipeline template "parseScale" has no version 3`},
+		{"missing parameter", `x [type=template template=parseFetchPrice url="https://example.com"]`, "missing parameters path"},
+		{"unknown parameter", `x [type=template template=parseScale times=3 tims=3]`, "unknown parameters tims"},
+		{"existing task", "x [type=template template=parseFetchPrice url=\"https://example.com\" path=\"a\"]\nx_fetch [type=memo]", "task x_fetch already exists"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseWithTemplates(tt.source, templates)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateTemplate("fetch-price_v2", `
+fetch [type=http method=GET url="{{url}}"]
+parse [type=jsonparse path="{{path}}"]
+fetch -> parse
+`))
+	require.ErrorContains(t, ValidateTemplate("2fetch", `a [type=memo]`), "invalid template name")
+	require.ErrorContains(t, ValidateTemplate("twoFinals", "a [type=memo]\nb [type=memo]"), "must have exactly one final task, got 2")
+	require.ErrorContains(t, ValidateTemplate("nested", `a [type=template template=other]`), "cannot contain template tasks")
+	require.ErrorContains(t, ValidateTemplate("subgraphs", `subgraph s { a [type=memo] }`), "cannot contain subgraphs")
+}
+
+func TestTemplateNames(t *testing.T) {
+	t.Parallel()
+
+	names, err := TemplateNames(`
+a [type=template template=one]
+b [type=TEMPLATE template="two"]
+c [type=template template=one]
+d [type=memo template=three]
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one", "two"}, names)
+}
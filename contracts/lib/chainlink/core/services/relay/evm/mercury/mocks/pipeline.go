@@ -34,12 +34,14 @@ func (m *MockTask) DotID() string           { return "" }
 func (m *MockTask) Run(ctx context.Context, lggr logger.Logger, vars pipeline.Vars, inputs []pipeline.Result) (pipeline.Result, pipeline.RunInfo) {
 	return m.result, pipeline.RunInfo{}
 }
-func (m *MockTask) Base() *pipeline.BaseTask           { return nil }
-func (m *MockTask) Outputs() []pipeline.Task           { return nil }
-func (m *MockTask) Inputs() []pipeline.TaskDependency  { return nil }
-func (m *MockTask) OutputIndex() int32                 { return 0 }
-func (m *MockTask) TaskTimeout() (time.Duration, bool) { return 0, false }
-func (m *MockTask) TaskRetries() uint32                { return 0 }
-func (m *MockTask) TaskMinBackoff() time.Duration      { return 0 }
-func (m *MockTask) TaskMaxBackoff() time.Duration      { return 0 }
-func (m *MockTask) TaskStreamID() *uint32              { return nil }
+func (m *MockTask) Base() *pipeline.BaseTask               { return nil }
+func (m *MockTask) Outputs() []pipeline.Task               { return nil }
+func (m *MockTask) Inputs() []pipeline.TaskDependency      { return nil }
+func (m *MockTask) OutputIndex() int32                     { return 0 }
+func (m *MockTask) TaskTimeout() (time.Duration, bool)     { return 0, false }
+func (m *MockTask) TaskRetries() uint32                    { return 0 }
+func (m *MockTask) TaskMinBackoff() time.Duration          { return 0 }
+func (m *MockTask) TaskMaxBackoff() time.Duration          { return 0 }
+func (m *MockTask) TaskRetryOn() []pipeline.RetryCondition { return nil }
+func (m *MockTask) TaskRetryJitter() pipeline.RetryJitter  { return "" }
+func (m *MockTask) TaskStreamID() *uint32                  { return nil }
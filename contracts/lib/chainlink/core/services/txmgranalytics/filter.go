@@ -0,0 +1,91 @@
+package txmgranalytics
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/v2/core/utils/stringutils"
+)
+
+const (
+	// DefaultWindow is the reporting window used when no start is given.
+	DefaultWindow = 7 * 24 * time.Hour
+	// MaxWindow bounds the reporting window, as every transaction in it is loaded to build a report.
+	MaxWindow = 31 * 24 * time.Hour
+)
+
+// Filter selects the transactions that make up a report.
+type Filter struct {
+	// EVMChainID, JobID and FromAddress restrict the report to a single chain, job or key. Optional.
+	EVMChainID  *big.Int
+	JobID       *int32
+	FromAddress *common.Address
+	// From and To bound the time the transaction was created, To being exclusive.
+	From time.Time
+	To   time.Time
+}
+
+// FilterParams are the filter arguments of a report, as given by a user. Empty values are unset.
+type FilterParams struct {
+	EVMChainID string
+	JobID      string
+	Address    string
+	From       *time.Time
+	To         *time.Time
+}
+
+// FilterError is an invalid filter argument.
+type FilterError struct {
+	// Param is the name of the argument in the web API.
+	Param   string
+	Message string
+}
+
+func (e FilterError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Param, e.Message)
+}
+
+// ParseFilter builds a Filter from params, returning an error for each invalid one. The window
+// ends now unless To is given, and spans DefaultWindow unless From is given.
+func ParseFilter(params FilterParams, now time.Time) (filter Filter, errs []FilterError) {
+	if params.EVMChainID != "" {
+		chainID, ok := new(big.Int).SetString(params.EVMChainID, 10)
+		if !ok {
+			errs = append(errs, FilterError{Param: "evmChainID", Message: "invalid chain ID"})
+		}
+		filter.EVMChainID = chainID
+	}
+	if params.JobID != "" {
+		jobID, err := stringutils.ToInt32(params.JobID)
+		if err != nil {
+			errs = append(errs, FilterError{Param: "jobID", Message: "invalid job ID"})
+		}
+		filter.JobID = &jobID
+	}
+	if params.Address != "" {
+		if !common.IsHexAddress(params.Address) {
+			errs = append(errs, FilterError{Param: "address", Message: "invalid address"})
+		}
+		address := common.HexToAddress(params.Address)
+		filter.FromAddress = &address
+	}
+
+	filter.To = now
+	if params.To != nil {
+		filter.To = *params.To
+	}
+	filter.From = filter.To.Add(-DefaultWindow)
+	if params.From != nil {
+		filter.From = *params.From
+	}
+	switch {
+	case !filter.From.Before(filter.To):
+		errs = append(errs, FilterError{Param: "from", Message: "must be before to"})
+	case filter.To.Sub(filter.From) > MaxWindow:
+		errs = append(errs, FilterError{Param: "from", Message: fmt.Sprintf("window must not be longer than %s", MaxWindow)})
+	}
+	return filter, errs
+}
@@ -0,0 +1,69 @@
+package txmgranalytics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/txmgranalytics"
+)
+
+func TestParseFilter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	t.Run("defaults", func(t *testing.T) {
+		filter, errs := txmgranalytics.ParseFilter(txmgranalytics.FilterParams{}, now)
+		require.Empty(t, errs)
+		assert.Nil(t, filter.EVMChainID)
+		assert.Nil(t, filter.JobID)
+		assert.Nil(t, filter.FromAddress)
+		assert.Equal(t, now, filter.To)
+		assert.Equal(t, now.Add(-txmgranalytics.DefaultWindow), filter.From)
+	})
+
+	t.Run("all params", func(t *testing.T) {
+		address := utils.RandomAddress()
+		from, to := now.Add(-time.Hour), now.Add(-time.Minute)
+		filter, errs := txmgranalytics.ParseFilter(txmgranalytics.FilterParams{
+			EVMChainID: "10",
+			JobID:      "7",
+			Address:    address.Hex(),
+			From:       &from,
+			To:         &to,
+		}, now)
+		require.Empty(t, errs)
+		assert.Equal(t, int64(10), filter.EVMChainID.Int64())
+		require.NotNil(t, filter.JobID)
+		assert.Equal(t, int32(7), *filter.JobID)
+		assert.Equal(t, address, *filter.FromAddress)
+		assert.Equal(t, from, filter.From)
+		assert.Equal(t, to, filter.To)
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		from := now.Add(time.Hour)
+		_, errs := txmgranalytics.ParseFilter(txmgranalytics.FilterParams{
+			EVMChainID: "abc",
+			JobID:      "abc",
+			Address:    "0x123",
+			From:       &from,
+		}, now)
+		var params []string
+		for _, err := range errs {
+			params = append(params, err.Param)
+		}
+		assert.Equal(t, []string{"evmChainID", "jobID", "address", "from"}, params)
+	})
+
+	t.Run("window too long", func(t *testing.T) {
+		from := now.Add(-txmgranalytics.MaxWindow - time.Second)
+		_, errs := txmgranalytics.ParseFilter(txmgranalytics.FilterParams{From: &from}, now)
+		require.Len(t, errs, 1)
+		assert.Equal(t, "from", errs[0].Param)
+	})
+}
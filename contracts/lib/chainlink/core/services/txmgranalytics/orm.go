@@ -0,0 +1,172 @@
+package txmgranalytics
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
+
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+)
+
+// TxOutcome is a single txmgr transaction, with the job it was sent for and its receipt, if any.
+type TxOutcome struct {
+	ID         int64
+	EVMChainID *big.Int
+	// JobID is nil for transactions that were not sent on behalf of a job, e.g. manual transfers.
+	JobID              *int32
+	FromAddress        common.Address
+	State              txmgrtypes.TxState
+	CreatedAt          time.Time
+	InitialBroadcastAt *time.Time
+	// BroadcastAttempts counts every attempt that was sent to the chain, the original one included.
+	BroadcastAttempts int64
+	// Receipt is nil until the transaction is included in a block.
+	Receipt    *Receipt
+	IncludedAt *time.Time
+}
+
+// Receipt holds the fields of a transaction receipt that reports are built from.
+type Receipt struct {
+	Status            uint64
+	GasUsed           uint64
+	EffectiveGasPrice *big.Int
+	// L1Fee is only set on L2 chains.
+	L1Fee *big.Int
+}
+
+// ORM loads txmgr transactions for analytics.
+type ORM interface {
+	TxOutcomes(ctx context.Context, filter Filter) ([]TxOutcome, error)
+}
+
+type orm struct {
+	ds sqlutil.DataSource
+}
+
+var _ ORM = (*orm)(nil)
+
+func NewORM(ds sqlutil.DataSource) ORM {
+	return &orm{ds: ds}
+}
+
+type txOutcomeRow struct {
+	ID                 int64
+	EVMChainID         *ubig.Big
+	JobID              *int32
+	FromAddress        common.Address
+	State              txmgrtypes.TxState
+	CreatedAt          time.Time
+	InitialBroadcastAt *time.Time
+	BroadcastAttempts  int64
+	// The receipt fields are hex encoded, as stored in the receipt JSON, and nil without a receipt.
+	ReceiptStatus            *string
+	ReceiptGasUsed           *string
+	ReceiptEffectiveGasPrice *string
+	ReceiptL1Fee             *string
+	IncludedAt               *time.Time
+}
+
+func (r txOutcomeRow) receipt() (*Receipt, error) {
+	if r.ReceiptStatus == nil || r.ReceiptGasUsed == nil {
+		return nil, nil
+	}
+	var (
+		receipt Receipt
+		err     error
+	)
+	if receipt.Status, err = hexutil.DecodeUint64(*r.ReceiptStatus); err != nil {
+		return nil, fmt.Errorf("invalid receipt status: %w", err)
+	}
+	if receipt.GasUsed, err = hexutil.DecodeUint64(*r.ReceiptGasUsed); err != nil {
+		return nil, fmt.Errorf("invalid receipt gasUsed: %w", err)
+	}
+	if r.ReceiptEffectiveGasPrice != nil {
+		if receipt.EffectiveGasPrice, err = hexutil.DecodeBig(*r.ReceiptEffectiveGasPrice); err != nil {
+			return nil, fmt.Errorf("invalid receipt effectiveGasPrice: %w", err)
+		}
+	}
+	if r.ReceiptL1Fee != nil {
+		if receipt.L1Fee, err = hexutil.DecodeBig(*r.ReceiptL1Fee); err != nil {
+			return nil, fmt.Errorf("invalid receipt l1Fee: %w", err)
+		}
+	}
+	return &receipt, nil
+}
+
+// TxOutcomes returns the transactions created in the given window, oldest first.
+//
+// The job of a transaction is taken from its JobID meta, set by the ethtx task, falling back to the
+// pipeline run that created it and to the VRF request it fulfills, as VRF jobs send their
+// fulfillments directly. Only the receipt fields the report needs are loaded, and the window is
+// expected to be bounded by MaxWindow, as every transaction in it is returned. IncludedAt is when the node fetched the receipt, not the block time.
+// Transactions sent through TxmV2 are not covered, as it does not store receipts.
+func (o *orm) TxOutcomes(ctx context.Context, filter Filter) ([]TxOutcome, error) {
+	stmt := `SELECT * FROM (
+			SELECT tx.id, tx.evm_chain_id, tx.from_address, tx.state, tx.created_at, tx.initial_broadcast_at,
+				COALESCE(
+					CASE WHEN tx.meta->>'JobID' ~ '^[0-9]+$' THEN (tx.meta->>'JobID')::int END,
+					(SELECT j.id FROM pipeline_task_runs ptr
+						JOIN pipeline_runs pr ON pr.id = ptr.pipeline_run_id
+						JOIN jobs j ON j.pipeline_spec_id = pr.pipeline_spec_id
+						WHERE ptr.id = tx.pipeline_task_run_id),
+					(SELECT l.job_id FROM vrf_request_lifecycle l WHERE l.eth_tx_id = tx.id LIMIT 1)
+				) AS job_id,
+				(SELECT count(*) FROM evm.tx_attempts a WHERE a.eth_tx_id = tx.id AND a.state = 'broadcast') AS broadcast_attempts,
+				r.receipt->>'status' AS receipt_status, r.receipt->>'gasUsed' AS receipt_gas_used,
+				r.receipt->>'effectiveGasPrice' AS receipt_effective_gas_price, r.receipt->>'l1Fee' AS receipt_l1_fee,
+				r.created_at AS included_at
+			FROM evm.txes tx
+			LEFT JOIN LATERAL (
+				SELECT r.receipt, r.created_at FROM evm.tx_attempts a
+				JOIN evm.receipts r ON r.tx_hash = a.hash
+				WHERE a.eth_tx_id = tx.id
+				ORDER BY r.block_number DESC LIMIT 1
+			) r ON TRUE
+			WHERE tx.created_at >= $1 AND tx.created_at < $2
+				AND ($3::numeric IS NULL OR tx.evm_chain_id = $3)
+				AND ($4::bytea IS NULL OR tx.from_address = $4)
+		) t
+		WHERE $5::int IS NULL OR t.job_id = $5
+		ORDER BY t.id`
+	var chainID *string
+	if filter.EVMChainID != nil {
+		s := filter.EVMChainID.String()
+		chainID = &s
+	}
+	var fromAddress []byte
+	if filter.FromAddress != nil {
+		fromAddress = filter.FromAddress.Bytes()
+	}
+	var rows []txOutcomeRow
+	if err := o.ds.SelectContext(ctx, &rows, stmt, filter.From, filter.To, chainID, fromAddress, filter.JobID); err != nil {
+		return nil, fmt.Errorf("failed to load txmgr transactions: %w", err)
+	}
+
+	outcomes := make([]TxOutcome, len(rows))
+	for i, r := range rows {
+		receipt, err := r.receipt()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode receipt of transaction %d: %w", r.ID, err)
+		}
+		outcomes[i] = TxOutcome{
+			ID:                 r.ID,
+			EVMChainID:         r.EVMChainID.ToInt(),
+			JobID:              r.JobID,
+			FromAddress:        r.FromAddress,
+			State:              r.State,
+			CreatedAt:          r.CreatedAt,
+			InitialBroadcastAt: r.InitialBroadcastAt,
+			BroadcastAttempts:  r.BroadcastAttempts,
+			Receipt:            receipt,
+			IncludedAt:         r.IncludedAt,
+		}
+	}
+	return outcomes, nil
+}
@@ -0,0 +1,88 @@
+package txmgranalytics_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	txmgrcommon "github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr/txmgrtest"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/txmgranalytics"
+)
+
+func TestORM_TxOutcomes(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	orm := txmgranalytics.NewORM(db)
+	jb, _ := cltest.MustInsertWebhookSpec(t, db)
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+	_, fromAddress := cltest.MustInsertRandomKey(t, ethKeyStore)
+	txStore := txmgrtest.NewTestTxStore(t, db)
+
+	confirmed := txmgrtest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, 1, fromAddress)
+	_, err := db.ExecContext(ctx, `UPDATE evm.txes SET meta = jsonb_build_object('JobID', $1::int) WHERE id = $2`, jb.ID, confirmed.ID)
+	require.NoError(t, err)
+	_, err = txStore.InsertReceipt(ctx, &evmtypes.Receipt{
+		TxHash:            confirmed.TxAttempts[0].Hash,
+		BlockHash:         utils.RandomHash(),
+		BlockNumber:       big.NewInt(10),
+		GasUsed:           100_000,
+		EffectiveGasPrice: big.NewInt(2),
+		Status:            1,
+	})
+	require.NoError(t, err)
+
+	unconfirmed := txmgrtest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, txStore, 1, fromAddress)
+	bump := txmgrtest.NewLegacyEthTxAttempt(t, unconfirmed.ID)
+	bump.State = txmgrtypes.TxAttemptBroadcast
+	require.NoError(t, txStore.InsertTxAttempt(ctx, &bump))
+
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	outcomes, err := orm.TxOutcomes(ctx, txmgranalytics.Filter{From: from, To: to})
+	require.NoError(t, err)
+	require.Len(t, outcomes, 2)
+
+	assert.Equal(t, confirmed.ID, outcomes[0].ID)
+	require.NotNil(t, outcomes[0].JobID)
+	assert.Equal(t, jb.ID, *outcomes[0].JobID)
+	require.NotNil(t, outcomes[0].Receipt)
+	assert.Equal(t, uint64(100_000), outcomes[0].Receipt.GasUsed)
+	assert.NotNil(t, outcomes[0].IncludedAt)
+	assert.Equal(t, int64(1), outcomes[0].BroadcastAttempts)
+
+	assert.Equal(t, unconfirmed.ID, outcomes[1].ID)
+	assert.Nil(t, outcomes[1].JobID)
+	assert.Nil(t, outcomes[1].Receipt)
+	assert.Equal(t, txmgrcommon.TxUnconfirmed, outcomes[1].State)
+	assert.Equal(t, int64(2), outcomes[1].BroadcastAttempts)
+
+	outcomes, err = orm.TxOutcomes(ctx, txmgranalytics.Filter{JobID: &jb.ID, From: from, To: to})
+	require.NoError(t, err)
+	require.Len(t, outcomes, 1)
+	assert.Equal(t, confirmed.ID, outcomes[0].ID)
+
+	otherAddress := utils.RandomAddress()
+	outcomes, err = orm.TxOutcomes(ctx, txmgranalytics.Filter{FromAddress: &otherAddress, From: from, To: to})
+	require.NoError(t, err)
+	assert.Empty(t, outcomes)
+
+	outcomes, err = orm.TxOutcomes(ctx, txmgranalytics.Filter{EVMChainID: big.NewInt(1337_1337), From: from, To: to})
+	require.NoError(t, err)
+	assert.Empty(t, outcomes)
+
+	outcomes, err = orm.TxOutcomes(ctx, txmgranalytics.Filter{From: to, To: to.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Empty(t, outcomes)
+}
@@ -0,0 +1,160 @@
+package txmgranalytics
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	txmgrcommon "github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+)
+
+// GroupBy is the dimension transactions are aggregated by.
+type GroupBy string
+
+const (
+	// GroupByJob aggregates the transactions of each job, and those sent without a job, per chain.
+	GroupByJob GroupBy = "job"
+	// GroupByKey aggregates the transactions of each sending key, per chain.
+	GroupByKey GroupBy = "key"
+)
+
+// ParseGroupBy parses a GroupBy, defaulting to GroupByJob when s is empty.
+func ParseGroupBy(s string) (GroupBy, error) {
+	switch g := GroupBy(s); g {
+	case "":
+		return GroupByJob, nil
+	case GroupByJob, GroupByKey:
+		return g, nil
+	default:
+		return "", fmt.Errorf("invalid groupBy: %s, must be %s or %s", s, GroupByJob, GroupByKey)
+	}
+}
+
+// Stats aggregates the cost and outcome of a group of transactions.
+type Stats struct {
+	EVMChainID *big.Int
+	// JobID is set when grouping by job, and nil for the group of transactions sent without a job.
+	JobID *int32
+	// FromAddress is set when grouping by key.
+	FromAddress *common.Address
+
+	Transactions int64
+	// Included transactions have a receipt, whether they succeeded or reverted.
+	Included int64
+	Reverted int64
+	Fatal    int64
+	// Pending transactions are neither included nor fatal yet.
+	Pending int64
+
+	GasUsed uint64
+	// GasCostWei is what the included transactions cost, including the L1 fee on L2 chains.
+	GasCostWei *big.Int
+	// AvgEffectiveGasPrice is the average gas price paid by the included transactions, weighted by gas used.
+	AvgEffectiveGasPrice *big.Int
+
+	BroadcastAttempts int64
+	// BumpAttempts counts the attempts broadcast after the first one of each transaction.
+	BumpAttempts int64
+
+	// AvgTimeToInclusion and MaxTimeToInclusion measure the time from the initial broadcast
+	// of a transaction until its receipt was fetched.
+	AvgTimeToInclusion time.Duration
+	MaxTimeToInclusion time.Duration
+
+	// RevertRate is the share of included transactions that reverted.
+	RevertRate float64
+}
+
+// Summarize aggregates outcomes per chain and the given dimension, in the order each group
+// first appears.
+func Summarize(outcomes []TxOutcome, groupBy GroupBy) []Stats {
+	type key struct {
+		chainID string
+		jobID   int64 // -1 for transactions without a job
+		address common.Address
+	}
+	var (
+		stats          []Stats
+		index          = make(map[key]int)
+		gasPriceWeight = make(map[key]*big.Int)
+		inclusionTime  = make(map[key]time.Duration)
+		timedInclusion = make(map[key]int64)
+	)
+	for _, o := range outcomes {
+		k := key{chainID: o.EVMChainID.String(), jobID: -1}
+		if groupBy == GroupByKey {
+			k.address = o.FromAddress
+		} else if o.JobID != nil {
+			k.jobID = int64(*o.JobID)
+		}
+		i, ok := index[k]
+		if !ok {
+			i = len(stats)
+			index[k] = i
+			gasPriceWeight[k] = big.NewInt(0)
+			s := Stats{
+				EVMChainID:           o.EVMChainID,
+				GasCostWei:           big.NewInt(0),
+				AvgEffectiveGasPrice: big.NewInt(0),
+			}
+			if groupBy == GroupByKey {
+				s.FromAddress = &o.FromAddress
+			} else {
+				s.JobID = o.JobID
+			}
+			stats = append(stats, s)
+		}
+		s := &stats[i]
+		s.Transactions++
+		s.BroadcastAttempts += o.BroadcastAttempts
+		if o.BroadcastAttempts > 1 {
+			s.BumpAttempts += o.BroadcastAttempts - 1
+		}
+
+		switch {
+		case o.Receipt != nil:
+			s.Included++
+			if o.Receipt.Status == 0 {
+				s.Reverted++
+			}
+			gasUsed := new(big.Int).SetUint64(o.Receipt.GasUsed)
+			s.GasUsed += o.Receipt.GasUsed
+			if o.Receipt.EffectiveGasPrice != nil {
+				cost := new(big.Int).Mul(gasUsed, o.Receipt.EffectiveGasPrice)
+				s.GasCostWei.Add(s.GasCostWei, cost)
+				gasPriceWeight[k].Add(gasPriceWeight[k], cost)
+			}
+			if o.Receipt.L1Fee != nil {
+				s.GasCostWei.Add(s.GasCostWei, o.Receipt.L1Fee)
+			}
+			if o.InitialBroadcastAt != nil && o.IncludedAt != nil {
+				d := o.IncludedAt.Sub(*o.InitialBroadcastAt)
+				inclusionTime[k] += d
+				timedInclusion[k]++
+				if d > s.MaxTimeToInclusion {
+					s.MaxTimeToInclusion = d
+				}
+			}
+		case o.State == txmgrcommon.TxFatalError:
+			s.Fatal++
+		default:
+			s.Pending++
+		}
+	}
+
+	for k, i := range index {
+		s := &stats[i]
+		if s.GasUsed > 0 {
+			s.AvgEffectiveGasPrice = new(big.Int).Div(gasPriceWeight[k], new(big.Int).SetUint64(s.GasUsed))
+		}
+		if n := timedInclusion[k]; n > 0 {
+			s.AvgTimeToInclusion = inclusionTime[k] / time.Duration(n)
+		}
+		if s.Included > 0 {
+			s.RevertRate = float64(s.Reverted) / float64(s.Included)
+		}
+	}
+	return stats
+}
@@ -0,0 +1,108 @@
+package txmgranalytics_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	txmgrcommon "github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/txmgranalytics"
+)
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	var (
+		jobID      = int32(7)
+		keyA, keyB = utils.RandomAddress(), utils.RandomAddress()
+		now        = time.Now()
+	)
+	included := func(key common.Address, status uint64, gasUsed uint64, gasPrice int64, attempts int64, took time.Duration) txmgranalytics.TxOutcome {
+		broadcastAt, includedAt := now, now.Add(took)
+		return txmgranalytics.TxOutcome{
+			EVMChainID:         testutils.FixtureChainID,
+			JobID:              &jobID,
+			FromAddress:        key,
+			State:              txmgrcommon.TxConfirmed,
+			InitialBroadcastAt: &broadcastAt,
+			BroadcastAttempts:  attempts,
+			Receipt:            &txmgranalytics.Receipt{Status: status, GasUsed: gasUsed, EffectiveGasPrice: big.NewInt(gasPrice), L1Fee: big.NewInt(1)},
+			IncludedAt:         &includedAt,
+		}
+	}
+	outcomes := []txmgranalytics.TxOutcome{
+		included(keyA, 1, 100, 1, 1, time.Second),
+		included(keyA, 0, 300, 3, 3, 3*time.Second),
+		included(keyB, 1, 100, 2, 1, 5*time.Second),
+		{EVMChainID: testutils.FixtureChainID, FromAddress: keyB, State: txmgrcommon.TxFatalError},
+		{EVMChainID: testutils.FixtureChainID, FromAddress: keyB, State: txmgrcommon.TxUnconfirmed, BroadcastAttempts: 2},
+	}
+
+	t.Run("by job", func(t *testing.T) {
+		stats := txmgranalytics.Summarize(outcomes, txmgranalytics.GroupByJob)
+		require.Len(t, stats, 2)
+
+		job := stats[0]
+		require.NotNil(t, job.JobID)
+		assert.Equal(t, jobID, *job.JobID)
+		assert.Nil(t, job.FromAddress)
+		assert.Equal(t, int64(3), job.Transactions)
+		assert.Equal(t, int64(3), job.Included)
+		assert.Equal(t, int64(1), job.Reverted)
+		assert.Equal(t, uint64(500), job.GasUsed)
+		assert.Equal(t, big.NewInt(100+900+200+3), job.GasCostWei)
+		assert.Equal(t, big.NewInt(1200/500), job.AvgEffectiveGasPrice)
+		assert.Equal(t, int64(5), job.BroadcastAttempts)
+		assert.Equal(t, int64(2), job.BumpAttempts)
+		assert.Equal(t, 3*time.Second, job.AvgTimeToInclusion)
+		assert.Equal(t, 5*time.Second, job.MaxTimeToInclusion)
+		assert.InDelta(t, 1.0/3, job.RevertRate, 1e-9)
+
+		noJob := stats[1]
+		assert.Nil(t, noJob.JobID)
+		assert.Equal(t, int64(2), noJob.Transactions)
+		assert.Equal(t, int64(1), noJob.Fatal)
+		assert.Equal(t, int64(1), noJob.Pending)
+		assert.Equal(t, int64(1), noJob.BumpAttempts)
+		assert.Equal(t, big.NewInt(0), noJob.GasCostWei)
+		assert.Zero(t, noJob.RevertRate)
+	})
+
+	t.Run("by key", func(t *testing.T) {
+		stats := txmgranalytics.Summarize(outcomes, txmgranalytics.GroupByKey)
+		require.Len(t, stats, 2)
+
+		require.NotNil(t, stats[0].FromAddress)
+		assert.Equal(t, keyA, *stats[0].FromAddress)
+		assert.Nil(t, stats[0].JobID)
+		assert.Equal(t, int64(2), stats[0].Transactions)
+		assert.Equal(t, 0.5, stats[0].RevertRate)
+
+		require.NotNil(t, stats[1].FromAddress)
+		assert.Equal(t, keyB, *stats[1].FromAddress)
+		assert.Equal(t, int64(3), stats[1].Transactions)
+		assert.Equal(t, big.NewInt(201), stats[1].GasCostWei)
+	})
+}
+
+func TestParseGroupBy(t *testing.T) {
+	t.Parallel()
+
+	groupBy, err := txmgranalytics.ParseGroupBy("")
+	require.NoError(t, err)
+	assert.Equal(t, txmgranalytics.GroupByJob, groupBy)
+
+	groupBy, err = txmgranalytics.ParseGroupBy("key")
+	require.NoError(t, err)
+	assert.Equal(t, txmgranalytics.GroupByKey, groupBy)
+
+	_, err = txmgranalytics.ParseGroupBy("subscription")
+	require.ErrorContains(t, err, "invalid groupBy")
+}
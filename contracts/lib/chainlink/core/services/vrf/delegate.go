@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	stderrors "errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -40,6 +41,16 @@ type Delegate struct {
 	legacyChains legacyevm.LegacyChainContainer
 	lggr         logger.Logger
 	mailMon      *mailbox.Monitor
+	refulfiller  *refulfiller
+	reconfigurer *reconfigurer
+	// bhs and bhf run the blockhash store and block header feeders declared by VRF jobs.
+	bhs job.Delegate
+	bhf job.Delegate
+	// remoteProver is set when the VRF keys are held by a remote prover rather than the keystore.
+	remoteProver bool
+	// httpClient and httpTimeout are used for the URLs of job specs, e.g. the SLA webhook.
+	httpClient  *http.Client
+	httpTimeout time.Duration
 }
 
 func NewDelegate(
@@ -49,7 +60,12 @@ func NewDelegate(
 	porm pipeline.ORM,
 	legacyChains legacyevm.LegacyChainContainer,
 	lggr logger.Logger,
-	mailMon *mailbox.Monitor) *Delegate {
+	mailMon *mailbox.Monitor,
+	bhs job.Delegate,
+	bhf job.Delegate,
+	remoteProver bool,
+	httpClient *http.Client,
+	httpTimeout time.Duration) *Delegate {
 	return &Delegate{
 		ds:           ds,
 		ks:           ks,
@@ -58,9 +74,26 @@ func NewDelegate(
 		legacyChains: legacyChains,
 		lggr:         lggr.Named("VRF"),
 		mailMon:      mailMon,
+		refulfiller:  newRefulfiller(),
+		reconfigurer: newReconfigurer(ks.Eth(), legacyChains),
+		bhs:          bhs,
+		bhf:          bhf,
+		remoteProver: remoteProver,
+		httpClient:   httpClient,
+		httpTimeout:  httpTimeout,
 	}
 }
 
+// Refulfiller returns the Refulfiller for the VRF jobs run by this delegate.
+func (d *Delegate) Refulfiller() Refulfiller {
+	return d.refulfiller
+}
+
+// Reconfigurer returns the Reconfigurer for the VRF jobs run by this delegate.
+func (d *Delegate) Reconfigurer() Reconfigurer {
+	return d.reconfigurer
+}
+
 func (d *Delegate) JobType() job.Type {
 	return job.VRF
 }
@@ -155,6 +188,10 @@ func (d *Delegate) ServicesForSpec(ctx context.Context, jb job.Job) ([]job.Servi
 			if err2 := CheckFromAddressMaxGasPrices(jb, chain.Config().EVM().GasEstimator().PriceMaxKey); err != nil {
 				return nil, err2
 			}
+
+			if err2 := CheckGasLanes(ctx, jb, d.localVRFKeys(), d.ks.Eth(), chain.Config().EVM().GasEstimator().PriceMaxKey); err2 != nil {
+				return nil, err2
+			}
 			if vrfOwner != nil {
 				return nil, errors.New("VRF Owner is not supported for VRF V2 Plus")
 			}
@@ -179,28 +216,33 @@ func (d *Delegate) ServicesForSpec(ctx context.Context, jb job.Job) ([]job.Servi
 				return nil, errors.Wrap(err2, "NewAggregatorV3Interface")
 			}
 
-			return []job.ServiceCtx{
-				v2.New(
-					chain.Config().EVM(),
-					chain.Config().EVM().GasEstimator(),
-					lV2Plus,
-					chain,
-					chain.ID(),
-					d.ds,
-					v2.NewCoordinatorV2_5(coordinatorV2Plus),
-					batchCoordinatorV2,
-					vrfOwner,
-					aggregator,
-					d.pr,
-					d.ks.Eth(),
-					jb,
-					func() {},
-					// the lookback in the deduper must be >= the lookback specified for the log poller
-					// otherwise we will end up re-delivering logs that were already delivered.
-					vrfcommon.NewInflightCache(int(chain.Config().EVM().FinalityDepth())),
-					vrfcommon.NewLogDeduper(int(chain.Config().EVM().FinalityDepth())),
-				),
-			}, nil
+			listener := v2.New(
+				chain.Config().EVM(),
+				chain.Config().EVM().GasEstimator(),
+				lV2Plus,
+				chain,
+				chain.ID(),
+				d.ds,
+				v2.NewCoordinatorV2_5(coordinatorV2Plus),
+				batchCoordinatorV2,
+				vrfOwner,
+				aggregator,
+				d.pr,
+				d.ks.Eth(),
+				jb,
+				func() {},
+				// the lookback in the deduper must be >= the lookback specified for the log poller
+				// otherwise we will end up re-delivering logs that were already delivered.
+				vrfcommon.NewInflightCache(int(chain.Config().EVM().FinalityDepth())),
+				vrfcommon.NewLogDeduper(int(chain.Config().EVM().FinalityDepth())),
+				d.httpClient,
+				d.httpTimeout,
+			)
+			return d.withFeeders(ctx, jb, vrfcommon.V2Plus, l.Name(), []job.ServiceCtx{
+				listener,
+				&refulfillerRegistration{jb: jb, listener: listener.(v2.Refulfiller), refulfiller: d.refulfiller},
+				&reconfigurerRegistration{jobID: jb.ID, listener: listener.(v2.Reconfigurer), reconfigurer: d.reconfigurer},
+			})
 		}
 		if _, ok := task.(*pipeline.VRFTaskV2); ok {
 			if err2 := CheckFromAddressesExist(ctx, jb, d.ks.Eth()); err != nil {
@@ -215,6 +257,10 @@ func (d *Delegate) ServicesForSpec(ctx context.Context, jb job.Job) ([]job.Servi
 				return nil, err2
 			}
 
+			if err2 := CheckGasLanes(ctx, jb, d.localVRFKeys(), d.ks.Eth(), chain.Config().EVM().GasEstimator().PriceMaxKey); err2 != nil {
+				return nil, err2
+			}
+
 			// Get the LINKETHFEED address with retries
 			// This is needed because the RPC endpoint may be down so we need to
 			// switch over to another one.
@@ -234,7 +280,7 @@ func (d *Delegate) ServicesForSpec(ctx context.Context, jb job.Job) ([]job.Servi
 				lV2.Infow("Running without VRFOwnerAddress set on the spec")
 			}
 
-			return []job.ServiceCtx{v2.New(
+			listener := v2.New(
 				chain.Config().EVM(),
 				chain.Config().EVM().GasEstimator(),
 				lV2,
@@ -253,8 +299,14 @@ func (d *Delegate) ServicesForSpec(ctx context.Context, jb job.Job) ([]job.Servi
 				// otherwise we will end up re-delivering logs that were already delivered.
 				vrfcommon.NewInflightCache(int(chain.Config().EVM().FinalityDepth())),
 				vrfcommon.NewLogDeduper(int(chain.Config().EVM().FinalityDepth())),
-			),
-			}, nil
+				d.httpClient,
+				d.httpTimeout,
+			)
+			return d.withFeeders(ctx, jb, vrfcommon.V2, l.Name(), []job.ServiceCtx{
+				listener,
+				&refulfillerRegistration{jb: jb, listener: listener.(v2.Refulfiller), refulfiller: d.refulfiller},
+				&reconfigurerRegistration{jobID: jb.ID, listener: listener.(v2.Reconfigurer), reconfigurer: d.reconfigurer},
+			})
 		}
 		if _, ok := task.(*pipeline.VRFTask); ok {
 			return []job.ServiceCtx{&v1.Listener{
@@ -310,6 +362,45 @@ func CheckFromAddressMaxGasPrices(jb job.Job, keySpecificMaxGas keySpecificMaxGa
 	return
 }
 
+// localVRFKeys returns the keystore holding the VRF keys of the jobs, or nil if they are held
+// by a remote prover.
+func (d *Delegate) localVRFKeys() keystore.VRF {
+	if d.remoteProver {
+		return nil
+	}
+	return d.ks.VRF()
+}
+
+// CheckGasLanes runs the checks of the spec's fromAddresses and gasLanePrice against each
+// of its gasLanes, and returns an error if the proving key of a lane is not in vrfks. The
+// proving keys are not checked if vrfks is nil, as when they are held by a remote prover.
+func CheckGasLanes(ctx context.Context, jb job.Job, vrfks keystore.VRF, gethks keystore.Eth, keySpecificMaxGas keySpecificMaxGasFn) (err error) {
+	for _, lane := range jb.VRFSpec.GasLanes {
+		if vrfks != nil {
+			if _, err2 := vrfks.Get(lane.PublicKey.String()); err2 != nil {
+				err = stderrors.Join(err, fmt.Errorf("gas lane %s: %w", lane.PublicKey.String(), err2))
+				continue
+			}
+		}
+		// The lane is checked as the spec would be if it were the lane's only one.
+		laneSpec := *jb.VRFSpec
+		laneSpec.FromAddresses = lane.FromAddresses
+		laneSpec.GasLanePrice = lane.GasLanePrice
+		laneJob := jb
+		laneJob.VRFSpec = &laneSpec
+
+		laneErr := CheckFromAddressesExist(ctx, laneJob, gethks)
+		if !FromAddressMaxGasPricesAllEqual(laneJob, keySpecificMaxGas) {
+			laneErr = stderrors.Join(laneErr, errors.New("key-specific max gas prices of all fromAddresses are not equal, please set them to equal values"))
+		}
+		laneErr = stderrors.Join(laneErr, CheckFromAddressMaxGasPrices(laneJob, keySpecificMaxGas))
+		if laneErr != nil {
+			err = stderrors.Join(err, fmt.Errorf("gas lane %s: %w", lane.PublicKey.String(), laneErr))
+		}
+	}
+	return
+}
+
 type keySpecificMaxGasFn func(common.Address) *assets.Wei
 
 // FromAddressMaxGasPricesAllEqual returns true if and only if all the specified from
@@ -0,0 +1,205 @@
+package vrf
+
+import (
+	"context"
+	stderrors "errors"
+	"slices"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// withFeeders adds the blockhash store and block header feeders declared by a VRF v2 or v2plus
+// job to the services of its listener. A job that declares feeders runs them all as a single
+// jobService, so that it has a single health report.
+func (d *Delegate) withFeeders(ctx context.Context, jb job.Job, version vrfcommon.Version, name string, srvs []job.ServiceCtx) ([]job.ServiceCtx, error) {
+	if vrfcommon.BlockhashStoreSpec(jb.VRFSpec, version) == nil && vrfcommon.BlockHeaderFeederSpec(jb.VRFSpec, version) == nil {
+		return srvs, nil
+	}
+
+	js := &jobService{
+		name:         name,
+		jobID:        jb.ID,
+		reconfigurer: d.reconfigurer,
+		spec:         jb.VRFSpec,
+		version:      version,
+		newFeeders: func(ctx context.Context, spec *job.VRFSpec) ([]namedService, error) {
+			updated := jb
+			updated.VRFSpec = spec
+			return d.newFeeders(ctx, updated, version)
+		},
+	}
+	for _, srv := range srvs {
+		js.srvs = append(js.srvs, namedService{srv: srv})
+	}
+	var err error
+	if js.feeders, err = d.newFeeders(ctx, jb, version); err != nil {
+		return nil, err
+	}
+	return []job.ServiceCtx{js}, nil
+}
+
+// newFeeders returns the services of the feeders declared by a VRF v2 or v2plus job.
+func (d *Delegate) newFeeders(ctx context.Context, jb job.Job, version vrfcommon.Version) ([]namedService, error) {
+	var feeders []namedService
+	if bhsSpec := vrfcommon.BlockhashStoreSpec(jb.VRFSpec, version); bhsSpec != nil {
+		if d.bhs == nil {
+			return nil, errors.New("blockhashStore is not supported by this node")
+		}
+		srvs, err := d.bhs.ServicesForSpec(ctx, job.Job{
+			ID:                 jb.ID,
+			ExternalJobID:      jb.ExternalJobID,
+			Name:               jb.Name,
+			Type:               job.BlockhashStore,
+			BlockhashStoreSpec: bhsSpec,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "blockhashStore")
+		}
+		for _, srv := range srvs {
+			feeders = append(feeders, namedService{name: "BlockhashStoreFeeder", srv: srv})
+		}
+	}
+	if bhfSpec := vrfcommon.BlockHeaderFeederSpec(jb.VRFSpec, version); bhfSpec != nil {
+		if d.bhf == nil {
+			return nil, errors.New("blockHeaderFeeder is not supported by this node")
+		}
+		srvs, err := d.bhf.ServicesForSpec(ctx, job.Job{
+			ID:                    jb.ID,
+			ExternalJobID:         jb.ExternalJobID,
+			Name:                  jb.Name,
+			Type:                  job.BlockHeaderFeeder,
+			BlockHeaderFeederSpec: bhfSpec,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "blockHeaderFeeder")
+		}
+		for _, srv := range srvs {
+			feeders = append(feeders, namedService{name: "BlockHeaderFeeder", srv: srv})
+		}
+	}
+	return feeders, nil
+}
+
+// feederFromAddresses returns the addresses the feeders of a VRF job send from, which are the
+// fromAddresses of the job for the feeders that don't set their own.
+func feederFromAddresses(spec *job.VRFSpec, version vrfcommon.Version) (bhs, bhf []evmtypes.EIP55Address) {
+	if bhsSpec := vrfcommon.BlockhashStoreSpec(spec, version); bhsSpec != nil {
+		bhs = bhsSpec.FromAddresses
+	}
+	if bhfSpec := vrfcommon.BlockHeaderFeederSpec(spec, version); bhfSpec != nil {
+		bhf = bhfSpec.FromAddresses
+	}
+	return bhs, bhf
+}
+
+// namedService is a service of a jobService. Services that don't report their own health are
+// reported under their name.
+type namedService struct {
+	name string
+	srv  job.ServiceCtx
+}
+
+// jobService runs the listener of a VRF job together with its feeders. Its services are started
+// in order and closed in reverse order, the feeders last. The feeders are rebuilt when an update
+// of the job changes the addresses they send from.
+type jobService struct {
+	services.StateMachine
+	name string
+	srvs []namedService
+	ms   services.MultiStart
+
+	jobID int32
+	// reconfigurer is nil if the feeders can't be updated.
+	reconfigurer *reconfigurer
+	version      vrfcommon.Version
+	newFeeders   func(ctx context.Context, spec *job.VRFSpec) ([]namedService, error)
+
+	feedersMu sync.RWMutex
+	spec      *job.VRFSpec
+	feeders   []namedService
+	feedersMS services.MultiStart
+}
+
+var _ services.HealthReporter = (*jobService)(nil)
+
+func (s *jobService) Start(ctx context.Context) error {
+	return s.StartOnce(s.name, func() error {
+		if err := s.ms.Start(ctx, startClosers(s.srvs)...); err != nil {
+			return err
+		}
+		s.feedersMu.Lock()
+		defer s.feedersMu.Unlock()
+		if err := s.feedersMS.Start(ctx, startClosers(s.feeders)...); err != nil {
+			return stderrors.Join(err, s.ms.Close())
+		}
+		if s.reconfigurer != nil {
+			s.reconfigurer.addFeeders(s.jobID, s)
+		}
+		return nil
+	})
+}
+
+func (s *jobService) Close() error {
+	return s.StopOnce(s.name, func() error {
+		if s.reconfigurer != nil {
+			s.reconfigurer.removeFeeders(s.jobID)
+		}
+		s.feedersMu.Lock()
+		defer s.feedersMu.Unlock()
+		return stderrors.Join(s.feedersMS.Close(), s.ms.Close())
+	})
+}
+
+func (s *jobService) Name() string { return s.name }
+
+// HealthReport merges the health of the job's listener and feeders.
+func (s *jobService) HealthReport() map[string]error {
+	s.feedersMu.RLock()
+	defer s.feedersMu.RUnlock()
+	report := map[string]error{s.Name(): s.Healthy()}
+	for _, ns := range append(slices.Clip(s.srvs), s.feeders...) {
+		switch srv := ns.srv.(type) {
+		case services.HealthReporter:
+			services.CopyHealth(report, srv.HealthReport())
+		case interface{ Healthy() error }:
+			if ns.name != "" {
+				report[s.Name()+"."+ns.name] = srv.Healthy()
+			}
+		}
+	}
+	return report
+}
+
+// reconfigureFeeders restarts the feeders with the updated spec of the job, if it changes the
+// addresses they send from. The feeders keep running as they are if the new ones can't be
+// built.
+func (s *jobService) reconfigureFeeders(ctx context.Context, spec *job.VRFSpec) error {
+	s.feedersMu.Lock()
+	defer s.feedersMu.Unlock()
+	oldBHS, oldBHF := feederFromAddresses(s.spec, s.version)
+	newBHS, newBHF := feederFromAddresses(spec, s.version)
+	if slices.Equal(oldBHS, newBHS) && slices.Equal(oldBHF, newBHF) {
+		return nil
+	}
+	feeders, err := s.newFeeders(ctx, spec)
+	if err != nil {
+		return err
+	}
+	closeErr := s.feedersMS.Close()
+	s.spec, s.feeders, s.feedersMS = spec, feeders, services.MultiStart{}
+	return stderrors.Join(closeErr, s.feedersMS.Start(ctx, startClosers(s.feeders)...))
+}
+
+func startClosers(srvs []namedService) []services.StartClose {
+	scs := make([]services.StartClose, len(srvs))
+	for i, ns := range srvs {
+		scs[i] = ns.srv
+	}
+	return scs
+}
@@ -0,0 +1,141 @@
+package vrf
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/services"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+type fakeService struct {
+	services.StateMachine
+	name     string
+	events   *[]string
+	startErr error
+	reporter bool
+}
+
+func (f *fakeService) Start(context.Context) error {
+	*f.events = append(*f.events, "start "+f.name)
+	if f.startErr != nil {
+		return f.startErr
+	}
+	return f.StartOnce(f.name, func() error { return nil })
+}
+
+func (f *fakeService) Close() error {
+	*f.events = append(*f.events, "close "+f.name)
+	return f.StopOnce(f.name, func() error { return nil })
+}
+
+// fakeReporter is a fakeService that reports its own health, as the listener does.
+type fakeReporter struct {
+	*fakeService
+}
+
+func (f fakeReporter) Name() string { return f.name }
+
+func (f fakeReporter) HealthReport() map[string]error {
+	return map[string]error{f.name: f.Healthy()}
+}
+
+func TestJobService(t *testing.T) {
+	t.Parallel()
+
+	newJobService := func(events *[]string, feederErr error) *jobService {
+		return &jobService{
+			name: "VRF.job",
+			srvs: []namedService{{srv: fakeReporter{&fakeService{name: "listener", events: events}}}},
+			feeders: []namedService{
+				{srv: &fakeService{name: "bhs", events: events}, name: "BlockhashStoreFeeder"},
+				{srv: &fakeService{name: "bhf", events: events, startErr: feederErr}, name: "BlockHeaderFeeder"},
+			},
+		}
+	}
+
+	t.Run("starts in order and closes in reverse order", func(t *testing.T) {
+		var events []string
+		s := newJobService(&events, nil)
+		var _ job.ServiceCtx = s
+
+		require.NoError(t, s.Start(testutils.Context(t)))
+		report := s.HealthReport()
+		assert.Len(t, report, 4)
+		for name, err := range report {
+			assert.NoError(t, err, name)
+		}
+		assert.Contains(t, report, "listener")
+		assert.Contains(t, report, "VRF.job.BlockhashStoreFeeder")
+		assert.Contains(t, report, "VRF.job.BlockHeaderFeeder")
+
+		require.NoError(t, s.Close())
+		assert.Equal(t, []string{"start listener", "start bhs", "start bhf", "close bhf", "close bhs", "close listener"}, events)
+		assert.Error(t, s.HealthReport()["VRF.job"])
+	})
+
+	t.Run("closes the started services when a feeder fails to start", func(t *testing.T) {
+		var events []string
+		s := newJobService(&events, errors.New("boom"))
+
+		require.ErrorContains(t, s.Start(testutils.Context(t)), "boom")
+		assert.Equal(t, []string{"start listener", "start bhs", "start bhf", "close bhs", "close listener"}, events)
+	})
+}
+
+func TestJobService_ReconfigureFeeders(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	fromAddress := testutils.NewAddress()
+	feederFromAddress := testutils.NewAddress()
+	spec := &job.VRFSpec{
+		CoordinatorAddress: evmtypes.EIP55AddressFromAddress(testutils.NewAddress()),
+		FromAddresses:      []evmtypes.EIP55Address{evmtypes.EIP55AddressFromAddress(fromAddress)},
+		BlockhashStore:     &job.VRFBlockhashStoreConfig{},
+		BlockHeaderFeeder: &job.VRFBlockHeaderFeederConfig{
+			FromAddresses: []evmtypes.EIP55Address{evmtypes.EIP55AddressFromAddress(feederFromAddress)},
+		},
+	}
+
+	var events []string
+	builds := 0
+	s := &jobService{
+		name:    "VRF.job",
+		srvs:    []namedService{{srv: &fakeService{name: "listener", events: &events}}},
+		spec:    spec,
+		version: vrfcommon.V2,
+		feeders: []namedService{{srv: &fakeService{name: "bhs", events: &events}, name: "BlockhashStoreFeeder"}},
+		newFeeders: func(_ context.Context, spec *job.VRFSpec) ([]namedService, error) {
+			builds++
+			bhs, _ := feederFromAddresses(spec, vrfcommon.V2)
+			return []namedService{{srv: &fakeService{name: "bhs " + bhs[0].String(), events: &events}, name: "BlockhashStoreFeeder"}}, nil
+		},
+	}
+	require.NoError(t, s.Start(ctx))
+	t.Cleanup(func() { assert.NoError(t, s.Close()) })
+
+	t.Run("keeps the feeders if their fromAddresses are unchanged", func(t *testing.T) {
+		updated := *spec
+		updated.ChunkSize = 10
+		require.NoError(t, s.reconfigureFeeders(ctx, &updated))
+		assert.Zero(t, builds)
+	})
+
+	t.Run("restarts the feeders that send from the job's fromAddresses", func(t *testing.T) {
+		newFromAddress := evmtypes.EIP55AddressFromAddress(testutils.NewAddress())
+		updated := *spec
+		updated.FromAddresses = []evmtypes.EIP55Address{newFromAddress}
+		require.NoError(t, s.reconfigureFeeders(ctx, &updated))
+		assert.Equal(t, 1, builds)
+		assert.Equal(t, []string{"start listener", "start bhs", "close bhs", "start bhs " + newFromAddress.String()}, events)
+		assert.Contains(t, s.HealthReport(), "VRF.job.BlockhashStoreFeeder")
+	})
+}
@@ -0,0 +1,59 @@
+// Package prover provides the backends that generate VRF proofs for the vrf, vrfv2
+// and vrfv2plus pipeline tasks.
+//
+// By default proofs are generated by the node's keystore. A RemoteProver instead asks
+// a separate process over gRPC, so the VRF secret keys never have to be stored in the
+// node's database. That process serves the keys it holds with NewServer.
+package prover
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+)
+
+var (
+	_ pipeline.VRFProver = (*KeystoreProver)(nil)
+	_ pipeline.VRFProver = (*InMemoryProver)(nil)
+)
+
+// KeystoreProver generates proofs with the VRF keys of the node's keystore.
+type KeystoreProver struct {
+	ks keystore.VRF
+}
+
+func NewKeystoreProver(ks keystore.VRF) *KeystoreProver {
+	return &KeystoreProver{ks: ks}
+}
+
+func (p *KeystoreProver) GenerateProof(_ context.Context, pubKey secp256k1.PublicKey, seed *big.Int) (vrfkey.Proof, error) {
+	return p.ks.GenerateProof(pubKey.String(), seed)
+}
+
+// InMemoryProver generates proofs with a fixed set of VRF keys held in memory. It is
+// meant for a standalone prover process, which loads its keys at startup and serves
+// them with NewServer.
+type InMemoryProver struct {
+	keys map[secp256k1.PublicKey]vrfkey.KeyV2
+}
+
+func NewInMemoryProver(keys ...vrfkey.KeyV2) *InMemoryProver {
+	p := &InMemoryProver{keys: make(map[secp256k1.PublicKey]vrfkey.KeyV2, len(keys))}
+	for _, key := range keys {
+		p.keys[key.PublicKey] = key
+	}
+	return p
+}
+
+func (p *InMemoryProver) GenerateProof(_ context.Context, pubKey secp256k1.PublicKey, seed *big.Int) (vrfkey.Proof, error) {
+	key, ok := p.keys[pubKey]
+	if !ok {
+		return vrfkey.Proof{}, fmt.Errorf("%w with public key %s", keystore.ErrMissingVRFKey, pubKey)
+	}
+	return key.GenerateProof(seed)
+}
@@ -0,0 +1,84 @@
+package prover_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/prover"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/prover/provertest"
+)
+
+func TestKeystoreProver(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	ks := cltest.NewKeyStore(t, pgtest.NewSqlxDB(t)).VRF()
+	key, err := ks.Create(ctx)
+	require.NoError(t, err)
+
+	p := prover.NewKeystoreProver(ks)
+	pr, err := p.GenerateProof(ctx, key.PublicKey, big.NewInt(42))
+	require.NoError(t, err)
+	valid, err := pr.VerifyVRFProof()
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = p.GenerateProof(ctx, vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1)).PublicKey, big.NewInt(42))
+	require.Error(t, err)
+}
+
+func TestRemoteProver(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	key := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1))
+	seed := big.NewInt(42)
+
+	remote, err := prover.NewRemoteProver(provertest.NewServer(t, key), false)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, remote.Close()) })
+
+	t.Run("generates the same proof as the key", func(t *testing.T) {
+		pr, err := remote.GenerateProof(ctx, key.PublicKey, seed)
+		require.NoError(t, err)
+
+		local, err := key.GenerateProof(seed)
+		require.NoError(t, err)
+		assert.Equal(t, local.Output, pr.Output)
+		assert.Equal(t, local.Seed, pr.Seed)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := remote.GenerateProof(ctx, vrfkey.MustNewV2XXXTestingOnly(big.NewInt(2)).PublicKey, seed)
+		require.ErrorContains(t, err, keystore.ErrMissingVRFKey.Error())
+	})
+
+	t.Run("rejects proofs for another key", func(t *testing.T) {
+		other := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(3))
+		liar, err := prover.NewRemoteProver(provertest.NewServerWithProver(t, wrongKeyProver{key: other}), false)
+		require.NoError(t, err)
+		t.Cleanup(func() { assert.NoError(t, liar.Close()) })
+
+		_, err = liar.GenerateProof(ctx, key.PublicKey, seed)
+		require.ErrorIs(t, err, prover.ErrInvalidProof)
+	})
+}
+
+// wrongKeyProver generates every proof with the same key, whatever key is requested.
+type wrongKeyProver struct {
+	key vrfkey.KeyV2
+}
+
+func (p wrongKeyProver) GenerateProof(_ context.Context, _ secp256k1.PublicKey, seed *big.Int) (vrfkey.Proof, error) {
+	return p.key.GenerateProof(seed)
+}
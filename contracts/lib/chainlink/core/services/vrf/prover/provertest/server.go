@@ -0,0 +1,34 @@
+// Package provertest provides a stand-in remote VRF prover for tests.
+package provertest
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/prover"
+)
+
+// NewServer starts a prover service on localhost, without TLS, that generates proofs
+// with the given keys. It returns the address to configure the RemoteProver with, and
+// is stopped when the test ends.
+func NewServer(t testing.TB, keys ...vrfkey.KeyV2) string {
+	return NewServerWithProver(t, prover.NewInMemoryProver(keys...))
+}
+
+// NewServerWithProver is like NewServer, but serves an arbitrary prover, e.g. one that
+// returns bad proofs.
+func NewServerWithProver(t testing.TB, p pipeline.VRFProver) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := prover.NewServer(p)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
@@ -0,0 +1,114 @@
+package prover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/proof"
+)
+
+// ErrInvalidProof is returned when a remote prover returns a proof that does not verify
+// against the requested public key and seed.
+var ErrInvalidProof = errors.New("remote prover returned an invalid proof")
+
+var (
+	_ pipeline.VRFProver  = (*RemoteProver)(nil)
+	_ services.ServiceCtx = (*RemoteProver)(nil)
+)
+
+// RemoteProver generates proofs by calling a prover service over gRPC. Every proof it
+// receives is verified before it is used, so a faulty or compromised prover can't make
+// the node submit bad fulfillments.
+type RemoteProver struct {
+	conn *grpc.ClientConn
+}
+
+// NewRemoteProver returns a RemoteProver for the prover service at url. The connection
+// is established lazily, on the first proof.
+func NewRemoteProver(url string, tlsEnabled bool) (*RemoteProver, error) {
+	if url == "" {
+		return nil, errors.New("remote prover URL is required")
+	}
+
+	opts := []grpc.DialOption{grpc.WithDefaultCallOptions(grpc.ForceCodec(jsonCodec{}))}
+	if tlsEnabled {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(nil)))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	conn, err := grpc.NewClient(url, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote prover at %s: %w", url, err)
+	}
+	return &RemoteProver{conn: conn}, nil
+}
+
+func (p *RemoteProver) GenerateProof(ctx context.Context, pubKey secp256k1.PublicKey, seed *big.Int) (vrfkey.Proof, error) {
+	req := &generateProofRequest{PublicKey: pubKey[:], Seed: (*hexutil.Big)(seed)}
+	resp := new(generateProofResponse)
+	if err := p.conn.Invoke(ctx, generateProofMethod, req, resp); err != nil {
+		return vrfkey.Proof{}, fmt.Errorf("remote prover failed to generate proof: %w", err)
+	}
+
+	pr, err := proof.UnmarshalSolidityProof(resp.Proof)
+	if err != nil {
+		return vrfkey.Proof{}, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	if err = verifyProof(pr, pubKey, seed); err != nil {
+		return vrfkey.Proof{}, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+	}
+	return pr, nil
+}
+
+func verifyProof(pr vrfkey.Proof, pubKey secp256k1.PublicKey, seed *big.Int) error {
+	point, err := pubKey.Point()
+	if err != nil {
+		return err
+	}
+	if !pr.PublicKey.Equal(point) {
+		return fmt.Errorf("proof is for public key %s, expected %s", pr.PublicKey, pubKey)
+	}
+	if pr.Seed.Cmp(seed) != 0 {
+		return fmt.Errorf("proof is for seed %s, expected %s", pr.Seed, seed)
+	}
+	valid, err := pr.VerifyVRFProof()
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("proof does not verify")
+	}
+	return nil
+}
+
+func (p *RemoteProver) Start(context.Context) error {
+	return nil
+}
+
+func (p *RemoteProver) Close() error {
+	return p.conn.Close()
+}
+
+func (p *RemoteProver) Name() string {
+	return "VRFRemoteProver"
+}
+
+func (p *RemoteProver) Ready() error {
+	return nil
+}
+
+func (p *RemoteProver) HealthReport() map[string]error {
+	return map[string]error{p.Name(): nil}
+}
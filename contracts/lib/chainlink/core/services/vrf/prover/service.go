@@ -0,0 +1,106 @@
+package prover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/proof"
+)
+
+// The prover service has a single unary method. Messages are JSON encoded, so other
+// implementations of the service only need a gRPC server with a JSON codec:
+//
+//	service Prover {
+//	  // {"publicKey": "0x<compressed public key>", "seed": "0x<seed>"}
+//	  //   -> {"proof": "0x<proof, as marshaled for the solidity verifier>"}
+//	  rpc GenerateProof(GenerateProofRequest) returns (GenerateProofResponse);
+//	}
+const (
+	serviceName         = "vrf.prover.v1.Prover"
+	generateProofMethod = "/" + serviceName + "/GenerateProof"
+)
+
+type generateProofRequest struct {
+	PublicKey hexutil.Bytes `json:"publicKey"`
+	Seed      *hexutil.Big  `json:"seed"`
+}
+
+type generateProofResponse struct {
+	Proof hexutil.Bytes `json:"proof"`
+}
+
+// jsonCodec encodes the prover service messages as JSON.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*pipeline.VRFProver)(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "GenerateProof",
+		Handler:    generateProofHandler,
+	}},
+	Streams: []grpc.StreamDesc{},
+}
+
+// NewServer returns a gRPC server that generates proofs with the given prover. It is
+// typically run in a separate, hardened process, with an InMemoryProver holding the
+// VRF secret keys.
+func NewServer(p pipeline.VRFProver, opts ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(append(opts, grpc.ForceServerCodec(jsonCodec{}))...)
+	s.RegisterService(&serviceDesc, p)
+	return s
+}
+
+func generateProofHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	in := new(generateProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return generateProof(ctx, srv.(pipeline.VRFProver), req.(*generateProofRequest))
+	}
+	if interceptor == nil {
+		return handler(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: generateProofMethod}
+	return interceptor(ctx, in, info, handler)
+}
+
+func generateProof(ctx context.Context, p pipeline.VRFProver, req *generateProofRequest) (*generateProofResponse, error) {
+	if req.Seed == nil {
+		return nil, status.Error(codes.InvalidArgument, "seed is required")
+	}
+	pubKey, err := secp256k1.NewPublicKeyFromBytes(req.PublicKey)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	pr, err := p.GenerateProof(ctx, pubKey, req.Seed.ToInt())
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	marshaled, err := proof.MarshalForSolidityVerifier(&pr)
+	if err != nil {
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to marshal proof: %v", err))
+	}
+	return &generateProofResponse{Proof: marshaled[:]}, nil
+}
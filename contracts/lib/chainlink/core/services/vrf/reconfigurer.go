@@ -0,0 +1,159 @@
+package vrf
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"sync"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/chains/legacyevm"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	v2 "github.com/smartcontractkit/chainlink/v2/core/services/vrf/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// ErrInvalidSpecUpdate is returned when an update can't be applied to a VRF job without
+// recreating it.
+var ErrInvalidSpecUpdate = stderrors.New("invalid VRF spec update")
+
+// Reconfigurer updates VRF v2 and v2plus jobs in place, without restarting their listener.
+type Reconfigurer interface {
+	// Reconfigure validates tomlString as an update of the job, saves it through orm and
+	// applies it to the job's running listener, if any. Its feeders are restarted if they send
+	// from the job's fromAddresses and those changed. Only the vrfcommon.MutableVRFSpecFields
+	// can change. It returns the updated job.
+	Reconfigure(ctx context.Context, orm job.ORM, jobID int32, tomlString string) (job.Job, error)
+}
+
+var _ Reconfigurer = (*reconfigurer)(nil)
+
+type reconfigurer struct {
+	ks           keystore.Eth
+	legacyChains legacyevm.LegacyChainContainer
+
+	mu        sync.RWMutex
+	listeners map[int32]v2.Reconfigurer
+	feeders   map[int32]*jobService
+}
+
+func newReconfigurer(ks keystore.Eth, legacyChains legacyevm.LegacyChainContainer) *reconfigurer {
+	return &reconfigurer{
+		ks:           ks,
+		legacyChains: legacyChains,
+		listeners:    make(map[int32]v2.Reconfigurer),
+		feeders:      make(map[int32]*jobService),
+	}
+}
+
+func (r *reconfigurer) Reconfigure(ctx context.Context, orm job.ORM, jobID int32, tomlString string) (job.Job, error) {
+	jb, err := orm.FindJob(ctx, jobID)
+	if err != nil {
+		return jb, err
+	}
+	spec, err := vrfcommon.ValidatedVRFSpecUpdate(jb, tomlString)
+	if err != nil {
+		return jb, fmt.Errorf("%w: %w", ErrInvalidSpecUpdate, err)
+	}
+	jb.VRFSpec = &spec
+	if err = r.checkKeys(ctx, jb); err != nil {
+		return jb, fmt.Errorf("%w: %w", ErrInvalidSpecUpdate, err)
+	}
+	if err = orm.UpdateVRFSpec(ctx, jb.VRFSpec); err != nil {
+		return jb, err
+	}
+
+	r.mu.RLock()
+	listener, ok := r.listeners[jobID]
+	feeders, hasFeeders := r.feeders[jobID]
+	r.mu.RUnlock()
+	if ok {
+		listener.Reconfigure(spec)
+	}
+	if hasFeeders {
+		if err = feeders.reconfigureFeeders(ctx, jb.VRFSpec); err != nil {
+			return jb, fmt.Errorf("failed to restart the feeders of job %d: %w", jobID, err)
+		}
+	}
+	return jb, nil
+}
+
+// checkKeys runs the same checks of the fromAddresses and gasLanePrice as when the job starts.
+func (r *reconfigurer) checkKeys(ctx context.Context, jb job.Job) error {
+	pl, err := jb.PipelineSpec.ParsePipeline()
+	if err != nil {
+		return err
+	}
+	var isV2 bool
+	for _, task := range pl.Tasks {
+		switch task.(type) {
+		case *pipeline.VRFTaskV2, *pipeline.VRFTaskV2Plus:
+			isV2 = true
+		}
+	}
+	if !isV2 {
+		return stderrors.New("only VRF v2 and v2plus jobs can be updated in place")
+	}
+
+	chainService, err := r.legacyChains.Get(jb.VRFSpec.EVMChainID.String())
+	if err != nil {
+		return err
+	}
+	chain, ok := chainService.(legacyevm.Chain)
+	if !ok {
+		return fmt.Errorf("vrf is not available in LOOP Plugin mode: %w", stderrors.ErrUnsupported)
+	}
+	if err = CheckFromAddressesExist(ctx, jb, r.ks); err != nil {
+		return err
+	}
+	priceMaxKey := chain.Config().EVM().GasEstimator().PriceMaxKey
+	if !FromAddressMaxGasPricesAllEqual(jb, priceMaxKey) {
+		return stderrors.New("key-specific max gas prices of all fromAddresses are not equal, please set them to equal values")
+	}
+	return CheckFromAddressMaxGasPrices(jb, priceMaxKey)
+}
+
+func (r *reconfigurer) add(jobID int32, listener v2.Reconfigurer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners[jobID] = listener
+}
+
+func (r *reconfigurer) remove(jobID int32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.listeners, jobID)
+}
+
+func (r *reconfigurer) addFeeders(jobID int32, feeders *jobService) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.feeders[jobID] = feeders
+}
+
+func (r *reconfigurer) removeFeeders(jobID int32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.feeders, jobID)
+}
+
+// reconfigurerRegistration makes a running listener available to the Reconfigurer. It is
+// started after, and closed before, the listener it registers.
+type reconfigurerRegistration struct {
+	jobID        int32
+	listener     v2.Reconfigurer
+	reconfigurer *reconfigurer
+}
+
+var _ job.ServiceCtx = (*reconfigurerRegistration)(nil)
+
+func (s *reconfigurerRegistration) Start(context.Context) error {
+	s.reconfigurer.add(s.jobID, s.listener)
+	return nil
+}
+
+func (s *reconfigurerRegistration) Close() error {
+	s.reconfigurer.remove(s.jobID)
+	return nil
+}
@@ -0,0 +1,102 @@
+package vrf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	v2 "github.com/smartcontractkit/chainlink/v2/core/services/vrf/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// Refulfiller re-fulfills VRF v2 and v2plus requests on demand, through the listener
+// of the running job that serves the request's coordinator and key hash.
+type Refulfiller interface {
+	Refulfill(ctx context.Context, coordinator common.Address, requestID *big.Int, opts vrfcommon.RefulfillOpts) (vrfcommon.RefulfillResult, error)
+}
+
+var _ Refulfiller = (*refulfiller)(nil)
+
+type registeredListener struct {
+	coordinator common.Address
+	listener    v2.Refulfiller
+}
+
+type refulfiller struct {
+	mu        sync.RWMutex
+	listeners map[int32]registeredListener
+}
+
+func newRefulfiller() *refulfiller {
+	return &refulfiller{listeners: make(map[int32]registeredListener)}
+}
+
+// Refulfill tries every listener of the coordinator in job ID order, since only the one
+// whose key hash matches the request can find it.
+func (r *refulfiller) Refulfill(ctx context.Context, coordinator common.Address, requestID *big.Int, opts vrfcommon.RefulfillOpts) (vrfcommon.RefulfillResult, error) {
+	r.mu.RLock()
+	var jobIDs []int32
+	for jobID, rl := range r.listeners {
+		if rl.coordinator == coordinator {
+			jobIDs = append(jobIDs, jobID)
+		}
+	}
+	listeners := make([]v2.Refulfiller, 0, len(jobIDs))
+	slices.Sort(jobIDs)
+	for _, jobID := range jobIDs {
+		listeners = append(listeners, r.listeners[jobID].listener)
+	}
+	r.mu.RUnlock()
+
+	if len(listeners) == 0 {
+		return vrfcommon.RefulfillResult{}, fmt.Errorf("no running VRF v2 or v2plus job for coordinator %s", coordinator)
+	}
+	var errs error
+	for _, l := range listeners {
+		res, err := l.Refulfill(ctx, requestID, opts)
+		if errors.Is(err, vrfcommon.ErrRequestNotFound) {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		return res, err
+	}
+	return vrfcommon.RefulfillResult{}, errs
+}
+
+func (r *refulfiller) add(jobID int32, coordinator common.Address, listener v2.Refulfiller) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners[jobID] = registeredListener{coordinator: coordinator, listener: listener}
+}
+
+func (r *refulfiller) remove(jobID int32) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.listeners, jobID)
+}
+
+// refulfillerRegistration makes a running listener available to the Refulfiller. It is
+// started after, and closed before, the listener it registers.
+type refulfillerRegistration struct {
+	jb          job.Job
+	listener    v2.Refulfiller
+	refulfiller *refulfiller
+}
+
+var _ job.ServiceCtx = (*refulfillerRegistration)(nil)
+
+func (s *refulfillerRegistration) Start(context.Context) error {
+	s.refulfiller.add(s.jb.ID, s.jb.VRFSpec.CoordinatorAddress.Address(), s.listener)
+	return nil
+}
+
+func (s *refulfillerRegistration) Close() error {
+	s.refulfiller.remove(s.jb.ID)
+	return nil
+}
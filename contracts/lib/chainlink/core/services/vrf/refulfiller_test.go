@@ -0,0 +1,65 @@
+package vrf
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+type fakeRefulfiller struct {
+	jobID int32
+	found bool
+	calls int
+}
+
+func (f *fakeRefulfiller) Refulfill(_ context.Context, requestID *big.Int, opts vrfcommon.RefulfillOpts) (vrfcommon.RefulfillResult, error) {
+	f.calls++
+	if !f.found {
+		return vrfcommon.RefulfillResult{}, vrfcommon.ErrRequestNotFound
+	}
+	return vrfcommon.RefulfillResult{JobID: f.jobID, RequestID: requestID, DryRun: opts.DryRun}, nil
+}
+
+func TestRefulfiller(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	coordinator := utils.RandomAddress()
+	otherCoordinator := utils.RandomAddress()
+
+	r := newRefulfiller()
+	wrongKeyHash := &fakeRefulfiller{jobID: 1}
+	rightKeyHash := &fakeRefulfiller{jobID: 2, found: true}
+	otherJob := &fakeRefulfiller{jobID: 3, found: true}
+	r.add(1, coordinator, wrongKeyHash)
+	r.add(2, coordinator, rightKeyHash)
+	r.add(3, otherCoordinator, otherJob)
+
+	t.Run("finds the listener serving the request", func(t *testing.T) {
+		res, err := r.Refulfill(ctx, coordinator, big.NewInt(42), vrfcommon.RefulfillOpts{DryRun: true})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), res.JobID)
+		assert.True(t, res.DryRun)
+		assert.Equal(t, 1, wrongKeyHash.calls)
+		assert.Zero(t, otherJob.calls)
+	})
+
+	t.Run("no listener finds the request", func(t *testing.T) {
+		r.remove(2)
+		_, err := r.Refulfill(ctx, coordinator, big.NewInt(42), vrfcommon.RefulfillOpts{})
+		require.ErrorIs(t, err, vrfcommon.ErrRequestNotFound)
+	})
+
+	t.Run("no listener for coordinator", func(t *testing.T) {
+		_, err := r.Refulfill(ctx, common.Address{}, big.NewInt(42), vrfcommon.RefulfillOpts{})
+		require.ErrorContains(t, err, "no running VRF v2 or v2plus job")
+	})
+}
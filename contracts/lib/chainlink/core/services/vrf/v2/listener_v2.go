@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"math/big"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
@@ -83,6 +84,8 @@ func New(
 	reqAdded func(),
 	inflightCache vrfcommon.InflightCache,
 	fulfillmentDeduper *vrfcommon.LogDeduper,
+	httpClient *http.Client,
+	httpTimeout time.Duration,
 ) job.ServiceCtx {
 	return &listenerV2{
 		cfg:                   cfg,
@@ -105,6 +108,12 @@ func New(
 		aggregator:            aggregator,
 		inflightCache:         inflightCache,
 		fulfillmentLogDeduper: fulfillmentDeduper,
+		requestLifecycle:      vrfcommon.NewRequestLifecycleORM(ds),
+		scheduler:             newRequestScheduler(job.VRFSpec),
+		sla:                   newSLAMonitor(job.VRFSpec, httpClient, httpTimeout),
+		balanceForecasts:      vrfcommon.NewBalanceForecastORM(ds),
+		lowFundsWarned:        make(map[lowFundsKey]time.Duration),
+		requestBlocks:         newRequestBlocks(),
 	}
 }
 
@@ -154,6 +163,38 @@ type listenerV2 struct {
 	// inflightCache is a cache of in-flight requests, used to prevent
 	// re-processing of requests that are in-flight or already fulfilled.
 	inflightCache vrfcommon.InflightCache
+
+	// requestBlocks remembers the block of each pending request, to detect requests re-mined in
+	// another block after a reorg. Can be nil in tests.
+	requestBlocks *requestBlocks
+
+	// requestLifecycle persists the progress of every request this listener handles,
+	// so that it can be inspected after the fact. Can be nil in tests.
+	requestLifecycle vrfcommon.RequestLifecycleORM
+	// simulated buffers the simulation results of the current poll, which are written to the
+	// audit trail in one statement once the poll is done.
+	simulatedMu sync.Mutex
+	simulated   []vrfcommon.SimulatedRequest
+
+	// scheduler holds back requests over the job's per-consumer and per-subscription limits,
+	// and orders subscriptions by weight. Can be nil in tests.
+	scheduler *requestScheduler
+
+	// sla measures fulfillment latency and reports requests that breach the job's
+	// fulfillment SLA. Can be nil in tests.
+	sla *slaMonitor
+
+	// balanceForecasts persists the balance forecasts of the subscriptions the job serves,
+	// when it sets lowFundsWarningHorizons. Can be nil in tests.
+	balanceForecasts vrfcommon.BalanceForecastORM
+	// lowFundsWarned is the smallest horizon each subscription was last warned at. It is only
+	// used by the balance forecaster.
+	lowFundsWarned map[lowFundsKey]time.Duration
+
+	// specMu guards specUpdate, and the mutable fields of job.VRFSpec where they are read
+	// outside of the processing loop.
+	specMu     sync.RWMutex
+	specUpdate *job.VRFSpec
 }
 
 func (lsn *listenerV2) HealthReport() map[string]error {
@@ -202,6 +243,14 @@ func (lsn *listenerV2) Start(ctx context.Context) error {
 			}()
 		}
 
+		if len(lsn.job.VRFSpec.LowFundsWarningHorizons) > 0 && lsn.balanceForecasts != nil {
+			lsn.wg.Add(1)
+			go func() {
+				defer lsn.wg.Done()
+				lsn.runBalanceForecaster()
+			}()
+		}
+
 		// Log listener gathers request logs and processes them
 		lsn.wg.Add(1)
 		go func() {
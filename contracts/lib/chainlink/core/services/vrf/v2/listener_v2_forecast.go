@@ -0,0 +1,123 @@
+package v2
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// balanceForecastInterval is how often the listener refreshes the balance forecasts of the
+// subscriptions it serves.
+const balanceForecastInterval = time.Minute
+
+// lowFundsKey is a subscription and payment currency that low funds warnings are fired for.
+type lowFundsKey struct {
+	subID         string
+	nativePayment bool
+}
+
+// runBalanceForecaster periodically projects when each subscription the job fulfilled
+// requests for within its balanceForecastWindow runs out of funds, and warns when that is
+// within one of its lowFundsWarningHorizons, ahead of requests getting stuck for lack of funds.
+func (lsn *listenerV2) runBalanceForecaster() {
+	tick := time.NewTicker(balanceForecastInterval)
+	defer tick.Stop()
+	ctx, cancel := lsn.chStop.NewCtx()
+	defer cancel()
+	for {
+		select {
+		case <-lsn.chStop:
+			return
+		case <-tick.C:
+			lsn.forecastBalances(ctx, time.Now())
+		}
+	}
+}
+
+func (lsn *listenerV2) forecastBalances(ctx context.Context, now time.Time) {
+	spec := lsn.job.VRFSpec
+	burns, err := lsn.balanceForecasts.SubscriptionBurns(ctx, lsn.job.ID, now.Add(-spec.BalanceForecastWindow))
+	if err != nil {
+		lsn.l.Warnw("Failed to load subscription burns, skipping balance forecast", "err", err)
+		return
+	}
+
+	forecasts := make([]vrfcommon.SubscriptionForecast, 0, len(burns))
+	for _, burn := range burns {
+		balance, reserved, err := lsn.subscriptionFunds(ctx, burn.SubID, burn.NativePayment)
+		if err != nil {
+			lsn.l.Warnw("Failed to get subscription funds, skipping its balance forecast",
+				"err", err, "subID", burn.SubID, "nativePayment", burn.NativePayment)
+			continue
+		}
+		f := vrfcommon.ForecastSubscription(burn, balance, reserved, spec.BalanceForecastWindow, spec.LowFundsWarningHorizons, now)
+		f.JobID = lsn.job.ID
+		f.EVMChainID = lsn.chainID
+		f.CoordinatorAddress = lsn.coordinator.Address()
+		forecasts = append(forecasts, f)
+
+		vrfcommon.UpdateSubscriptionForecast(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), f, now)
+		lsn.warnLowFunds(f, now)
+	}
+
+	if err = lsn.balanceForecasts.ReplaceForecasts(ctx, lsn.job.ID, forecasts); err != nil {
+		lsn.l.Warnw("Failed to save subscription balance forecasts", "err", err)
+	}
+}
+
+// subscriptionFunds returns the on-chain balance of the subscription in the payment currency,
+// and how much of it is reserved for fulfillments still in flight.
+func (lsn *listenerV2) subscriptionFunds(ctx context.Context, subID *big.Int, nativePayment bool) (balance, reserved *big.Int, err error) {
+	sub, err := lsn.coordinator.GetSubscription(&bind.CallOpts{Context: ctx}, subID)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "get subscription")
+	}
+
+	var unreserved *big.Int
+	if nativePayment {
+		if sub.Version() != vrfcommon.V2Plus {
+			return nil, nil, errors.Errorf("native payment is not supported by vrf version %s", sub.Version())
+		}
+		balance = sub.NativeBalance()
+		unreserved, err = lsn.MaybeSubtractReservedEth(ctx, balance, lsn.chainID, subID, lsn.coordinator.Version())
+	} else {
+		balance = sub.Balance()
+		unreserved, err = lsn.MaybeSubtractReservedLink(ctx, balance, lsn.chainID, subID, lsn.coordinator.Version())
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return balance, new(big.Int).Sub(balance, unreserved), nil
+}
+
+// warnLowFunds warns once per horizon as a subscription gets closer to running out of funds,
+// and again once it was refunded past all horizons and gets close again.
+func (lsn *listenerV2) warnLowFunds(f vrfcommon.SubscriptionForecast, now time.Time) {
+	key := lowFundsKey{subID: f.SubID.String(), nativePayment: f.NativePayment}
+	if f.LowFundsHorizon == 0 {
+		delete(lsn.lowFundsWarned, key)
+		return
+	}
+	if warned, ok := lsn.lowFundsWarned[key]; ok && warned <= f.LowFundsHorizon {
+		return
+	}
+	lsn.lowFundsWarned[key] = f.LowFundsHorizon
+
+	timeToEmpty, _ := f.TimeToEmpty(now)
+	lsn.l.Warnw("VRF subscription is forecast to run out of funds",
+		"subID", key.subID,
+		"nativePayment", f.NativePayment,
+		"horizon", f.LowFundsHorizon.String(),
+		"timeToEmpty", timeToEmpty.String(),
+		"depletesAt", f.DepletesAt,
+		"balance", f.Balance.String(),
+		"reserved", f.Reserved.String(),
+		"burnRatePerHour", f.BurnRatePerHour.String(),
+		"fulfilledInWindow", f.Fulfilled)
+	vrfcommon.IncLowFundsWarnings(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), f.SubID, f.NativePayment, f.LowFundsHorizon)
+}
@@ -1,13 +1,16 @@
 package v2
 
 import (
+	stderrors "errors"
 	"math/big"
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/hex"
 	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
 )
 
@@ -72,6 +75,26 @@ func observeRequestSimDuration(jobName string, extJobID uuid.UUID, vrfVersion vr
 	}
 }
 
+// fulfillmentResult interprets the simulation of a fulfillRandomWords call, made by the pipeline
+// of a job in simulateFulfillment or against an RPC in SimulateRequest. output is the return value
+// of the call, i.e. the payment the coordinator would charge, and err the error of the simulation.
+// The error is joined with the reason the listener acts on: a blockhash missing from the store, a
+// proof the coordinator rejected, or a revert that is likely an underfunded subscription.
+func fulfillmentResult(output []byte, err error) (*big.Int, error) {
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "blockhash not found in store"):
+			return nil, stderrors.Join(err, errBlockhashNotInStore{})
+		case isProofVerificationError(err.Error()):
+			return nil, stderrors.Join(err, errProofVerificationFailed{})
+		case strings.Contains(err.Error(), "execution reverted"):
+			return nil, stderrors.Join(err, errPossiblyInsufficientFunds{})
+		}
+		return nil, err
+	}
+	return hex.ParseBig(hexutil.Encode(output)[2:])
+}
+
 func ptr[T any](t T) *T { return &t }
 
 func isProofVerificationError(errMsg string) bool {
@@ -0,0 +1,108 @@
+package v2
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// gasLane is a key hash served by the job: the key that proves its requests and the
+// addresses that fulfill them. All the fromAddresses of a lane have the same key specific
+// max gas price, which is the lane's gas price.
+type gasLane struct {
+	keyHash       common.Hash
+	publicKey     secp256k1.PublicKey
+	fromAddresses []common.Address
+}
+
+// laneRequests are the requests of a subscription routed to a gas lane.
+type laneRequests struct {
+	lane gasLane
+	reqs []pendingRequest
+}
+
+// lanes returns the gas lanes of the job: first the one of the spec's publicKey and
+// fromAddresses, then those of its gasLanes.
+func (lsn *listenerV2) lanes() []gasLane {
+	lsn.specMu.RLock()
+	defer lsn.specMu.RUnlock()
+	spec := lsn.job.VRFSpec
+	lanes := make([]gasLane, 0, len(spec.GasLanes)+1)
+	lanes = append(lanes, gasLane{
+		keyHash:       spec.PublicKey.MustHash(),
+		publicKey:     spec.PublicKey,
+		fromAddresses: addresses(spec.FromAddresses),
+	})
+	for _, l := range spec.GasLanes {
+		lanes = append(lanes, gasLane{
+			keyHash:       l.PublicKey.MustHash(),
+			publicKey:     l.PublicKey,
+			fromAddresses: addresses(l.FromAddresses),
+		})
+	}
+	return lanes
+}
+
+// keyHashes returns the key hashes of the job's gas lanes.
+func (lsn *listenerV2) keyHashes() []common.Hash {
+	var keyHashes []common.Hash
+	for _, l := range lsn.lanes() {
+		keyHashes = append(keyHashes, l.keyHash)
+	}
+	return keyHashes
+}
+
+// findLane returns the gas lane serving keyHash, ok is false if none of the lanes serves it.
+func findLane(lanes []gasLane, keyHash common.Hash) (lane gasLane, ok bool) {
+	for _, l := range lanes {
+		if l.keyHash == keyHash {
+			return l, true
+		}
+	}
+	return gasLane{}, false
+}
+
+// maxGasPrice returns the price the requests of the lane are simulated and fulfilled at.
+func (lsn *listenerV2) maxGasPrice(lane gasLane) *assets.Wei {
+	return lsn.feeCfg.PriceMaxKey(lane.fromAddresses[0])
+}
+
+// routeByLane splits the requests of a subscription by gas lane, in the order of the job's
+// lanes. Requests of key hashes the job doesn't serve are left out, the log poller filter
+// doesn't return them.
+func (lsn *listenerV2) routeByLane(reqs []pendingRequest) []laneRequests {
+	lanes := lsn.lanes()
+	byLane := make(map[common.Hash][]pendingRequest, len(lanes))
+	for _, req := range reqs {
+		byLane[req.req.KeyHash()] = append(byLane[req.req.KeyHash()], req)
+	}
+	var routed []laneRequests
+	for _, l := range lanes {
+		if len(byLane[l.keyHash]) > 0 {
+			routed = append(routed, laneRequests{lane: l, reqs: byLane[l.keyHash]})
+		}
+	}
+	return routed
+}
+
+// updateLaneQueueSizes reports the number of queued requests of each gas lane.
+func (lsn *listenerV2) updateLaneQueueSizes(pendingRequests []pendingRequest) {
+	byLane := make(map[common.Hash][]pendingRequest)
+	for _, req := range pendingRequests {
+		byLane[req.req.KeyHash()] = append(byLane[req.req.KeyHash()], req)
+	}
+	for _, keyHash := range lsn.keyHashes() {
+		vrfcommon.UpdateLaneQueueSize(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), keyHash, uniqueReqs(byLane[keyHash]))
+	}
+}
+
+func addresses(eip55Addresses []evmtypes.EIP55Address) []common.Address {
+	var addrs []common.Address
+	for _, a := range eip55Addresses {
+		addrs = append(addrs, a.Address())
+	}
+	return addrs
+}
@@ -0,0 +1,70 @@
+package v2
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+)
+
+func newLaneRequest(reqID int64, keyHash common.Hash) pendingRequest {
+	return pendingRequest{
+		req: NewV2RandomWordsRequested(&vrf_coordinator_v2.VRFCoordinatorV2RandomWordsRequested{
+			RequestId: big.NewInt(reqID),
+			KeyHash:   keyHash,
+			SubId:     1,
+		}),
+	}
+}
+
+func TestListenerV2_RouteByLane(t *testing.T) {
+	t.Parallel()
+
+	primaryKey := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1)).PublicKey
+	laneKey := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(2)).PublicKey
+	primaryFrom := evmtypes.EIP55AddressFromAddress(testutils.NewAddress())
+	laneFrom := evmtypes.EIP55AddressFromAddress(testutils.NewAddress())
+	lsn := &listenerV2{
+		job: job.Job{VRFSpec: &job.VRFSpec{
+			PublicKey:     primaryKey,
+			FromAddresses: []evmtypes.EIP55Address{primaryFrom},
+			GasLanes: job.VRFGasLanes{
+				{PublicKey: laneKey, FromAddresses: []evmtypes.EIP55Address{laneFrom}},
+			},
+		}},
+	}
+	primaryHash, laneHash := primaryKey.MustHash(), laneKey.MustHash()
+	assert.Equal(t, []common.Hash{primaryHash, laneHash}, lsn.keyHashes())
+
+	routed := lsn.routeByLane([]pendingRequest{
+		newLaneRequest(1, laneHash),
+		newLaneRequest(2, primaryHash),
+		newLaneRequest(3, laneHash),
+		newLaneRequest(4, common.Hash(testutils.Random32Byte())),
+	})
+	require.Len(t, routed, 2)
+
+	assert.Equal(t, primaryHash, routed[0].lane.keyHash)
+	assert.Equal(t, primaryKey, routed[0].lane.publicKey)
+	assert.Equal(t, []common.Address{primaryFrom.Address()}, routed[0].lane.fromAddresses)
+	assert.Equal(t, []int64{2}, requestIDs(routed[0].reqs))
+
+	assert.Equal(t, laneHash, routed[1].lane.keyHash)
+	assert.Equal(t, laneKey, routed[1].lane.publicKey)
+	assert.Equal(t, []common.Address{laneFrom.Address()}, routed[1].lane.fromAddresses)
+	assert.Equal(t, []int64{1, 3}, requestIDs(routed[1].reqs))
+
+	lane, ok := findLane(lsn.lanes(), laneHash)
+	require.True(t, ok)
+	assert.Equal(t, laneKey, lane.publicKey)
+	_, ok = findLane(lsn.lanes(), common.Hash(testutils.Random32Byte()))
+	assert.False(t, ok)
+}
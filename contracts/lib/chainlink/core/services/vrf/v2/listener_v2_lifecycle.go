@@ -0,0 +1,115 @@
+package v2
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// The helpers below persist the request lifecycle audit trail. Failures are logged
+// and otherwise ignored, since the audit trail must never hold up fulfillment.
+
+func (lsn *listenerV2) recordObserved(ctx context.Context, reqs []pendingRequest) {
+	if lsn.requestLifecycle == nil || len(reqs) == 0 {
+		return
+	}
+	observed := make([]vrfcommon.ObservedRequest, len(reqs))
+	for i, r := range reqs {
+		observed[i] = vrfcommon.ObservedRequest{
+			RequestID:        r.req.RequestID(),
+			SubID:            r.req.SubID(),
+			Sender:           r.req.Sender(),
+			TxHash:           r.req.Raw().TxHash,
+			BlockHash:        r.req.Raw().BlockHash,
+			BlockNumber:      r.req.Raw().BlockNumber,
+			ConfirmedAtBlock: r.confirmedAtBlock,
+			ObservedAt:       r.utcTimestamp,
+		}
+	}
+	if err := lsn.requestLifecycle.RecordObserved(ctx, lsn.job.ID, lsn.chainID, lsn.coordinator.Address(), observed); err != nil {
+		lsn.l.Warnw("Failed to record observed requests", "err", err)
+	}
+}
+
+// recordConfirmed returns the time each confirmed request was first confirmed, nil if the
+// audit trail is not available.
+func (lsn *listenerV2) recordConfirmed(ctx context.Context, confirmed map[string][]pendingRequest) map[string]time.Time {
+	if lsn.requestLifecycle == nil || len(confirmed) == 0 {
+		return nil
+	}
+	var reqIDs []*big.Int
+	for _, reqs := range confirmed {
+		for _, r := range reqs {
+			reqIDs = append(reqIDs, r.req.RequestID())
+		}
+	}
+	confirmedAt, err := lsn.requestLifecycle.RecordConfirmed(ctx, lsn.job.ID, reqIDs)
+	if err != nil {
+		lsn.l.Warnw("Failed to record confirmed requests", "err", err)
+	}
+	return confirmedAt
+}
+
+// recordSimulated buffers the simulation results of a poll, until flushSimulated writes them.
+func (lsn *listenerV2) recordSimulated(results []vrfPipelineResult) {
+	if lsn.requestLifecycle == nil || len(results) == 0 {
+		return
+	}
+	lsn.simulatedMu.Lock()
+	defer lsn.simulatedMu.Unlock()
+	for _, res := range results {
+		lsn.simulated = append(lsn.simulated, vrfcommon.SimulatedRequest{RequestID: res.req.req.RequestID(), Err: res.err})
+	}
+}
+
+func (lsn *listenerV2) flushSimulated(ctx context.Context) {
+	if lsn.requestLifecycle == nil {
+		return
+	}
+	lsn.simulatedMu.Lock()
+	sims := lsn.simulated
+	lsn.simulated = nil
+	lsn.simulatedMu.Unlock()
+	if err := lsn.requestLifecycle.RecordSimulated(ctx, lsn.job.ID, sims); err != nil {
+		lsn.l.Warnw("Failed to record simulated requests", "err", err, "count", len(sims))
+	}
+}
+
+func (lsn *listenerV2) recordEnqueued(ctx context.Context, reqIDs []*big.Int, ethTxID int64) {
+	if lsn.requestLifecycle == nil {
+		return
+	}
+	if err := lsn.requestLifecycle.RecordEnqueued(ctx, lsn.job.ID, reqIDs, ethTxID); err != nil {
+		lsn.l.Warnw("Failed to record enqueued requests", "err", err, "ethTxID", ethTxID)
+	}
+}
+
+func (lsn *listenerV2) recordFulfilled(ctx context.Context, fulfilled RandomWordsFulfilled) {
+	if lsn.requestLifecycle == nil {
+		return
+	}
+	if err := lsn.requestLifecycle.RecordFulfilled(ctx, lsn.job.ID, fulfilled.RequestID(), fulfilled.Raw().TxHash, fulfilled.Success(),
+		fulfilled.Payment(), fulfilled.NativePayment()); err != nil {
+		lsn.l.Warnw("Failed to record fulfilled request", "err", err, "reqID", fulfilled.RequestID())
+	}
+}
+
+func (lsn *listenerV2) recordDropped(ctx context.Context, reqID *big.Int, reason vrfcommon.DropReason) {
+	if lsn.requestLifecycle == nil {
+		return
+	}
+	if err := lsn.requestLifecycle.RecordDropped(ctx, lsn.job.ID, reqID, reason); err != nil {
+		lsn.l.Warnw("Failed to record dropped request", "err", err, "reqID", reqID)
+	}
+}
+
+func (lsn *listenerV2) recordReorged(ctx context.Context, reqID *big.Int) {
+	if lsn.requestLifecycle == nil {
+		return
+	}
+	if err := lsn.requestLifecycle.RecordReorged(ctx, lsn.job.ID, reqID); err != nil {
+		lsn.l.Warnw("Failed to record reorged request", "err", err, "reqID", reqID)
+	}
+}
@@ -1,7 +1,6 @@
 package v2
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -28,7 +27,17 @@ func (lsn *listenerV2) runLogListener(
 	var (
 		lastProcessedBlock int64
 		startingUp         = true
+		// reorgFrom is the first block removed by the reorgs that are still to be handled, or -1.
+		reorgFrom int64 = -1
+		reorgs    <-chan int64
 	)
+	if lp, ok := lsn.chain.LogPoller().(logpoller.ReorgSubscriber); ok {
+		var unsubscribe func()
+		reorgs, unsubscribe = lp.SubscribeToReorgs()
+		defer unsubscribe()
+	} else {
+		lsn.l.Warnw("Log poller does not notify of reorgs, fulfillments of reorged requests will revert on-chain")
+	}
 	filterName := lsn.getLogPollerFilterName()
 	ctx, cancel := lsn.chStop.NewCtx()
 	defer cancel()
@@ -36,9 +45,27 @@ func (lsn *listenerV2) runLogListener(
 		select {
 		case <-lsn.chStop:
 			return
+		case blockAfterLCA := <-reorgs:
+			if reorgFrom < 0 || blockAfterLCA < reorgFrom {
+				reorgFrom = blockAfterLCA
+			}
+			if err := lsn.handleReorg(ctx, reorgFrom); err != nil {
+				lsn.l.Errorw("error handling reorg, retrying", "err", err, "blockAfterLCA", reorgFrom)
+				continue
+			}
+			reorgFrom = -1
 		case <-ticker.C:
 			start := time.Now()
 			lsn.l.Debugw("log listener loop")
+			lsn.applySpecUpdate()
+
+			if reorgFrom >= 0 {
+				if err := lsn.handleReorg(ctx, reorgFrom); err != nil {
+					lsn.l.Errorw("error handling reorg, retrying", "err", err, "blockAfterLCA", reorgFrom)
+				} else {
+					reorgFrom = -1
+				}
+			}
 
 			// If filter has not already been successfully registered, register it.
 			if !lsn.chain.LogPoller().HasFilter(filterName) {
@@ -147,9 +174,9 @@ func (lsn *listenerV2) initializeLastProcessedBlock(ctx context.Context) (lastPr
 		lsn.coordinator.RandomWordsRequestedTopic(), // event sig
 		lsn.coordinator.Address(),                   // address
 		1,                                           // topic index
-		[]common.Hash{lsn.job.VRFSpec.PublicKey.MustHash()}, // topic values
-		fromTimestamp,      // from time
-		evmtypes.Finalized, // confs
+		lsn.keyHashes(),                             // topic values
+		fromTimestamp,                               // from time
+		evmtypes.Finalized,                          // confs
 	)
 	if err != nil {
 		return 0, fmt.Errorf("LogPoller.LogsCreatedAfter RandomWordsRequested logs: %w", err)
@@ -277,18 +304,23 @@ func (lsn *listenerV2) pollLogs(ctx context.Context, minConfs uint32, lastProces
 		ll.Debugw("no unfulfilled logs found")
 	}
 
-	lsn.handleFulfilled(fulfilled)
+	lsn.handleFulfilled(ctx, fulfilled)
 
-	return lsn.handleRequested(unfulfilled, unfulfilledLP, minConfs), nil
+	pending = lsn.handleRequested(unfulfilled, unfulfilledLP, minConfs)
+	lsn.recordObserved(ctx, lsn.observeRequestBlocks(pending))
+	return pending, nil
 }
 
 func (lsn *listenerV2) getUnfulfilled(logs []logpoller.Log, ll logger.Logger) (unfulfilled []RandomWordsRequested, unfulfilledLP []logpoller.Log, fulfilled map[string]RandomWordsFulfilled) {
 	var (
-		requested       = make(map[string]RandomWordsRequested)
-		requestedLP     = make(map[string]logpoller.Log)
-		errs            error
-		expectedKeyHash = lsn.job.VRFSpec.PublicKey.MustHash()
+		requested         = make(map[string]RandomWordsRequested)
+		requestedLP       = make(map[string]logpoller.Log)
+		errs              error
+		expectedKeyHashes = make(map[common.Hash]struct{})
 	)
+	for _, keyHash := range lsn.keyHashes() {
+		expectedKeyHashes[keyHash] = struct{}{}
+	}
 	fulfilled = make(map[string]RandomWordsFulfilled)
 	for _, l := range logs {
 		if l.EventSig == lsn.coordinator.RandomWordsFulfilledTopic() {
@@ -306,8 +338,7 @@ func (lsn *listenerV2) getUnfulfilled(logs []logpoller.Log, ll logger.Logger) (u
 				errs = errors.Join(errs, err2)
 				continue
 			}
-			keyHash := parsed.KeyHash()
-			if !bytes.Equal(keyHash[:], expectedKeyHash[:]) {
+			if _, ok := expectedKeyHashes[parsed.KeyHash()]; !ok {
 				// wrong keyhash, can ignore
 				continue
 			}
@@ -366,7 +397,7 @@ func (lsn *listenerV2) getConfirmedAt(req RandomWordsRequested, nodeMinConfs uin
 	return req.Raw().BlockNumber + newConfs
 }
 
-func (lsn *listenerV2) handleFulfilled(fulfilled map[string]RandomWordsFulfilled) {
+func (lsn *listenerV2) handleFulfilled(ctx context.Context, fulfilled map[string]RandomWordsFulfilled) {
 	for _, v := range fulfilled {
 		// don't process same log over again
 		// log key includes block number and blockhash, so on re-orgs it would return true
@@ -380,6 +411,9 @@ func (lsn *listenerV2) handleFulfilled(fulfilled map[string]RandomWordsFulfilled
 			blockNumber: v.Raw().BlockNumber,
 			reqID:       v.RequestID().String(),
 		})
+		lsn.recordFulfilled(ctx, v)
+		lsn.slaFulfilled(v)
+		lsn.forgetRequestBlock(v.RequestID())
 	}
 }
 
@@ -1,14 +1,12 @@
 package v2
 
 import (
-	"cmp"
 	"context"
 	"database/sql"
 	stderrors "errors"
 	"fmt"
 	"math"
 	"math/big"
-	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -22,10 +20,10 @@ import (
 
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
-	"github.com/smartcontractkit/chainlink-common/pkg/utils/hex"
 	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2"
 	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2plus_interface"
 	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
+	txmtypes "github.com/smartcontractkit/chainlink-evm/pkg/txm/types"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr"
 	txmgrcommon "github.com/smartcontractkit/chainlink-framework/chains/txmgr"
 	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
@@ -38,6 +36,7 @@ import (
 // Returns all the confirmed logs from the provided pending queue by subscription
 func (lsn *listenerV2) getConfirmedLogsBySub(latestHead uint64, pendingRequests []pendingRequest) map[string][]pendingRequest {
 	vrfcommon.UpdateQueueSize(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), uniqueReqs(pendingRequests))
+	lsn.updateLaneQueueSizes(pendingRequests)
 	var toProcess = make(map[string][]pendingRequest)
 	for _, request := range pendingRequests {
 		if lsn.ready(request, latestHead) {
@@ -110,11 +109,15 @@ func (lsn *listenerV2) pruneConfirmedRequestCounts() {
 // we simply retry TODO: follow up where if we see a fulfillment revert, return log to the queue.
 func (lsn *listenerV2) processPendingVRFRequests(ctx context.Context, pendingRequests []pendingRequest) {
 	confirmed := lsn.getConfirmedLogsBySub(lsn.getLatestHead(), pendingRequests)
+	confirmedAt := lsn.recordConfirmed(ctx, confirmed)
+	lsn.slaConfirmed(confirmed, confirmedAt)
 	var processedMu sync.Mutex
 	processed := make(map[string]struct{})
+	throttled := 0
 	start := time.Now()
 
 	defer func() {
+		lsn.flushSimulated(ctx)
 		for _, subReqs := range confirmed {
 			for _, req := range subReqs {
 				if _, ok := processed[req.req.RequestID().String()]; ok {
@@ -125,6 +128,7 @@ func (lsn *listenerV2) processPendingVRFRequests(ctx context.Context, pendingReq
 		}
 		lsn.l.Infow("Finished processing pending requests",
 			"totalProcessed", len(processed),
+			"totalThrottled", throttled,
 			"totalFailed", len(pendingRequests)-len(processed),
 			"total", len(pendingRequests),
 			"time", time.Since(start).String(),
@@ -135,7 +139,10 @@ func (lsn *listenerV2) processPendingVRFRequests(ctx context.Context, pendingReq
 		lsn.l.Infow("No pending requests ready for processing")
 		return
 	}
-	for subID, reqs := range confirmed {
+	var scheduledSubs []subRequests
+	scheduledSubs, throttled = lsn.schedule(confirmed)
+	for _, scheduled := range scheduledSubs {
+		subID, reqs := scheduled.subID, scheduled.reqs
 		l := lsn.l.With("subID", subID, "startTime", time.Now(), "numReqsForSub", len(reqs))
 		// Get the balance of the subscription and also it's active status.
 		// The reason we need both is that we cannot determine if a subscription
@@ -179,21 +186,20 @@ func (lsn *listenerV2) processPendingVRFRequests(ctx context.Context, pendingReq
 			subIsActive = true
 		}
 
-		// Sort requests in ascending order by CallbackGasLimit
-		// so that we process the "cheapest" requests for each subscription
-		// first. This allows us to break out of the processing loop as early as possible
-		// in the event that a subscription is too underfunded to have it's
-		// requests processed.
-		slices.SortFunc(reqs, func(a, b pendingRequest) int {
-			return cmp.Compare(a.req.CallbackGasLimit(), b.req.CallbackGasLimit())
-		})
-
-		p := lsn.processRequestsPerSub(ctx, sID, startLinkBalance, startEthBalance, reqs, subIsActive)
-		processedMu.Lock()
-		for reqID := range p {
-			processed[reqID] = struct{}{}
+		// The requests are processed in the order of the scheduler, which takes them
+		// round-robin across consumers, cheapest first within each round.
+		//
+		// Each gas lane proves and fulfills its requests with its own key and addresses.
+		// The lanes are processed one after the other, so that the reserved balance
+		// accounts for the fulfillments enqueued by the previous ones.
+		for _, routed := range lsn.routeByLane(reqs) {
+			p := lsn.processRequestsPerSub(ctx, routed.lane, sID, startLinkBalance, startEthBalance, routed.reqs, subIsActive)
+			processedMu.Lock()
+			for reqID := range p {
+				processed[reqID] = struct{}{}
+			}
+			processedMu.Unlock()
 		}
-		processedMu.Unlock()
 	}
 	lsn.pruneConfirmedRequestCounts()
 }
@@ -293,6 +299,7 @@ func (lsn *listenerV2) MaybeSubtractReservedEth(ctx context.Context, startBalanc
 // It is also used to keep track of the remaining balance for the current request, after fulfilling it at max gas price.
 func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 	ctx context.Context,
+	lane gasLane,
 	subID *big.Int,
 	startBalance *big.Int,
 	startBalanceNoReserved *big.Int,
@@ -321,6 +328,7 @@ func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 
 	l := lsn.l.With(
 		"subID", subID,
+		"keyHash", lane.keyHash,
 		"eligibleSubReqs", len(reqs),
 		"startBalance", startBalance.String(),
 		"startBalanceNoReserved", startBalanceNoReserved.String(),
@@ -339,7 +347,7 @@ func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 
 	l.Infow("Processing requests for subscription with batching")
 
-	ready, expired := lsn.getReadyAndExpired(l, reqs)
+	ready, expired := lsn.getReadyAndExpired(ctx, l, reqs)
 	for _, reqID := range expired {
 		processed[reqID] = struct{}{}
 	}
@@ -371,9 +379,7 @@ func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 			}
 		}
 
-		// All fromAddresses passed to the VRFv2 job have the same KeySpecific-MaxPrice value.
-		fromAddresses := lsn.fromAddresses()
-		maxGasPriceWei := lsn.feeCfg.PriceMaxKey(fromAddresses[0])
+		maxGasPriceWei := lsn.maxGasPrice(lane)
 
 		// Cases:
 		// 1. Never simulated: in this case, we want to observe the time until simulated
@@ -383,7 +389,7 @@ func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 		// the request.
 		observeRequestSimDuration(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), unfulfilled)
 
-		pipelines := lsn.runPipelines(ctx, l, maxGasPriceWei, unfulfilled)
+		pipelines := lsn.runPipelines(ctx, l, lane, maxGasPriceWei, unfulfilled)
 		batches := newBatchFulfillments(batchMaxGas, lsn.coordinator.Version())
 		outOfBalance := false
 		for _, p := range pipelines {
@@ -399,8 +405,7 @@ func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 				"blockNumber", p.req.req.Raw().BlockNumber,
 				"blockHash", p.req.req.Raw().BlockHash,
 			)
-			fromAddresses := lsn.fromAddresses()
-			fromAddress, err := lsn.gethks.GetRoundRobinAddress(ctx, lsn.chainID, fromAddresses...)
+			fromAddress, err := lsn.gethks.GetRoundRobinAddress(ctx, lsn.chainID, lane.fromAddresses...)
 			if err != nil {
 				l.Errorw("Couldn't get next from address", "err", err)
 				continue
@@ -430,6 +435,8 @@ func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 							continue
 						}
 						ll.Infow("Successfully enqueued force-fulfillment", "ethTxID", etx.ID)
+						lsn.recordEnqueued(ctx, []*big.Int{p.req.req.RequestID()}, etx.ID)
+						lsn.chargeScheduled([]*big.Int{p.req.req.RequestID()})
 						processed[p.req.req.RequestID().String()] = struct{}{}
 
 						// Need to put a continue here, otherwise the next if statement will be hit
@@ -456,6 +463,10 @@ func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 							"blockNumber", p.req.req.Raw().BlockNumber,
 							"blockHash", p.req.req.Raw().BlockHash,
 						)
+						vrfcommon.IncDroppedReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), vrfcommon.ReasonInvalidConsumer)
+						lsn.recordDropped(ctx, p.req.req.RequestID(), vrfcommon.ReasonInvalidConsumer)
+						lsn.slaForget(p.req.req.RequestID())
+						lsn.forgetRequestBlock(p.req.req.RequestID())
 						processed[p.req.req.RequestID().String()] = struct{}{}
 						continue
 					}
@@ -503,6 +514,7 @@ func (lsn *listenerV2) processRequestsPerSubBatchHelper(
 // This is a wrapper function that splits the requests into native and LINK requests, and processes them in parallel,
 func (lsn *listenerV2) processRequestsPerSubBatch(
 	ctx context.Context,
+	lane gasLane,
 	subID *big.Int,
 	startLinkBalance *big.Int,
 	startEthBalance *big.Int,
@@ -541,11 +553,11 @@ func (lsn *listenerV2) processRequestsPerSubBatch(
 	wg.Add(2)
 	go func() {
 		defer wg.Done()
-		nativeProcessed = lsn.processRequestsPerSubBatchHelper(ctx, subID, startEthBalance, startBalanceNoReserveEth, nativeRequests, subIsActive, true)
+		nativeProcessed = lsn.processRequestsPerSubBatchHelper(ctx, lane, subID, startEthBalance, startBalanceNoReserveEth, nativeRequests, subIsActive, true)
 	}()
 	go func() {
 		defer wg.Done()
-		linkProcessed = lsn.processRequestsPerSubBatchHelper(ctx, subID, startLinkBalance, startBalanceNoReserveLink, linkRequests, subIsActive, false)
+		linkProcessed = lsn.processRequestsPerSubBatchHelper(ctx, lane, subID, startLinkBalance, startBalanceNoReserveLink, linkRequests, subIsActive, false)
 	}()
 	wg.Wait()
 	// combine the processed link and native requests into the processed map
@@ -619,7 +631,7 @@ func (lsn *listenerV2) enqueueForceFulfillment(
 		ToAddress:      lsn.vrfOwner.Address(),
 		EncodedPayload: txData,
 		FeeLimit:       estimateGasLimit,
-		Strategy:       txmgrcommon.NewSendEveryStrategy(),
+		Strategy:       fulfillmentStrategy(),
 		Meta: &txmgr.TxMeta{
 			RequestID:     &requestID,
 			SubID:         ptr(subID.Uint64()),
@@ -654,6 +666,7 @@ func (lsn *listenerV2) isConsumerValidAfterFinalityDepthElapsed(ctx context.Cont
 // minus any pending requests that have already been processed and not yet fulfilled onchain.
 func (lsn *listenerV2) processRequestsPerSubHelper(
 	ctx context.Context,
+	lane gasLane,
 	subID *big.Int,
 	startBalance *big.Int,
 	startBalanceNoReserved *big.Int,
@@ -666,6 +679,7 @@ func (lsn *listenerV2) processRequestsPerSubHelper(
 
 	l := lsn.l.With(
 		"subID", subID,
+		"keyHash", lane.keyHash,
 		"eligibleSubReqs", len(reqs),
 		"startBalance", startBalance.String(),
 		"startBalanceNoReserved", startBalanceNoReserved.String(),
@@ -683,7 +697,7 @@ func (lsn *listenerV2) processRequestsPerSubHelper(
 
 	l.Infow("Processing requests for subscription")
 
-	ready, expired := lsn.getReadyAndExpired(l, reqs)
+	ready, expired := lsn.getReadyAndExpired(ctx, l, reqs)
 	for _, reqID := range expired {
 		processed[reqID] = struct{}{}
 	}
@@ -712,11 +726,9 @@ func (lsn *listenerV2) processRequestsPerSubHelper(
 			}
 		}
 
-		// All fromAddresses passed to the VRFv2 job have the same KeySpecific-MaxPrice value.
-		fromAddresses := lsn.fromAddresses()
-		maxGasPriceWei := lsn.feeCfg.PriceMaxKey(fromAddresses[0])
+		maxGasPriceWei := lsn.maxGasPrice(lane)
 		observeRequestSimDuration(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), unfulfilled)
-		pipelines := lsn.runPipelines(ctx, l, maxGasPriceWei, unfulfilled)
+		pipelines := lsn.runPipelines(ctx, l, lane, maxGasPriceWei, unfulfilled)
 		for _, p := range pipelines {
 			ll := l.With("reqID", p.req.req.RequestID().String(),
 				"txHash", p.req.req.Raw().TxHash,
@@ -730,7 +742,7 @@ func (lsn *listenerV2) processRequestsPerSubHelper(
 				"blockNumber", p.req.req.Raw().BlockNumber,
 				"blockHash", p.req.req.Raw().BlockHash,
 			)
-			fromAddress, err := lsn.gethks.GetRoundRobinAddress(ctx, lsn.chainID, fromAddresses...)
+			fromAddress, err := lsn.gethks.GetRoundRobinAddress(ctx, lsn.chainID, lane.fromAddresses...)
 			if err != nil {
 				l.Errorw("Couldn't get next from address", "err", err)
 				continue
@@ -761,6 +773,8 @@ func (lsn *listenerV2) processRequestsPerSubHelper(
 							continue
 						}
 						ll.Infow("Enqueued force-fulfillment", "ethTxID", etx.ID)
+						lsn.recordEnqueued(ctx, []*big.Int{p.req.req.RequestID()}, etx.ID)
+						lsn.chargeScheduled([]*big.Int{p.req.req.RequestID()})
 						processed[p.req.req.RequestID().String()] = struct{}{}
 
 						// Need to put a continue here, otherwise the next if statement will be hit
@@ -784,6 +798,10 @@ func (lsn *listenerV2) processRequestsPerSubHelper(
 							"blockNumber", p.req.req.Raw().BlockNumber,
 							"blockHash", p.req.req.Raw().BlockHash,
 						)
+						vrfcommon.IncDroppedReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), vrfcommon.ReasonInvalidConsumer)
+						lsn.recordDropped(ctx, p.req.req.RequestID(), vrfcommon.ReasonInvalidConsumer)
+						lsn.slaForget(p.req.req.RequestID())
+						lsn.forgetRequestBlock(p.req.req.RequestID())
 						processed[p.req.req.RequestID().String()] = struct{}{}
 						continue
 					}
@@ -798,70 +816,85 @@ func (lsn *listenerV2) processRequestsPerSubHelper(
 			}
 
 			ll.Infow("Enqueuing fulfillment")
-			var transaction txmgr.Tx
-			err = sqlutil.TransactDataSource(ctx, lsn.ds, nil, func(tx sqlutil.DataSource) error {
-				if err = lsn.pipelineRunner.InsertFinishedRun(ctx, tx, p.run, true); err != nil {
-					return err
-				}
-
-				var maxLink, maxEth *string
-				tmp := p.maxFee.String()
-				if p.reqCommitment.NativePayment() {
-					maxEth = &tmp
-				} else {
-					maxLink = &tmp
-				}
-				var (
-					txMetaSubID       *uint64
-					txMetaGlobalSubID *string
-				)
-				if lsn.coordinator.Version() == vrfcommon.V2Plus {
-					txMetaGlobalSubID = ptr(p.req.req.SubID().String())
-				} else if lsn.coordinator.Version() == vrfcommon.V2 {
-					txMetaSubID = ptr(p.req.req.SubID().Uint64())
-				}
-				requestID := common.BytesToHash(p.req.req.RequestID().Bytes())
-				coordinatorAddress := lsn.coordinator.Address()
-				requestTxHash := p.req.req.Raw().TxHash
-				transaction, err = lsn.chain.TxManager().CreateTransaction(ctx, txmgr.TxRequest{
-					FromAddress:    fromAddress,
-					ToAddress:      lsn.coordinator.Address(),
-					EncodedPayload: hexutil.MustDecode(p.payload),
-					FeeLimit:       p.gasLimit,
-					Meta: &txmgr.TxMeta{
-						RequestID:     &requestID,
-						MaxLink:       maxLink,
-						MaxEth:        maxEth,
-						SubID:         txMetaSubID,
-						GlobalSubID:   txMetaGlobalSubID,
-						RequestTxHash: &requestTxHash,
-					},
-					Strategy: txmgrcommon.NewSendEveryStrategy(),
-					Checker: txmgr.TransmitCheckerSpec{
-						CheckerType:           lsn.transmitCheckerType(),
-						VRFCoordinatorAddress: &coordinatorAddress,
-						VRFRequestBlockNumber: new(big.Int).SetUint64(p.req.req.Raw().BlockNumber),
-					},
-				})
-				return err
-			})
+			transaction, err := lsn.enqueueFulfillment(ctx, p, fromAddress)
 			if err != nil {
 				ll.Errorw("Error enqueuing fulfillment, requeuing request", "err", err)
 				continue
 			}
 			ll.Infow("Enqueued fulfillment", "ethTxID", transaction.GetID())
+			lsn.recordEnqueued(ctx, []*big.Int{p.req.req.RequestID()}, transaction.ID)
+			lsn.chargeScheduled([]*big.Int{p.req.req.RequestID()})
 
 			// If we successfully enqueued for the txm, subtract that balance
 			// And loop to attempt to enqueue another fulfillment
 			startBalanceNoReserved.Sub(startBalanceNoReserved, p.maxFee)
 			processed[p.req.req.RequestID().String()] = struct{}{}
 			vrfcommon.IncProcessedReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version())
+			vrfcommon.IncLaneProcessedReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), lane.keyHash)
 		}
 	}
 
 	return
 }
 
+// enqueueFulfillment stores the pipeline run of the given result and creates the
+// fulfillment transaction in the txmgr.
+func (lsn *listenerV2) enqueueFulfillment(ctx context.Context, p vrfPipelineResult, fromAddress common.Address) (transaction txmgr.Tx, err error) {
+	err = sqlutil.TransactDataSource(ctx, lsn.ds, nil, func(tx sqlutil.DataSource) error {
+		if err = lsn.pipelineRunner.InsertFinishedRun(ctx, tx, p.run, true); err != nil {
+			return err
+		}
+
+		var maxLink, maxEth *string
+		tmp := p.maxFee.String()
+		if p.reqCommitment.NativePayment() {
+			maxEth = &tmp
+		} else {
+			maxLink = &tmp
+		}
+		var (
+			txMetaSubID       *uint64
+			txMetaGlobalSubID *string
+		)
+		if lsn.coordinator.Version() == vrfcommon.V2Plus {
+			txMetaGlobalSubID = ptr(p.req.req.SubID().String())
+		} else if lsn.coordinator.Version() == vrfcommon.V2 {
+			txMetaSubID = ptr(p.req.req.SubID().Uint64())
+		}
+		requestID := common.BytesToHash(p.req.req.RequestID().Bytes())
+		coordinatorAddress := lsn.coordinator.Address()
+		requestTxHash := p.req.req.Raw().TxHash
+		transaction, err = lsn.chain.TxManager().CreateTransaction(ctx, txmgr.TxRequest{
+			FromAddress:    fromAddress,
+			ToAddress:      lsn.coordinator.Address(),
+			EncodedPayload: hexutil.MustDecode(p.payload),
+			FeeLimit:       p.gasLimit,
+			Meta: &txmgr.TxMeta{
+				RequestID:     &requestID,
+				MaxLink:       maxLink,
+				MaxEth:        maxEth,
+				SubID:         txMetaSubID,
+				GlobalSubID:   txMetaGlobalSubID,
+				RequestTxHash: &requestTxHash,
+			},
+			Strategy: fulfillmentStrategy(),
+			Checker: txmgr.TransmitCheckerSpec{
+				CheckerType:           lsn.transmitCheckerType(),
+				VRFCoordinatorAddress: &coordinatorAddress,
+				VRFRequestBlockNumber: new(big.Int).SetUint64(p.req.req.Raw().BlockNumber),
+			},
+		})
+		return err
+	})
+	return transaction, err
+}
+
+// fulfillmentStrategy is the strategy of fulfillment transactions. Fulfillments are time sensitive, so TXM queues
+// them with high priority, ahead of the rest of the transactions of the same key.
+func fulfillmentStrategy() txmgrtypes.TxStrategy {
+	return txmtypes.WithPriority(txmgrcommon.NewSendEveryStrategy(), txmtypes.PriorityHigh)
+}
+
 func (lsn *listenerV2) transmitCheckerType() txmgrtypes.TransmitCheckerType {
 	if lsn.coordinator.Version() == vrfcommon.V2 {
 		return txmgr.TransmitCheckerTypeVRFV2
@@ -871,6 +904,7 @@ func (lsn *listenerV2) transmitCheckerType() txmgrtypes.TransmitCheckerType {
 
 func (lsn *listenerV2) processRequestsPerSub(
 	ctx context.Context,
+	lane gasLane,
 	subID *big.Int,
 	startLinkBalance *big.Int,
 	startEthBalance *big.Int,
@@ -878,7 +912,7 @@ func (lsn *listenerV2) processRequestsPerSub(
 	subIsActive bool,
 ) map[string]struct{} {
 	if lsn.job.VRFSpec.BatchFulfillmentEnabled && lsn.batchCoordinator != nil {
-		return lsn.processRequestsPerSubBatch(ctx, subID, startLinkBalance, startEthBalance, reqs, subIsActive)
+		return lsn.processRequestsPerSubBatch(ctx, lane, subID, startLinkBalance, startEthBalance, reqs, subIsActive)
 	}
 
 	var processed = make(map[string]struct{})
@@ -928,6 +962,7 @@ func (lsn *listenerV2) processRequestsPerSub(
 		defer wg.Done()
 		nativeProcessed = lsn.processRequestsPerSubHelper(
 			ctx,
+			lane,
 			subID,
 			startEthBalance,
 			startBalanceNoReserveEth,
@@ -939,6 +974,7 @@ func (lsn *listenerV2) processRequestsPerSub(
 		defer wg.Done()
 		linkProcessed = lsn.processRequestsPerSubHelper(
 			ctx,
+			lane,
 			subID,
 			startLinkBalance,
 			startBalanceNoReserveLink,
@@ -1052,6 +1088,7 @@ func (lsn *listenerV2) checkReqsFulfilled(ctx context.Context, l logger.Logger,
 func (lsn *listenerV2) runPipelines(
 	ctx context.Context,
 	l logger.Logger,
+	lane gasLane,
 	maxGasPriceWei *assets.Wei,
 	reqs []pendingRequest,
 ) []vrfPipelineResult {
@@ -1066,10 +1103,11 @@ func (lsn *listenerV2) runPipelines(
 		go func(i int, req pendingRequest) {
 			defer wg.Done()
 			ll := logger.With(l, "reqID", req.req.RequestID().String())
-			results[i] = lsn.simulateFulfillment(ctx, maxGasPriceWei, req, ll)
+			results[i] = lsn.simulateFulfillment(ctx, lane, maxGasPriceWei, req, ll)
 		}(i, req)
 	}
 	wg.Wait()
+	lsn.recordSimulated(results)
 
 	l.Debugw("Finished running pipelines",
 		"count", len(reqs), "time", time.Since(start).String())
@@ -1107,6 +1145,7 @@ func (lsn *listenerV2) estimateFee(
 // then simulate the transaction at the max gas price to determine its maximum link cost.
 func (lsn *listenerV2) simulateFulfillment(
 	ctx context.Context,
+	lane gasLane,
 	maxGasPriceWei *assets.Wei,
 	req pendingRequest,
 	lg logger.Logger,
@@ -1129,7 +1168,7 @@ func (lsn *listenerV2) simulateFulfillment(
 			"databaseID":    lsn.job.ID,
 			"externalJobID": lsn.job.ExternalJobID,
 			"name":          lsn.job.Name.ValueOrZero(),
-			"publicKey":     lsn.job.VRFSpec.PublicKey[:],
+			"publicKey":     lane.publicKey[:],
 			"maxGasPrice":   maxGasPriceWei.ToInt().String(),
 			"evmChainID":    lsn.job.VRFSpec.EVMChainID.String(),
 		},
@@ -1149,13 +1188,8 @@ func (lsn *listenerV2) simulateFulfillment(
 	}
 	// The call task will fail if there are insufficient funds
 	if res.run.AllErrors.HasError() {
-		res.err = errors.WithStack(res.run.AllErrors.ToError())
-
-		if strings.Contains(res.err.Error(), "blockhash not found in store") {
-			res.err = stderrors.Join(res.err, errBlockhashNotInStore{})
-		} else if isProofVerificationError(res.err.Error()) {
-			res.err = stderrors.Join(res.err, errProofVerificationFailed{})
-		} else if strings.Contains(res.err.Error(), "execution reverted") {
+		_, res.err = fulfillmentResult(nil, errors.WithStack(res.run.AllErrors.ToError()))
+		if errors.Is(res.err, errPossiblyInsufficientFunds{}) {
 			// Even if the simulation fails, we want to get the
 			// txData for the fulfillRandomWords call, in case
 			// we need to force fulfill.
@@ -1179,7 +1213,6 @@ func (lsn *listenerV2) simulateFulfillment(
 					res.reqCommitment = NewRequestCommitment(m["requestCommitment"])
 				}
 			}
-			res.err = stderrors.Join(res.err, errPossiblyInsufficientFunds{})
 		}
 
 		return res
@@ -1197,9 +1230,8 @@ func (lsn *listenerV2) simulateFulfillment(
 		return res
 	}
 
-	res.maxFee, err = hex.ParseBig(hexutil.Encode(b)[2:])
-	if err != nil {
-		res.err = err
+	res.maxFee, res.err = fulfillmentResult(b, nil)
+	if res.err != nil {
 		return res
 	}
 
@@ -1226,9 +1258,7 @@ func (lsn *listenerV2) simulateFulfillment(
 }
 
 func (lsn *listenerV2) fromAddresses() []common.Address {
-	var addresses []common.Address
-	for _, a := range lsn.job.VRFSpec.FromAddresses {
-		addresses = append(addresses, a.Address())
-	}
-	return addresses
+	lsn.specMu.RLock()
+	defer lsn.specMu.RUnlock()
+	return addresses(lsn.job.VRFSpec.FromAddresses)
 }
@@ -0,0 +1,60 @@
+package v2
+
+import (
+	"time"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+)
+
+// Reconfigurer is implemented by listeners that can pick up an updated spec without a restart.
+type Reconfigurer interface {
+	// Reconfigure applies the vrfcommon.MutableVRFSpecFields of spec before the next round of
+	// processing. The other fields of spec are ignored.
+	Reconfigure(spec job.VRFSpec)
+}
+
+var _ Reconfigurer = (*listenerV2)(nil)
+
+func (lsn *listenerV2) Reconfigure(spec job.VRFSpec) {
+	lsn.specMu.Lock()
+	defer lsn.specMu.Unlock()
+	lsn.specUpdate = &spec
+}
+
+// applySpecUpdate applies the last spec passed to Reconfigure, if any. It is only called from
+// the processing loop, which therefore reads the spec without locking. The pending requests,
+// along with their attempts and backoff, are kept.
+func (lsn *listenerV2) applySpecUpdate() {
+	lsn.specMu.Lock()
+	defer lsn.specMu.Unlock()
+	update := lsn.specUpdate
+	if update == nil {
+		return
+	}
+	lsn.specUpdate = nil
+
+	spec := lsn.job.VRFSpec
+	spec.ChunkSize = update.ChunkSize
+	spec.BackoffInitialDelay = update.BackoffInitialDelay
+	spec.BackoffMaxDelay = update.BackoffMaxDelay
+	spec.GasLanePrice = update.GasLanePrice
+	spec.FromAddresses = update.FromAddresses
+	spec.BatchFulfillmentGasMultiplier = update.BatchFulfillmentGasMultiplier
+	spec.ConsumerRateLimit = update.ConsumerRateLimit
+	spec.ConsumerRateLimitBurst = update.ConsumerRateLimitBurst
+	spec.SubscriptionRateLimit = update.SubscriptionRateLimit
+	spec.SubscriptionRateLimitBurst = update.SubscriptionRateLimitBurst
+	spec.SubscriptionWeights = update.SubscriptionWeights
+	spec.UpdatedAt = update.UpdatedAt
+	if lsn.scheduler != nil {
+		lsn.scheduler.reconfigure(time.Now(), spec)
+	}
+
+	lsn.l.Infow("Applied updated VRF spec",
+		"chunkSize", spec.ChunkSize,
+		"backoffInitialDelay", spec.BackoffInitialDelay,
+		"backoffMaxDelay", spec.BackoffMaxDelay,
+		"gasLanePrice", spec.GasLanePrice,
+		"fromAddresses", spec.FromAddresses,
+		"batchFulfillmentGasMultiplier", spec.BatchFulfillmentGasMultiplier)
+}
@@ -0,0 +1,106 @@
+package v2
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/logger"
+	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+)
+
+func TestListenerV2_Reconfigure(t *testing.T) {
+	t.Parallel()
+
+	coordinator := evmtypes.EIP55AddressFromAddress(testutils.NewAddress())
+	spec := &job.VRFSpec{
+		CoordinatorAddress:     coordinator,
+		ChunkSize:              10,
+		BackoffMaxDelay:        time.Minute,
+		FromAddresses:          []evmtypes.EIP55Address{evmtypes.EIP55AddressFromAddress(testutils.NewAddress())},
+		ConsumerRateLimit:      1,
+		ConsumerRateLimitBurst: 10,
+	}
+	lsn := &listenerV2{
+		l:         logger.Sugared(logger.Test(t)),
+		job:       job.Job{VRFSpec: spec},
+		scheduler: newRequestScheduler(spec),
+	}
+	consumer := testutils.NewAddress()
+	scheduled, _ := lsn.scheduler.schedule(time.Now(), map[string][]pendingRequest{
+		"1": {newScheduledRequest(1, 1, consumer, 100)},
+	})
+	require.Len(t, scheduled, 1)
+
+	fromAddress := evmtypes.EIP55AddressFromAddress(testutils.NewAddress())
+	lsn.Reconfigure(job.VRFSpec{
+		CoordinatorAddress:     evmtypes.EIP55AddressFromAddress(testutils.NewAddress()),
+		ChunkSize:              5,
+		BackoffMaxDelay:        time.Hour,
+		GasLanePrice:           assets.GWei(100),
+		FromAddresses:          []evmtypes.EIP55Address{fromAddress},
+		ConsumerRateLimit:      2,
+		ConsumerRateLimitBurst: 5,
+	})
+	assert.Equal(t, uint32(10), spec.ChunkSize, "the update waits for the processing loop")
+
+	lsn.applySpecUpdate()
+	assert.Equal(t, uint32(5), spec.ChunkSize)
+	assert.Equal(t, time.Hour, spec.BackoffMaxDelay)
+	assert.Equal(t, assets.GWei(100), spec.GasLanePrice)
+	assert.Equal(t, []common.Address{fromAddress.Address()}, lsn.fromAddresses())
+	assert.Equal(t, coordinator, spec.CoordinatorAddress, "immutable fields are ignored")
+	assert.Nil(t, lsn.specUpdate)
+
+	require.Contains(t, lsn.scheduler.consumers, consumer, "buckets are kept")
+	assert.Equal(t, 5, lsn.scheduler.consumers[consumer].Burst())
+	assert.Equal(t, 5, lsn.scheduler.chunkSize)
+}
+
+// Refulfill reads the lanes of the job on the HTTP goroutine while the processing loop applies
+// spec updates, the race detector checks that they don't race.
+func TestListenerV2_LanesDuringReconfigure(t *testing.T) {
+	t.Parallel()
+
+	publicKey := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1)).PublicKey
+	spec := &job.VRFSpec{
+		PublicKey:     publicKey,
+		ChunkSize:     10,
+		FromAddresses: []evmtypes.EIP55Address{evmtypes.EIP55AddressFromAddress(testutils.NewAddress())},
+	}
+	lsn := &listenerV2{
+		l:   logger.Sugared(logger.Test(t)),
+		job: job.Job{VRFSpec: spec},
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			lsn.Reconfigure(job.VRFSpec{
+				ChunkSize:     10,
+				FromAddresses: []evmtypes.EIP55Address{evmtypes.EIP55AddressFromAddress(testutils.NewAddress())},
+			})
+			lsn.applySpecUpdate()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			lane, ok := findLane(lsn.lanes(), publicKey.MustHash())
+			assert.True(t, ok)
+			assert.Len(t, lane.fromAddresses, 1)
+		}
+	}()
+	wg.Wait()
+}
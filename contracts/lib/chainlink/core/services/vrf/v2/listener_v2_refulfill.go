@@ -0,0 +1,148 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// Refulfiller is implemented by listeners that can re-fulfill a request on demand.
+type Refulfiller interface {
+	Refulfill(ctx context.Context, requestID *big.Int, opts vrfcommon.RefulfillOpts) (vrfcommon.RefulfillResult, error)
+}
+
+var _ Refulfiller = (*listenerV2)(nil)
+
+// Refulfill re-fulfills a request that the listener is no longer tracking, e.g. because it
+// fell out of the RequestTimeout window or was skipped for lack of funds. The request log is
+// fetched again from the chain and goes through the same pipeline and txmgr path as any
+// other request, so the transmit checker still guards against double fulfillment.
+//
+// Refulfill runs on the caller's goroutine, concurrently with the processing loop applying
+// spec updates. It reads the mutable fields of the spec it needs once, under specMu, by
+// taking the gas lanes of the job.
+func (lsn *listenerV2) Refulfill(ctx context.Context, requestID *big.Int, opts vrfcommon.RefulfillOpts) (res vrfcommon.RefulfillResult, err error) {
+	l := lsn.l.With("reqID", requestID.String(), "dryRun", opts.DryRun)
+
+	lanes := lsn.lanes()
+	req, err := lsn.fetchRequest(ctx, requestID, opts.BlockNumber, lanes)
+	if err != nil {
+		return res, err
+	}
+	if lsn.inflightCache.Contains(req.Raw()) {
+		return res, vrfcommon.ErrRequestInflight
+	}
+	pending := pendingRequest{
+		confirmedAtBlock: req.Raw().BlockNumber,
+		req:              req,
+		utcTimestamp:     time.Now().UTC(),
+	}
+	fulfilled, err := lsn.checkReqsFulfilled(ctx, l, []pendingRequest{pending})
+	if err != nil {
+		return res, fmt.Errorf("checking fulfillment status: %w", err)
+	}
+	if fulfilled[0] {
+		return res, vrfcommon.ErrRequestAlreadyFulfilled
+	}
+
+	lane, ok := findLane(lanes, req.KeyHash())
+	if !ok {
+		return res, fmt.Errorf("%w: key hash %s is not served by the job", vrfcommon.ErrRequestNotFound, common.Hash(req.KeyHash()))
+	}
+	maxGasPriceWei := lsn.maxGasPrice(lane)
+	p := lsn.runPipelines(ctx, l, lane, maxGasPriceWei, []pendingRequest{pending})[0]
+	lsn.flushSimulated(ctx)
+	res = vrfcommon.RefulfillResult{
+		JobID:          lsn.job.ID,
+		RequestID:      requestID,
+		SubID:          req.SubID(),
+		Sender:         req.Sender(),
+		RequestTxHash:  req.Raw().TxHash,
+		NativePayment:  req.NativePayment(),
+		GasLimit:       p.gasLimit,
+		MaxGasPriceWei: maxGasPriceWei.ToInt(),
+		MaxFee:         p.maxFee,
+		DryRun:         opts.DryRun,
+	}
+	if p.err != nil {
+		return res, fmt.Errorf("simulating fulfillment (funds needed %s): %w", p.fundsNeeded, p.err)
+	}
+	if opts.DryRun {
+		l.Infow("Simulated re-fulfillment", "gasLimit", p.gasLimit, "payment", p.maxFee)
+		return res, nil
+	}
+
+	res.FromAddress, err = lsn.gethks.GetRoundRobinAddress(ctx, lsn.chainID, lane.fromAddresses...)
+	if err != nil {
+		return res, fmt.Errorf("getting from address: %w", err)
+	}
+	transaction, err := lsn.enqueueFulfillment(ctx, p, res.FromAddress)
+	if err != nil {
+		return res, fmt.Errorf("enqueuing fulfillment: %w", err)
+	}
+	l.Infow("Enqueued re-fulfillment", "ethTxID", transaction.ID, "fromAddress", res.FromAddress)
+	res.EthTxID = &transaction.ID
+	lsn.inflightCache.Add(req.Raw())
+	lsn.recordEnqueued(ctx, []*big.Int{requestID}, transaction.ID)
+	vrfcommon.IncProcessedReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version())
+	vrfcommon.IncLaneProcessedReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), lane.keyHash)
+	return res, nil
+}
+
+// fetchRequest fetches the RandomWordsRequested log of the given request for one of the lanes
+// from the chain.
+func (lsn *listenerV2) fetchRequest(ctx context.Context, requestID *big.Int, blockNumber *uint64, lanes []gasLane) (RandomWordsRequested, error) {
+	if blockNumber == nil {
+		bn, err := lsn.requestBlockNumber(ctx, requestID)
+		if err != nil {
+			return nil, err
+		}
+		blockNumber = &bn
+	}
+
+	var keyHashes [][32]byte
+	for _, lane := range lanes {
+		keyHashes = append(keyHashes, lane.keyHash)
+	}
+	it, err := lsn.coordinator.FilterRandomWordsRequested(&bind.FilterOpts{
+		Start:   *blockNumber,
+		End:     blockNumber,
+		Context: ctx,
+	}, keyHashes, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("filtering RandomWordsRequested logs: %w", err)
+	}
+	defer it.Close()
+	for it.Next() {
+		if it.Event().RequestID().Cmp(requestID) == 0 {
+			return it.Event(), nil
+		}
+	}
+	if err = it.Error(); err != nil {
+		return nil, fmt.Errorf("iterating RandomWordsRequested logs: %w", err)
+	}
+	return nil, fmt.Errorf("%w in block %d", vrfcommon.ErrRequestNotFound, *blockNumber)
+}
+
+// requestBlockNumber looks up the block a request was made in from the audit trail.
+func (lsn *listenerV2) requestBlockNumber(ctx context.Context, requestID *big.Int) (uint64, error) {
+	if lsn.requestLifecycle == nil {
+		return 0, fmt.Errorf("%w: block number is required", vrfcommon.ErrRequestNotFound)
+	}
+	lifecycles, err := lsn.requestLifecycle.FindByRequestID(ctx, requestID)
+	if err != nil {
+		return 0, err
+	}
+	for _, lc := range lifecycles {
+		if lc.JobID == lsn.job.ID {
+			return uint64(lc.RequestBlockNumber), nil
+		}
+	}
+	return 0, fmt.Errorf("%w: not observed by job %d, block number is required", vrfcommon.ErrRequestNotFound, lsn.job.ID)
+}
@@ -0,0 +1,209 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr"
+	txmgrcommon "github.com/smartcontractkit/chainlink-framework/chains/txmgr"
+	txmgrtypes "github.com/smartcontractkit/chainlink-framework/chains/txmgr/types"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// requestBlock is the block a request log was mined in.
+type requestBlock struct {
+	hash   common.Hash
+	number uint64
+}
+
+// requestBlocks remembers the block of each pending request the listener observed, so that the
+// requests mined in the blocks removed by a reorg can be found.
+type requestBlocks struct {
+	mu     sync.Mutex
+	blocks map[string]requestBlock
+}
+
+func newRequestBlocks() *requestBlocks {
+	return &requestBlocks{blocks: make(map[string]requestBlock)}
+}
+
+// observe records the block of the request log. It reports whether the request is new, or was
+// re-mined in another block since it was last observed.
+func (b *requestBlocks) observe(reqID string, lg types.Log) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	block := requestBlock{hash: lg.BlockHash, number: lg.BlockNumber}
+	if prev, ok := b.blocks[reqID]; ok && prev == block {
+		return false
+	}
+	b.blocks[reqID] = block
+	return true
+}
+
+func (b *requestBlocks) forget(reqID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.blocks, reqID)
+}
+
+// from returns the requests mined in the given block or after it.
+func (b *requestBlocks) from(blockNumber uint64) map[string]requestBlock {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	reqs := make(map[string]requestBlock)
+	for reqID, block := range b.blocks {
+		if block.number >= blockNumber {
+			reqs[reqID] = block
+		}
+	}
+	return reqs
+}
+
+// observeRequestBlocks records the blocks of the pending requests. It returns the requests that
+// were not observed before in their block, which are the only ones to record in the lifecycle
+// audit trail: every poll returns all the pending requests again.
+func (lsn *listenerV2) observeRequestBlocks(pending []pendingRequest) []pendingRequest {
+	if lsn.requestBlocks == nil {
+		return pending
+	}
+	var observed []pendingRequest
+	for _, p := range pending {
+		if lsn.requestBlocks.observe(p.req.RequestID().String(), p.req.Raw()) {
+			observed = append(observed, p)
+		}
+	}
+	return observed
+}
+
+// handleReorg invalidates the pending requests that were mined in the blocks the log poller
+// removed for a reorg, from blockAfterLCA on. The proof seed of a request depends on the hash of
+// its block, so their in-flight fulfillments would revert: they are abandoned through the txmgr.
+// The log poller then saves the logs of the canonical chain, and a request re-mined in another
+// block is processed like a new one, as the inflight cache holds the log of the old block, which
+// regenerates its proof for the new block. It returns an error if the blocks of the requests could
+// not be checked, for the reorg to be handled again later.
+func (lsn *listenerV2) handleReorg(ctx context.Context, blockAfterLCA int64) error {
+	if lsn.requestBlocks == nil || blockAfterLCA < 0 {
+		return nil
+	}
+	candidates := lsn.requestBlocks.from(uint64(blockAfterLCA))
+	if len(candidates) == 0 {
+		return nil
+	}
+	reorged, err := lsn.reorgedRequests(ctx, candidates)
+	if err != nil {
+		return err
+	}
+
+	fulfillments := lsn.inflightFulfillments(ctx)
+	abandoner, canAbandon := lsn.chain.TxManager().(txmgr.TxAbandoner)
+	for reqID, block := range reorged {
+		ll := lsn.l.With(
+			"reqID", reqID,
+			"blockHash", block.hash,
+			"blockNumber", block.number,
+			"blockAfterLCA", blockAfterLCA)
+		reqIDBig, _ := new(big.Int).SetString(reqID, 10)
+		var abandoned []int64
+		for _, txID := range fulfillments[common.BytesToHash(reqIDBig.Bytes())] {
+			if !canAbandon {
+				ll.Warnw("Transaction manager cannot abandon transactions, fulfillment of reorged request will revert on-chain", "ethTxID", txID)
+				continue
+			}
+			ok, err := abandoner.AbandonTx(ctx, txID)
+			if err != nil {
+				ll.Errorw("Failed to abandon fulfillment of reorged request, it will revert on-chain", "ethTxID", txID, "err", err)
+				continue
+			}
+			if ok {
+				abandoned = append(abandoned, txID)
+			}
+		}
+		ll.Warnw("Request block was removed by a reorg, its proof will be regenerated if it is re-mined", "abandonedEthTxIDs", abandoned)
+		lsn.requestBlocks.forget(reqID)
+		// A re-mined request is confirmed again, and its SLA measured from then.
+		lsn.slaForget(reqIDBig)
+		vrfcommon.IncReorgedReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version())
+		lsn.recordReorged(ctx, reqIDBig)
+	}
+	return nil
+}
+
+// reorgedRequests returns the candidates whose block is not part of the canonical chain of the
+// log poller anymore. Requests observed again in their new block since the reorg are left out.
+func (lsn *listenerV2) reorgedRequests(ctx context.Context, candidates map[string]requestBlock) (map[string]requestBlock, error) {
+	var numbers []uint64
+	seen := make(map[uint64]struct{})
+	for _, block := range candidates {
+		if _, ok := seen[block.number]; !ok {
+			seen[block.number] = struct{}{}
+			numbers = append(numbers, block.number)
+		}
+	}
+	blocks, err := lsn.chain.LogPoller().GetBlocksRange(ctx, numbers)
+	if err != nil {
+		return nil, fmt.Errorf("LogPoller.GetBlocksRange: %w", err)
+	}
+	canonical := make(map[uint64]common.Hash)
+	for _, block := range blocks {
+		canonical[uint64(block.BlockNumber)] = block.BlockHash //nolint:gosec // G115 false positive
+	}
+
+	reorged := make(map[string]requestBlock)
+	for reqID, block := range candidates {
+		if hash, ok := canonical[block.number]; !ok || hash != block.hash {
+			reorged[reqID] = block
+		}
+	}
+	return reorged, nil
+}
+
+// inflightFulfillments returns the IDs of the fulfillment transactions of this listener that are
+// not broadcast or not confirmed yet, by request ID.
+func (lsn *listenerV2) inflightFulfillments(ctx context.Context) map[common.Hash][]int64 {
+	txes, err := lsn.chain.TxManager().FindTxesWithMetaFieldByStates(ctx, "RequestID",
+		[]txmgrtypes.TxState{txmgrcommon.TxUnstarted, txmgrcommon.TxUnconfirmed}, lsn.chainID)
+	if err != nil {
+		lsn.l.Errorw("Failed to find in-flight fulfillments", "err", err)
+		return nil
+	}
+	toAddresses := []common.Address{lsn.coordinator.Address()}
+	if lsn.job.VRFSpec.VRFOwnerAddress != nil {
+		toAddresses = append(toAddresses, lsn.job.VRFSpec.VRFOwnerAddress.Address())
+	}
+	return fulfillmentsByRequest(txes, toAddresses)
+}
+
+func fulfillmentsByRequest(txes []*txmgr.Tx, toAddresses []common.Address) map[common.Hash][]int64 {
+	fulfillments := make(map[common.Hash][]int64)
+	for _, tx := range txes {
+		isFulfillment := false
+		for _, to := range toAddresses {
+			if tx.ToAddress == to {
+				isFulfillment = true
+				break
+			}
+		}
+		if !isFulfillment {
+			continue
+		}
+		meta, err := tx.GetMeta()
+		if err != nil || meta == nil || meta.RequestID == nil {
+			continue
+		}
+		fulfillments[*meta.RequestID] = append(fulfillments[*meta.RequestID], tx.ID)
+	}
+	return fulfillments
+}
+
+func (lsn *listenerV2) forgetRequestBlock(reqID *big.Int) {
+	if lsn.requestBlocks == nil {
+		return
+	}
+	lsn.requestBlocks.forget(reqID.String())
+}
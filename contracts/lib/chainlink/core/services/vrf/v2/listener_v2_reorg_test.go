@@ -0,0 +1,68 @@
+package v2
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr"
+	evmutils "github.com/smartcontractkit/chainlink-evm/pkg/utils"
+)
+
+func TestRequestBlocks(t *testing.T) {
+	t.Parallel()
+
+	blocks := newRequestBlocks()
+	block10 := types.Log{BlockHash: evmutils.NewHash(), BlockNumber: 10}
+	block11 := types.Log{BlockHash: evmutils.NewHash(), BlockNumber: 11}
+	assert.True(t, blocks.observe("1", block10))
+	assert.True(t, blocks.observe("2", block11))
+	assert.False(t, blocks.observe("1", block10), "already observed in its block")
+
+	assert.Len(t, blocks.from(10), 2)
+	assert.Equal(t, map[string]requestBlock{"2": {hash: block11.BlockHash, number: 11}}, blocks.from(11))
+	assert.Empty(t, blocks.from(12))
+
+	// A request re-mined in another block replaces the block it was observed in.
+	block12 := types.Log{BlockHash: evmutils.NewHash(), BlockNumber: 12}
+	assert.True(t, blocks.observe("1", block12))
+	assert.Equal(t, map[string]requestBlock{"1": {hash: block12.BlockHash, number: 12}}, blocks.from(12))
+
+	blocks.forget("1")
+	assert.Empty(t, blocks.from(12))
+}
+
+func TestFulfillmentsByRequest(t *testing.T) {
+	t.Parallel()
+
+	coordinator := evmutils.NewAddress()
+	vrfOwner := evmutils.NewAddress()
+	reqID := common.BytesToHash(big.NewInt(1).Bytes())
+	otherReqID := common.BytesToHash(big.NewInt(2).Bytes())
+
+	txes := []*txmgr.Tx{
+		fulfillmentTx(t, 1, coordinator, &reqID),
+		fulfillmentTx(t, 2, vrfOwner, &reqID),
+		fulfillmentTx(t, 3, coordinator, &otherReqID),
+		fulfillmentTx(t, 4, evmutils.NewAddress(), &reqID),
+		fulfillmentTx(t, 5, coordinator, nil),
+	}
+
+	assert.Equal(t, map[common.Hash][]int64{
+		reqID:      {1, 2},
+		otherReqID: {3},
+	}, fulfillmentsByRequest(txes, []common.Address{coordinator, vrfOwner}))
+}
+
+func fulfillmentTx(t *testing.T, id int64, to common.Address, reqID *common.Hash) *txmgr.Tx {
+	b, err := json.Marshal(txmgr.TxMeta{RequestID: reqID})
+	require.NoError(t, err)
+	meta := sqlutil.JSON(b)
+	return &txmgr.Tx{ID: id, ToAddress: to, Meta: &meta}
+}
@@ -0,0 +1,295 @@
+package v2
+
+import (
+	"cmp"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/time/rate"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// subRequests are the requests of a subscription that are processed in a round.
+type subRequests struct {
+	subID string
+	reqs  []pendingRequest
+}
+
+// throttledRequest is a ready request that is held back until a later round.
+type throttledRequest struct {
+	req    pendingRequest
+	reason vrfcommon.ThrottleReason
+}
+
+// requestScheduler decides which confirmed requests are processed in a round, and in which
+// order, so that a single noisy consumer or subscription can't starve the others:
+//   - each consumer contract and each subscription has a token bucket, refilled at the job's
+//     consumerRateLimit and subscriptionRateLimit, which a request is scheduled against and
+//     spends a token of once its fulfillment is enqueued, see charge;
+//   - the requests of a subscription are taken round-robin across its consumers, so a
+//     consumer with many requests doesn't use up the subscription's share or balance first;
+//   - subscriptions are ordered by smooth weighted round-robin and, when the job sets
+//     subscriptionWeights, each gets at most its weight times chunkSize requests per round.
+//
+// It is not safe for concurrent use, the listener only uses it from its processing loop.
+type requestScheduler struct {
+	consumerRate  rate.Limit
+	consumerBurst int
+	subRate       rate.Limit
+	subBurst      int
+	weights       job.VRFSubscriptionWeights
+	chunkSize     int
+
+	consumers map[common.Address]*rate.Limiter
+	subs      map[string]*rate.Limiter
+	// credit is the smooth weighted round-robin state of the subscriptions with ready requests.
+	credit map[string]int64
+	// scheduled are the requests of the last round, by request ID, whose tokens are not spent yet.
+	scheduled map[string]scheduledRequest
+}
+
+// scheduledRequest is the subscription and consumer a scheduled request spends tokens of.
+type scheduledRequest struct {
+	subID  string
+	sender common.Address
+}
+
+func newRequestScheduler(spec *job.VRFSpec) *requestScheduler {
+	return &requestScheduler{
+		consumerRate:  rate.Limit(spec.ConsumerRateLimit),
+		consumerBurst: int(spec.ConsumerRateLimitBurst),
+		subRate:       rate.Limit(spec.SubscriptionRateLimit),
+		subBurst:      int(spec.SubscriptionRateLimitBurst),
+		weights:       spec.SubscriptionWeights,
+		chunkSize:     int(spec.ChunkSize),
+		consumers:     make(map[common.Address]*rate.Limiter),
+		subs:          make(map[string]*rate.Limiter),
+		credit:        make(map[string]int64),
+		scheduled:     make(map[string]scheduledRequest),
+	}
+}
+
+// reconfigure applies the limits of an updated spec. The buckets keep their tokens and the
+// subscriptions their round-robin credit.
+func (s *requestScheduler) reconfigure(now time.Time, spec *job.VRFSpec) {
+	updated := newRequestScheduler(spec)
+	s.consumerRate, s.consumerBurst = updated.consumerRate, updated.consumerBurst
+	s.subRate, s.subBurst = updated.subRate, updated.subBurst
+	s.weights, s.chunkSize = updated.weights, updated.chunkSize
+	reconfigureLimiters(now, s.consumers, s.consumerRate, s.consumerBurst)
+	reconfigureLimiters(now, s.subs, s.subRate, s.subBurst)
+}
+
+// schedule returns the subscriptions to process this round, in order, with the requests to
+// process for each, and the requests that are held back. The tokens of the scheduled requests
+// are only reserved for the round, a request that is not enqueued does not spend any.
+func (s *requestScheduler) schedule(now time.Time, confirmed map[string][]pendingRequest) ([]subRequests, []throttledRequest) {
+	var (
+		scheduled []subRequests
+		throttled []throttledRequest
+		reserved  = make(map[common.Address]int)
+	)
+	clear(s.scheduled)
+	for _, subID := range s.order(confirmed) {
+		share := len(confirmed[subID])
+		if len(s.weights) > 0 {
+			share = int(s.weights.Weight(subID)) * s.chunkSize
+		}
+		subLimiter := getLimiter(s.subs, subID, s.subRate, s.subBurst)
+
+		var picked []pendingRequest
+		for _, req := range interleaveConsumers(confirmed[subID]) {
+			consumerLimiter := getLimiter(s.consumers, req.req.Sender(), s.consumerRate, s.consumerBurst)
+			switch {
+			case len(picked) >= share:
+				throttled = append(throttled, throttledRequest{req, vrfcommon.ThrottleSubscriptionShare})
+			case subLimiter != nil && subLimiter.TokensAt(now) < float64(len(picked)+1):
+				throttled = append(throttled, throttledRequest{req, vrfcommon.ThrottleSubscriptionRateLimit})
+			case consumerLimiter != nil && consumerLimiter.TokensAt(now) < float64(reserved[req.req.Sender()]+1):
+				throttled = append(throttled, throttledRequest{req, vrfcommon.ThrottleConsumerRateLimit})
+			default:
+				reserved[req.req.Sender()]++
+				s.scheduled[req.req.RequestID().String()] = scheduledRequest{subID: subID, sender: req.req.Sender()}
+				picked = append(picked, req)
+			}
+		}
+		if len(picked) > 0 {
+			scheduled = append(scheduled, subRequests{subID: subID, reqs: picked})
+		}
+	}
+	s.prune(now)
+	return scheduled, throttled
+}
+
+// charge spends the tokens of the scheduled requests whose fulfillments were enqueued.
+// Requests that were not scheduled in the last round, or were already charged, are ignored.
+func (s *requestScheduler) charge(now time.Time, reqIDs []*big.Int) {
+	for _, reqID := range reqIDs {
+		req, ok := s.scheduled[reqID.String()]
+		if !ok {
+			continue
+		}
+		delete(s.scheduled, reqID.String())
+		if lim := getLimiter(s.subs, req.subID, s.subRate, s.subBurst); lim != nil {
+			lim.AllowN(now, 1)
+		}
+		if lim := getLimiter(s.consumers, req.sender, s.consumerRate, s.consumerBurst); lim != nil {
+			lim.AllowN(now, 1)
+		}
+	}
+}
+
+// order returns the subscriptions in smooth weighted round-robin order: the subscription
+// with the most credit goes first, and pays for it with the weight of the others, so that
+// over rounds each subscription goes first in proportion to its weight.
+func (s *requestScheduler) order(confirmed map[string][]pendingRequest) []string {
+	for subID := range s.credit {
+		if _, ok := confirmed[subID]; !ok {
+			delete(s.credit, subID)
+		}
+	}
+
+	remaining := make([]string, 0, len(confirmed))
+	for subID := range confirmed {
+		remaining = append(remaining, subID)
+	}
+	slices.Sort(remaining)
+
+	ordered := make([]string, 0, len(remaining))
+	for len(remaining) > 0 {
+		var total int64
+		best := 0
+		for i, subID := range remaining {
+			weight := int64(s.weights.Weight(subID))
+			s.credit[subID] += weight
+			total += weight
+			if s.credit[subID] > s.credit[remaining[best]] {
+				best = i
+			}
+		}
+		s.credit[remaining[best]] -= total
+		ordered = append(ordered, remaining[best])
+		remaining = slices.Delete(remaining, best, best+1)
+	}
+	return ordered
+}
+
+// schedule applies the job's fairness limits to the confirmed requests, and returns the
+// number of requests held back. Without a scheduler, as in some tests, every subscription
+// is processed with all its requests, cheapest first.
+func (lsn *listenerV2) schedule(confirmed map[string][]pendingRequest) ([]subRequests, int) {
+	if lsn.scheduler == nil {
+		scheduled := make([]subRequests, 0, len(confirmed))
+		for subID, reqs := range confirmed {
+			slices.SortFunc(reqs, func(a, b pendingRequest) int {
+				return cmp.Compare(a.req.CallbackGasLimit(), b.req.CallbackGasLimit())
+			})
+			scheduled = append(scheduled, subRequests{subID: subID, reqs: reqs})
+		}
+		return scheduled, 0
+	}
+
+	scheduled, throttled := lsn.scheduler.schedule(time.Now(), confirmed)
+	for _, t := range throttled {
+		lsn.l.Debugw("Holding back request until a later round",
+			"reqID", t.req.req.RequestID().String(),
+			"subID", t.req.req.SubID().String(),
+			"sender", t.req.req.Sender(),
+			"reason", t.reason)
+		vrfcommon.IncThrottledReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), t.reason)
+	}
+	if len(throttled) > 0 {
+		lsn.l.Infow("Throttled pending requests", "throttled", len(throttled))
+	}
+	return scheduled, len(throttled)
+}
+
+// chargeScheduled spends the scheduler's tokens of the requests whose fulfillments were
+// enqueued.
+func (lsn *listenerV2) chargeScheduled(reqIDs []*big.Int) {
+	if lsn.scheduler == nil {
+		return
+	}
+	lsn.scheduler.charge(time.Now(), reqIDs)
+}
+
+// getLimiter returns the bucket of key, nil if limit disables it.
+func getLimiter[K comparable](limiters map[K]*rate.Limiter, key K, limit rate.Limit, burst int) *rate.Limiter {
+	if limit == 0 {
+		return nil
+	}
+	lim, ok := limiters[key]
+	if !ok {
+		lim = rate.NewLimiter(limit, max(burst, 1))
+		limiters[key] = lim
+	}
+	return lim
+}
+
+// reconfigureLimiters applies limit and burst to existing buckets, and drops them all if
+// limit disables them.
+func reconfigureLimiters[K comparable](now time.Time, limiters map[K]*rate.Limiter, limit rate.Limit, burst int) {
+	for key, lim := range limiters {
+		if limit == 0 {
+			delete(limiters, key)
+			continue
+		}
+		lim.SetLimitAt(now, limit)
+		lim.SetBurstAt(now, max(burst, 1))
+	}
+}
+
+// prune forgets the buckets that have refilled, they are the same as new ones.
+func (s *requestScheduler) prune(now time.Time) {
+	for consumer, lim := range s.consumers {
+		if lim.TokensAt(now) >= float64(lim.Burst()) {
+			delete(s.consumers, consumer)
+		}
+	}
+	for subID, lim := range s.subs {
+		if lim.TokensAt(now) >= float64(lim.Burst()) {
+			delete(s.subs, subID)
+		}
+	}
+}
+
+// interleaveConsumers orders the requests of a subscription round-robin across consumers:
+// each consumer's cheapest request by callback gas limit, then each one's second cheapest,
+// and so on. Within a round the requests are ordered cheapest first, so that an expensive
+// request which the subscription can't pay for does not hold back cheaper ones.
+func interleaveConsumers(reqs []pendingRequest) []pendingRequest {
+	var (
+		consumers  []common.Address
+		byConsumer = make(map[common.Address][]pendingRequest)
+	)
+	for _, req := range reqs {
+		sender := req.req.Sender()
+		if _, ok := byConsumer[sender]; !ok {
+			consumers = append(consumers, sender)
+		}
+		byConsumer[sender] = append(byConsumer[sender], req)
+	}
+	for _, consumerReqs := range byConsumer {
+		slices.SortStableFunc(consumerReqs, func(a, b pendingRequest) int {
+			return cmp.Compare(a.req.CallbackGasLimit(), b.req.CallbackGasLimit())
+		})
+	}
+
+	interleaved := make([]pendingRequest, 0, len(reqs))
+	for i := 0; len(interleaved) < len(reqs); i++ {
+		round := len(interleaved)
+		for _, consumer := range consumers {
+			if i < len(byConsumer[consumer]) {
+				interleaved = append(interleaved, byConsumer[consumer][i])
+			}
+		}
+		slices.SortStableFunc(interleaved[round:], func(a, b pendingRequest) int {
+			return cmp.Compare(a.req.CallbackGasLimit(), b.req.CallbackGasLimit())
+		})
+	}
+	return interleaved
+}
@@ -0,0 +1,201 @@
+package v2
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+func newScheduledRequest(reqID int64, subID uint64, sender common.Address, callbackGasLimit uint32) pendingRequest {
+	return pendingRequest{
+		req: NewV2RandomWordsRequested(&vrf_coordinator_v2.VRFCoordinatorV2RandomWordsRequested{
+			RequestId:        big.NewInt(reqID),
+			SubId:            subID,
+			Sender:           sender,
+			CallbackGasLimit: callbackGasLimit,
+		}),
+	}
+}
+
+func requestIDs(reqs []pendingRequest) []int64 {
+	var ids []int64
+	for _, req := range reqs {
+		ids = append(ids, req.req.RequestID().Int64())
+	}
+	return ids
+}
+
+func throttleReasons(throttled []throttledRequest) map[int64]vrfcommon.ThrottleReason {
+	reasons := make(map[int64]vrfcommon.ThrottleReason)
+	for _, t := range throttled {
+		reasons[t.req.req.RequestID().Int64()] = t.reason
+	}
+	return reasons
+}
+
+func TestRequestScheduler_InterleavesConsumers(t *testing.T) {
+	t.Parallel()
+
+	noisy, quiet := testutils.NewAddress(), testutils.NewAddress()
+	s := newRequestScheduler(&job.VRFSpec{ChunkSize: 10})
+
+	scheduled, throttled := s.schedule(time.Now(), map[string][]pendingRequest{
+		"1": {
+			newScheduledRequest(1, 1, noisy, 300),
+			newScheduledRequest(2, 1, noisy, 100),
+			newScheduledRequest(3, 1, noisy, 200),
+			newScheduledRequest(4, 1, quiet, 500),
+			newScheduledRequest(5, 1, quiet, 50),
+		},
+	})
+	require.Empty(t, throttled)
+	require.Len(t, scheduled, 1)
+	// Each consumer's cheapest request, then each one's second cheapest, and so on, cheapest
+	// first within each round.
+	assert.Equal(t, []int64{5, 2, 3, 4, 1}, requestIDs(scheduled[0].reqs))
+}
+
+func TestRequestScheduler_RateLimits(t *testing.T) {
+	t.Parallel()
+
+	noisy, quiet := testutils.NewAddress(), testutils.NewAddress()
+	now := time.Now()
+
+	t.Run("per consumer", func(t *testing.T) {
+		s := newRequestScheduler(&job.VRFSpec{ChunkSize: 10, ConsumerRateLimit: 1, ConsumerRateLimitBurst: 2})
+		confirmed := map[string][]pendingRequest{
+			"1": {
+				newScheduledRequest(1, 1, noisy, 100),
+				newScheduledRequest(2, 1, noisy, 100),
+				newScheduledRequest(3, 1, noisy, 100),
+				newScheduledRequest(4, 1, quiet, 100),
+			},
+		}
+
+		scheduled, throttled := s.schedule(now, confirmed)
+		require.Len(t, scheduled, 1)
+		assert.ElementsMatch(t, []int64{1, 2, 4}, requestIDs(scheduled[0].reqs))
+		assert.Equal(t, map[int64]vrfcommon.ThrottleReason{3: vrfcommon.ThrottleConsumerRateLimit}, throttleReasons(throttled))
+
+		// Requests whose fulfillments were not enqueued do not spend tokens.
+		scheduled, throttled = s.schedule(now, confirmed)
+		require.Len(t, scheduled, 1)
+		assert.ElementsMatch(t, []int64{1, 2, 4}, requestIDs(scheduled[0].reqs))
+		assert.Len(t, throttled, 1)
+
+		s.charge(now, []*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(4)})
+		scheduled, throttled = s.schedule(now, map[string][]pendingRequest{"1": confirmed["1"][2:3]})
+		assert.Empty(t, scheduled)
+		assert.Equal(t, map[int64]vrfcommon.ThrottleReason{3: vrfcommon.ThrottleConsumerRateLimit}, throttleReasons(throttled))
+
+		// The bucket refills at one request per second.
+		scheduled, throttled = s.schedule(now.Add(time.Second), map[string][]pendingRequest{"1": confirmed["1"][2:3]})
+		require.Len(t, scheduled, 1)
+		assert.Equal(t, []int64{3}, requestIDs(scheduled[0].reqs))
+		assert.Empty(t, throttled)
+	})
+
+	t.Run("per subscription", func(t *testing.T) {
+		s := newRequestScheduler(&job.VRFSpec{ChunkSize: 10, SubscriptionRateLimit: 1, SubscriptionRateLimitBurst: 2})
+
+		scheduled, throttled := s.schedule(now, map[string][]pendingRequest{
+			"1": {
+				newScheduledRequest(1, 1, noisy, 100),
+				newScheduledRequest(2, 1, noisy, 100),
+				newScheduledRequest(3, 1, quiet, 100),
+			},
+			"2": {
+				newScheduledRequest(4, 2, quiet, 100),
+			},
+		})
+		require.Len(t, scheduled, 2)
+		assert.Equal(t, map[int64]vrfcommon.ThrottleReason{2: vrfcommon.ThrottleSubscriptionRateLimit}, throttleReasons(throttled))
+	})
+}
+
+func TestRequestScheduler_WeightedRoundRobin(t *testing.T) {
+	t.Parallel()
+
+	consumer := testutils.NewAddress()
+	s := newRequestScheduler(&job.VRFSpec{
+		ChunkSize:           2,
+		SubscriptionWeights: job.VRFSubscriptionWeights{"1": 2},
+	})
+
+	confirmed := map[string][]pendingRequest{"1": {}, "2": {}}
+	for i := int64(0); i < 5; i++ {
+		confirmed["1"] = append(confirmed["1"], newScheduledRequest(10+i, 1, consumer, 100))
+		confirmed["2"] = append(confirmed["2"], newScheduledRequest(20+i, 2, consumer, 100))
+	}
+
+	t.Run("each subscription gets its weighted share of the round", func(t *testing.T) {
+		scheduled, throttled := s.schedule(time.Now(), confirmed)
+		require.Len(t, scheduled, 2)
+		shares := map[string]int{}
+		for _, sr := range scheduled {
+			shares[sr.subID] = len(sr.reqs)
+		}
+		assert.Equal(t, map[string]int{"1": 4, "2": 2}, shares)
+		assert.Len(t, throttled, 4)
+		for _, tr := range throttled {
+			assert.Equal(t, vrfcommon.ThrottleSubscriptionShare, tr.reason)
+		}
+	})
+
+	t.Run("subscriptions go first in proportion to their weight", func(t *testing.T) {
+		first := map[string]int{}
+		for i := 0; i < 30; i++ {
+			scheduled, _ := s.schedule(time.Now(), confirmed)
+			first[scheduled[0].subID]++
+		}
+		assert.Equal(t, map[string]int{"1": 20, "2": 10}, first)
+	})
+}
+
+func TestListener_Schedule(t *testing.T) {
+	t.Parallel()
+
+	noisy, quiet := testutils.NewAddress(), testutils.NewAddress()
+	confirmed := func() map[string][]pendingRequest {
+		return map[string][]pendingRequest{
+			"1": {
+				newScheduledRequest(1, 1, noisy, 100),
+				newScheduledRequest(2, 1, noisy, 200),
+				newScheduledRequest(3, 1, noisy, 300),
+				newScheduledRequest(4, 1, quiet, 500),
+			},
+		}
+	}
+
+	t.Run("keeps the order of the scheduler", func(t *testing.T) {
+		spec := &job.VRFSpec{ChunkSize: 10, ConsumerRateLimit: 1, ConsumerRateLimitBurst: 2}
+		lsn := &listenerV2{
+			l:           logger.Sugared(logger.Test(t)),
+			job:         job.Job{VRFSpec: spec},
+			coordinator: NewCoordinatorV2(nil),
+			scheduler:   newRequestScheduler(spec),
+		}
+		scheduled, throttled := lsn.schedule(confirmed())
+		require.Len(t, scheduled, 1)
+		assert.Equal(t, []int64{1, 4, 2}, requestIDs(scheduled[0].reqs))
+		assert.Equal(t, 1, throttled)
+	})
+
+	t.Run("without a scheduler, cheapest first", func(t *testing.T) {
+		lsn := &listenerV2{l: logger.Sugared(logger.Test(t))}
+		scheduled, throttled := lsn.schedule(confirmed())
+		require.Len(t, scheduled, 1)
+		assert.Equal(t, []int64{1, 2, 3, 4}, requestIDs(scheduled[0].reqs))
+		assert.Zero(t, throttled)
+	})
+}
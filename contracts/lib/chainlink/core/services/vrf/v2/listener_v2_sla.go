@@ -0,0 +1,221 @@
+package v2
+
+import (
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// slaRequest is a confirmed request the slaMonitor waits to see fulfilled.
+type slaRequest struct {
+	req              RandomWordsRequested
+	confirmedAtBlock uint64
+	confirmedAt      time.Time
+	breached         bool
+}
+
+// slaBreach is a request that was not fulfilled within the job's SLA.
+type slaBreach struct {
+	req           *slaRequest
+	sla           vrfcommon.SLAKind
+	elapsedBlocks uint64
+	elapsed       time.Duration
+}
+
+// slaMonitor follows confirmed requests until they are fulfilled, to measure their
+// fulfillment latency and detect those that breach the job's fulfillment SLA, both from their
+// confirmation. Requests are confirmed once the listener first finds them ready for processing.
+// The time of the confirmation is the one recorded in the request's lifecycle, so that it
+// survives restarts of the node. Without it, e.g. if it couldn't be recorded, it is the time
+// the monitor first followed the request, which is reset by a restart.
+//
+// It is not safe for concurrent use, the listener only uses it from its processing loop.
+type slaMonitor struct {
+	blocks   uint64
+	duration time.Duration
+	// webhook is nil if the job has no slaWebhookURL.
+	webhook  *vrfcommon.SLAWebhook
+	requests map[string]*slaRequest
+}
+
+func newSLAMonitor(spec *job.VRFSpec, httpClient *http.Client, httpTimeout time.Duration) *slaMonitor {
+	m := &slaMonitor{
+		blocks:   uint64(spec.FulfillmentSLABlocks),
+		duration: spec.FulfillmentSLA,
+		requests: make(map[string]*slaRequest),
+	}
+	if spec.SLAWebhookURL != "" {
+		m.webhook = vrfcommon.NewSLAWebhook(spec.SLAWebhookURL, httpClient, httpTimeout)
+	}
+	return m
+}
+
+// confirmed starts following the requests that aren't followed yet. confirmedAt holds the
+// recorded confirmation times of the requests, by request ID.
+func (m *slaMonitor) confirmed(now time.Time, confirmed map[string][]pendingRequest, confirmedAt map[string]time.Time) {
+	for _, reqs := range confirmed {
+		for _, r := range reqs {
+			reqID := r.req.RequestID().String()
+			if _, ok := m.requests[reqID]; ok {
+				continue
+			}
+			at, ok := confirmedAt[reqID]
+			if !ok {
+				at = now
+			}
+			m.requests[reqID] = &slaRequest{
+				req:              r.req,
+				confirmedAtBlock: r.confirmedAtBlock,
+				confirmedAt:      at,
+			}
+		}
+	}
+}
+
+// pendingBreaches returns the requests that breached the SLA while still pending, and were
+// not reported yet.
+func (m *slaMonitor) pendingBreaches(now time.Time, head uint64) []slaBreach {
+	var breaches []slaBreach
+	for _, r := range m.requests {
+		if breach, ok := m.check(r, now, head); ok {
+			breaches = append(breaches, breach)
+		}
+	}
+	return breaches
+}
+
+// fulfilled stops following the request. It returns the request's fulfillment latency since
+// its confirmation, and a breach if it was fulfilled late and not reported yet. ok is false
+// for requests that weren't followed, e.g. those of other jobs.
+func (m *slaMonitor) fulfilled(now time.Time, fulfilled RandomWordsFulfilled) (latency time.Duration, breach *slaBreach, ok bool) {
+	reqID := fulfilled.RequestID().String()
+	r, ok := m.requests[reqID]
+	if !ok {
+		return 0, nil, false
+	}
+	delete(m.requests, reqID)
+	if b, breached := m.check(r, now, fulfilled.Raw().BlockNumber); breached {
+		breach = &b
+	}
+	return now.Sub(r.confirmedAt), breach, true
+}
+
+// forget stops following a request that will not be fulfilled, e.g. a dropped one.
+func (m *slaMonitor) forget(reqID *big.Int) {
+	delete(m.requests, reqID.String())
+}
+
+func (m *slaMonitor) check(r *slaRequest, now time.Time, block uint64) (slaBreach, bool) {
+	if r.breached {
+		return slaBreach{}, false
+	}
+	var elapsedBlocks uint64
+	if block > r.confirmedAtBlock {
+		elapsedBlocks = block - r.confirmedAtBlock
+	}
+	elapsed := now.Sub(r.confirmedAt)
+
+	breach := slaBreach{req: r, elapsedBlocks: elapsedBlocks, elapsed: elapsed}
+	switch {
+	case m.blocks > 0 && elapsedBlocks > m.blocks:
+		breach.sla = vrfcommon.SLABlocks
+	case m.duration > 0 && elapsed > m.duration:
+		breach.sla = vrfcommon.SLADuration
+	default:
+		return slaBreach{}, false
+	}
+	r.breached = true
+	return breach, true
+}
+
+// slaConfirmed follows the newly confirmed requests, and reports the pending requests that
+// breached the SLA.
+func (lsn *listenerV2) slaConfirmed(confirmed map[string][]pendingRequest, confirmedAt map[string]time.Time) {
+	if lsn.sla == nil {
+		return
+	}
+	now := time.Now()
+	lsn.sla.confirmed(now, confirmed, confirmedAt)
+	for _, breach := range lsn.sla.pendingBreaches(now, lsn.getLatestHead()) {
+		lsn.reportSLABreach(breach, nil)
+	}
+}
+
+// slaFulfilled records the fulfillment latency of a request, and reports it if it was
+// fulfilled late.
+func (lsn *listenerV2) slaFulfilled(fulfilled RandomWordsFulfilled) {
+	if lsn.sla == nil {
+		return
+	}
+	latency, breach, ok := lsn.sla.fulfilled(time.Now(), fulfilled)
+	if !ok {
+		return
+	}
+	vrfcommon.ObserveFulfillmentLatency(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), latency)
+	if breach != nil {
+		txHash := fulfilled.Raw().TxHash
+		lsn.reportSLABreach(*breach, &txHash)
+	}
+}
+
+func (lsn *listenerV2) slaForget(reqID *big.Int) {
+	if lsn.sla == nil {
+		return
+	}
+	lsn.sla.forget(reqID)
+}
+
+func (lsn *listenerV2) reportSLABreach(b slaBreach, fulfillmentTxHash *common.Hash) {
+	event := vrfcommon.SLABreach{
+		JobID:              lsn.job.ID,
+		ExternalJobID:      lsn.job.ExternalJobID,
+		JobName:            lsn.job.Name.ValueOrZero(),
+		EVMChainID:         lsn.chainID.String(),
+		CoordinatorAddress: lsn.coordinator.Address(),
+		RequestID:          b.req.req.RequestID().String(),
+		SubID:              b.req.req.SubID().String(),
+		Sender:             b.req.req.Sender(),
+		RequestTxHash:      b.req.req.Raw().TxHash,
+		SLA:                b.sla,
+		SLABlocks:          uint32(lsn.sla.blocks),
+		SLADuration:        lsn.sla.duration.String(),
+		ConfirmedAtBlock:   b.req.confirmedAtBlock,
+		ConfirmedAt:        b.req.confirmedAt,
+		ElapsedBlocks:      b.elapsedBlocks,
+		Elapsed:            b.elapsed.String(),
+		Fulfilled:          fulfillmentTxHash != nil,
+		FulfillmentTxHash:  fulfillmentTxHash,
+		DetectedAt:         time.Now(),
+	}
+	lsn.l.Errorw("VRF request breached fulfillment SLA",
+		"reqID", event.RequestID,
+		"subID", event.SubID,
+		"sender", event.Sender,
+		"requestTxHash", event.RequestTxHash,
+		"sla", event.SLA,
+		"slaBlocks", event.SLABlocks,
+		"slaDuration", event.SLADuration,
+		"confirmedAtBlock", event.ConfirmedAtBlock,
+		"elapsedBlocks", event.ElapsedBlocks,
+		"elapsed", event.Elapsed,
+		"fulfilled", event.Fulfilled)
+	vrfcommon.IncSLABreaches(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, lsn.coordinator.Version(), b.sla)
+
+	if lsn.sla.webhook == nil {
+		return
+	}
+	lsn.wg.Add(1)
+	go func() {
+		defer lsn.wg.Done()
+		ctx, cancel := lsn.chStop.NewCtx()
+		defer cancel()
+		if err := lsn.sla.webhook.Notify(ctx, event); err != nil {
+			lsn.l.Warnw("Failed to notify SLA webhook", "err", err, "reqID", event.RequestID)
+		}
+	}()
+}
@@ -0,0 +1,94 @@
+package v2
+
+import (
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+func newSLARequest(reqID int64, confirmedAtBlock uint64) pendingRequest {
+	return pendingRequest{
+		confirmedAtBlock: confirmedAtBlock,
+		req: NewV2RandomWordsRequested(&vrf_coordinator_v2.VRFCoordinatorV2RandomWordsRequested{
+			RequestId: big.NewInt(reqID),
+			SubId:     1,
+			Sender:    testutils.NewAddress(),
+		}),
+	}
+}
+
+func newSLAFulfillment(reqID int64, blockNumber uint64) RandomWordsFulfilled {
+	return NewV2RandomWordsFulfilled(&vrf_coordinator_v2.VRFCoordinatorV2RandomWordsFulfilled{
+		RequestId: big.NewInt(reqID),
+		Success:   true,
+		Raw:       types.Log{BlockNumber: blockNumber},
+	})
+}
+
+func TestSLAMonitor(t *testing.T) {
+	t.Parallel()
+
+	start := time.Now()
+	m := newSLAMonitor(&job.VRFSpec{FulfillmentSLABlocks: 10, FulfillmentSLA: time.Minute}, http.DefaultClient, 0)
+	// Request 1 was confirmed before a restart, as recorded in its lifecycle.
+	m.confirmed(start, map[string][]pendingRequest{
+		"1": {
+			newSLARequest(1, 100),
+			newSLARequest(2, 100),
+			newSLARequest(3, 100),
+			newSLARequest(4, 100),
+		},
+	}, map[string]time.Time{"1": start.Add(-30 * time.Second)})
+
+	t.Run("fulfilled within the SLA", func(t *testing.T) {
+		latency, breach, ok := m.fulfilled(start.Add(10*time.Second), newSLAFulfillment(1, 105))
+		require.True(t, ok)
+		assert.Nil(t, breach)
+		assert.Equal(t, 40*time.Second, latency)
+	})
+
+	t.Run("pending past the block SLA", func(t *testing.T) {
+		assert.Empty(t, m.pendingBreaches(start.Add(10*time.Second), 110))
+
+		breaches := m.pendingBreaches(start.Add(10*time.Second), 111)
+		require.Len(t, breaches, 3)
+		for _, b := range breaches {
+			assert.Equal(t, vrfcommon.SLABlocks, b.sla)
+			assert.Equal(t, uint64(11), b.elapsedBlocks)
+		}
+
+		// Breaches are only reported once per request.
+		assert.Empty(t, m.pendingBreaches(start.Add(2*time.Minute), 200))
+		_, breach, ok := m.fulfilled(start.Add(2*time.Minute), newSLAFulfillment(2, 200))
+		require.True(t, ok)
+		assert.Nil(t, breach)
+	})
+
+	t.Run("fulfilled past the duration SLA", func(t *testing.T) {
+		m.confirmed(start, map[string][]pendingRequest{"1": {newSLARequest(5, 300)}}, nil)
+
+		_, breach, ok := m.fulfilled(start.Add(2*time.Minute), newSLAFulfillment(5, 301))
+		require.True(t, ok)
+		require.NotNil(t, breach)
+		assert.Equal(t, vrfcommon.SLADuration, breach.sla)
+		assert.Equal(t, 2*time.Minute, breach.elapsed)
+	})
+
+	t.Run("requests that are not followed", func(t *testing.T) {
+		m.forget(big.NewInt(3))
+		_, _, ok := m.fulfilled(start, newSLAFulfillment(3, 101))
+		assert.False(t, ok)
+		_, _, ok = m.fulfilled(start, newSLAFulfillment(42, 101))
+		assert.False(t, ok)
+	})
+}
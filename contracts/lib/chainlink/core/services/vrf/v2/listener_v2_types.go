@@ -1,6 +1,7 @@
 package v2
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"time"
@@ -11,7 +12,6 @@ import (
 	"github.com/smartcontractkit/chainlink-common/pkg/logger"
 	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr"
-	txmgrcommon "github.com/smartcontractkit/chainlink-framework/chains/txmgr"
 	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
 )
@@ -90,6 +90,9 @@ type batchFulfillment struct {
 	txHashes      []common.Hash
 	fromAddress   common.Address
 	version       vrfcommon.Version
+	// keyHash is the gas lane of the batch, requests of different lanes are never batched
+	// together.
+	keyHash common.Hash
 }
 
 func newBatchFulfillment(result vrfPipelineResult, fromAddress common.Address, version vrfcommon.Version) *batchFulfillment {
@@ -115,6 +118,7 @@ func newBatchFulfillment(result vrfPipelineResult, fromAddress common.Address, v
 		},
 		fromAddress: fromAddress,
 		version:     version,
+		keyHash:     result.req.req.KeyHash(),
 	}
 }
 
@@ -241,7 +245,7 @@ func (lsn *listenerV2) processBatch(
 			ToAddress:      lsn.batchCoordinator.Address(),
 			EncodedPayload: payload,
 			FeeLimit:       uint64(totalGasLimitBumped),
-			Strategy:       txmgrcommon.NewSendEveryStrategy(),
+			Strategy:       fulfillmentStrategy(),
 			Meta: &txmgr.TxMeta{
 				RequestIDs:      reqIDHashes,
 				MaxLink:         &maxLink,
@@ -262,12 +266,15 @@ func (lsn *listenerV2) processBatch(
 		return
 	}
 	ll.Infow("Enqueued fulfillment", "ethTxID", ethTX.GetID())
+	lsn.recordEnqueued(ctx, batch.reqIDs, ethTX.ID)
+	lsn.chargeScheduled(batch.reqIDs)
 
 	// mark requests as processed since the fulfillment has been successfully enqueued
 	// to the txm.
 	for _, reqID := range batch.reqIDs {
 		processedRequestIDs = append(processedRequestIDs, reqID.String())
 		vrfcommon.IncProcessedReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, vrfcommon.V2)
+		vrfcommon.IncLaneProcessedReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, vrfcommon.V2, batch.keyHash)
 	}
 
 	ll.Infow("Successfully enqueued batch", "duration", time.Since(start))
@@ -277,7 +284,7 @@ func (lsn *listenerV2) processBatch(
 
 // getReadyAndExpired filters out requests that are expired from the given pendingRequest slice
 // and returns requests that are ready for processing.
-func (lsn *listenerV2) getReadyAndExpired(l logger.Logger, reqs []pendingRequest) (ready []pendingRequest, expired []string) {
+func (lsn *listenerV2) getReadyAndExpired(ctx context.Context, l logger.Logger, reqs []pendingRequest) (ready []pendingRequest, expired []string) {
 	for _, req := range reqs {
 		// Check if we can ignore the request due to its age.
 		if time.Now().UTC().Sub(req.utcTimestamp) >= lsn.job.VRFSpec.RequestTimeout {
@@ -286,6 +293,9 @@ func (lsn *listenerV2) getReadyAndExpired(l logger.Logger, reqs []pendingRequest
 				"txHash", req.req.Raw().TxHash)
 			expired = append(expired, req.req.RequestID().String())
 			vrfcommon.IncDroppedReqs(lsn.job.Name.ValueOrZero(), lsn.job.ExternalJobID, vrfcommon.V2, vrfcommon.ReasonAge)
+			lsn.recordDropped(ctx, req.req.RequestID(), vrfcommon.ReasonAge)
+			lsn.slaForget(req.req.RequestID())
+			lsn.forgetRequestBlock(req.req.RequestID())
 			continue
 		}
 		// we always check if the requests are already fulfilled prior to trying to fulfill them again
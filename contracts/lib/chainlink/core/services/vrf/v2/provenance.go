@@ -0,0 +1,418 @@
+package v2
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/pkg/errors"
+	"go.dedis.ch/kyber/v3"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2_5"
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/proof"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// fulfillmentABIs are the contracts a node sends fulfillments to. The VRF owner's
+// fulfillRandomWords has the same selector as the v2 coordinator's.
+var fulfillmentABIs = []abi.ABI{coordinatorV2ABI, coordinatorV2PlusABI, batchCoordinatorV2ABI, batchCoordinatorV2PlusABI, vrfOwnerABI}
+
+// ProvenanceClient is the subset of an RPC client needed to build a provenance bundle.
+type ProvenanceClient interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, error)
+}
+
+// ProvenanceBundle holds everything needed to verify the randomness of a fulfilled request
+// without trusting the node: the request log, the inputs of proof.FinalSeed, the VRF proof,
+// the fulfillment transaction and log, and the random words derived from the proof's output.
+// The logs and the transaction can in turn be checked against any block explorer.
+type ProvenanceBundle struct {
+	Version     vrfcommon.Version `json:"version"`
+	ChainID     *ubig.Big         `json:"chainID"`
+	Coordinator common.Address    `json:"coordinator"`
+	RequestID   *ubig.Big         `json:"requestID"`
+	KeyHash     common.Hash       `json:"keyHash"`
+	// RequestLog is the RandomWordsRequested log of the request.
+	RequestLog types.Log `json:"requestLog"`
+	// PreSeed and BlockHash are mixed by proof.FinalSeed into the seed of the proof.
+	PreSeed   *ubig.Big       `json:"preSeed"`
+	BlockHash common.Hash     `json:"blockHash"`
+	Proof     ProvenanceProof `json:"proof"`
+	// FulfillmentTx is the signed fulfillment transaction, in its binary encoding. It may
+	// be a batch fulfillment, or go through the VRF owner.
+	FulfillmentTxHash common.Hash   `json:"fulfillmentTxHash"`
+	FulfillmentTx     hexutil.Bytes `json:"fulfillmentTx"`
+	// FulfillmentLog is the RandomWordsFulfilled log of the request.
+	FulfillmentLog types.Log `json:"fulfillmentLog"`
+	// RandomWords are the words the coordinator passed to the consumer.
+	RandomWords []*ubig.Big `json:"randomWords"`
+}
+
+// ProvenanceProof holds the fields of a vrfkey.Proof. Seed is the final seed, not the preSeed
+// the on-chain proof carries.
+type ProvenanceProof struct {
+	PublicKey secp256k1.PublicKey `json:"publicKey"`
+	Gamma     [2]*ubig.Big        `json:"gamma"`
+	C         *ubig.Big           `json:"c"`
+	S         *ubig.Big           `json:"s"`
+	Seed      *ubig.Big           `json:"seed"`
+	Output    *ubig.Big           `json:"output"`
+}
+
+// VRFProof returns the vrfkey.Proof p holds.
+func (p ProvenanceProof) VRFProof() (vrfkey.Proof, error) {
+	if p.Gamma[0] == nil || p.Gamma[1] == nil || p.C == nil || p.S == nil || p.Seed == nil || p.Output == nil {
+		return vrfkey.Proof{}, errors.New("incomplete proof")
+	}
+	pk, err := p.PublicKey.Point()
+	if err != nil {
+		return vrfkey.Proof{}, errors.Wrap(err, "invalid public key")
+	}
+	gamma, err := unmarshalPoint([2]*big.Int{p.Gamma[0].ToInt(), p.Gamma[1].ToInt()})
+	if err != nil {
+		return vrfkey.Proof{}, errors.Wrap(err, "invalid gamma")
+	}
+	return vrfkey.Proof{
+		PublicKey: pk,
+		Gamma:     gamma,
+		C:         p.C.ToInt(),
+		S:         p.S.ToInt(),
+		Seed:      p.Seed.ToInt(),
+		Output:    p.Output.ToInt(),
+	}, nil
+}
+
+// onChainProof is the proof struct of the coordinators' fulfillRandomWords. Its seed is the
+// preSeed of the request.
+type onChainProof struct {
+	Pk            [2]*big.Int
+	Gamma         [2]*big.Int
+	C             *big.Int
+	S             *big.Int
+	Seed          *big.Int
+	UWitness      common.Address
+	CGammaWitness [2]*big.Int
+	SHashWitness  [2]*big.Int
+	ZInv          *big.Int
+}
+
+// provenanceRequest holds the fields of a RandomWordsRequested log of either coordinator
+// version that matter to the provenance of its randomness.
+type provenanceRequest struct {
+	version   vrfcommon.Version
+	requestID *big.Int
+	keyHash   common.Hash
+	preSeed   *big.Int
+	numWords  uint32
+}
+
+// provenanceFulfillment holds the fields of a RandomWordsFulfilled log of either coordinator
+// version that matter to the provenance of its randomness.
+type provenanceFulfillment struct {
+	requestID  *big.Int
+	outputSeed *big.Int
+}
+
+// BuildProvenanceBundle builds the provenance bundle of a request fulfilled on-chain, from the
+// transaction that made the request and the one that fulfilled it. The proof is the one the
+// fulfillment transaction submitted, so that the bundle proves what the consumer received.
+func BuildProvenanceBundle(
+	ctx context.Context,
+	client ProvenanceClient,
+	chainID *big.Int,
+	requestID *big.Int,
+	requestTxHash common.Hash,
+	fulfillmentTxHash common.Hash,
+) (ProvenanceBundle, error) {
+	b := ProvenanceBundle{ChainID: ubig.New(chainID), RequestID: ubig.New(requestID), FulfillmentTxHash: fulfillmentTxHash}
+
+	receipt, err := client.TransactionReceipt(ctx, requestTxHash)
+	if err != nil {
+		return ProvenanceBundle{}, errors.Wrapf(err, "failed to get receipt of request tx %s", requestTxHash)
+	}
+	var req *provenanceRequest
+	for _, lg := range receipt.Logs {
+		if r, parseErr := parseProvenanceRequest(*lg); parseErr == nil && r.requestID.Cmp(requestID) == 0 {
+			req, b.RequestLog = &r, *lg
+			break
+		}
+	}
+	if req == nil {
+		return ProvenanceBundle{}, errors.Errorf("tx %s has no RandomWordsRequested log for request %s", requestTxHash, requestID)
+	}
+	b.Version = req.version
+	b.Coordinator = b.RequestLog.Address
+	b.KeyHash = req.keyHash
+	b.PreSeed = ubig.New(req.preSeed)
+	b.BlockHash = b.RequestLog.BlockHash
+
+	receipt, err = client.TransactionReceipt(ctx, fulfillmentTxHash)
+	if err != nil {
+		return ProvenanceBundle{}, errors.Wrapf(err, "failed to get receipt of fulfillment tx %s", fulfillmentTxHash)
+	}
+	var fulfilled bool
+	for _, lg := range receipt.Logs {
+		if f, parseErr := parseProvenanceFulfillment(*lg); parseErr == nil && lg.Address == b.Coordinator && f.requestID.Cmp(requestID) == 0 {
+			b.FulfillmentLog, fulfilled = *lg, true
+			break
+		}
+	}
+	if !fulfilled {
+		return ProvenanceBundle{}, errors.Errorf("tx %s has no RandomWordsFulfilled log for request %s", fulfillmentTxHash, requestID)
+	}
+
+	tx, err := client.TransactionByHash(ctx, fulfillmentTxHash)
+	if err != nil {
+		return ProvenanceBundle{}, errors.Wrapf(err, "failed to get fulfillment tx %s", fulfillmentTxHash)
+	}
+	if b.FulfillmentTx, err = tx.MarshalBinary(); err != nil {
+		return ProvenanceBundle{}, errors.Wrap(err, "failed to encode fulfillment tx")
+	}
+	p, err := fulfillmentProof(tx.Data(), req.preSeed)
+	if err != nil {
+		return ProvenanceBundle{}, err
+	}
+	if b.Proof, err = newProvenanceProof(p, finalSeed(req.preSeed, b.BlockHash)); err != nil {
+		return ProvenanceBundle{}, err
+	}
+	for _, word := range randomWords(b.Proof.Output.ToInt(), make([]*big.Int, req.numWords)) {
+		b.RandomWords = append(b.RandomWords, ubig.New(word))
+	}
+	return b, nil
+}
+
+// VerifyProvenanceBundle re-checks a provenance bundle offline. It checks that the request log
+// holds the bundle's request, that the seed is proof.FinalSeed of its preSeed and block hash,
+// that the proof verifies with vrfkey and is the proof submitted by the fulfillment
+// transaction, for the key hash of the request, and that the fulfillment log and the random
+// words match its output.
+func VerifyProvenanceBundle(b ProvenanceBundle) error {
+	if b.RequestID == nil || b.PreSeed == nil {
+		return errors.New("bundle has no request ID or preSeed")
+	}
+	req, err := parseProvenanceRequest(b.RequestLog)
+	if err != nil {
+		return errors.Wrap(err, "invalid request log")
+	}
+	switch {
+	case req.version != b.Version:
+		return errors.Errorf("request log is a %s request, bundle says %s", req.version, b.Version)
+	case b.RequestLog.Address != b.Coordinator:
+		return errors.Errorf("request log was emitted by %s, not by coordinator %s", b.RequestLog.Address, b.Coordinator)
+	case req.requestID.Cmp(b.RequestID.ToInt()) != 0:
+		return errors.Errorf("request log is for request %s, not %s", req.requestID, b.RequestID)
+	case req.keyHash != b.KeyHash:
+		return errors.Errorf("request log is for key hash %s, not %s", req.keyHash, b.KeyHash)
+	case req.preSeed.Cmp(b.PreSeed.ToInt()) != 0:
+		return errors.Errorf("request log has preSeed %s, not %s", req.preSeed, b.PreSeed)
+	case b.RequestLog.BlockHash != b.BlockHash:
+		return errors.Errorf("request log is in block %s, not %s", b.RequestLog.BlockHash, b.BlockHash)
+	}
+
+	p, err := b.Proof.VRFProof()
+	if err != nil {
+		return err
+	}
+	if seed := finalSeed(req.preSeed, b.BlockHash); p.Seed.Cmp(seed) != 0 {
+		return errors.Errorf("proof seed %s is not the final seed %s of the request", p.Seed, seed)
+	}
+	if keyHash, err2 := b.Proof.PublicKey.Hash(); err2 != nil || keyHash != b.KeyHash {
+		return errors.Errorf("proof public key %s does not hash to the key hash %s of the request", b.Proof.PublicKey, b.KeyHash)
+	}
+	valid, err := p.VerifyVRFProof()
+	if err != nil {
+		return errors.Wrap(err, "failed to verify proof")
+	}
+	if !valid {
+		return errors.New("proof is invalid")
+	}
+
+	var tx types.Transaction
+	if err = tx.UnmarshalBinary(b.FulfillmentTx); err != nil {
+		return errors.Wrap(err, "invalid fulfillment tx")
+	}
+	if tx.Hash() != b.FulfillmentTxHash {
+		return errors.Errorf("fulfillment tx hashes to %s, not %s", tx.Hash(), b.FulfillmentTxHash)
+	}
+	submitted, err := fulfillmentProof(tx.Data(), req.preSeed)
+	if err != nil {
+		return err
+	}
+	if sp, err2 := newProvenanceProof(submitted, p.Seed); err2 != nil || !sameProof(sp, b.Proof) {
+		return errors.New("proof is not the one submitted by the fulfillment tx")
+	}
+
+	f, err := parseProvenanceFulfillment(b.FulfillmentLog)
+	if err != nil {
+		return errors.Wrap(err, "invalid fulfillment log")
+	}
+	switch {
+	case b.FulfillmentLog.Address != b.Coordinator:
+		return errors.Errorf("fulfillment log was emitted by %s, not by coordinator %s", b.FulfillmentLog.Address, b.Coordinator)
+	case b.FulfillmentLog.TxHash != b.FulfillmentTxHash:
+		return errors.Errorf("fulfillment log is in tx %s, not %s", b.FulfillmentLog.TxHash, b.FulfillmentTxHash)
+	case f.requestID.Cmp(req.requestID) != 0:
+		return errors.Errorf("fulfillment log is for request %s, not %s", f.requestID, req.requestID)
+	case f.outputSeed.Cmp(p.Output) != 0:
+		return errors.Errorf("fulfillment log has output %s, not the proof output %s", f.outputSeed, p.Output)
+	}
+
+	words := randomWords(p.Output, make([]*big.Int, req.numWords))
+	if len(b.RandomWords) != len(words) {
+		return errors.Errorf("bundle has %d random words, request asked for %d", len(b.RandomWords), len(words))
+	}
+	for i, word := range words {
+		if b.RandomWords[i] == nil || b.RandomWords[i].ToInt().Cmp(word) != 0 {
+			return errors.Errorf("random word %d is %s, not %s", i, b.RandomWords[i], word)
+		}
+	}
+	return nil
+}
+
+func parseProvenanceRequest(lg types.Log) (provenanceRequest, error) {
+	if len(lg.Topics) == 0 {
+		return provenanceRequest{}, errors.New("not a RandomWordsRequested log")
+	}
+	switch lg.Topics[0] {
+	case vrf_coordinator_v2.VRFCoordinatorV2RandomWordsRequested{}.Topic():
+		filterer, err := vrf_coordinator_v2.NewVRFCoordinatorV2Filterer(lg.Address, nil)
+		if err != nil {
+			return provenanceRequest{}, err
+		}
+		event, err := filterer.ParseRandomWordsRequested(lg)
+		if err != nil {
+			return provenanceRequest{}, err
+		}
+		return provenanceRequest{vrfcommon.V2, event.RequestId, event.KeyHash, event.PreSeed, event.NumWords}, nil
+	case vrf_coordinator_v2_5.VRFCoordinatorV25RandomWordsRequested{}.Topic():
+		filterer, err := vrf_coordinator_v2_5.NewVRFCoordinatorV25Filterer(lg.Address, nil)
+		if err != nil {
+			return provenanceRequest{}, err
+		}
+		event, err := filterer.ParseRandomWordsRequested(lg)
+		if err != nil {
+			return provenanceRequest{}, err
+		}
+		return provenanceRequest{vrfcommon.V2Plus, event.RequestId, event.KeyHash, event.PreSeed, event.NumWords}, nil
+	default:
+		return provenanceRequest{}, errors.New("not a RandomWordsRequested log")
+	}
+}
+
+func parseProvenanceFulfillment(lg types.Log) (provenanceFulfillment, error) {
+	if len(lg.Topics) == 0 {
+		return provenanceFulfillment{}, errors.New("not a RandomWordsFulfilled log")
+	}
+	switch lg.Topics[0] {
+	case vrf_coordinator_v2.VRFCoordinatorV2RandomWordsFulfilled{}.Topic():
+		filterer, err := vrf_coordinator_v2.NewVRFCoordinatorV2Filterer(lg.Address, nil)
+		if err != nil {
+			return provenanceFulfillment{}, err
+		}
+		event, err := filterer.ParseRandomWordsFulfilled(lg)
+		if err != nil {
+			return provenanceFulfillment{}, err
+		}
+		return provenanceFulfillment{event.RequestId, event.OutputSeed}, nil
+	case vrf_coordinator_v2_5.VRFCoordinatorV25RandomWordsFulfilled{}.Topic():
+		filterer, err := vrf_coordinator_v2_5.NewVRFCoordinatorV25Filterer(lg.Address, nil)
+		if err != nil {
+			return provenanceFulfillment{}, err
+		}
+		event, err := filterer.ParseRandomWordsFulfilled(lg)
+		if err != nil {
+			return provenanceFulfillment{}, err
+		}
+		return provenanceFulfillment{event.RequestId, event.OutputSeed}, nil
+	default:
+		return provenanceFulfillment{}, errors.New("not a RandomWordsFulfilled log")
+	}
+}
+
+// fulfillmentProof returns the proof for preSeed submitted by the fulfillRandomWords calldata
+// of a coordinator, batch coordinator or VRF owner.
+func fulfillmentProof(data []byte, preSeed *big.Int) (onChainProof, error) {
+	if len(data) < 4 {
+		return onChainProof{}, errors.New("fulfillment tx has no calldata")
+	}
+	for _, contractABI := range fulfillmentABIs {
+		method, err := contractABI.MethodById(data[:4])
+		if err != nil || method.Name != "fulfillRandomWords" {
+			continue
+		}
+		args, err := method.Inputs.Unpack(data[4:])
+		if err != nil {
+			return onChainProof{}, errors.Wrap(err, "failed to unpack fulfillRandomWords")
+		}
+		var proofs []onChainProof
+		if method.Inputs[0].Type.T == abi.SliceTy {
+			proofs = *abi.ConvertType(args[0], new([]onChainProof)).(*[]onChainProof)
+		} else {
+			proofs = append(proofs, *abi.ConvertType(args[0], new(onChainProof)).(*onChainProof))
+		}
+		for _, p := range proofs {
+			if p.Seed.Cmp(preSeed) == 0 {
+				return p, nil
+			}
+		}
+		return onChainProof{}, errors.Errorf("fulfillment tx has no proof for preSeed %s", preSeed)
+	}
+	return onChainProof{}, errors.New("fulfillment tx is not a fulfillRandomWords call")
+}
+
+func newProvenanceProof(p onChainProof, seed *big.Int) (ProvenanceProof, error) {
+	pk, err := unmarshalPoint(p.Pk)
+	if err != nil {
+		return ProvenanceProof{}, errors.Wrap(err, "invalid public key")
+	}
+	compressed, err := pk.MarshalBinary()
+	if err != nil {
+		return ProvenanceProof{}, errors.Wrap(err, "invalid public key")
+	}
+	publicKey, err := secp256k1.NewPublicKeyFromBytes(compressed)
+	if err != nil {
+		return ProvenanceProof{}, err
+	}
+	gamma, err := unmarshalPoint(p.Gamma)
+	if err != nil {
+		return ProvenanceProof{}, errors.Wrap(err, "invalid gamma")
+	}
+	output := utils.MustHash(string(append(vrfkey.RandomOutputHashPrefix, secp256k1.LongMarshal(gamma)...)))
+	return ProvenanceProof{
+		PublicKey: publicKey,
+		Gamma:     [2]*ubig.Big{ubig.New(p.Gamma[0]), ubig.New(p.Gamma[1])},
+		C:         ubig.New(p.C),
+		S:         ubig.New(p.S),
+		Seed:      ubig.New(seed),
+		Output:    ubig.New(output.Big()),
+	}, nil
+}
+
+func sameProof(a, b ProvenanceProof) bool {
+	same := func(x, y *ubig.Big) bool { return x != nil && y != nil && x.Cmp(y) == 0 }
+	return a.PublicKey == b.PublicKey && same(a.Gamma[0], b.Gamma[0]) && same(a.Gamma[1], b.Gamma[1]) &&
+		same(a.C, b.C) && same(a.S, b.S) && same(a.Output, b.Output)
+}
+
+// finalSeed is proof.FinalSeed of a preSeed parsed from a request log, which always fits a Seed.
+func finalSeed(preSeed *big.Int, blockHash common.Hash) *big.Int {
+	seed, _ := proof.BigToSeed(preSeed)
+	return proof.FinalSeed(proof.PreSeedData{PreSeed: seed, BlockHash: blockHash})
+}
+
+func unmarshalPoint(xy [2]*big.Int) (kyber.Point, error) {
+	if xy[0] == nil || xy[1] == nil {
+		return nil, errors.New("missing coordinate")
+	}
+	return secp256k1.LongUnmarshal(append(common.BigToHash(xy[0]).Bytes(), common.BigToHash(xy[1]).Bytes()...))
+}
@@ -0,0 +1,163 @@
+package v2_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2_5"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/proof"
+	v2 "github.com/smartcontractkit/chainlink/v2/core/services/vrf/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+type provenanceClient struct {
+	receipts map[common.Hash]*types.Receipt
+	txs      map[common.Hash]*types.Transaction
+}
+
+func (c *provenanceClient) TransactionReceipt(_ context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return c.receipts[txHash], nil
+}
+
+func (c *provenanceClient) TransactionByHash(_ context.Context, txHash common.Hash) (*types.Transaction, error) {
+	return c.txs[txHash], nil
+}
+
+func TestProvenanceBundle(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	key := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1))
+	coordinator := testutils.NewAddress()
+	sender := testutils.NewAddress()
+	chainID := big.NewInt(1337)
+	reqID, preSeed, subID := big.NewInt(7), big.NewInt(42), big.NewInt(1)
+	reqTxHash := common.HexToHash("0x1")
+
+	coordinatorABI := evmtypes.MustGetABI(vrf_coordinator_v2_5.VRFCoordinatorV25ABI)
+	requested := coordinatorABI.Events["RandomWordsRequested"]
+	data, err := requested.Inputs.NonIndexed().Pack(reqID, preSeed, uint16(3), uint32(200_000), uint32(2), []byte{})
+	require.NoError(t, err)
+	reqLog := &types.Log{
+		Address: coordinator,
+		Topics: []common.Hash{
+			requested.ID,
+			key.PublicKey.MustHash(),
+			common.BigToHash(subID),
+			common.BytesToHash(sender.Bytes()),
+		},
+		Data:        data,
+		BlockNumber: 10,
+		BlockHash:   common.HexToHash("0x2"),
+		TxHash:      reqTxHash,
+	}
+
+	seed, err := proof.BigToSeed(preSeed)
+	require.NoError(t, err)
+	preSeedData := proof.PreSeedDataV2Plus{
+		PreSeed:          seed,
+		BlockHash:        reqLog.BlockHash,
+		BlockNum:         reqLog.BlockNumber,
+		SubId:            subID,
+		CallbackGasLimit: 200_000,
+		NumWords:         2,
+		Sender:           sender,
+		ExtraArgs:        []byte{},
+	}
+	p, err := key.GenerateProof(proof.FinalSeedV2Plus(preSeedData))
+	require.NoError(t, err)
+	onChainProof, rc, err := proof.GenerateProofResponseFromProofV2Plus(p, preSeedData)
+	require.NoError(t, err)
+	payload, err := coordinatorABI.Pack("fulfillRandomWords", onChainProof, rc, false)
+	require.NoError(t, err)
+	ethKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	fulfillmentTx, err := types.SignNewTx(ethKey, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		To:        &coordinator,
+		Gas:       500_000,
+		GasFeeCap: big.NewInt(1e9),
+		GasTipCap: big.NewInt(1e9),
+		Data:      payload,
+	})
+	require.NoError(t, err)
+
+	fulfilled := coordinatorABI.Events["RandomWordsFulfilled"]
+	data, err = fulfilled.Inputs.NonIndexed().Pack(p.Output, big.NewInt(1e15), false, true, false)
+	require.NoError(t, err)
+	fulfillmentLog := &types.Log{
+		Address:     coordinator,
+		Topics:      []common.Hash{fulfilled.ID, common.BigToHash(reqID), common.BigToHash(subID)},
+		Data:        data,
+		BlockNumber: 14,
+		BlockHash:   common.HexToHash("0x3"),
+		TxHash:      fulfillmentTx.Hash(),
+	}
+
+	client := &provenanceClient{
+		receipts: map[common.Hash]*types.Receipt{
+			reqTxHash:            {TxHash: reqTxHash, Logs: []*types.Log{reqLog}},
+			fulfillmentTx.Hash(): {TxHash: fulfillmentTx.Hash(), Logs: []*types.Log{fulfillmentLog}},
+		},
+		txs: map[common.Hash]*types.Transaction{fulfillmentTx.Hash(): fulfillmentTx},
+	}
+
+	bundle, err := v2.BuildProvenanceBundle(ctx, client, chainID, reqID, reqTxHash, fulfillmentTx.Hash())
+	require.NoError(t, err)
+
+	t.Run("builds the bundle from the chain", func(t *testing.T) {
+		assert.Equal(t, vrfcommon.V2Plus, bundle.Version)
+		assert.Equal(t, coordinator, bundle.Coordinator)
+		assert.Equal(t, key.PublicKey.MustHash(), bundle.KeyHash)
+		assert.Equal(t, preSeed, bundle.PreSeed.ToInt())
+		assert.Equal(t, reqLog.BlockHash, bundle.BlockHash)
+		assert.Equal(t, key.PublicKey, bundle.Proof.PublicKey)
+		assert.Equal(t, p.Seed, bundle.Proof.Seed.ToInt())
+		assert.Equal(t, p.Output, bundle.Proof.Output.ToInt())
+		require.Len(t, bundle.RandomWords, 2)
+	})
+
+	t.Run("verifies after a JSON round trip", func(t *testing.T) {
+		b, err := json.Marshal(bundle)
+		require.NoError(t, err)
+		var decoded v2.ProvenanceBundle
+		require.NoError(t, json.Unmarshal(b, &decoded))
+		require.NoError(t, v2.VerifyProvenanceBundle(decoded))
+	})
+
+	t.Run("tampered block hash", func(t *testing.T) {
+		tampered := bundle
+		tampered.BlockHash = common.HexToHash("0x4")
+		require.ErrorContains(t, v2.VerifyProvenanceBundle(tampered), "request log is in block")
+	})
+
+	t.Run("tampered proof", func(t *testing.T) {
+		tampered := bundle
+		tampered.Proof.S = ubig.New(new(big.Int).Add(bundle.Proof.S.ToInt(), big.NewInt(1)))
+		require.ErrorContains(t, v2.VerifyProvenanceBundle(tampered), "proof is invalid")
+	})
+
+	t.Run("tampered random words", func(t *testing.T) {
+		tampered := bundle
+		tampered.RandomWords = []*ubig.Big{bundle.RandomWords[1], bundle.RandomWords[0]}
+		require.ErrorContains(t, v2.VerifyProvenanceBundle(tampered), "random word 0")
+	})
+
+	t.Run("fulfillment tx not found", func(t *testing.T) {
+		_, err := v2.BuildProvenanceBundle(ctx, client, chainID, reqID, reqTxHash, reqTxHash)
+		require.ErrorContains(t, err, "has no RandomWordsFulfilled log")
+	})
+}
@@ -23,7 +23,6 @@ import (
 	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr"
 	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
 	evmutils "github.com/smartcontractkit/chainlink-evm/pkg/utils"
-	txmgrcommon "github.com/smartcontractkit/chainlink-framework/chains/txmgr"
 	"github.com/smartcontractkit/chainlink/v2/core/utils"
 )
 
@@ -705,7 +704,7 @@ func (lsn *listenerV2) enqueueForceFulfillmentForRevertedTxn(
 		ToAddress:      lsn.vrfOwner.Address(),
 		EncodedPayload: txData,
 		FeeLimit:       estimateGasLimit,
-		Strategy:       txmgrcommon.NewSendEveryStrategy(),
+		Strategy:       fulfillmentStrategy(),
 		Meta: &txmgr.TxMeta{
 			RequestID:               &reqID,
 			SubID:                   &revertedTxn.DBReceipt.SubID,
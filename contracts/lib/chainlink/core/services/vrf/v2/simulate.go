@@ -0,0 +1,298 @@
+package v2
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_consumer_v2"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2_5"
+	evmclient "github.com/smartcontractkit/chainlink-evm/pkg/client"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/proof"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+var consumerV2ABI = evmtypes.MustGetABI(vrf_consumer_v2.VRFConsumerV2ABI)
+
+// SimulationClient is the subset of an RPC client needed to simulate a fulfillment.
+// *ethclient.Client implements it, so any RPC, including a local anvil fork, can be used.
+type SimulationClient interface {
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
+	CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+}
+
+// SimulationResult is the outcome of simulating the fulfillment of a single request.
+type SimulationResult struct {
+	Version          vrfcommon.Version
+	Coordinator      common.Address
+	RequestID        *big.Int
+	SubID            *big.Int
+	Sender           common.Address
+	NativePayment    bool
+	CallbackGasLimit uint32
+	RandomWords      []*big.Int
+
+	// FulfillmentPayload is the fulfillRandomWords calldata the node would send to the coordinator.
+	FulfillmentPayload []byte
+	// FulfillmentGas is the estimated gas of the fulfillment, zero if it reverts.
+	FulfillmentGas uint64
+	// Payment is the amount the coordinator would charge the subscription, nil if the fulfillment reverts.
+	Payment *big.Int
+	// FulfillmentRevertReason is set when the coordinator reverts the fulfillment.
+	FulfillmentRevertReason string
+	// FulfillmentFailure is how a job's listener would treat the failed fulfillment, e.g. a revert
+	// is taken for an underfunded subscription and the request is left pending.
+	FulfillmentFailure string
+
+	// CallbackPayload is the rawFulfillRandomWords calldata the coordinator sends to the consumer.
+	CallbackPayload []byte
+	// CallbackGas is the estimated gas of the consumer callback, zero if it reverts.
+	CallbackGas uint64
+	// CallbackRevertReason is set when the consumer callback reverts. The coordinator does
+	// not revert in that case, it records the fulfillment as failed.
+	CallbackRevertReason string
+}
+
+// SimulateRequest simulates the fulfillment of the RandomWordsRequested log at logIndex of
+// the transaction txHash, without a job and without sending a transaction.
+//
+// The proof is generated with prover, the fulfillment is then estimated and called against
+// the coordinator at the latest block, as the pipeline of a job does, and its outcome is
+// interpreted as simulateFulfillment does.
+// The consumer callback is also called on its own, from the coordinator and with the
+// request's callback gas limit, to surface its revert reason.
+func SimulateRequest(
+	ctx context.Context,
+	client SimulationClient,
+	prover pipeline.VRFProver,
+	pubKey secp256k1.PublicKey,
+	txHash common.Hash,
+	logIndex uint,
+) (SimulationResult, error) {
+	receipt, err := client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return SimulationResult{}, errors.Wrapf(err, "failed to get receipt of tx %s", txHash)
+	}
+	var lg *types.Log
+	for _, l := range receipt.Logs {
+		if l.Index == logIndex {
+			lg = l
+			break
+		}
+	}
+	if lg == nil {
+		return SimulationResult{}, errors.Errorf("tx %s has no log with index %d", txHash, logIndex)
+	}
+	if len(lg.Topics) == 0 {
+		return SimulationResult{}, errors.Errorf("log %d of tx %s is not a RandomWordsRequested log", logIndex, txHash)
+	}
+
+	var (
+		res    SimulationResult
+		output *big.Int
+	)
+	switch lg.Topics[0] {
+	case vrf_coordinator_v2.VRFCoordinatorV2RandomWordsRequested{}.Topic():
+		output, res, err = simulateV2Request(ctx, prover, pubKey, *lg)
+	case vrf_coordinator_v2_5.VRFCoordinatorV25RandomWordsRequested{}.Topic():
+		output, res, err = simulateV2PlusRequest(ctx, prover, pubKey, *lg)
+	default:
+		return SimulationResult{}, errors.Errorf("log %d of tx %s is not a RandomWordsRequested log", logIndex, txHash)
+	}
+	if err != nil {
+		return SimulationResult{}, err
+	}
+	res.Coordinator = lg.Address
+	res.RandomWords = randomWords(output, res.RandomWords)
+	res.CallbackPayload, err = consumerV2ABI.Pack("rawFulfillRandomWords", res.RequestID, res.RandomWords)
+	if err != nil {
+		return SimulationResult{}, errors.Wrap(err, "failed to pack rawFulfillRandomWords")
+	}
+
+	// Same as the estimategaslimit task of a VRF job, with its 1.1 multiplier, then the ethcall
+	// task. The outcome is interpreted like the results of the job's pipeline in
+	// simulateFulfillment.
+	fulfillment := ethereum.CallMsg{To: &res.Coordinator, Data: res.FulfillmentPayload}
+	var output []byte
+	gas, err := client.EstimateGas(ctx, fulfillment)
+	if err == nil {
+		fulfillment.Gas = gas * 11 / 10
+		output, err = client.CallContract(ctx, fulfillment, nil)
+	}
+	if err != nil {
+		res.FulfillmentRevertReason = revertReason(err)
+	} else {
+		res.FulfillmentGas = gas
+	}
+	if res.Payment, err = fulfillmentResult(output, err); err != nil {
+		res.FulfillmentFailure = fulfillmentFailure(err)
+	}
+
+	callback := ethereum.CallMsg{
+		From: res.Coordinator,
+		To:   &res.Sender,
+		Gas:  uint64(res.CallbackGasLimit),
+		Data: res.CallbackPayload,
+	}
+	if _, err = client.CallContract(ctx, callback, nil); err != nil {
+		res.CallbackRevertReason = revertReason(err)
+	} else if gas, err = client.EstimateGas(ctx, callback); err == nil {
+		res.CallbackGas = gas
+	}
+	return res, nil
+}
+
+func simulateV2Request(ctx context.Context, prover pipeline.VRFProver, pubKey secp256k1.PublicKey, lg types.Log) (*big.Int, SimulationResult, error) {
+	filterer, err := vrf_coordinator_v2.NewVRFCoordinatorV2Filterer(lg.Address, nil)
+	if err != nil {
+		return nil, SimulationResult{}, err
+	}
+	event, err := filterer.ParseRandomWordsRequested(lg)
+	if err != nil {
+		return nil, SimulationResult{}, errors.Wrap(err, "failed to parse RandomWordsRequested log")
+	}
+	if err = checkKeyHash(event.KeyHash, pubKey); err != nil {
+		return nil, SimulationResult{}, err
+	}
+	preSeed, err := proof.BigToSeed(event.PreSeed)
+	if err != nil {
+		return nil, SimulationResult{}, errors.Wrap(err, "invalid preSeed")
+	}
+	preSeedData := proof.PreSeedDataV2{
+		PreSeed:          preSeed,
+		BlockHash:        lg.BlockHash,
+		BlockNum:         lg.BlockNumber,
+		SubId:            event.SubId,
+		CallbackGasLimit: event.CallbackGasLimit,
+		NumWords:         event.NumWords,
+		Sender:           event.Sender,
+	}
+	p, err := prover.GenerateProof(ctx, pubKey, proof.FinalSeedV2(preSeedData))
+	if err != nil {
+		return nil, SimulationResult{}, errors.Wrap(err, "failed to generate proof")
+	}
+	onChainProof, rc, err := proof.GenerateProofResponseFromProofV2(p, preSeedData)
+	if err != nil {
+		return nil, SimulationResult{}, err
+	}
+	payload, err := coordinatorV2ABI.Pack("fulfillRandomWords", onChainProof, rc)
+	if err != nil {
+		return nil, SimulationResult{}, errors.Wrap(err, "failed to pack fulfillRandomWords")
+	}
+	return p.Output, SimulationResult{
+		Version:            vrfcommon.V2,
+		RequestID:          event.RequestId,
+		SubID:              new(big.Int).SetUint64(event.SubId),
+		Sender:             event.Sender,
+		CallbackGasLimit:   event.CallbackGasLimit,
+		RandomWords:        make([]*big.Int, event.NumWords),
+		FulfillmentPayload: payload,
+	}, nil
+}
+
+func simulateV2PlusRequest(ctx context.Context, prover pipeline.VRFProver, pubKey secp256k1.PublicKey, lg types.Log) (*big.Int, SimulationResult, error) {
+	filterer, err := vrf_coordinator_v2_5.NewVRFCoordinatorV25Filterer(lg.Address, nil)
+	if err != nil {
+		return nil, SimulationResult{}, err
+	}
+	event, err := filterer.ParseRandomWordsRequested(lg)
+	if err != nil {
+		return nil, SimulationResult{}, errors.Wrap(err, "failed to parse RandomWordsRequested log")
+	}
+	if err = checkKeyHash(event.KeyHash, pubKey); err != nil {
+		return nil, SimulationResult{}, err
+	}
+	preSeed, err := proof.BigToSeed(event.PreSeed)
+	if err != nil {
+		return nil, SimulationResult{}, errors.Wrap(err, "invalid preSeed")
+	}
+	preSeedData := proof.PreSeedDataV2Plus{
+		PreSeed:          preSeed,
+		BlockHash:        lg.BlockHash,
+		BlockNum:         lg.BlockNumber,
+		SubId:            event.SubId,
+		CallbackGasLimit: event.CallbackGasLimit,
+		NumWords:         event.NumWords,
+		Sender:           event.Sender,
+		ExtraArgs:        event.ExtraArgs,
+	}
+	p, err := prover.GenerateProof(ctx, pubKey, proof.FinalSeedV2Plus(preSeedData))
+	if err != nil {
+		return nil, SimulationResult{}, errors.Wrap(err, "failed to generate proof")
+	}
+	onChainProof, rc, err := proof.GenerateProofResponseFromProofV2Plus(p, preSeedData)
+	if err != nil {
+		return nil, SimulationResult{}, err
+	}
+	payload, err := coordinatorV2PlusABI.Pack("fulfillRandomWords", onChainProof, rc, false)
+	if err != nil {
+		return nil, SimulationResult{}, errors.Wrap(err, "failed to pack fulfillRandomWords")
+	}
+	return p.Output, SimulationResult{
+		Version:            vrfcommon.V2Plus,
+		RequestID:          event.RequestId,
+		SubID:              event.SubId,
+		Sender:             event.Sender,
+		NativePayment:      NewV2_5RandomWordsRequested(event).NativePayment(),
+		CallbackGasLimit:   event.CallbackGasLimit,
+		RandomWords:        make([]*big.Int, event.NumWords),
+		FulfillmentPayload: payload,
+	}, nil
+}
+
+func checkKeyHash(keyHash common.Hash, pubKey secp256k1.PublicKey) error {
+	if h := pubKey.MustHash(); keyHash != h {
+		return errors.Errorf("request is for key hash %s, but the VRF key's hash is %s", keyHash, h)
+	}
+	return nil
+}
+
+// randomWords expands the VRF output into the words the coordinator passes to the
+// consumer, i.e. keccak256(abi.encode(output, i)) for each word.
+func randomWords(output *big.Int, words []*big.Int) []*big.Int {
+	for i := range words {
+		h := crypto.Keccak256(common.BigToHash(output).Bytes(), common.BigToHash(big.NewInt(int64(i))).Bytes())
+		words[i] = new(big.Int).SetBytes(h)
+	}
+	return words
+}
+
+// fulfillmentFailure returns the reason fulfillmentResult found for the listener to act on, if any.
+func fulfillmentFailure(err error) string {
+	for _, reason := range []error{errBlockhashNotInStore{}, errProofVerificationFailed{}, errPossiblyInsufficientFunds{}} {
+		if errors.Is(err, reason) {
+			return reason.Error()
+		}
+	}
+	return ""
+}
+
+// revertReason returns the decoded revert reason of a failed call, falling back to the
+// RPC error when the revert data isn't an Error(string).
+func revertReason(err error) string {
+	rpcErr, extractErr := evmclient.ExtractRPCError(err)
+	if extractErr != nil {
+		return err.Error()
+	}
+	if data, ok := rpcErr.Data.(string); ok {
+		if b, decodeErr := hexutil.Decode(data); decodeErr == nil {
+			if reason, unpackErr := abi.UnpackRevert(b); unpackErr == nil {
+				return reason
+			}
+		}
+	}
+	return rpcErr.String()
+}
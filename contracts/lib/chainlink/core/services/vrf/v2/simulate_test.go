@@ -0,0 +1,144 @@
+package v2_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/prover"
+	v2 "github.com/smartcontractkit/chainlink/v2/core/services/vrf/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// rpcError marshals like the errors returned by a geth RPC for a reverted call.
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+type simulationClient struct {
+	receipt        *types.Receipt
+	coordinator    common.Address
+	payment        []byte
+	fulfillmentErr error
+	callbackErr    error
+}
+
+func (c *simulationClient) TransactionReceipt(context.Context, common.Hash) (*types.Receipt, error) {
+	return c.receipt, nil
+}
+
+func (c *simulationClient) EstimateGas(context.Context, ethereum.CallMsg) (uint64, error) {
+	return 100_000, nil
+}
+
+func (c *simulationClient) CallContract(_ context.Context, call ethereum.CallMsg, _ *big.Int) ([]byte, error) {
+	if *call.To == c.coordinator {
+		return c.payment, c.fulfillmentErr
+	}
+	return nil, c.callbackErr
+}
+
+func TestSimulateRequest(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	key := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(1))
+	coordinator := testutils.NewAddress()
+	sender := testutils.NewAddress()
+	txHash := common.HexToHash("0x1")
+
+	coordinatorABI := evmtypes.MustGetABI(vrf_coordinator_v2.VRFCoordinatorV2ABI)
+	event := coordinatorABI.Events["RandomWordsRequested"]
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(7), big.NewInt(42), uint16(3), uint32(200_000), uint32(2))
+	require.NoError(t, err)
+	lg := &types.Log{
+		Address: coordinator,
+		Topics: []common.Hash{
+			event.ID,
+			key.PublicKey.MustHash(),
+			common.BigToHash(big.NewInt(1)),
+			common.BytesToHash(sender.Bytes()),
+		},
+		Data:        data,
+		BlockNumber: 10,
+		BlockHash:   common.HexToHash("0x2"),
+		TxHash:      txHash,
+		Index:       3,
+	}
+	payment, err := coordinatorABI.Methods["fulfillRandomWords"].Outputs.Pack(big.NewInt(1e15))
+	require.NoError(t, err)
+	// abi.encodeWithSignature("Error(string)", "not enough tickets")
+	revertData := append(crypto.Keccak256([]byte("Error(string)"))[:4],
+		common.LeftPadBytes([]byte{0x20}, 32)...)
+	revertData = append(revertData, common.LeftPadBytes([]byte{18}, 32)...)
+	revertData = append(revertData, common.RightPadBytes([]byte("not enough tickets"), 32)...)
+
+	client := &simulationClient{
+		receipt:     &types.Receipt{TxHash: txHash, Logs: []*types.Log{lg}},
+		coordinator: coordinator,
+		payment:     payment,
+		callbackErr: &rpcError{Code: 3, Message: "execution reverted", Data: hexutil.Encode(revertData)},
+	}
+
+	t.Run("simulates the fulfillment and the callback", func(t *testing.T) {
+		res, err := v2.SimulateRequest(ctx, client, prover.NewInMemoryProver(key), key.PublicKey, txHash, 3)
+		require.NoError(t, err)
+
+		assert.Equal(t, vrfcommon.V2, res.Version)
+		assert.Equal(t, coordinator, res.Coordinator)
+		assert.Equal(t, big.NewInt(7), res.RequestID)
+		assert.Equal(t, big.NewInt(1), res.SubID)
+		assert.Equal(t, sender, res.Sender)
+		assert.Equal(t, uint32(200_000), res.CallbackGasLimit)
+		assert.Len(t, res.RandomWords, 2)
+		assert.NotEmpty(t, res.FulfillmentPayload)
+		assert.Equal(t, uint64(100_000), res.FulfillmentGas)
+		assert.Equal(t, big.NewInt(1e15), res.Payment)
+		assert.Empty(t, res.FulfillmentRevertReason)
+		assert.Empty(t, res.FulfillmentFailure)
+		assert.NotEmpty(t, res.CallbackPayload)
+		assert.Zero(t, res.CallbackGas)
+		assert.Equal(t, "not enough tickets", res.CallbackRevertReason)
+	})
+
+	t.Run("fulfillment reverts", func(t *testing.T) {
+		reverting := *client
+		reverting.payment = nil
+		reverting.fulfillmentErr = &rpcError{Code: 3, Message: "execution reverted"}
+		res, err := v2.SimulateRequest(ctx, &reverting, prover.NewInMemoryProver(key), key.PublicKey, txHash, 3)
+		require.NoError(t, err)
+
+		assert.Zero(t, res.FulfillmentGas)
+		assert.Nil(t, res.Payment)
+		assert.Contains(t, res.FulfillmentRevertReason, "execution reverted")
+		assert.Contains(t, res.FulfillmentFailure, "possibly insufficient funds")
+	})
+
+	t.Run("unknown log index", func(t *testing.T) {
+		_, err := v2.SimulateRequest(ctx, client, prover.NewInMemoryProver(key), key.PublicKey, txHash, 4)
+		require.ErrorContains(t, err, "has no log with index 4")
+	})
+
+	t.Run("request for another key", func(t *testing.T) {
+		other := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(2))
+		_, err := v2.SimulateRequest(ctx, client, prover.NewInMemoryProver(other), other.PublicKey, txHash, 3)
+		require.ErrorContains(t, err, "request is for key hash")
+	})
+}
@@ -0,0 +1,229 @@
+package vrfcommon
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+)
+
+// DefaultBalanceForecastWindow is the burn rate window of jobs that don't set balanceForecastWindow.
+const DefaultBalanceForecastWindow = 24 * time.Hour
+
+// SubscriptionBurn is what a subscription paid for the requests of a job that were fulfilled
+// since a given time, for a single payment currency.
+type SubscriptionBurn struct {
+	SubID         *big.Int
+	NativePayment bool
+	// Spent is in wei for native payments and in juels otherwise.
+	Spent     *big.Int
+	Fulfilled int64
+}
+
+// SubscriptionForecast projects when a subscription runs out of funds for a single payment
+// currency, at the rate it paid for the fulfillments of a job within the forecast window.
+type SubscriptionForecast struct {
+	JobID              int32
+	EVMChainID         *big.Int
+	CoordinatorAddress common.Address
+	SubID              *big.Int
+	NativePayment      bool
+	// Balance is the on-chain balance of the subscription, in wei for native payments and in
+	// juels otherwise, as are the other amounts.
+	Balance *big.Int
+	// Reserved is the part of Balance set aside for fulfillments that are still in flight.
+	Reserved *big.Int
+	// Spent is what the subscription paid for the Fulfilled requests within Window.
+	Spent           *big.Int
+	Fulfilled       int64
+	Window          time.Duration
+	BurnRatePerHour *big.Int
+	// DepletesAt is when the balance left after reservations runs out at the burn rate. It is
+	// nil if the subscription paid nothing within Window.
+	DepletesAt *time.Time
+	// LowFundsHorizon is the smallest of the job's warning horizons that DepletesAt is
+	// within, 0 if none.
+	LowFundsHorizon time.Duration
+	UpdatedAt       time.Time
+}
+
+// ForecastSubscription projects when the subscription runs out of funds at the rate of burn
+// over window, and which of the warning horizons that is within.
+func ForecastSubscription(burn SubscriptionBurn, balance, reserved *big.Int, window time.Duration, horizons []time.Duration, now time.Time) SubscriptionForecast {
+	f := SubscriptionForecast{
+		SubID:           burn.SubID,
+		NativePayment:   burn.NativePayment,
+		Balance:         balance,
+		Reserved:        reserved,
+		Spent:           burn.Spent,
+		Fulfilled:       burn.Fulfilled,
+		Window:          window,
+		BurnRatePerHour: big.NewInt(0),
+		UpdatedAt:       now,
+	}
+	if burn.Spent.Sign() <= 0 || window <= 0 {
+		return f
+	}
+	f.BurnRatePerHour.Div(new(big.Int).Mul(burn.Spent, big.NewInt(int64(time.Hour))), big.NewInt(int64(window)))
+
+	available := new(big.Int).Sub(balance, reserved)
+	if available.Sign() < 0 {
+		available.SetInt64(0)
+	}
+	// available / (spent / window), in nanoseconds, capped to the longest time.Duration.
+	timeToEmpty := time.Duration(math.MaxInt64)
+	if ns := new(big.Int).Div(new(big.Int).Mul(available, big.NewInt(int64(window))), burn.Spent); ns.IsInt64() {
+		timeToEmpty = time.Duration(ns.Int64())
+	}
+	depletesAt := now.Add(timeToEmpty)
+	f.DepletesAt = &depletesAt
+
+	for _, h := range horizons {
+		if timeToEmpty <= h && (f.LowFundsHorizon == 0 || h < f.LowFundsHorizon) {
+			f.LowFundsHorizon = h
+		}
+	}
+	return f
+}
+
+// TimeToEmpty returns how long until the subscription runs out of funds, ok is false if it
+// burned nothing within the window.
+func (f SubscriptionForecast) TimeToEmpty(now time.Time) (timeToEmpty time.Duration, ok bool) {
+	if f.DepletesAt == nil {
+		return 0, false
+	}
+	return max(f.DepletesAt.Sub(now), 0), true
+}
+
+// ForecastFilter selects subscription forecasts. Both fields are optional.
+type ForecastFilter struct {
+	JobID *int32
+	SubID *big.Int
+}
+
+// BalanceForecastORM persists the latest subscription forecasts of VRF jobs.
+type BalanceForecastORM interface {
+	// SubscriptionBurns returns what each subscription paid for the requests of the job
+	// fulfilled since the given time.
+	SubscriptionBurns(ctx context.Context, jobID int32, since time.Time) ([]SubscriptionBurn, error)
+	// ReplaceForecasts replaces the forecasts of the job.
+	ReplaceForecasts(ctx context.Context, jobID int32, forecasts []SubscriptionForecast) error
+	Forecasts(ctx context.Context, filter ForecastFilter) ([]SubscriptionForecast, error)
+}
+
+type balanceForecastORM struct {
+	ds sqlutil.DataSource
+}
+
+var _ BalanceForecastORM = (*balanceForecastORM)(nil)
+
+func NewBalanceForecastORM(ds sqlutil.DataSource) BalanceForecastORM {
+	return &balanceForecastORM{ds: ds}
+}
+
+type subscriptionBurnRow struct {
+	SubID         *ubig.Big
+	NativePayment bool
+	Spent         *ubig.Big
+	Fulfilled     int64
+}
+
+func (o *balanceForecastORM) SubscriptionBurns(ctx context.Context, jobID int32, since time.Time) ([]SubscriptionBurn, error) {
+	stmt := `SELECT sub_id, COALESCE(native_payment, FALSE) AS native_payment, SUM(payment) AS spent, COUNT(*) AS fulfilled
+		FROM vrf_request_lifecycle
+		WHERE job_id = $1 AND fulfilled_at >= $2 AND payment IS NOT NULL
+		GROUP BY sub_id, COALESCE(native_payment, FALSE)
+		ORDER BY sub_id, native_payment`
+	var rows []subscriptionBurnRow
+	if err := o.ds.SelectContext(ctx, &rows, stmt, jobID, since); err != nil {
+		return nil, fmt.Errorf("failed to load vrf subscription burns: %w", err)
+	}
+	burns := make([]SubscriptionBurn, len(rows))
+	for i, r := range rows {
+		burns[i] = SubscriptionBurn{
+			SubID:         r.SubID.ToInt(),
+			NativePayment: r.NativePayment,
+			Spent:         r.Spent.ToInt(),
+			Fulfilled:     r.Fulfilled,
+		}
+	}
+	return burns, nil
+}
+
+func (o *balanceForecastORM) ReplaceForecasts(ctx context.Context, jobID int32, forecasts []SubscriptionForecast) error {
+	stmt := `INSERT INTO vrf_subscription_forecasts (job_id, sub_id, native_payment, evm_chain_id, coordinator_address,
+		balance, reserved, spent, fulfilled, forecast_window, burn_rate_per_hour, depletes_at, low_funds_horizon, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+	return sqlutil.TransactDataSource(ctx, o.ds, nil, func(tx sqlutil.DataSource) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM vrf_subscription_forecasts WHERE job_id = $1`, jobID); err != nil {
+			return fmt.Errorf("failed to delete vrf subscription forecasts: %w", err)
+		}
+		for _, f := range forecasts {
+			if _, err := tx.ExecContext(ctx, stmt, jobID, ubig.New(f.SubID), f.NativePayment, ubig.New(f.EVMChainID), f.CoordinatorAddress,
+				ubig.New(f.Balance), ubig.New(f.Reserved), ubig.New(f.Spent), f.Fulfilled, int64(f.Window), ubig.New(f.BurnRatePerHour),
+				f.DepletesAt, int64(f.LowFundsHorizon), f.UpdatedAt); err != nil {
+				return fmt.Errorf("failed to insert vrf subscription forecast %s: %w", f.SubID, err)
+			}
+		}
+		return nil
+	})
+}
+
+type subscriptionForecastRow struct {
+	JobID              int32
+	SubID              *ubig.Big
+	NativePayment      bool
+	EVMChainID         *ubig.Big
+	CoordinatorAddress common.Address
+	Balance            *ubig.Big
+	Reserved           *ubig.Big
+	Spent              *ubig.Big
+	Fulfilled          int64
+	ForecastWindow     int64
+	BurnRatePerHour    *ubig.Big
+	DepletesAt         *time.Time
+	LowFundsHorizon    int64
+	UpdatedAt          time.Time
+}
+
+// Forecasts returns the forecasts selected by filter, those closest to running out of funds first.
+func (o *balanceForecastORM) Forecasts(ctx context.Context, filter ForecastFilter) ([]SubscriptionForecast, error) {
+	stmt := `SELECT * FROM vrf_subscription_forecasts
+		WHERE ($1::integer IS NULL OR job_id = $1) AND ($2::numeric IS NULL OR sub_id = $2)
+		ORDER BY depletes_at ASC NULLS LAST, job_id, sub_id, native_payment`
+	var subID *string
+	if filter.SubID != nil {
+		s := filter.SubID.String()
+		subID = &s
+	}
+	var rows []subscriptionForecastRow
+	if err := o.ds.SelectContext(ctx, &rows, stmt, filter.JobID, subID); err != nil {
+		return nil, fmt.Errorf("failed to load vrf subscription forecasts: %w", err)
+	}
+	forecasts := make([]SubscriptionForecast, len(rows))
+	for i, r := range rows {
+		forecasts[i] = SubscriptionForecast{
+			JobID:              r.JobID,
+			EVMChainID:         r.EVMChainID.ToInt(),
+			CoordinatorAddress: r.CoordinatorAddress,
+			SubID:              r.SubID.ToInt(),
+			NativePayment:      r.NativePayment,
+			Balance:            r.Balance.ToInt(),
+			Reserved:           r.Reserved.ToInt(),
+			Spent:              r.Spent.ToInt(),
+			Fulfilled:          r.Fulfilled,
+			Window:             time.Duration(r.ForecastWindow),
+			BurnRatePerHour:    r.BurnRatePerHour.ToInt(),
+			DepletesAt:         r.DepletesAt,
+			LowFundsHorizon:    time.Duration(r.LowFundsHorizon),
+			UpdatedAt:          r.UpdatedAt,
+		}
+	}
+	return forecasts, nil
+}
@@ -0,0 +1,132 @@
+package vrfcommon_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+func TestForecastSubscription(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	horizons := []time.Duration{72 * time.Hour, 24 * time.Hour, time.Hour}
+	burn := vrfcommon.SubscriptionBurn{SubID: big.NewInt(1), Spent: big.NewInt(240), Fulfilled: 12}
+
+	t.Run("within a horizon", func(t *testing.T) {
+		// 240 over 24h is 10 per hour, the 300 left after reservations last 30h.
+		f := vrfcommon.ForecastSubscription(burn, big.NewInt(400), big.NewInt(100), 24*time.Hour, horizons, now)
+		assert.Equal(t, big.NewInt(10), f.BurnRatePerHour)
+		require.NotNil(t, f.DepletesAt)
+		assert.Equal(t, now.Add(30*time.Hour), *f.DepletesAt)
+		assert.Equal(t, 72*time.Hour, f.LowFundsHorizon)
+		timeToEmpty, ok := f.TimeToEmpty(now)
+		require.True(t, ok)
+		assert.Equal(t, 30*time.Hour, timeToEmpty)
+	})
+
+	t.Run("smallest horizon", func(t *testing.T) {
+		f := vrfcommon.ForecastSubscription(burn, big.NewInt(5), big.NewInt(0), 24*time.Hour, horizons, now)
+		assert.Equal(t, time.Hour, f.LowFundsHorizon)
+	})
+
+	t.Run("over reserved", func(t *testing.T) {
+		f := vrfcommon.ForecastSubscription(burn, big.NewInt(100), big.NewInt(200), 24*time.Hour, horizons, now)
+		require.NotNil(t, f.DepletesAt)
+		assert.Equal(t, now, *f.DepletesAt)
+		assert.Equal(t, time.Hour, f.LowFundsHorizon)
+	})
+
+	t.Run("beyond all horizons", func(t *testing.T) {
+		f := vrfcommon.ForecastSubscription(burn, big.NewInt(1e18), big.NewInt(0), 24*time.Hour, horizons, now)
+		require.NotNil(t, f.DepletesAt)
+		assert.Zero(t, f.LowFundsHorizon)
+	})
+
+	t.Run("no burn", func(t *testing.T) {
+		idle := vrfcommon.SubscriptionBurn{SubID: big.NewInt(1), Spent: big.NewInt(0)}
+		f := vrfcommon.ForecastSubscription(idle, big.NewInt(0), big.NewInt(0), 24*time.Hour, horizons, now)
+		assert.Nil(t, f.DepletesAt)
+		assert.Zero(t, f.LowFundsHorizon)
+		_, ok := f.TimeToEmpty(now)
+		assert.False(t, ok)
+	})
+}
+
+func TestBalanceForecastORM(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	lifecycle := vrfcommon.NewRequestLifecycleORM(db)
+	orm := vrfcommon.NewBalanceForecastORM(db)
+	jb, _ := cltest.MustInsertWebhookSpec(t, db)
+
+	coordinator := utils.RandomAddress()
+	var observed []vrfcommon.ObservedRequest
+	for i := int64(1); i <= 3; i++ {
+		observed = append(observed, vrfcommon.ObservedRequest{
+			RequestID:   big.NewInt(i),
+			SubID:       big.NewInt(1),
+			Sender:      utils.RandomAddress(),
+			TxHash:      utils.RandomHash(),
+			BlockHash:   utils.RandomHash(),
+			BlockNumber: 100,
+			ObservedAt:  time.Now().UTC(),
+		})
+	}
+	require.NoError(t, lifecycle.RecordObserved(ctx, jb.ID, testutils.FixtureChainID, coordinator, observed))
+	require.NoError(t, lifecycle.RecordFulfilled(ctx, jb.ID, big.NewInt(1), utils.RandomHash(), true, big.NewInt(10), false))
+	require.NoError(t, lifecycle.RecordFulfilled(ctx, jb.ID, big.NewInt(2), utils.RandomHash(), true, big.NewInt(20), false))
+	require.NoError(t, lifecycle.RecordFulfilled(ctx, jb.ID, big.NewInt(3), utils.RandomHash(), true, big.NewInt(5), true))
+
+	burns, err := orm.SubscriptionBurns(ctx, jb.ID, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, burns, 2)
+	assert.False(t, burns[0].NativePayment)
+	assert.Equal(t, big.NewInt(30), burns[0].Spent)
+	assert.Equal(t, int64(2), burns[0].Fulfilled)
+	assert.True(t, burns[1].NativePayment)
+	assert.Equal(t, big.NewInt(5), burns[1].Spent)
+
+	burns, err = orm.SubscriptionBurns(ctx, jb.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, burns)
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	var forecasts []vrfcommon.SubscriptionForecast
+	for _, burn := range []vrfcommon.SubscriptionBurn{
+		{SubID: big.NewInt(1), Spent: big.NewInt(30), Fulfilled: 2},
+		{SubID: big.NewInt(2), Spent: big.NewInt(0)},
+	} {
+		f := vrfcommon.ForecastSubscription(burn, big.NewInt(60), big.NewInt(0), time.Hour, []time.Duration{4 * time.Hour}, now)
+		f.JobID, f.EVMChainID, f.CoordinatorAddress = jb.ID, testutils.FixtureChainID, coordinator
+		forecasts = append(forecasts, f)
+	}
+	require.NoError(t, orm.ReplaceForecasts(ctx, jb.ID, forecasts))
+	require.NoError(t, orm.ReplaceForecasts(ctx, jb.ID, forecasts))
+
+	loaded, err := orm.Forecasts(ctx, vrfcommon.ForecastFilter{JobID: &jb.ID})
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	assert.Equal(t, big.NewInt(1), loaded[0].SubID, "closest to running out of funds first")
+	assert.Equal(t, big.NewInt(30), loaded[0].BurnRatePerHour)
+	assert.Equal(t, 4*time.Hour, loaded[0].LowFundsHorizon)
+	require.NotNil(t, loaded[0].DepletesAt)
+	assert.True(t, now.Add(2*time.Hour).Equal(*loaded[0].DepletesAt))
+	assert.Nil(t, loaded[1].DepletesAt)
+
+	loaded, err = orm.Forecasts(ctx, vrfcommon.ForecastFilter{SubID: big.NewInt(2)})
+	require.NoError(t, err)
+	require.Len(t, loaded, 1)
+	assert.Equal(t, time.Hour, loaded[0].Window)
+}
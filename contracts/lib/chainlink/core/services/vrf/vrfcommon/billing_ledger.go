@@ -0,0 +1,203 @@
+package vrfcommon
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+)
+
+// BillingFilter selects the fulfilled requests that make up a billing ledger.
+type BillingFilter struct {
+	// SubID restricts the ledger to a single subscription. Optional.
+	SubID *big.Int
+	// From and To bound the fulfillment time, To being exclusive.
+	From time.Time
+	To   time.Time
+}
+
+// BillingLedgerEntry is a single request fulfilled by this node, with the gas its
+// fulfillment transaction spent and the payment the coordinator charged for it.
+type BillingLedgerEntry struct {
+	JobID              int32
+	EVMChainID         *big.Int
+	CoordinatorAddress common.Address
+	SubID              *big.Int
+	RequestID          *big.Int
+	NativePayment      bool
+	FulfilledAt        time.Time
+	FulfillmentTxHash  common.Hash
+	FulfillmentSuccess bool
+	GasUsed            uint64
+	EffectiveGasPrice  *big.Int
+	// GasCostWei is what the fulfillment transaction cost this node, including the L1 fee on
+	// L2 chains. Batch fulfillments share a transaction, so it is the cost of the whole batch.
+	GasCostWei *big.Int
+	// Payment is in wei for native payments and in juels otherwise.
+	Payment *big.Int
+}
+
+// BillingSummary aggregates the ledger of a subscription for a single payment currency.
+type BillingSummary struct {
+	EVMChainID         *big.Int
+	SubID              *big.Int
+	NativePayment      bool
+	RequestsFulfilled  int64
+	FulfillmentTxs     int64
+	GasUsed            uint64
+	GasCostWei         *big.Int
+	Payment            *big.Int
+	LastFulfilledAt    time.Time
+	CoordinatorAddress common.Address
+}
+
+type billingLedgerRow struct {
+	JobID              int32
+	EVMChainID         *ubig.Big
+	CoordinatorAddress common.Address
+	SubID              *ubig.Big
+	RequestID          *ubig.Big
+	NativePayment      bool
+	Payment            *ubig.Big
+	FulfilledAt        time.Time
+	FulfillmentTxHash  common.Hash
+	FulfillmentSuccess bool
+	Receipt            evmtypes.Receipt
+}
+
+// BillingLedger returns the requests fulfilled by this node in the given window, oldest first.
+// Requests fulfilled by other nodes have no receipt in this node's txmgr and are left out.
+func (o *requestLifecycleORM) BillingLedger(ctx context.Context, filter BillingFilter) ([]BillingLedgerEntry, error) {
+	stmt := `SELECT l.job_id, l.evm_chain_id, l.coordinator_address, l.sub_id, l.request_id,
+			COALESCE(l.native_payment, FALSE) AS native_payment, COALESCE(l.payment, 0) AS payment,
+			l.fulfilled_at, l.fulfillment_tx_hash, COALESCE(l.fulfillment_success, FALSE) AS fulfillment_success, r.receipt
+		FROM vrf_request_lifecycle l
+		JOIN LATERAL (
+			SELECT receipt FROM evm.receipts WHERE tx_hash = l.fulfillment_tx_hash ORDER BY block_number DESC LIMIT 1
+		) r ON TRUE
+		WHERE l.fulfilled_at >= $1 AND l.fulfilled_at < $2 AND ($3::numeric IS NULL OR l.sub_id = $3)
+		ORDER BY l.fulfilled_at, l.request_id`
+	var subID *string
+	if filter.SubID != nil {
+		s := filter.SubID.String()
+		subID = &s
+	}
+	var rows []billingLedgerRow
+	if err := o.ds.SelectContext(ctx, &rows, stmt, filter.From, filter.To, subID); err != nil {
+		return nil, fmt.Errorf("failed to load vrf billing ledger: %w", err)
+	}
+
+	entries := make([]BillingLedgerEntry, len(rows))
+	for i, r := range rows {
+		effectiveGasPrice := r.Receipt.EffectiveGasPrice
+		if effectiveGasPrice == nil {
+			effectiveGasPrice = big.NewInt(0)
+		}
+		gasCost := new(big.Int).Mul(new(big.Int).SetUint64(r.Receipt.GasUsed), effectiveGasPrice)
+		if r.Receipt.L1Fee != nil {
+			gasCost.Add(gasCost, r.Receipt.L1Fee)
+		}
+		entries[i] = BillingLedgerEntry{
+			JobID:              r.JobID,
+			EVMChainID:         r.EVMChainID.ToInt(),
+			CoordinatorAddress: r.CoordinatorAddress,
+			SubID:              r.SubID.ToInt(),
+			RequestID:          r.RequestID.ToInt(),
+			NativePayment:      r.NativePayment,
+			FulfilledAt:        r.FulfilledAt,
+			FulfillmentTxHash:  r.FulfillmentTxHash,
+			FulfillmentSuccess: r.FulfillmentSuccess,
+			GasUsed:            r.Receipt.GasUsed,
+			EffectiveGasPrice:  effectiveGasPrice,
+			GasCostWei:         gasCost,
+			Payment:            r.Payment.ToInt(),
+		}
+	}
+	return entries, nil
+}
+
+// SummarizeBilling aggregates ledger entries per chain, subscription and payment currency,
+// in the order each group first appears. Gas is only counted once per fulfillment
+// transaction, so batch fulfillments are not double counted.
+func SummarizeBilling(entries []BillingLedgerEntry) []BillingSummary {
+	type key struct {
+		chainID, subID string
+		native         bool
+	}
+	var (
+		summaries []BillingSummary
+		index     = make(map[key]int)
+		seenTxs   = make(map[key]map[common.Hash]struct{})
+	)
+	for _, e := range entries {
+		k := key{chainID: e.EVMChainID.String(), subID: e.SubID.String(), native: e.NativePayment}
+		i, ok := index[k]
+		if !ok {
+			i = len(summaries)
+			index[k] = i
+			seenTxs[k] = make(map[common.Hash]struct{})
+			summaries = append(summaries, BillingSummary{
+				EVMChainID:         e.EVMChainID,
+				SubID:              e.SubID,
+				NativePayment:      e.NativePayment,
+				GasCostWei:         big.NewInt(0),
+				Payment:            big.NewInt(0),
+				CoordinatorAddress: e.CoordinatorAddress,
+			})
+		}
+		s := &summaries[i]
+		s.RequestsFulfilled++
+		s.Payment.Add(s.Payment, e.Payment)
+		if e.FulfilledAt.After(s.LastFulfilledAt) {
+			s.LastFulfilledAt = e.FulfilledAt
+		}
+		if _, seen := seenTxs[k][e.FulfillmentTxHash]; !seen {
+			seenTxs[k][e.FulfillmentTxHash] = struct{}{}
+			s.FulfillmentTxs++
+			s.GasUsed += e.GasUsed
+			s.GasCostWei.Add(s.GasCostWei, e.GasCostWei)
+		}
+	}
+	return summaries
+}
+
+var billingLedgerCSVHeader = []string{"job_id", "evm_chain_id", "coordinator_address", "sub_id", "request_id",
+	"native_payment", "fulfilled_at", "fulfillment_tx_hash", "fulfillment_success", "gas_used",
+	"effective_gas_price_wei", "gas_cost_wei", "payment"}
+
+// WriteBillingLedgerCSV writes the ledger entries as CSV, with a header row.
+func WriteBillingLedgerCSV(w io.Writer, entries []BillingLedgerEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(billingLedgerCSVHeader); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := cw.Write([]string{
+			strconv.FormatInt(int64(e.JobID), 10),
+			e.EVMChainID.String(),
+			e.CoordinatorAddress.Hex(),
+			e.SubID.String(),
+			e.RequestID.String(),
+			strconv.FormatBool(e.NativePayment),
+			e.FulfilledAt.UTC().Format(time.RFC3339),
+			e.FulfillmentTxHash.Hex(),
+			strconv.FormatBool(e.FulfillmentSuccess),
+			strconv.FormatUint(e.GasUsed, 10),
+			e.EffectiveGasPrice.String(),
+			e.GasCostWei.String(),
+			e.Payment.String(),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
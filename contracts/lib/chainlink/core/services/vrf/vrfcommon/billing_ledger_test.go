@@ -0,0 +1,159 @@
+package vrfcommon_test
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr/txmgrtest"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+func TestRequestLifecycleORM_BillingLedger(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	orm := vrfcommon.NewRequestLifecycleORM(db)
+	jb, _ := cltest.MustInsertWebhookSpec(t, db)
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+	_, fromAddress := cltest.MustInsertRandomKey(t, ethKeyStore)
+	txStore := txmgrtest.NewTestTxStore(t, db)
+
+	etx := txmgrtest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, 1, fromAddress)
+	txHash := etx.TxAttempts[0].Hash
+	_, err := txStore.InsertReceipt(ctx, &evmtypes.Receipt{
+		TxHash:            txHash,
+		BlockHash:         utils.RandomHash(),
+		BlockNumber:       big.NewInt(110),
+		GasUsed:           100_000,
+		EffectiveGasPrice: big.NewInt(2),
+		L1Fee:             big.NewInt(5),
+		Status:            1,
+	})
+	require.NoError(t, err)
+
+	coordinator := utils.RandomAddress()
+	subID := big.NewInt(1)
+	var observed []vrfcommon.ObservedRequest
+	for i := int64(1); i <= 3; i++ {
+		observed = append(observed, vrfcommon.ObservedRequest{
+			RequestID:        big.NewInt(i),
+			SubID:            subID,
+			Sender:           utils.RandomAddress(),
+			TxHash:           utils.RandomHash(),
+			BlockHash:        utils.RandomHash(),
+			BlockNumber:      100,
+			ConfirmedAtBlock: 103,
+			ObservedAt:       time.Now().UTC(),
+		})
+	}
+	observed[2].SubID = big.NewInt(2)
+	require.NoError(t, orm.RecordObserved(ctx, jb.ID, testutils.FixtureChainID, coordinator, observed))
+
+	// requests 1 and 2 were batch fulfilled by this node, request 3 by another node
+	require.NoError(t, orm.RecordFulfilled(ctx, jb.ID, big.NewInt(1), txHash, true, big.NewInt(1e15), true))
+	require.NoError(t, orm.RecordFulfilled(ctx, jb.ID, big.NewInt(2), txHash, true, big.NewInt(2e15), true))
+	require.NoError(t, orm.RecordFulfilled(ctx, jb.ID, big.NewInt(3), utils.RandomHash(), true, big.NewInt(1), false))
+
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	entries, err := orm.BillingLedger(ctx, vrfcommon.BillingFilter{From: from, To: to})
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	for _, e := range entries {
+		assert.Equal(t, txHash, e.FulfillmentTxHash)
+		assert.Equal(t, uint64(100_000), e.GasUsed)
+		assert.Equal(t, big.NewInt(200_005), e.GasCostWei)
+		assert.True(t, e.NativePayment)
+		assert.True(t, e.FulfillmentSuccess)
+	}
+	assert.Equal(t, big.NewInt(1e15), entries[0].Payment)
+
+	entries, err = orm.BillingLedger(ctx, vrfcommon.BillingFilter{SubID: big.NewInt(2), From: from, To: to})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	entries, err = orm.BillingLedger(ctx, vrfcommon.BillingFilter{From: to, To: to.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestSummarizeBilling(t *testing.T) {
+	t.Parallel()
+
+	batchTx, singleTx := utils.RandomHash(), utils.RandomHash()
+	now := time.Now().UTC()
+	entry := func(subID int64, native bool, txHash common.Hash, payment int64, at time.Time) vrfcommon.BillingLedgerEntry {
+		return vrfcommon.BillingLedgerEntry{
+			EVMChainID:        testutils.FixtureChainID,
+			SubID:             big.NewInt(subID),
+			RequestID:         big.NewInt(payment),
+			NativePayment:     native,
+			FulfilledAt:       at,
+			FulfillmentTxHash: txHash,
+			GasUsed:           100,
+			EffectiveGasPrice: big.NewInt(3),
+			GasCostWei:        big.NewInt(300),
+			Payment:           big.NewInt(payment),
+		}
+	}
+	summaries := vrfcommon.SummarizeBilling([]vrfcommon.BillingLedgerEntry{
+		entry(1, true, batchTx, 10, now),
+		entry(1, true, batchTx, 20, now),
+		entry(1, false, singleTx, 30, now.Add(time.Minute)),
+		entry(1, true, singleTx, 40, now.Add(time.Minute)),
+	})
+	require.Len(t, summaries, 2)
+
+	native := summaries[0]
+	assert.True(t, native.NativePayment)
+	assert.Equal(t, int64(3), native.RequestsFulfilled)
+	assert.Equal(t, int64(2), native.FulfillmentTxs)
+	assert.Equal(t, uint64(200), native.GasUsed)
+	assert.Equal(t, big.NewInt(600), native.GasCostWei)
+	assert.Equal(t, big.NewInt(70), native.Payment)
+	assert.Equal(t, now.Add(time.Minute), native.LastFulfilledAt)
+
+	link := summaries[1]
+	assert.False(t, link.NativePayment)
+	assert.Equal(t, int64(1), link.RequestsFulfilled)
+	assert.Equal(t, int64(1), link.FulfillmentTxs)
+	assert.Equal(t, big.NewInt(30), link.Payment)
+}
+
+func TestWriteBillingLedgerCSV(t *testing.T) {
+	t.Parallel()
+
+	txHash := utils.RandomHash()
+	var buf bytes.Buffer
+	require.NoError(t, vrfcommon.WriteBillingLedgerCSV(&buf, []vrfcommon.BillingLedgerEntry{{
+		JobID:              1,
+		EVMChainID:         testutils.FixtureChainID,
+		SubID:              big.NewInt(2),
+		RequestID:          big.NewInt(3),
+		NativePayment:      true,
+		FulfilledAt:        time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		FulfillmentTxHash:  txHash,
+		FulfillmentSuccess: true,
+		GasUsed:            100,
+		EffectiveGasPrice:  big.NewInt(3),
+		GasCostWei:         big.NewInt(300),
+		Payment:            big.NewInt(1000),
+	}}))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "job_id,evm_chain_id,coordinator_address,sub_id,request_id,"))
+	assert.Contains(t, lines[1], ",2,3,true,2024-01-02T03:04:05Z,"+txHash.Hex()+",true,100,3,300,1000")
+}
@@ -0,0 +1,98 @@
+package vrfcommon
+
+import (
+	"github.com/pkg/errors"
+
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	"github.com/smartcontractkit/chainlink/v2/core/services/blockhashstore"
+	"github.com/smartcontractkit/chainlink/v2/core/services/blockheaderfeeder"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+)
+
+// BlockhashStoreSpec returns the spec of the blockhash store feeder declared by a VRF job, for
+// its coordinator of the given version, nil if the job declares none.
+func BlockhashStoreSpec(spec *job.VRFSpec, version Version) *job.BlockhashStoreSpec {
+	c := spec.BlockhashStore
+	if c == nil {
+		return nil
+	}
+	bhs := &job.BlockhashStoreSpec{
+		WaitBlocks:                     c.WaitBlocks,
+		LookbackBlocks:                 c.LookbackBlocks,
+		HeartbeatPeriod:                c.HeartbeatPeriod,
+		BlockhashStoreAddress:          c.BlockhashStoreAddress,
+		TrustedBlockhashStoreAddress:   c.TrustedBlockhashStoreAddress,
+		TrustedBlockhashStoreBatchSize: c.TrustedBlockhashStoreBatchSize,
+		PollPeriod:                     c.PollPeriod,
+		RunTimeout:                     c.RunTimeout,
+		EVMChainID:                     spec.EVMChainID,
+		FromAddresses:                  feederFromAddresses(c.FromAddresses, spec.FromAddresses),
+	}
+	bhs.CoordinatorV2Address, bhs.CoordinatorV2PlusAddress = feederCoordinator(spec, version)
+	return bhs
+}
+
+// BlockHeaderFeederSpec returns the spec of the block header feeder declared by a VRF job, for
+// its coordinator of the given version, nil if the job declares none.
+func BlockHeaderFeederSpec(spec *job.VRFSpec, version Version) *job.BlockHeaderFeederSpec {
+	c := spec.BlockHeaderFeeder
+	if c == nil {
+		return nil
+	}
+	bhf := &job.BlockHeaderFeederSpec{
+		LookbackBlocks:             c.LookbackBlocks,
+		WaitBlocks:                 c.WaitBlocks,
+		BlockhashStoreAddress:      c.BlockhashStoreAddress,
+		BatchBlockhashStoreAddress: c.BatchBlockhashStoreAddress,
+		PollPeriod:                 c.PollPeriod,
+		RunTimeout:                 c.RunTimeout,
+		EVMChainID:                 spec.EVMChainID,
+		FromAddresses:              feederFromAddresses(c.FromAddresses, spec.FromAddresses),
+		GetBlockhashesBatchSize:    c.GetBlockhashesBatchSize,
+		StoreBlockhashesBatchSize:  c.StoreBlockhashesBatchSize,
+	}
+	bhf.CoordinatorV2Address, bhf.CoordinatorV2PlusAddress = feederCoordinator(spec, version)
+	return bhf
+}
+
+// validateFeeders validates the feeders declared by a VRF job with the checks of their own job
+// types, and sets the defaults of the settings they omit.
+func validateFeeders(spec *job.VRFSpec, version Version) error {
+	if spec.EVMChainID == nil {
+		return errors.Wrap(ErrKeyNotSet, "evmChainID must be set to run blockhashStore or blockHeaderFeeder")
+	}
+	if bhs := BlockhashStoreSpec(spec, version); bhs != nil {
+		if err := blockhashstore.ValidateSpec(bhs); err != nil {
+			return errors.Wrap(err, "blockhashStore")
+		}
+		c := spec.BlockhashStore
+		c.WaitBlocks, c.LookbackBlocks = bhs.WaitBlocks, bhs.LookbackBlocks
+		c.PollPeriod, c.RunTimeout = bhs.PollPeriod, bhs.RunTimeout
+	}
+	if bhf := BlockHeaderFeederSpec(spec, version); bhf != nil {
+		if err := blockheaderfeeder.ValidateSpec(bhf); err != nil {
+			return errors.Wrap(err, "blockHeaderFeeder")
+		}
+		c := spec.BlockHeaderFeeder
+		c.WaitBlocks, c.LookbackBlocks = bhf.WaitBlocks, bhf.LookbackBlocks
+		c.PollPeriod, c.RunTimeout = bhf.PollPeriod, bhf.RunTimeout
+		c.GetBlockhashesBatchSize, c.StoreBlockhashesBatchSize = bhf.GetBlockhashesBatchSize, bhf.StoreBlockhashesBatchSize
+	}
+	return nil
+}
+
+// feederCoordinator returns the job's coordinator as the v2 or v2plus coordinator of a feeder.
+func feederCoordinator(spec *job.VRFSpec, version Version) (v2, v2Plus *evmtypes.EIP55Address) {
+	coordinator := spec.CoordinatorAddress
+	if version == V2Plus {
+		return nil, &coordinator
+	}
+	return &coordinator, nil
+}
+
+func feederFromAddresses(feeder, jobFromAddresses []evmtypes.EIP55Address) []evmtypes.EIP55Address {
+	if len(feeder) > 0 {
+		return feeder
+	}
+	return jobFromAddresses
+}
@@ -1,8 +1,12 @@
 package vrfcommon
 
 import (
+	"math"
+	"math/big"
+	"strconv"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -17,16 +21,37 @@ const (
 	V2Plus Version = "V2Plus"
 )
 
-// dropReason describes a reason why a VRF request is dropped from the queue.
-type dropReason string
+// DropReason describes a reason why a VRF request is dropped from the queue.
+type DropReason string
 
 const (
 	// ReasonMailboxSize describes when a VRF request is dropped due to the log mailbox being
 	// over capacity.
-	ReasonMailboxSize dropReason = "mailbox_size"
+	ReasonMailboxSize DropReason = "mailbox_size"
 
 	// ReasonAge describes when a VRF request is dropped due to its age.
-	ReasonAge dropReason = "age"
+	ReasonAge DropReason = "age"
+
+	// ReasonInvalidConsumer describes when a VRF request is dropped because the consumer
+	// that made it has no code once the finality depth has elapsed.
+	ReasonInvalidConsumer DropReason = "invalid_consumer"
+)
+
+// ThrottleReason describes why a ready VRF request is held back for a later round.
+type ThrottleReason string
+
+const (
+	// ThrottleConsumerRateLimit describes when a VRF request is held back because its consumer
+	// is over the job's consumerRateLimit.
+	ThrottleConsumerRateLimit ThrottleReason = "consumer_rate_limit"
+
+	// ThrottleSubscriptionRateLimit describes when a VRF request is held back because its
+	// subscription is over the job's subscriptionRateLimit.
+	ThrottleSubscriptionRateLimit ThrottleReason = "subscription_rate_limit"
+
+	// ThrottleSubscriptionShare describes when a VRF request is held back because its
+	// subscription already got its weighted share of the round.
+	ThrottleSubscriptionShare ThrottleReason = "subscription_share"
 )
 
 var (
@@ -40,16 +65,62 @@ var (
 		Help: "The number of VRF requests processed.",
 	}, []string{"job_name", "external_job_id", "vrf_version"})
 
+	MetricLaneQueueSize = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vrf_lane_request_queue_size",
+		Help: "The number of VRF requests of a gas lane currently in the in-memory queue.",
+	}, []string{"job_name", "external_job_id", "vrf_version", "key_hash"})
+
+	MetricLaneProcessedReqs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vrf_lane_processed_request_count",
+		Help: "The number of VRF requests of a gas lane processed.",
+	}, []string{"job_name", "external_job_id", "vrf_version", "key_hash"})
+
 	MetricDroppedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "vrf_dropped_request_count",
 		Help: "The number of VRF requests dropped due to reasons such as expiry or mailbox size.",
 	}, []string{"job_name", "external_job_id", "vrf_version", "drop_reason"})
 
+	MetricThrottledRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vrf_throttled_request_count",
+		Help: "The number of times a ready VRF request was held back by the per-consumer or per-subscription limits.",
+	}, []string{"job_name", "external_job_id", "vrf_version", "throttle_reason"})
+
+	MetricFulfillmentLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "vrf_request_fulfillment_latency_seconds",
+		Help:    "How long it takes from the confirmation of a VRF request until its fulfillment log.",
+		Buckets: []float64{5, 15, 30, 60, 120, 300, 600, 1800, 3600},
+	}, []string{"job_name", "external_job_id", "vrf_version"})
+
+	MetricSLABreaches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vrf_sla_breach_count",
+		Help: "The number of VRF requests that were not fulfilled within the job's fulfillment SLA.",
+	}, []string{"job_name", "external_job_id", "vrf_version", "sla"})
+
+	MetricSubscriptionTimeToEmpty = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vrf_subscription_time_to_empty_seconds",
+		Help: "How long until a VRF subscription runs out of funds at its recent burn rate, +Inf if it burned nothing.",
+	}, []string{"job_name", "external_job_id", "vrf_version", "sub_id", "native_payment"})
+
+	MetricSubscriptionBurnRate = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "vrf_subscription_burn_rate_per_hour",
+		Help: "What a VRF subscription paid per hour for fulfillments, in juels or wei, over the job's balance forecast window.",
+	}, []string{"job_name", "external_job_id", "vrf_version", "sub_id", "native_payment"})
+
+	MetricLowFundsWarnings = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vrf_low_funds_warning_count",
+		Help: "The number of times a VRF subscription was forecast to run out of funds within one of the job's warning horizons.",
+	}, []string{"job_name", "external_job_id", "vrf_version", "sub_id", "native_payment", "horizon"})
+
 	MetricDupeRequests = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "vrf_duplicate_requests",
 		Help: "The number of times the VRF listener receives duplicate requests, which could indicate a reorg.",
 	}, []string{"job_name", "external_job_id", "vrf_version"})
 
+	MetricReorgedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "vrf_reorged_requests",
+		Help: "The number of VRF requests that were re-mined in another block after a reorg, and had their proof regenerated.",
+	}, []string{"job_name", "external_job_id", "vrf_version"})
+
 	MetricTimeBetweenSims = promauto.NewHistogramVec(prometheus.HistogramOpts{
 		Name: "vrf_request_time_between_sims",
 		Help: "How long a VRF request sits in the in-memory queue in between simulation attempts.",
@@ -84,11 +155,54 @@ func IncProcessedReqs(jobName string, extJobID uuid.UUID, vrfVersion Version) {
 	MetricProcessedReqs.WithLabelValues(jobName, extJobID.String(), string(vrfVersion)).Inc()
 }
 
-func IncDroppedReqs(jobName string, extJobID uuid.UUID, vrfVersion Version, reason dropReason) {
+func UpdateLaneQueueSize(jobName string, extJobID uuid.UUID, vrfVersion Version, keyHash common.Hash, size int) {
+	MetricLaneQueueSize.WithLabelValues(jobName, extJobID.String(), string(vrfVersion), keyHash.Hex()).
+		Set(float64(size))
+}
+
+func IncLaneProcessedReqs(jobName string, extJobID uuid.UUID, vrfVersion Version, keyHash common.Hash) {
+	MetricLaneProcessedReqs.WithLabelValues(jobName, extJobID.String(), string(vrfVersion), keyHash.Hex()).Inc()
+}
+
+func IncDroppedReqs(jobName string, extJobID uuid.UUID, vrfVersion Version, reason DropReason) {
 	MetricDroppedRequests.WithLabelValues(
 		jobName, extJobID.String(), string(vrfVersion), string(reason)).Inc()
 }
 
+func IncThrottledReqs(jobName string, extJobID uuid.UUID, vrfVersion Version, reason ThrottleReason) {
+	MetricThrottledRequests.WithLabelValues(
+		jobName, extJobID.String(), string(vrfVersion), string(reason)).Inc()
+}
+
+func ObserveFulfillmentLatency(jobName string, extJobID uuid.UUID, vrfVersion Version, latency time.Duration) {
+	MetricFulfillmentLatency.WithLabelValues(jobName, extJobID.String(), string(vrfVersion)).
+		Observe(latency.Seconds())
+}
+
+func IncSLABreaches(jobName string, extJobID uuid.UUID, vrfVersion Version, sla SLAKind) {
+	MetricSLABreaches.WithLabelValues(jobName, extJobID.String(), string(vrfVersion), string(sla)).Inc()
+}
+
+func UpdateSubscriptionForecast(jobName string, extJobID uuid.UUID, vrfVersion Version, f SubscriptionForecast, now time.Time) {
+	labels := []string{jobName, extJobID.String(), string(vrfVersion), f.SubID.String(), strconv.FormatBool(f.NativePayment)}
+	timeToEmpty := math.Inf(1)
+	if d, ok := f.TimeToEmpty(now); ok {
+		timeToEmpty = d.Seconds()
+	}
+	MetricSubscriptionTimeToEmpty.WithLabelValues(labels...).Set(timeToEmpty)
+	burnRate, _ := new(big.Float).SetInt(f.BurnRatePerHour).Float64()
+	MetricSubscriptionBurnRate.WithLabelValues(labels...).Set(burnRate)
+}
+
+func IncLowFundsWarnings(jobName string, extJobID uuid.UUID, vrfVersion Version, subID *big.Int, nativePayment bool, horizon time.Duration) {
+	MetricLowFundsWarnings.WithLabelValues(
+		jobName, extJobID.String(), string(vrfVersion), subID.String(), strconv.FormatBool(nativePayment), horizon.String()).Inc()
+}
+
 func IncDupeReqs(jobName string, extJobID uuid.UUID, vrfVersion Version) {
 	MetricDupeRequests.WithLabelValues(jobName, extJobID.String(), string(vrfVersion)).Inc()
 }
+
+func IncReorgedReqs(jobName string, extJobID uuid.UUID, vrfVersion Version) {
+	MetricReorgedRequests.WithLabelValues(jobName, extJobID.String(), string(vrfVersion)).Inc()
+}
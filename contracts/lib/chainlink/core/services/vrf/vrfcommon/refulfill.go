@@ -0,0 +1,45 @@
+package vrfcommon
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrRequestNotFound is returned when a request to re-fulfill can't be found on-chain.
+	ErrRequestNotFound = errors.New("vrf request not found")
+	// ErrRequestAlreadyFulfilled is returned when a request to re-fulfill was already fulfilled on-chain.
+	ErrRequestAlreadyFulfilled = errors.New("vrf request already fulfilled")
+	// ErrRequestInflight is returned when a request to re-fulfill already has a fulfillment in flight.
+	ErrRequestInflight = errors.New("vrf request fulfillment already in flight")
+)
+
+// RefulfillOpts configures an operator-triggered re-fulfillment of a VRF request.
+type RefulfillOpts struct {
+	// BlockNumber is the block the request was made in. If nil, it is looked up in the
+	// request lifecycle audit trail.
+	BlockNumber *uint64
+	// DryRun only simulates the fulfillment, without submitting it to the txmgr.
+	DryRun bool
+}
+
+// RefulfillResult describes the outcome of a re-fulfillment.
+type RefulfillResult struct {
+	JobID          int32
+	RequestID      *big.Int
+	SubID          *big.Int
+	Sender         common.Address
+	RequestTxHash  common.Hash
+	NativePayment  bool
+	FromAddress    common.Address
+	GasLimit       uint64
+	MaxGasPriceWei *big.Int
+	// MaxFee is the most the coordinator can charge for the fulfillment, at the max gas price, in
+	// juels or wei depending on NativePayment.
+	MaxFee *big.Int
+	DryRun bool
+	// EthTxID is the txmgr transaction of the fulfillment. Nil for dry-runs.
+	EthTxID *int64
+}
@@ -0,0 +1,305 @@
+package vrfcommon
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lib/pq"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/sqlutil"
+	ubig "github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+)
+
+// RequestStage describes how far a VRF request has progressed through the listener.
+type RequestStage string
+
+const (
+	// StageObserved means the request log was picked up by the listener.
+	StageObserved RequestStage = "observed"
+	// StageConfirmed means the request reached its confirmedAtBlock and became eligible for processing.
+	StageConfirmed RequestStage = "confirmed"
+	// StageSimulated means the fulfillment was simulated at least once.
+	StageSimulated RequestStage = "simulated"
+	// StageEnqueued means a fulfillment transaction was created in the txmgr.
+	StageEnqueued RequestStage = "enqueued"
+	// StageFulfilled means a RandomWordsFulfilled log was seen for the request.
+	StageFulfilled RequestStage = "fulfilled"
+	// StageDropped means the listener gave up on the request.
+	StageDropped RequestStage = "dropped"
+)
+
+// RequestLifecycle is the persisted audit trail of a single VRF request, as seen by a single job.
+type RequestLifecycle struct {
+	JobID               int32
+	RequestID           *ubig.Big
+	EVMChainID          *ubig.Big
+	CoordinatorAddress  common.Address
+	SubID               *ubig.Big
+	Sender              common.Address
+	RequestTxHash       common.Hash
+	RequestBlockHash    common.Hash
+	RequestBlockNumber  int64
+	ConfirmedAtBlock    int64
+	ObservedAt          time.Time
+	ConfirmedAt         *time.Time
+	SimulationAttempts  int32
+	LastSimulatedAt     *time.Time
+	LastSimulationError *string
+	EnqueuedAt          *time.Time
+	EthTxID             *int64
+	FulfilledAt         *time.Time
+	FulfillmentTxHash   *common.Hash
+	FulfillmentSuccess  *bool
+	DroppedAt           *time.Time
+	DropReason          *string
+	UpdatedAt           time.Time
+	Payment             *ubig.Big
+	NativePayment       *bool
+}
+
+// Stage returns the furthest stage the request has reached.
+func (r RequestLifecycle) Stage() RequestStage {
+	switch {
+	case r.FulfilledAt != nil:
+		return StageFulfilled
+	case r.DroppedAt != nil:
+		return StageDropped
+	case r.EnqueuedAt != nil:
+		return StageEnqueued
+	case r.LastSimulatedAt != nil:
+		return StageSimulated
+	case r.ConfirmedAt != nil:
+		return StageConfirmed
+	default:
+		return StageObserved
+	}
+}
+
+// ObservedRequest holds the fields of a request log that are recorded when the
+// listener first observes it.
+type ObservedRequest struct {
+	RequestID        *big.Int
+	SubID            *big.Int
+	Sender           common.Address
+	TxHash           common.Hash
+	BlockHash        common.Hash
+	BlockNumber      uint64
+	ConfirmedAtBlock uint64
+	ObservedAt       time.Time
+}
+
+// SimulatedRequest is the outcome of simulating the fulfillment of a request. Err is nil if the
+// simulation succeeded.
+type SimulatedRequest struct {
+	RequestID *big.Int
+	Err       error
+}
+
+// RequestLifecycleORM persists the lifecycle of VRF requests handled by a listener.
+type RequestLifecycleORM interface {
+	RecordObserved(ctx context.Context, jobID int32, chainID *big.Int, coordinator common.Address, reqs []ObservedRequest) error
+	RecordConfirmed(ctx context.Context, jobID int32, requestIDs []*big.Int) (map[string]time.Time, error)
+	RecordSimulated(ctx context.Context, jobID int32, sims []SimulatedRequest) error
+	RecordEnqueued(ctx context.Context, jobID int32, requestIDs []*big.Int, ethTxID int64) error
+	RecordFulfilled(ctx context.Context, jobID int32, requestID *big.Int, txHash common.Hash, success bool, payment *big.Int, nativePayment bool) error
+	RecordDropped(ctx context.Context, jobID int32, requestID *big.Int, reason DropReason) error
+	RecordReorged(ctx context.Context, jobID int32, requestID *big.Int) error
+	FindByRequestID(ctx context.Context, requestID *big.Int) ([]RequestLifecycle, error)
+	BillingLedger(ctx context.Context, filter BillingFilter) ([]BillingLedgerEntry, error)
+}
+
+type requestLifecycleORM struct {
+	ds sqlutil.DataSource
+}
+
+var _ RequestLifecycleORM = (*requestLifecycleORM)(nil)
+
+func NewRequestLifecycleORM(ds sqlutil.DataSource) RequestLifecycleORM {
+	return &requestLifecycleORM{ds: ds}
+}
+
+// observedRequestsBatchSize bounds the number of requests inserted by a single statement, to
+// keep it under the limit of bind parameters.
+const observedRequestsBatchSize = 1000
+
+type observedRequestRow struct {
+	JobID              int32          `db:"job_id"`
+	RequestID          *ubig.Big      `db:"request_id"`
+	EVMChainID         *ubig.Big      `db:"evm_chain_id"`
+	CoordinatorAddress common.Address `db:"coordinator_address"`
+	SubID              *ubig.Big      `db:"sub_id"`
+	Sender             common.Address `db:"sender"`
+	RequestTxHash      common.Hash    `db:"request_tx_hash"`
+	RequestBlockHash   common.Hash    `db:"request_block_hash"`
+	RequestBlockNumber int64          `db:"request_block_number"`
+	ConfirmedAtBlock   int64          `db:"confirmed_at_block"`
+	ObservedAt         time.Time      `db:"observed_at"`
+}
+
+// RecordObserved inserts the given requests, in batches. Requests that were already observed
+// are left as they are, unless they were re-mined in another block: those keep their original
+// observation time, but take the new block and confirmation data.
+func (o *requestLifecycleORM) RecordObserved(ctx context.Context, jobID int32, chainID *big.Int, coordinator common.Address, reqs []ObservedRequest) error {
+	if len(reqs) == 0 {
+		return nil
+	}
+	rows := make([]observedRequestRow, len(reqs))
+	for i, r := range reqs {
+		rows[i] = observedRequestRow{
+			JobID:              jobID,
+			RequestID:          ubig.New(r.RequestID),
+			EVMChainID:         ubig.New(chainID),
+			CoordinatorAddress: coordinator,
+			SubID:              ubig.New(r.SubID),
+			Sender:             r.Sender,
+			RequestTxHash:      r.TxHash,
+			RequestBlockHash:   r.BlockHash,
+			RequestBlockNumber: int64(r.BlockNumber),
+			ConfirmedAtBlock:   int64(r.ConfirmedAtBlock),
+			ObservedAt:         r.ObservedAt,
+		}
+	}
+	stmt := `INSERT INTO vrf_request_lifecycle (job_id, request_id, evm_chain_id, coordinator_address, sub_id, sender,
+		request_tx_hash, request_block_hash, request_block_number, confirmed_at_block, observed_at, updated_at)
+		VALUES (:job_id, :request_id, :evm_chain_id, :coordinator_address, :sub_id, :sender,
+		:request_tx_hash, :request_block_hash, :request_block_number, :confirmed_at_block, :observed_at, NOW())
+		ON CONFLICT (job_id, request_id) DO UPDATE SET
+			request_tx_hash = EXCLUDED.request_tx_hash,
+			request_block_hash = EXCLUDED.request_block_hash,
+			request_block_number = EXCLUDED.request_block_number,
+			confirmed_at_block = EXCLUDED.confirmed_at_block,
+			updated_at = NOW()
+		WHERE vrf_request_lifecycle.request_block_hash <> EXCLUDED.request_block_hash`
+	for start := 0; start < len(rows); start += observedRequestsBatchSize {
+		end := min(start+observedRequestsBatchSize, len(rows))
+		if _, err := o.ds.NamedExecContext(ctx, stmt, rows[start:end]); err != nil {
+			return fmt.Errorf("failed to record observed vrf requests: %w", err)
+		}
+	}
+	return nil
+}
+
+// RecordConfirmed marks the given requests as confirmed, unless they already were. It returns
+// the time each of them was first confirmed, by request ID, which outlives restarts of the node.
+func (o *requestLifecycleORM) RecordConfirmed(ctx context.Context, jobID int32, requestIDs []*big.Int) (map[string]time.Time, error) {
+	if len(requestIDs) == 0 {
+		return nil, nil
+	}
+	// The SELECT sees the table as it was before the UPDATE, so the requests it returns are
+	// the ones confirmed before.
+	stmt := `WITH confirmed AS (
+		UPDATE vrf_request_lifecycle SET confirmed_at = NOW(), updated_at = NOW()
+		WHERE job_id = $1 AND request_id = ANY($2::numeric[]) AND confirmed_at IS NULL
+		RETURNING request_id, confirmed_at
+	)
+	SELECT request_id, confirmed_at FROM confirmed
+	UNION ALL
+	SELECT request_id, confirmed_at FROM vrf_request_lifecycle
+	WHERE job_id = $1 AND request_id = ANY($2::numeric[]) AND confirmed_at IS NOT NULL`
+	var rows []struct {
+		RequestID   ubig.Big  `db:"request_id"`
+		ConfirmedAt time.Time `db:"confirmed_at"`
+	}
+	if err := o.ds.SelectContext(ctx, &rows, stmt, jobID, pq.Array(bigsToStrings(requestIDs))); err != nil {
+		return nil, fmt.Errorf("failed to record confirmed vrf requests: %w", err)
+	}
+	confirmedAt := make(map[string]time.Time, len(rows))
+	for _, row := range rows {
+		confirmedAt[row.RequestID.String()] = row.ConfirmedAt
+	}
+	return confirmedAt, nil
+}
+
+// RecordSimulated counts a simulation attempt for each of the given requests, along with its
+// error if it failed.
+func (o *requestLifecycleORM) RecordSimulated(ctx context.Context, jobID int32, sims []SimulatedRequest) error {
+	if len(sims) == 0 {
+		return nil
+	}
+	requestIDs := make([]string, len(sims))
+	simErrs := make([]sql.NullString, len(sims))
+	for i, sim := range sims {
+		requestIDs[i] = sim.RequestID.String()
+		if sim.Err != nil {
+			simErrs[i] = sql.NullString{String: sim.Err.Error(), Valid: true}
+		}
+	}
+	stmt := `UPDATE vrf_request_lifecycle SET simulation_attempts = simulation_attempts + 1, last_simulated_at = NOW(),
+		last_simulation_error = sims.simulation_error, updated_at = NOW()
+		FROM unnest($2::numeric[], $3::text[]) AS sims(request_id, simulation_error)
+		WHERE vrf_request_lifecycle.job_id = $1 AND vrf_request_lifecycle.request_id = sims.request_id`
+	if _, err := o.ds.ExecContext(ctx, stmt, jobID, pq.Array(requestIDs), pq.Array(simErrs)); err != nil {
+		return fmt.Errorf("failed to record simulated vrf requests: %w", err)
+	}
+	return nil
+}
+
+// RecordEnqueued links the given requests to the txmgr transaction that fulfills them.
+func (o *requestLifecycleORM) RecordEnqueued(ctx context.Context, jobID int32, requestIDs []*big.Int, ethTxID int64) error {
+	if len(requestIDs) == 0 {
+		return nil
+	}
+	stmt := `UPDATE vrf_request_lifecycle SET enqueued_at = NOW(), eth_tx_id = $3, updated_at = NOW()
+		WHERE job_id = $1 AND request_id = ANY($2::numeric[])`
+	if _, err := o.ds.ExecContext(ctx, stmt, jobID, pq.Array(bigsToStrings(requestIDs)), ethTxID); err != nil {
+		return fmt.Errorf("failed to record enqueued vrf requests: %w", err)
+	}
+	return nil
+}
+
+// RecordFulfilled marks a request as fulfilled on-chain, along with the payment the coordinator
+// charged for it. Fulfillment logs are not filtered by key hash, so requests that this job never
+// observed are ignored.
+func (o *requestLifecycleORM) RecordFulfilled(ctx context.Context, jobID int32, requestID *big.Int, txHash common.Hash, success bool, payment *big.Int, nativePayment bool) error {
+	stmt := `UPDATE vrf_request_lifecycle SET fulfilled_at = NOW(), fulfillment_tx_hash = $3, fulfillment_success = $4,
+		payment = $5, native_payment = $6, updated_at = NOW()
+		WHERE job_id = $1 AND request_id = $2`
+	if _, err := o.ds.ExecContext(ctx, stmt, jobID, ubig.New(requestID), txHash, success, ubig.New(payment), nativePayment); err != nil {
+		return fmt.Errorf("failed to record fulfilled vrf request %s: %w", requestID, err)
+	}
+	return nil
+}
+
+// RecordDropped marks a request as dropped by the listener for the given reason.
+func (o *requestLifecycleORM) RecordDropped(ctx context.Context, jobID int32, requestID *big.Int, reason DropReason) error {
+	stmt := `UPDATE vrf_request_lifecycle SET dropped_at = NOW(), drop_reason = $3, updated_at = NOW()
+		WHERE job_id = $1 AND request_id = $2`
+	if _, err := o.ds.ExecContext(ctx, stmt, jobID, ubig.New(requestID), string(reason)); err != nil {
+		return fmt.Errorf("failed to record dropped vrf request %s: %w", requestID, err)
+	}
+	return nil
+}
+
+// RecordReorged resets the progress of a request that was re-mined in another block after a
+// reorg, unless it was fulfilled already, since it is confirmed, simulated and enqueued again
+// with a proof for the new block.
+func (o *requestLifecycleORM) RecordReorged(ctx context.Context, jobID int32, requestID *big.Int) error {
+	stmt := `UPDATE vrf_request_lifecycle SET confirmed_at = NULL, last_simulated_at = NULL, last_simulation_error = NULL,
+		enqueued_at = NULL, eth_tx_id = NULL, updated_at = NOW()
+		WHERE job_id = $1 AND request_id = $2 AND fulfilled_at IS NULL`
+	if _, err := o.ds.ExecContext(ctx, stmt, jobID, ubig.New(requestID)); err != nil {
+		return fmt.Errorf("failed to record reorged vrf request %s: %w", requestID, err)
+	}
+	return nil
+}
+
+// FindByRequestID returns the lifecycle of the given request for every job that observed it.
+func (o *requestLifecycleORM) FindByRequestID(ctx context.Context, requestID *big.Int) (lifecycles []RequestLifecycle, err error) {
+	stmt := `SELECT * FROM vrf_request_lifecycle WHERE request_id = $1 ORDER BY job_id`
+	if err = o.ds.SelectContext(ctx, &lifecycles, stmt, ubig.New(requestID)); err != nil {
+		return nil, fmt.Errorf("failed to find vrf request %s: %w", requestID, err)
+	}
+	return lifecycles, nil
+}
+
+func bigsToStrings(bigs []*big.Int) []string {
+	strs := make([]string, len(bigs))
+	for i, b := range bigs {
+		strs[i] = b.String()
+	}
+	return strs
+}
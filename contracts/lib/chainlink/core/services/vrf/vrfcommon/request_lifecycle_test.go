@@ -0,0 +1,164 @@
+package vrfcommon_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+func TestRequestLifecycleORM(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	db := pgtest.NewSqlxDB(t)
+	orm := vrfcommon.NewRequestLifecycleORM(db)
+	jb, _ := cltest.MustInsertWebhookSpec(t, db)
+
+	coordinator := utils.RandomAddress()
+	reqID := big.NewInt(42)
+	otherReqID := big.NewInt(43)
+	observed := vrfcommon.ObservedRequest{
+		RequestID:        reqID,
+		SubID:            big.NewInt(1),
+		Sender:           utils.RandomAddress(),
+		TxHash:           utils.RandomHash(),
+		BlockHash:        utils.RandomHash(),
+		BlockNumber:      100,
+		ConfirmedAtBlock: 103,
+		ObservedAt:       time.Now().UTC(),
+	}
+	other := observed
+	other.RequestID = otherReqID
+
+	t.Run("observed", func(t *testing.T) {
+		require.NoError(t, orm.RecordObserved(ctx, jb.ID, testutils.FixtureChainID, coordinator, []vrfcommon.ObservedRequest{observed, other}))
+
+		// re-observing after a re-org updates the block data
+		reorged := observed
+		reorged.BlockHash = utils.RandomHash()
+		reorged.BlockNumber = 101
+		reorged.ConfirmedAtBlock = 104
+		require.NoError(t, orm.RecordObserved(ctx, jb.ID, testutils.FixtureChainID, coordinator, []vrfcommon.ObservedRequest{reorged}))
+
+		lifecycles, err := orm.FindByRequestID(ctx, reqID)
+		require.NoError(t, err)
+		require.Len(t, lifecycles, 1)
+		assert.Equal(t, vrfcommon.StageObserved, lifecycles[0].Stage())
+		assert.Equal(t, coordinator, lifecycles[0].CoordinatorAddress)
+		assert.Equal(t, reorged.BlockHash, lifecycles[0].RequestBlockHash)
+		assert.Equal(t, int64(104), lifecycles[0].ConfirmedAtBlock)
+
+		// re-observing in the same block leaves the request as it is
+		again := other
+		again.ConfirmedAtBlock = 110
+		require.NoError(t, orm.RecordObserved(ctx, jb.ID, testutils.FixtureChainID, coordinator, []vrfcommon.ObservedRequest{again}))
+		lifecycles, err = orm.FindByRequestID(ctx, otherReqID)
+		require.NoError(t, err)
+		require.Len(t, lifecycles, 1)
+		assert.Equal(t, int64(103), lifecycles[0].ConfirmedAtBlock)
+	})
+
+	t.Run("confirmed and simulated", func(t *testing.T) {
+		confirmedAt, err := orm.RecordConfirmed(ctx, jb.ID, []*big.Int{reqID})
+		require.NoError(t, err)
+		require.Contains(t, confirmedAt, reqID.String())
+		// confirming it again keeps the time it was first confirmed
+		again, err := orm.RecordConfirmed(ctx, jb.ID, []*big.Int{reqID})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]time.Time{reqID.String(): confirmedAt[reqID.String()]}, again)
+
+		// the simulations of a poll are recorded together
+		require.NoError(t, orm.RecordSimulated(ctx, jb.ID, []vrfcommon.SimulatedRequest{
+			{RequestID: reqID, Err: errors.New("execution reverted")},
+			{RequestID: otherReqID, Err: errors.New("insufficient balance")},
+		}))
+		require.NoError(t, orm.RecordSimulated(ctx, jb.ID, []vrfcommon.SimulatedRequest{{RequestID: reqID}}))
+		require.NoError(t, orm.RecordSimulated(ctx, jb.ID, nil))
+
+		lifecycles, err := orm.FindByRequestID(ctx, reqID)
+		require.NoError(t, err)
+		require.Len(t, lifecycles, 1)
+		assert.Equal(t, vrfcommon.StageSimulated, lifecycles[0].Stage())
+		assert.NotNil(t, lifecycles[0].ConfirmedAt)
+		assert.Equal(t, int32(2), lifecycles[0].SimulationAttempts)
+		assert.Nil(t, lifecycles[0].LastSimulationError)
+
+		lifecycles, err = orm.FindByRequestID(ctx, otherReqID)
+		require.NoError(t, err)
+		require.Len(t, lifecycles, 1)
+		assert.Equal(t, int32(1), lifecycles[0].SimulationAttempts)
+		require.NotNil(t, lifecycles[0].LastSimulationError)
+		assert.Equal(t, "insufficient balance", *lifecycles[0].LastSimulationError)
+	})
+
+	t.Run("reorged", func(t *testing.T) {
+		require.NoError(t, orm.RecordEnqueued(ctx, jb.ID, []*big.Int{reqID}, 6))
+		// a request re-mined in another block goes back to observed
+		require.NoError(t, orm.RecordReorged(ctx, jb.ID, reqID))
+
+		lifecycles, err := orm.FindByRequestID(ctx, reqID)
+		require.NoError(t, err)
+		require.Len(t, lifecycles, 1)
+		assert.Equal(t, vrfcommon.StageObserved, lifecycles[0].Stage())
+		assert.Nil(t, lifecycles[0].ConfirmedAt)
+		assert.Nil(t, lifecycles[0].EthTxID)
+	})
+
+	t.Run("enqueued and fulfilled", func(t *testing.T) {
+		require.NoError(t, orm.RecordEnqueued(ctx, jb.ID, []*big.Int{reqID}, 7))
+		lifecycles, err := orm.FindByRequestID(ctx, reqID)
+		require.NoError(t, err)
+		require.Len(t, lifecycles, 1)
+		assert.Equal(t, vrfcommon.StageEnqueued, lifecycles[0].Stage())
+		require.NotNil(t, lifecycles[0].EthTxID)
+		assert.Equal(t, int64(7), *lifecycles[0].EthTxID)
+
+		txHash := utils.RandomHash()
+		require.NoError(t, orm.RecordFulfilled(ctx, jb.ID, reqID, txHash, true, big.NewInt(1e15), true))
+		lifecycles, err = orm.FindByRequestID(ctx, reqID)
+		require.NoError(t, err)
+		require.Len(t, lifecycles, 1)
+		assert.Equal(t, vrfcommon.StageFulfilled, lifecycles[0].Stage())
+		require.NotNil(t, lifecycles[0].FulfillmentTxHash)
+		assert.Equal(t, txHash, *lifecycles[0].FulfillmentTxHash)
+		require.NotNil(t, lifecycles[0].Payment)
+		assert.Equal(t, big.NewInt(1e15), lifecycles[0].Payment.ToInt())
+		require.NotNil(t, lifecycles[0].NativePayment)
+		assert.True(t, *lifecycles[0].NativePayment)
+
+		// fulfilled requests are left alone
+		require.NoError(t, orm.RecordReorged(ctx, jb.ID, reqID))
+		lifecycles, err = orm.FindByRequestID(ctx, reqID)
+		require.NoError(t, err)
+		require.Len(t, lifecycles, 1)
+		assert.Equal(t, vrfcommon.StageFulfilled, lifecycles[0].Stage())
+	})
+
+	t.Run("dropped", func(t *testing.T) {
+		require.NoError(t, orm.RecordDropped(ctx, jb.ID, otherReqID, vrfcommon.ReasonAge))
+		lifecycles, err := orm.FindByRequestID(ctx, otherReqID)
+		require.NoError(t, err)
+		require.Len(t, lifecycles, 1)
+		assert.Equal(t, vrfcommon.StageDropped, lifecycles[0].Stage())
+		require.NotNil(t, lifecycles[0].DropReason)
+		assert.Equal(t, string(vrfcommon.ReasonAge), *lifecycles[0].DropReason)
+	})
+
+	t.Run("unknown request", func(t *testing.T) {
+		// fulfillments of requests never observed by the job are ignored
+		require.NoError(t, orm.RecordFulfilled(ctx, jb.ID, big.NewInt(44), utils.RandomHash(), true, big.NewInt(1), false))
+		lifecycles, err := orm.FindByRequestID(ctx, big.NewInt(44))
+		require.NoError(t, err)
+		assert.Empty(t, lifecycles)
+	})
+}
@@ -0,0 +1,95 @@
+package vrfcommon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+)
+
+// SLAKind describes which fulfillment SLA of a VRF job a request breached.
+type SLAKind string
+
+const (
+	// SLABlocks is breached when a request is not fulfilled within the job's
+	// fulfillmentSLABlocks of its confirmation.
+	SLABlocks SLAKind = "blocks"
+
+	// SLADuration is breached when a request is not fulfilled within the job's
+	// fulfillmentSLA of its confirmation.
+	SLADuration SLAKind = "duration"
+)
+
+// SLABreach is the event emitted, and posted to the job's slaWebhookURL, when a request is
+// not fulfilled within the job's SLA. A breach is reported once per request: either while
+// the request is still pending, or when it is fulfilled late.
+type SLABreach struct {
+	JobID              int32          `json:"jobID"`
+	ExternalJobID      uuid.UUID      `json:"externalJobID"`
+	JobName            string         `json:"jobName"`
+	EVMChainID         string         `json:"evmChainID"`
+	CoordinatorAddress common.Address `json:"coordinatorAddress"`
+	RequestID          string         `json:"requestID"`
+	SubID              string         `json:"subID"`
+	Sender             common.Address `json:"sender"`
+	RequestTxHash      common.Hash    `json:"requestTxHash"`
+	SLA                SLAKind        `json:"sla"`
+	SLABlocks          uint32         `json:"slaBlocks"`
+	SLADuration        string         `json:"slaDuration"`
+	ConfirmedAtBlock   uint64         `json:"confirmedAtBlock"`
+	ConfirmedAt        time.Time      `json:"confirmedAt"`
+	// ElapsedBlocks and Elapsed are measured from the request's confirmation until its
+	// fulfillment, or until the breach was detected if it is still pending.
+	ElapsedBlocks     uint64       `json:"elapsedBlocks"`
+	Elapsed           string       `json:"elapsed"`
+	Fulfilled         bool         `json:"fulfilled"`
+	FulfillmentTxHash *common.Hash `json:"fulfillmentTxHash,omitempty"`
+	DetectedAt        time.Time    `json:"detectedAt"`
+}
+
+// SLAWebhook posts SLA breaches as JSON to a URL.
+type SLAWebhook struct {
+	url     string
+	client  *http.Client
+	timeout time.Duration
+}
+
+// NewSLAWebhook returns a webhook posting to url with client, the node's HTTP client for
+// job spec URLs. Each post is cancelled after timeout, unless it is 0.
+func NewSLAWebhook(url string, client *http.Client, timeout time.Duration) *SLAWebhook {
+	return &SLAWebhook{url: url, client: client, timeout: timeout}
+}
+
+// Notify posts the breach to the webhook. Any non-2xx response is an error.
+func (w *SLAWebhook) Notify(ctx context.Context, breach SLABreach) error {
+	body, err := json.Marshal(breach)
+	if err != nil {
+		return fmt.Errorf("failed to marshal SLA breach: %w", err)
+	}
+	if w.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create SLA webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post SLA breach: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("SLA webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
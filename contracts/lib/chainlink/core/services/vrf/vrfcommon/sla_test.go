@@ -0,0 +1,53 @@
+package vrfcommon_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+func TestSLAWebhook_Notify(t *testing.T) {
+	t.Parallel()
+
+	breach := vrfcommon.SLABreach{
+		JobID:         1,
+		RequestID:     "42",
+		SubID:         "7",
+		Sender:        testutils.NewAddress(),
+		SLA:           vrfcommon.SLABlocks,
+		SLABlocks:     10,
+		ElapsedBlocks: 11,
+	}
+
+	t.Run("posts the breach as JSON", func(t *testing.T) {
+		var received vrfcommon.SLABreach
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		t.Cleanup(srv.Close)
+
+		require.NoError(t, vrfcommon.NewSLAWebhook(srv.URL, srv.Client(), time.Second).Notify(testutils.Context(t), breach))
+		assert.Equal(t, breach, received)
+	})
+
+	t.Run("non-2xx response", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		t.Cleanup(srv.Close)
+
+		err := vrfcommon.NewSLAWebhook(srv.URL, srv.Client(), time.Second).Notify(testutils.Context(t), breach)
+		require.ErrorContains(t, err, "status 500")
+	})
+}
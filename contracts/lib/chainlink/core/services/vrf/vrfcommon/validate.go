@@ -3,8 +3,14 @@ package vrfcommon
 import (
 	"bytes"
 	"fmt"
+	"math/big"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 	"github.com/pelletier/go-toml"
 	"github.com/pkg/errors"
@@ -75,27 +81,173 @@ func ValidatedVRFSpec(tomlString string) (job.Job, error) {
 			spec.BackoffMaxDelay.String(), spec.BackoffInitialDelay.String())
 	}
 
+	if spec.ConsumerRateLimit < 0 {
+		return jb, fmt.Errorf("consumerRateLimit cannot be negative, given: %v", spec.ConsumerRateLimit)
+	}
+	if spec.ConsumerRateLimitBurst == 0 {
+		spec.ConsumerRateLimitBurst = spec.ChunkSize
+	}
+	if spec.SubscriptionRateLimit < 0 {
+		return jb, fmt.Errorf("subscriptionRateLimit cannot be negative, given: %v", spec.SubscriptionRateLimit)
+	}
+	if spec.SubscriptionRateLimitBurst == 0 {
+		spec.SubscriptionRateLimitBurst = spec.ChunkSize
+	}
+	for subID, weight := range spec.SubscriptionWeights {
+		if _, ok := new(big.Int).SetString(subID, 10); !ok {
+			return jb, fmt.Errorf("subscriptionWeights: invalid subscription ID %q", subID)
+		}
+		if weight == 0 {
+			return jb, fmt.Errorf("subscriptionWeights: weight of subscription %s must be positive", subID)
+		}
+	}
+
+	if spec.FulfillmentSLA < 0 {
+		return jb, fmt.Errorf("fulfillmentSLA cannot be negative, given: %s", spec.FulfillmentSLA)
+	}
+	if spec.SLAWebhookURL != "" {
+		u, err2 := url.Parse(spec.SLAWebhookURL)
+		if err2 != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return jb, fmt.Errorf("slaWebhookURL must be an http or https URL, given: %q", spec.SLAWebhookURL)
+		}
+		if spec.FulfillmentSLABlocks == 0 && spec.FulfillmentSLA == 0 {
+			return jb, errors.New("slaWebhookURL requires fulfillmentSLABlocks or fulfillmentSLA")
+		}
+	}
+
 	if spec.GasLanePrice != nil && spec.GasLanePrice.Cmp(assets.GWei(0)) <= 0 {
 		return jb, fmt.Errorf("gasLanePrice must be positive, given: %s", spec.GasLanePrice.String())
 	}
 
-	var foundVRFTask bool
+	for _, h := range spec.LowFundsWarningHorizons {
+		if h <= 0 {
+			return jb, fmt.Errorf("lowFundsWarningHorizons must be positive, given: %s", h)
+		}
+	}
+	if spec.BalanceForecastWindow < 0 {
+		return jb, fmt.Errorf("balanceForecastWindow must be positive, given: %s", spec.BalanceForecastWindow)
+	}
+	if spec.BalanceForecastWindow > 0 && len(spec.LowFundsWarningHorizons) == 0 {
+		return jb, errors.New("balanceForecastWindow requires lowFundsWarningHorizons")
+	}
+	if len(spec.LowFundsWarningHorizons) > 0 && spec.BalanceForecastWindow == 0 {
+		spec.BalanceForecastWindow = DefaultBalanceForecastWindow
+	}
+
+	keyHashes := make(map[common.Hash]struct{})
+	if keyHash, err2 := spec.PublicKey.Hash(); err2 == nil {
+		keyHashes[keyHash] = struct{}{}
+	}
+	for i, lane := range spec.GasLanes {
+		if bytes.Equal(lane.PublicKey[:], empty[:]) {
+			return jb, errors.Wrapf(ErrKeyNotSet, "gasLanes[%d].publicKey", i)
+		}
+		keyHash, err2 := lane.PublicKey.Hash()
+		if err2 != nil {
+			return jb, errors.Wrapf(err2, "gasLanes[%d].publicKey", i)
+		}
+		if _, ok := keyHashes[keyHash]; ok {
+			return jb, fmt.Errorf("gasLanes[%d]: key hash %s is already served by the job", i, keyHash)
+		}
+		keyHashes[keyHash] = struct{}{}
+		if lane.GasLanePrice != nil && lane.GasLanePrice.Cmp(assets.GWei(0)) <= 0 {
+			return jb, fmt.Errorf("gasLanes[%d].gasLanePrice must be positive, given: %s", i, lane.GasLanePrice.String())
+		}
+	}
+
+	var foundVRFTask, foundV2Task, foundV2PlusTask bool
 	for _, t := range jb.Pipeline.Tasks {
 		if t.Type() == pipeline.TaskTypeVRF || t.Type() == pipeline.TaskTypeVRFV2 || t.Type() == pipeline.TaskTypeVRFV2Plus {
 			foundVRFTask = true
 		}
 
+		if t.Type() == pipeline.TaskTypeVRFV2Plus {
+			foundV2PlusTask = true
+		}
 		if t.Type() == pipeline.TaskTypeVRFV2 || t.Type() == pipeline.TaskTypeVRFV2Plus {
+			foundV2Task = true
 			if len(spec.FromAddresses) == 0 {
 				return jb, errors.Wrap(ErrKeyNotSet, "fromAddreses needs to have a non-zero length")
 			}
+			for i, lane := range spec.GasLanes {
+				if len(lane.FromAddresses) == 0 {
+					return jb, errors.Wrapf(ErrKeyNotSet, "gasLanes[%d].fromAddresses needs to have a non-zero length", i)
+				}
+			}
 		}
 	}
 	if !foundVRFTask {
 		return jb, errors.Wrapf(ErrKeyNotSet, "invalid pipeline, expected a vrf task")
 	}
+	if len(spec.GasLanes) > 0 && !foundV2Task {
+		return jb, errors.New("gasLanes are only supported by VRF v2 and v2plus jobs")
+	}
+	if len(spec.LowFundsWarningHorizons) > 0 && !foundV2Task {
+		return jb, errors.New("lowFundsWarningHorizons are only supported by VRF v2 and v2plus jobs")
+	}
+	if spec.BlockhashStore != nil || spec.BlockHeaderFeeder != nil {
+		if !foundV2Task {
+			return jb, errors.New("blockhashStore and blockHeaderFeeder are only supported by VRF v2 and v2plus jobs")
+		}
+		version := V2
+		if foundV2PlusTask {
+			version = V2Plus
+		}
+		if err = validateFeeders(&spec, version); err != nil {
+			return jb, err
+		}
+	}
 
 	jb.VRFSpec = &spec
 
 	return jb, nil
 }
+
+// MutableVRFSpecFields are the fields of a VRF spec, by TOML key, that can be updated without
+// recreating the job: the running listener picks them up before its next round, and keeps the
+// requests it is tracking along with their backoff state. The fairness limits are included
+// since their bursts default to chunkSize.
+var MutableVRFSpecFields = []string{
+	"chunkSize",
+	"backoffInitialDelay",
+	"backoffMaxDelay",
+	"gasLanePrice",
+	"fromAddresses",
+	"batchFulfillmentGasMultiplier",
+	"consumerRateLimit",
+	"consumerRateLimitBurst",
+	"subscriptionRateLimit",
+	"subscriptionRateLimitBurst",
+	"subscriptionWeights",
+}
+
+// ValidatedVRFSpecUpdate validates tomlString as an update of the existing VRF job current.
+// The TOML must be a valid VRF job spec that only differs from current in the
+// MutableVRFSpecFields; job-level fields such as the name are not updated. The returned
+// spec has the ID of current's.
+func ValidatedVRFSpecUpdate(current job.Job, tomlString string) (job.VRFSpec, error) {
+	if current.Type != job.VRF || current.VRFSpec == nil {
+		return job.VRFSpec{}, errors.Errorf("job %d is not a VRF job", current.ID)
+	}
+	jb, err := ValidatedVRFSpec(tomlString)
+	if err != nil {
+		return job.VRFSpec{}, err
+	}
+	if current.PipelineSpec != nil && strings.TrimSpace(jb.Pipeline.Source) != strings.TrimSpace(current.PipelineSpec.DotDagSource) {
+		return job.VRFSpec{}, errors.New("observationSource cannot be updated without recreating the job")
+	}
+
+	updated := *jb.VRFSpec
+	updated.ID = current.VRFSpec.ID
+	cur, upd := reflect.ValueOf(*current.VRFSpec), reflect.ValueOf(updated)
+	for i := 0; i < cur.NumField(); i++ {
+		key := cur.Type().Field(i).Tag.Get("toml")
+		if key == "" || key == "-" || slices.Contains(MutableVRFSpecFields, key) {
+			continue
+		}
+		if !reflect.DeepEqual(cur.Field(i).Interface(), upd.Field(i).Interface()) {
+			return job.VRFSpec{}, errors.Errorf("%s cannot be updated without recreating the job, only %s can", key, strings.Join(MutableVRFSpecFields, ", "))
+		}
+	}
+	return updated, nil
+}
@@ -1,6 +1,8 @@
 package vrfcommon
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 
 	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 )
 
 func TestValidateVRFJobSpec(t *testing.T) {
@@ -468,6 +471,225 @@ decode_log->vrf->encode_tx->submit_tx
 				require.Error(t, err)
 			},
 		},
+		{
+			name: "fairness limits provided",
+			toml: `
+type            = "vrf"
+schemaVersion   = 1
+minIncomingConfirmations = 10
+publicKey = "0x79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F8179800"
+coordinatorAddress = "0xB3b7874F13387D44a3398D298B075B7A3505D8d4"
+requestTimeout = "168h" # 7 days
+chunkSize = 25
+consumerRateLimit = 0.5
+subscriptionRateLimit = 10
+subscriptionRateLimitBurst = 50
+subscriptionWeights = { "1" = 3, "115792089237316195423570985008687907853269984665640564039457584007913129639935" = 2 }
+observationSource = """
+decode_log   [type=ethabidecodelog
+              abi="RandomnessRequest(bytes32 keyHash,uint256 seed,bytes32 indexed jobID,address sender,uint256 fee,bytes32 requestID)"
+              data="$(jobRun.logData)"
+              topics="$(jobRun.logTopics)"]
+vrf          [type=vrf
+			  publicKey="$(jobSpec.publicKey)"
+              requestBlockHash="$(jobRun.logBlockHash)"
+              requestBlockNumber="$(jobRun.logBlockNumber)"
+              topics="$(jobRun.logTopics)"]
+encode_tx    [type=ethabiencode
+              abi="fulfillRandomnessRequest(bytes proof)"
+              data="{\\"proof\\": $(vrf)}"]
+submit_tx  [type=ethtx to="%s"
+			data="$(encode_tx)"
+            txMeta="{\\"requestTxHash\\": $(jobRun.logTxHash),\\"requestID\\": $(decode_log.requestID),\\"jobID\\": $(jobSpec.databaseID)}"]
+decode_log->vrf->encode_tx->submit_tx
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, 0.5, float64(s.VRFSpec.ConsumerRateLimit))
+				assert.Equal(t, uint32(25), s.VRFSpec.ConsumerRateLimitBurst)
+				assert.Equal(t, 10.0, float64(s.VRFSpec.SubscriptionRateLimit))
+				assert.Equal(t, uint32(50), s.VRFSpec.SubscriptionRateLimitBurst)
+				assert.Equal(t, uint32(3), s.VRFSpec.SubscriptionWeights.Weight("1"))
+				assert.Equal(t, uint32(1), s.VRFSpec.SubscriptionWeights.Weight("2"))
+			},
+		},
+		{
+			name: "negative consumer rate limit, invalid",
+			toml: `
+type            = "vrf"
+schemaVersion   = 1
+minIncomingConfirmations = 10
+publicKey = "0x79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F8179800"
+coordinatorAddress = "0xB3b7874F13387D44a3398D298B075B7A3505D8d4"
+requestTimeout = "168h" # 7 days
+chunkSize = 25
+consumerRateLimit = -1
+observationSource = """
+decode_log   [type=ethabidecodelog
+              abi="RandomnessRequest(bytes32 keyHash,uint256 seed,bytes32 indexed jobID,address sender,uint256 fee,bytes32 requestID)"
+              data="$(jobRun.logData)"
+              topics="$(jobRun.logTopics)"]
+vrf          [type=vrf
+			  publicKey="$(jobSpec.publicKey)"
+              requestBlockHash="$(jobRun.logBlockHash)"
+              requestBlockNumber="$(jobRun.logBlockNumber)"
+              topics="$(jobRun.logTopics)"]
+encode_tx    [type=ethabiencode
+              abi="fulfillRandomnessRequest(bytes proof)"
+              data="{\\"proof\\": $(vrf)}"]
+submit_tx  [type=ethtx to="%s"
+			data="$(encode_tx)"
+            txMeta="{\\"requestTxHash\\": $(jobRun.logTxHash),\\"requestID\\": $(decode_log.requestID),\\"jobID\\": $(jobSpec.databaseID)}"]
+decode_log->vrf->encode_tx->submit_tx
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.ErrorContains(t, err, "consumerRateLimit cannot be negative")
+			},
+		},
+		{
+			name: "zero subscription weight, invalid",
+			toml: `
+type            = "vrf"
+schemaVersion   = 1
+minIncomingConfirmations = 10
+publicKey = "0x79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F8179800"
+coordinatorAddress = "0xB3b7874F13387D44a3398D298B075B7A3505D8d4"
+requestTimeout = "168h" # 7 days
+chunkSize = 25
+subscriptionWeights = { "1" = 0 }
+observationSource = """
+decode_log   [type=ethabidecodelog
+              abi="RandomnessRequest(bytes32 keyHash,uint256 seed,bytes32 indexed jobID,address sender,uint256 fee,bytes32 requestID)"
+              data="$(jobRun.logData)"
+              topics="$(jobRun.logTopics)"]
+vrf          [type=vrf
+			  publicKey="$(jobSpec.publicKey)"
+              requestBlockHash="$(jobRun.logBlockHash)"
+              requestBlockNumber="$(jobRun.logBlockNumber)"
+              topics="$(jobRun.logTopics)"]
+encode_tx    [type=ethabiencode
+              abi="fulfillRandomnessRequest(bytes proof)"
+              data="{\\"proof\\": $(vrf)}"]
+submit_tx  [type=ethtx to="%s"
+			data="$(encode_tx)"
+            txMeta="{\\"requestTxHash\\": $(jobRun.logTxHash),\\"requestID\\": $(decode_log.requestID),\\"jobID\\": $(jobSpec.databaseID)}"]
+decode_log->vrf->encode_tx->submit_tx
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.ErrorContains(t, err, "weight of subscription 1 must be positive")
+			},
+		},
+		{
+			name: "fulfillment SLA provided",
+			toml: `
+type            = "vrf"
+schemaVersion   = 1
+minIncomingConfirmations = 10
+publicKey = "0x79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F8179800"
+coordinatorAddress = "0xB3b7874F13387D44a3398D298B075B7A3505D8d4"
+requestTimeout = "168h" # 7 days
+chunkSize = 25
+fulfillmentSLABlocks = 50
+fulfillmentSLA = "10m"
+slaWebhookURL = "https://alerts.example.com/vrf"
+observationSource = """
+decode_log   [type=ethabidecodelog
+              abi="RandomnessRequest(bytes32 keyHash,uint256 seed,bytes32 indexed jobID,address sender,uint256 fee,bytes32 requestID)"
+              data="$(jobRun.logData)"
+              topics="$(jobRun.logTopics)"]
+vrf          [type=vrf
+			  publicKey="$(jobSpec.publicKey)"
+              requestBlockHash="$(jobRun.logBlockHash)"
+              requestBlockNumber="$(jobRun.logBlockNumber)"
+              topics="$(jobRun.logTopics)"]
+encode_tx    [type=ethabiencode
+              abi="fulfillRandomnessRequest(bytes proof)"
+              data="{\\"proof\\": $(vrf)}"]
+submit_tx  [type=ethtx to="%s"
+			data="$(encode_tx)"
+            txMeta="{\\"requestTxHash\\": $(jobRun.logTxHash),\\"requestID\\": $(decode_log.requestID),\\"jobID\\": $(jobSpec.databaseID)}"]
+decode_log->vrf->encode_tx->submit_tx
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, uint32(50), s.VRFSpec.FulfillmentSLABlocks)
+				assert.Equal(t, 10*time.Minute, s.VRFSpec.FulfillmentSLA)
+				assert.Equal(t, "https://alerts.example.com/vrf", s.VRFSpec.SLAWebhookURL)
+			},
+		},
+		{
+			name: "SLA webhook without SLA, invalid",
+			toml: `
+type            = "vrf"
+schemaVersion   = 1
+minIncomingConfirmations = 10
+publicKey = "0x79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F8179800"
+coordinatorAddress = "0xB3b7874F13387D44a3398D298B075B7A3505D8d4"
+requestTimeout = "168h" # 7 days
+chunkSize = 25
+slaWebhookURL = "https://alerts.example.com/vrf"
+observationSource = """
+decode_log   [type=ethabidecodelog
+              abi="RandomnessRequest(bytes32 keyHash,uint256 seed,bytes32 indexed jobID,address sender,uint256 fee,bytes32 requestID)"
+              data="$(jobRun.logData)"
+              topics="$(jobRun.logTopics)"]
+vrf          [type=vrf
+			  publicKey="$(jobSpec.publicKey)"
+              requestBlockHash="$(jobRun.logBlockHash)"
+              requestBlockNumber="$(jobRun.logBlockNumber)"
+              topics="$(jobRun.logTopics)"]
+encode_tx    [type=ethabiencode
+              abi="fulfillRandomnessRequest(bytes proof)"
+              data="{\\"proof\\": $(vrf)}"]
+submit_tx  [type=ethtx to="%s"
+			data="$(encode_tx)"
+            txMeta="{\\"requestTxHash\\": $(jobRun.logTxHash),\\"requestID\\": $(decode_log.requestID),\\"jobID\\": $(jobSpec.databaseID)}"]
+decode_log->vrf->encode_tx->submit_tx
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.ErrorContains(t, err, "slaWebhookURL requires fulfillmentSLABlocks or fulfillmentSLA")
+			},
+		},
+		{
+			name: "invalid SLA webhook URL",
+			toml: `
+type            = "vrf"
+schemaVersion   = 1
+minIncomingConfirmations = 10
+publicKey = "0x79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F8179800"
+coordinatorAddress = "0xB3b7874F13387D44a3398D298B075B7A3505D8d4"
+requestTimeout = "168h" # 7 days
+chunkSize = 25
+fulfillmentSLA = "10m"
+slaWebhookURL = "alerts.example.com"
+observationSource = """
+decode_log   [type=ethabidecodelog
+              abi="RandomnessRequest(bytes32 keyHash,uint256 seed,bytes32 indexed jobID,address sender,uint256 fee,bytes32 requestID)"
+              data="$(jobRun.logData)"
+              topics="$(jobRun.logTopics)"]
+vrf          [type=vrf
+			  publicKey="$(jobSpec.publicKey)"
+              requestBlockHash="$(jobRun.logBlockHash)"
+              requestBlockNumber="$(jobRun.logBlockNumber)"
+              topics="$(jobRun.logTopics)"]
+encode_tx    [type=ethabiencode
+              abi="fulfillRandomnessRequest(bytes proof)"
+              data="{\\"proof\\": $(vrf)}"]
+submit_tx  [type=ethtx to="%s"
+			data="$(encode_tx)"
+            txMeta="{\\"requestTxHash\\": $(jobRun.logTxHash),\\"requestID\\": $(decode_log.requestID),\\"jobID\\": $(jobSpec.databaseID)}"]
+decode_log->vrf->encode_tx->submit_tx
+"""
+`,
+			assertion: func(t *testing.T, s job.Job, err error) {
+				require.ErrorContains(t, err, "slaWebhookURL must be an http or https URL")
+			},
+		},
 		{
 			name: "gas lane price provided",
 			toml: `
@@ -593,3 +815,275 @@ decode_log->vrf->encode_tx->submit_tx
 		})
 	}
 }
+
+func TestValidatedVRFSpecUpdate(t *testing.T) {
+	specTOML := func(coordinator string, chunkSize int, submitTo string) string {
+		return fmt.Sprintf(`
+type            = "vrf"
+schemaVersion   = 1
+minIncomingConfirmations = 10
+publicKey = "0x79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F8179800"
+coordinatorAddress = "%s"
+requestTimeout = "168h" # 7 days
+chunkSize = %d
+backoffInitialDelay = "1m"
+backoffMaxDelay = "2h"
+observationSource = """
+vrf          [type=vrf
+              publicKey="$(jobSpec.publicKey)"
+              requestBlockHash="$(jobRun.logBlockHash)"
+              requestBlockNumber="$(jobRun.logBlockNumber)"
+              topics="$(jobRun.logTopics)"]
+submit_tx    [type=ethtx to="%s" data="$(vrf)"]
+vrf->submit_tx
+"""
+`, coordinator, chunkSize, submitTo)
+	}
+	const (
+		coordinator = "0xB3b7874F13387D44a3398D298B075B7A3505D8d4"
+		submitTo    = "0x2a0d386f122851dc5AFBE45cb2E8411CE255b000"
+	)
+
+	current, err := ValidatedVRFSpec(specTOML(coordinator, 25, submitTo))
+	require.NoError(t, err)
+	current.ID = 1
+	current.VRFSpec.ID = 7
+	current.PipelineSpec = &pipeline.Spec{DotDagSource: current.Pipeline.Source}
+
+	t.Run("mutable fields", func(t *testing.T) {
+		spec, err := ValidatedVRFSpecUpdate(current, specTOML(coordinator, 50, submitTo))
+		require.NoError(t, err)
+		assert.Equal(t, int32(7), spec.ID)
+		assert.Equal(t, uint32(50), spec.ChunkSize)
+		assert.Equal(t, uint32(50), spec.ConsumerRateLimitBurst)
+	})
+
+	t.Run("immutable field", func(t *testing.T) {
+		_, err := ValidatedVRFSpecUpdate(current, specTOML("0x2a0d386f122851dc5AFBE45cb2E8411CE255b000", 25, submitTo))
+		require.ErrorContains(t, err, "coordinatorAddress cannot be updated")
+	})
+
+	t.Run("pipeline", func(t *testing.T) {
+		_, err := ValidatedVRFSpecUpdate(current, specTOML(coordinator, 25, coordinator))
+		require.ErrorContains(t, err, "observationSource cannot be updated")
+	})
+
+	t.Run("not a VRF job", func(t *testing.T) {
+		_, err := ValidatedVRFSpecUpdate(job.Job{ID: 2, Type: job.Cron}, specTOML(coordinator, 25, submitTo))
+		require.ErrorContains(t, err, "job 2 is not a VRF job")
+	})
+}
+
+func TestValidatedVRFSpec_GasLanes(t *testing.T) {
+	specTOML := func(vrfTask string, gasLanes string) string {
+		return fmt.Sprintf(`
+type            = "vrf"
+schemaVersion   = 1
+minIncomingConfirmations = 10
+publicKey = "0x79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F8179800"
+coordinatorAddress = "0xB3b7874F13387D44a3398D298B075B7A3505D8d4"
+fromAddresses = ["0x2a0d386f122851dc5AFBE45cb2E8411CE255b000"]
+gasLanePrice = "100 gwei"
+observationSource = """
+vrf          [type=%s
+              publicKey="$(jobSpec.publicKey)"
+              requestBlockHash="$(jobRun.logBlockHash)"
+              requestBlockNumber="$(jobRun.logBlockNumber)"
+              topics="$(jobRun.logTopics)"]
+submit_tx    [type=ethtx to="0xB3b7874F13387D44a3398D298B075B7A3505D8d4" data="$(vrf)"]
+vrf->submit_tx
+"""
+%s`, vrfTask, gasLanes)
+	}
+	const secondKey = "0xC6047F9441ED7D6D3045406E95C07CD85C778E4B8CEF3CA7ABAC09B95C709EE500"
+
+	t.Run("valid", func(t *testing.T) {
+		jb, err := ValidatedVRFSpec(specTOML("vrfv2", fmt.Sprintf(`
+[[gasLanes]]
+publicKey = "%s"
+gasLanePrice = "500 gwei"
+fromAddresses = ["0xB3b7874F13387D44a3398D298B075B7A3505D8d4", "0x2a0d386f122851dc5AFBE45cb2E8411CE255b000"]
+`, secondKey)))
+		require.NoError(t, err)
+		require.Len(t, jb.VRFSpec.GasLanes, 1)
+		lane := jb.VRFSpec.GasLanes[0]
+		assert.Equal(t, strings.ToLower(secondKey), lane.PublicKey.String())
+		assert.Equal(t, assets.GWei(500), lane.GasLanePrice)
+		assert.Len(t, lane.FromAddresses, 2)
+	})
+
+	t.Run("duplicate key hash", func(t *testing.T) {
+		_, err := ValidatedVRFSpec(specTOML("vrfv2", `
+[[gasLanes]]
+publicKey = "0x79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F8179800"
+fromAddresses = ["0xB3b7874F13387D44a3398D298B075B7A3505D8d4"]
+`))
+		require.ErrorContains(t, err, "is already served by the job")
+	})
+
+	t.Run("missing fromAddresses", func(t *testing.T) {
+		_, err := ValidatedVRFSpec(specTOML("vrfv2", fmt.Sprintf(`
+[[gasLanes]]
+publicKey = "%s"
+`, secondKey)))
+		require.ErrorContains(t, err, "gasLanes[0].fromAddresses needs to have a non-zero length")
+	})
+
+	t.Run("zero gas lane price", func(t *testing.T) {
+		_, err := ValidatedVRFSpec(specTOML("vrfv2", fmt.Sprintf(`
+[[gasLanes]]
+publicKey = "%s"
+gasLanePrice = "0 gwei"
+fromAddresses = ["0xB3b7874F13387D44a3398D298B075B7A3505D8d4"]
+`, secondKey)))
+		require.ErrorContains(t, err, "gasLanes[0].gasLanePrice must be positive")
+	})
+
+	t.Run("VRF v1 job", func(t *testing.T) {
+		_, err := ValidatedVRFSpec(specTOML("vrf", fmt.Sprintf(`
+[[gasLanes]]
+publicKey = "%s"
+fromAddresses = ["0xB3b7874F13387D44a3398D298B075B7A3505D8d4"]
+`, secondKey)))
+		require.ErrorContains(t, err, "gasLanes are only supported by VRF v2 and v2plus jobs")
+	})
+}
+
+func TestValidatedVRFSpec_LowFundsWarningHorizons(t *testing.T) {
+	specTOML := func(vrfTask string, forecast string) string {
+		return fmt.Sprintf(`
+type            = "vrf"
+schemaVersion   = 1
+minIncomingConfirmations = 10
+publicKey = "0x79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F8179800"
+coordinatorAddress = "0xB3b7874F13387D44a3398D298B075B7A3505D8d4"
+fromAddresses = ["0x2a0d386f122851dc5AFBE45cb2E8411CE255b000"]
+observationSource = """
+vrf          [type=%s
+              publicKey="$(jobSpec.publicKey)"
+              requestBlockHash="$(jobRun.logBlockHash)"
+              requestBlockNumber="$(jobRun.logBlockNumber)"
+              topics="$(jobRun.logTopics)"]
+submit_tx    [type=ethtx to="0xB3b7874F13387D44a3398D298B075B7A3505D8d4" data="$(vrf)"]
+vrf->submit_tx
+"""
+%s`, vrfTask, forecast)
+	}
+
+	t.Run("default window", func(t *testing.T) {
+		jb, err := ValidatedVRFSpec(specTOML("vrfv2", `lowFundsWarningHorizons = ["72h", "24h"]`))
+		require.NoError(t, err)
+		assert.Equal(t, job.VRFForecastHorizons{72 * time.Hour, 24 * time.Hour}, jb.VRFSpec.LowFundsWarningHorizons)
+		assert.Equal(t, DefaultBalanceForecastWindow, jb.VRFSpec.BalanceForecastWindow)
+	})
+
+	t.Run("custom window", func(t *testing.T) {
+		jb, err := ValidatedVRFSpec(specTOML("vrfv2plus", `
+lowFundsWarningHorizons = ["6h"]
+balanceForecastWindow = "2h"`))
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Hour, jb.VRFSpec.BalanceForecastWindow)
+	})
+
+	t.Run("zero horizon", func(t *testing.T) {
+		_, err := ValidatedVRFSpec(specTOML("vrfv2", `lowFundsWarningHorizons = ["0s"]`))
+		require.ErrorContains(t, err, "lowFundsWarningHorizons must be positive")
+	})
+
+	t.Run("window without horizons", func(t *testing.T) {
+		_, err := ValidatedVRFSpec(specTOML("vrfv2", `balanceForecastWindow = "2h"`))
+		require.ErrorContains(t, err, "balanceForecastWindow requires lowFundsWarningHorizons")
+	})
+
+	t.Run("VRF v1 job", func(t *testing.T) {
+		_, err := ValidatedVRFSpec(specTOML("vrf", `lowFundsWarningHorizons = ["24h"]`))
+		require.ErrorContains(t, err, "lowFundsWarningHorizons are only supported by VRF v2 and v2plus jobs")
+	})
+}
+
+func TestValidatedVRFSpec_Feeders(t *testing.T) {
+	specTOML := func(vrfTask string, feeders string) string {
+		return fmt.Sprintf(`
+type            = "vrf"
+schemaVersion   = 1
+minIncomingConfirmations = 10
+publicKey = "0x79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F8179800"
+coordinatorAddress = "0xB3b7874F13387D44a3398D298B075B7A3505D8d4"
+evmChainID = "4"
+fromAddresses = ["0x2a0d386f122851dc5AFBE45cb2E8411CE255b000"]
+observationSource = """
+vrf          [type=%s
+              publicKey="$(jobSpec.publicKey)"
+              requestBlockHash="$(jobRun.logBlockHash)"
+              requestBlockNumber="$(jobRun.logBlockNumber)"
+              topics="$(jobRun.logTopics)"]
+submit_tx    [type=ethtx to="0xB3b7874F13387D44a3398D298B075B7A3505D8d4" data="$(vrf)"]
+vrf->submit_tx
+"""
+%s`, vrfTask, feeders)
+	}
+
+	t.Run("v2 with both feeders", func(t *testing.T) {
+		jb, err := ValidatedVRFSpec(specTOML("vrfv2", `
+[blockhashStore]
+blockhashStoreAddress = "0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"
+
+[blockHeaderFeeder]
+blockhashStoreAddress = "0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"
+batchBlockhashStoreAddress = "0xD04E5b2ea4e55AEbe6f7522bc2A69Ec6639bfc63"
+fromAddresses = ["0x469aA2CD13e037DC5236320783dCfd0e641c0559"]`))
+		require.NoError(t, err)
+
+		require.NotNil(t, jb.VRFSpec.BlockhashStore)
+		assert.Equal(t, int32(100), jb.VRFSpec.BlockhashStore.WaitBlocks)
+		assert.Equal(t, int32(200), jb.VRFSpec.BlockhashStore.LookbackBlocks)
+		assert.Equal(t, 30*time.Second, jb.VRFSpec.BlockhashStore.PollPeriod)
+		require.NotNil(t, jb.VRFSpec.BlockHeaderFeeder)
+		assert.Equal(t, int32(256), jb.VRFSpec.BlockHeaderFeeder.WaitBlocks)
+		assert.Equal(t, uint16(10), jb.VRFSpec.BlockHeaderFeeder.StoreBlockhashesBatchSize)
+
+		bhs := BlockhashStoreSpec(jb.VRFSpec, V2)
+		require.NotNil(t, bhs)
+		require.NotNil(t, bhs.CoordinatorV2Address)
+		assert.Equal(t, jb.VRFSpec.CoordinatorAddress, *bhs.CoordinatorV2Address)
+		assert.Nil(t, bhs.CoordinatorV2PlusAddress)
+		assert.Equal(t, jb.VRFSpec.EVMChainID, bhs.EVMChainID)
+		assert.Equal(t, jb.VRFSpec.FromAddresses, bhs.FromAddresses, "defaults to the job's keys")
+
+		bhf := BlockHeaderFeederSpec(jb.VRFSpec, V2Plus)
+		require.NotNil(t, bhf)
+		assert.Nil(t, bhf.CoordinatorV2Address)
+		require.NotNil(t, bhf.CoordinatorV2PlusAddress)
+		assert.Equal(t, "0x469aA2CD13e037DC5236320783dCfd0e641c0559", bhf.FromAddresses[0].String())
+	})
+
+	t.Run("no feeders", func(t *testing.T) {
+		jb, err := ValidatedVRFSpec(specTOML("vrfv2plus", ""))
+		require.NoError(t, err)
+		assert.Nil(t, BlockhashStoreSpec(jb.VRFSpec, V2Plus))
+		assert.Nil(t, BlockHeaderFeederSpec(jb.VRFSpec, V2Plus))
+	})
+
+	t.Run("invalid blockhash store feeder", func(t *testing.T) {
+		_, err := ValidatedVRFSpec(specTOML("vrfv2plus", `
+[blockhashStore]
+blockhashStoreAddress = "0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"
+waitBlocks = 300
+lookbackBlocks = 400`))
+		require.EqualError(t, err, `blockhashStore: "waitBlocks" must be less than 256`)
+	})
+
+	t.Run("invalid block header feeder", func(t *testing.T) {
+		_, err := ValidatedVRFSpec(specTOML("vrfv2", `
+[blockHeaderFeeder]
+blockhashStoreAddress = "0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"`))
+		require.EqualError(t, err, `blockHeaderFeeder: "batchBlockhashStoreAddress" must be set`)
+	})
+
+	t.Run("VRF v1 job", func(t *testing.T) {
+		_, err := ValidatedVRFSpec(specTOML("vrf", `
+[blockhashStore]
+blockhashStoreAddress = "0x3e20Cef636EdA7ba135bCbA4fe6177Bd3cE0aB17"`))
+		require.ErrorContains(t, err, "blockhashStore and blockHeaderFeeder are only supported by VRF v2 and v2plus jobs")
+	})
+}
@@ -0,0 +1,431 @@
+// Package vrftest runs a VRF v2plus job against an in-process simulated chain, so that
+// consumer contracts can be tested end-to-end against the node's listener, without a full
+// application.
+//
+// The harness deploys LINK, a LINK/native feed, a blockhash store, a VRFCoordinatorV2_5 and the
+// consumer under test, registers a proving key, and funds a subscription the consumer is added
+// to. Blocks are only mined by the harness and the tests using it, so tests control exactly
+// when requests are confirmed, time out or get reorged.
+package vrftest
+
+import (
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	commonassets "github.com/smartcontractkit/chainlink-common/pkg/assets"
+	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/smartcontractkit/chainlink-common/pkg/services/servicetest"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/mailbox/mailboxtest"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/blockhash_store"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/link_token_interface"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/mock_v3_aggregator_contract"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrf_coordinator_v2_5"
+	"github.com/smartcontractkit/chainlink-evm/gethwrappers/generated/vrfv2plus_consumer_example"
+	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
+	"github.com/smartcontractkit/chainlink-evm/pkg/client"
+	"github.com/smartcontractkit/chainlink-evm/pkg/config/toml"
+	evmtestutils "github.com/smartcontractkit/chainlink-evm/pkg/testutils"
+	evmtypes "github.com/smartcontractkit/chainlink-evm/pkg/types"
+	evmutils "github.com/smartcontractkit/chainlink-evm/pkg/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/bridges"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/evmtest"
+	"github.com/smartcontractkit/chainlink/v2/core/logger"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
+	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/services/signatures/secp256k1"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/prover"
+	v2 "github.com/smartcontractkit/chainlink/v2/core/services/vrf/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrftesthelpers"
+	"github.com/smartcontractkit/chainlink/v2/core/testdata/testspecs"
+	"github.com/smartcontractkit/chainlink/v2/core/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/utils/testutils/heavyweight"
+)
+
+const (
+	// vrfKeySecret is the secret of the harness' proving key, fixed so that its key hash is
+	// the same across runs.
+	vrfKeySecret = 0xC0FFEE
+
+	// CallbackGasLimit is the callback gas limit of the requests made with RequestRandomWords.
+	CallbackGasLimit = 500_000
+
+	defaultMinIncomingConfirmations = 3
+	defaultRequestTimeout           = 20 * time.Second
+	gasLaneMaxGas                   = 500_000
+)
+
+// DeployConsumerFunc deploys the consumer under test, given the coordinator and LINK token
+// addresses, and returns its address.
+type DeployConsumerFunc func(auth *bind.TransactOpts, backend bind.ContractBackend, coordinator, link common.Address) (common.Address, error)
+
+// RequestFunc sends a transaction that requests randomness from the coordinator, usually
+// through the consumer.
+type RequestFunc func(auth *bind.TransactOpts) (*gethtypes.Transaction, error)
+
+// Options configures a Harness. All fields are optional.
+type Options struct {
+	// DeployConsumer deploys the consumer under test. It defaults to VRFV2PlusConsumerExample,
+	// available as Harness.ExampleConsumer.
+	DeployConsumer DeployConsumerFunc
+	// MinIncomingConfirmations of the job, defaults to 3.
+	MinIncomingConfirmations uint32
+	// RequestTimeout of the job, defaults to 20s. ForceTimeout waits that long.
+	RequestTimeout time.Duration
+	// FundingJuels and FundingWei are the LINK and native balances of the subscription,
+	// they default to 100 LINK and 10 ETH.
+	FundingJuels *big.Int
+	FundingWei   *big.Int
+}
+
+// Harness is a VRF v2plus job served by a listener running against a simulated chain.
+type Harness struct {
+	Backend evmtypes.Backend
+	Client  *client.SimulatedBackendClient
+	// Owner deployed the contracts and the consumer, and owns the subscription.
+	Owner *bind.TransactOpts
+
+	Link               *link_token_interface.LinkToken
+	LinkAddress        common.Address
+	BHS                *blockhash_store.BlockhashStore
+	BHSAddress         common.Address
+	Coordinator        *vrf_coordinator_v2_5.VRFCoordinatorV25
+	CoordinatorAddress common.Address
+	ConsumerAddress    common.Address
+	// ExampleConsumer is only set when Options.DeployConsumer is nil.
+	ExampleConsumer *vrfv2plus_consumer_example.VRFV2PlusConsumerExample
+
+	SubID   *big.Int
+	KeyHash common.Hash
+	Job     job.Job
+
+	lifecycle vrfcommon.RequestLifecycleORM
+}
+
+// New deploys the contracts, creates the job and starts its listener. Everything is torn
+// down when the test ends. The harness needs a Postgres database, like the other
+// heavyweight tests, and is skipped in short mode.
+func New(t *testing.T, opts Options) *Harness {
+	ctx := testutils.Context(t)
+	if opts.MinIncomingConfirmations == 0 {
+		opts.MinIncomingConfirmations = defaultMinIncomingConfirmations
+	}
+	if opts.RequestTimeout == 0 {
+		opts.RequestTimeout = defaultRequestTimeout
+	}
+	if opts.FundingJuels == nil {
+		opts.FundingJuels = assets.Ether(100).ToInt()
+	}
+	if opts.FundingWei == nil {
+		opts.FundingWei = assets.Ether(10).ToInt()
+	}
+
+	owner := evmtestutils.MustNewSimTransactor(t)
+	sendingKey := cltest.MustGenerateRandomKey(t)
+	gasLanePrice := assets.GWei(10)
+	cfg, db := heavyweight.FullTestDBV2(t, func(c *chainlink.Config, s *chainlink.Secrets) {
+		c.EVM[0].GasEstimator.Mode = testutils.Ptr("FixedPrice")
+		c.EVM[0].GasEstimator.PriceDefault = gasLanePrice
+		c.EVM[0].GasEstimator.LimitDefault = testutils.Ptr[uint64](3_500_000)
+		c.Feature.LogPoller = testutils.Ptr(true)
+		c.EVM[0].LogPollInterval = commonconfig.MustNewDuration(time.Second)
+		c.EVM[0].HeadTracker.MaxBufferSize = testutils.Ptr[uint32](100)
+		c.EVM[0].HeadTracker.SamplingInterval = commonconfig.MustNewDuration(0)
+		c.EVM[0].Transactions.ResendAfterThreshold = commonconfig.MustNewDuration(0)
+		c.EVM[0].Transactions.ReaperThreshold = commonconfig.MustNewDuration(100 * time.Millisecond)
+		c.EVM[0].FinalityDepth = testutils.Ptr[uint32](15)
+		c.EVM[0].MinIncomingConfirmations = testutils.Ptr[uint32](1)
+		c.EVM[0].MinContractPayment = commonassets.NewLinkFromJuels(100)
+		c.EVM[0].KeySpecific = toml.KeySpecificConfig{{
+			Key:          &sendingKey.EIP55Address,
+			GasEstimator: toml.KeySpecificGasEstimator{PriceMax: gasLanePrice},
+		}}
+	})
+	lggr := logger.TestLogger(t)
+
+	ks := keystore.NewInMemory(db, utils.FastScryptParams, lggr.Infof)
+	require.NoError(t, ks.Unlock(ctx, testutils.Password))
+	ks.Eth().XXXTestingOnlyAdd(ctx, sendingKey)
+	require.NoError(t, ks.Eth().Add(ctx, sendingKey.Address, testutils.SimulatedChainID))
+	require.NoError(t, ks.Eth().Enable(ctx, sendingKey.Address, testutils.SimulatedChainID))
+	vrfKey := vrfkey.MustNewV2XXXTestingOnly(big.NewInt(vrfKeySecret))
+	require.NoError(t, ks.VRF().Add(ctx, vrfKey))
+
+	backend := cltest.NewSimulatedBackend(t, gethtypes.GenesisAlloc{
+		owner.From:         {Balance: assets.Ether(1000).ToInt()},
+		sendingKey.Address: {Balance: assets.Ether(1000).ToInt()},
+		// Fulfillments are simulated from the 0x0 address, which needs a balance on the simulated chain.
+		common.HexToAddress("0x0"): {Balance: assets.Ether(1000).ToInt()},
+	}, ethconfig.Defaults.Miner.GasCeil)
+	h := &Harness{
+		Backend:   backend,
+		Client:    client.NewSimulatedBackendClient(t, backend, testutils.SimulatedChainID),
+		Owner:     owner,
+		KeyHash:   vrfKey.PublicKey.MustHash(),
+		lifecycle: vrfcommon.NewRequestLifecycleORM(db),
+	}
+	h.deployContracts(t, vrfKey, opts)
+
+	mailMon := servicetest.Run(t, mailboxtest.NewMonitor(t))
+	legacyChains := evmtest.NewLegacyChains(t, evmtest.TestChainOpts{
+		Client:         h.Client,
+		DB:             db,
+		ChainConfigs:   cfg.EVMConfigs(),
+		DatabaseConfig: cfg.Database(),
+		FeatureConfig:  cfg.Feature(),
+		ListenerConfig: cfg.Database().Listener(),
+		KeyStore:       ks.Eth(),
+		MailMon:        mailMon,
+	})
+	servicetest.Run(t, evmtest.MustGetDefaultChain(t, legacyChains))
+
+	prm := pipeline.NewORM(db, lggr, cfg.JobPipeline().MaxSuccessfulRuns())
+	btORM := bridges.NewORM(db)
+	pr := pipeline.NewRunner(prm, btORM, cfg.JobPipeline(), cfg.WebServer(), legacyChains, ks.Eth(), prover.NewKeystoreProver(ks.VRF()), lggr, nil, nil)
+	jrm := job.NewORM(db, prm, btORM, ks, lggr)
+	t.Cleanup(func() { require.NoError(t, jrm.Close()) })
+
+	spec := testspecs.GenerateVRFSpec(testspecs.VRFSpecParams{
+		VRFVersion:               vrfcommon.V2Plus,
+		V2:                       true,
+		CoordinatorAddress:       h.CoordinatorAddress.Hex(),
+		PublicKey:                vrfKey.PublicKey.String(),
+		FromAddresses:            []string{sendingKey.Address.Hex()},
+		MinIncomingConfirmations: int(opts.MinIncomingConfirmations),
+		RequestTimeout:           opts.RequestTimeout,
+		EVMChainID:               testutils.SimulatedChainID.String(),
+		GasLanePrice:             gasLanePrice,
+		PollPeriod:               time.Second,
+	}).Toml()
+	jb, err := vrfcommon.ValidatedVRFSpec(spec)
+	require.NoError(t, err)
+	require.NoError(t, jrm.CreateJob(ctx, &jb))
+	h.Job = jb
+
+	srvs, err := vrf.NewDelegate(db, ks, pr, prm, legacyChains, lggr, mailMon, nil, nil, false, http.DefaultClient, 0).ServicesForSpec(ctx, jb)
+	require.NoError(t, err)
+	for _, srv := range srvs {
+		servicetest.Run(t, srv)
+	}
+	return h
+}
+
+func (h *Harness) deployContracts(t *testing.T, vrfKey vrfkey.KeyV2, opts Options) {
+	var err error
+	h.LinkAddress, _, h.Link, err = link_token_interface.DeployLinkToken(h.Owner, h.Backend.Client())
+	require.NoError(t, err, "failed to deploy LINK")
+	linkNativeFeed, _, _, err := mock_v3_aggregator_contract.DeployMockV3AggregatorContract(
+		h.Owner, h.Backend.Client(), 18, vrftesthelpers.WeiPerUnitLink.BigInt())
+	require.NoError(t, err, "failed to deploy LINK/native feed")
+	h.BHSAddress, _, h.BHS, err = blockhash_store.DeployBlockhashStore(h.Owner, h.Backend.Client())
+	require.NoError(t, err, "failed to deploy BlockhashStore")
+	h.Backend.Commit()
+
+	h.CoordinatorAddress, _, h.Coordinator, err = vrf_coordinator_v2_5.DeployVRFCoordinatorV25(h.Owner, h.Backend.Client(), h.BHSAddress)
+	require.NoError(t, err, "failed to deploy VRFCoordinatorV2_5")
+	h.Backend.Commit()
+	_, err = h.Coordinator.SetLINKAndLINKNativeFeed(h.Owner, h.LinkAddress, linkNativeFeed)
+	require.NoError(t, err)
+	_, err = h.Coordinator.SetConfig(h.Owner,
+		uint16(1),                             // minimumRequestConfirmations
+		uint32(2.5e6),                         // maxGasLimit
+		uint32(60*60*24),                      // stalenessSeconds
+		uint32(v2.GasAfterPaymentCalculation), // gasAfterPaymentCalculation
+		big.NewInt(1e16),                      // fallbackWeiPerUnitLink
+		uint32(5),                             // fulfillmentFlatFeeNativePPM
+		uint32(1),                             // fulfillmentFlatFeeLinkDiscountPPM
+		uint8(10),                             // nativePremiumPercentage
+		uint8(5),                              // linkPremiumPercentage
+	)
+	require.NoError(t, err, "failed to configure coordinator")
+	p, err := vrfKey.PublicKey.Point()
+	require.NoError(t, err)
+	x, y := secp256k1.Coordinates(p)
+	_, err = h.Coordinator.RegisterProvingKey(h.Owner, [2]*big.Int{x, y}, gasLaneMaxGas)
+	require.NoError(t, err, "failed to register proving key")
+	h.Backend.Commit()
+
+	if opts.DeployConsumer != nil {
+		h.ConsumerAddress, err = opts.DeployConsumer(h.Owner, h.Backend.Client(), h.CoordinatorAddress, h.LinkAddress)
+	} else {
+		h.ConsumerAddress, _, h.ExampleConsumer, err = vrfv2plus_consumer_example.DeployVRFV2PlusConsumerExample(
+			h.Owner, h.Backend.Client(), h.CoordinatorAddress, h.LinkAddress)
+	}
+	require.NoError(t, err, "failed to deploy consumer")
+	h.Backend.Commit()
+
+	tx, err := h.Coordinator.CreateSubscription(h.Owner)
+	require.NoError(t, err)
+	h.Backend.Commit()
+	receipt := h.receipt(t, tx)
+	for _, l := range receipt.Logs {
+		if l.Topics[0] == (vrf_coordinator_v2_5.VRFCoordinatorV25SubscriptionCreated{}).Topic() {
+			created, err2 := h.Coordinator.ParseSubscriptionCreated(*l)
+			require.NoError(t, err2)
+			h.SubID = created.SubId
+		}
+	}
+	require.NotNil(t, h.SubID, "SubscriptionCreated not found")
+
+	_, err = h.Coordinator.AddConsumer(h.Owner, h.SubID, h.ConsumerAddress)
+	require.NoError(t, err, "failed to add consumer")
+	subID, err := evmutils.ABIEncode(`[{"type":"uint256"}]`, h.SubID)
+	require.NoError(t, err)
+	_, err = h.Link.TransferAndCall(h.Owner, h.CoordinatorAddress, opts.FundingJuels, subID)
+	require.NoError(t, err, "failed to fund subscription with LINK")
+	native := *h.Owner
+	native.Value = opts.FundingWei
+	_, err = h.Coordinator.FundSubscriptionWithNative(&native, h.SubID)
+	require.NoError(t, err, "failed to fund subscription with native")
+	if h.ExampleConsumer != nil {
+		_, err = h.ExampleConsumer.SetSubId(h.Owner, h.SubID)
+		require.NoError(t, err)
+	}
+	h.Backend.Commit()
+}
+
+// RequestRandomWords returns a RequestFunc requesting numWords from the example consumer, with
+// the job's minimum confirmations.
+func (h *Harness) RequestRandomWords(numWords uint32, nativePayment bool) RequestFunc {
+	return func(auth *bind.TransactOpts) (*gethtypes.Transaction, error) {
+		if h.ExampleConsumer == nil {
+			return nil, errors.New("the harness was started with a custom consumer")
+		}
+		confs := uint16(h.Job.VRFSpec.MinIncomingConfirmations)
+		return h.ExampleConsumer.RequestRandomWords(auth, CallbackGasLimit, confs, numWords, h.KeyHash, nativePayment)
+	}
+}
+
+// Fulfillment is a request and its on-chain fulfillment.
+type Fulfillment struct {
+	Request   *vrf_coordinator_v2_5.VRFCoordinatorV25RandomWordsRequested
+	Fulfilled *vrf_coordinator_v2_5.VRFCoordinatorV25RandomWordsFulfilled
+}
+
+// RandomWords returns the words the coordinator derived from the fulfillment's output seed
+// and passed to the consumer.
+func (f Fulfillment) RandomWords() ([]*big.Int, error) {
+	words := make([]*big.Int, f.Request.NumWords)
+	for i := range words {
+		b, err := evmutils.ABIEncode(`[{"type":"uint256"},{"type":"uint256"}]`, f.Fulfilled.OutputSeed, big.NewInt(int64(i)))
+		if err != nil {
+			return nil, err
+		}
+		words[i] = new(big.Int).SetBytes(crypto.Keccak256(b))
+	}
+	return words, nil
+}
+
+// Request sends the request from Owner, mines it and returns its RandomWordsRequested log.
+func (h *Harness) Request(t *testing.T, request RequestFunc) *vrf_coordinator_v2_5.VRFCoordinatorV25RandomWordsRequested {
+	tx, err := request(h.Owner)
+	require.NoError(t, err, "failed to send request")
+	h.Backend.Commit()
+	for _, l := range h.receipt(t, tx).Logs {
+		if l.Address == h.CoordinatorAddress && l.Topics[0] == (vrf_coordinator_v2_5.VRFCoordinatorV25RandomWordsRequested{}).Topic() {
+			req, err := h.Coordinator.ParseRandomWordsRequested(*l)
+			require.NoError(t, err)
+			return req
+		}
+	}
+	require.FailNow(t, "RandomWordsRequested not found", "tx %s", tx.Hash())
+	return nil
+}
+
+// RequestAndFulfill sends the request, then mines blocks until the listener fulfilled it.
+func (h *Harness) RequestAndFulfill(t *testing.T, request RequestFunc) Fulfillment {
+	req := h.Request(t, request)
+	f := Fulfillment{Request: req}
+	require.Eventually(t, func() bool {
+		h.Backend.Commit()
+		f.Fulfilled = h.fulfilled(t, req.RequestId)
+		return f.Fulfilled != nil
+	}, testutils.WaitTimeout(t), 250*time.Millisecond, "request %s was not fulfilled", req.RequestId)
+	return f
+}
+
+// ForceTimeout sends the request and lets the listener observe it, but only confirms it once
+// it is older than the job's requestTimeout, and returns when the listener dropped it.
+func (h *Harness) ForceTimeout(t *testing.T, request RequestFunc) *vrf_coordinator_v2_5.VRFCoordinatorV25RandomWordsRequested {
+	req := h.Request(t, request)
+	var observedAt time.Time
+	require.Eventually(t, func() bool {
+		lc := h.Lifecycle(t, req.RequestId)
+		if lc == nil {
+			return false
+		}
+		observedAt = lc.ObservedAt
+		return true
+	}, testutils.WaitTimeout(t), 100*time.Millisecond, "request %s was not observed", req.RequestId)
+
+	time.Sleep(time.Until(observedAt.Add(h.Job.VRFSpec.RequestTimeout)))
+	require.Eventually(t, func() bool {
+		h.Backend.Commit()
+		lc := h.Lifecycle(t, req.RequestId)
+		return lc.Stage() == vrfcommon.StageDropped && lc.DropReason != nil && *lc.DropReason == string(vrfcommon.ReasonAge)
+	}, testutils.WaitTimeout(t), time.Second, "request %s was not dropped", req.RequestId)
+	require.Nil(t, h.fulfilled(t, req.RequestId), "request %s was fulfilled", req.RequestId)
+	return req
+}
+
+// InjectReorg replaces the last depth blocks with a longer branch forked from the block
+// below them. Like on a live chain, the transactions of the replaced blocks may be mined
+// again on the new branch.
+func (h *Harness) InjectReorg(t *testing.T, depth uint64) {
+	ctx := testutils.Context(t)
+	latest, err := h.Backend.Client().HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	require.True(t, depth > 0 && depth < latest.Number.Uint64(), "invalid reorg depth %d at block %d", depth, latest.Number)
+	ancestor, err := h.Backend.Client().HeaderByNumber(ctx, new(big.Int).SetUint64(latest.Number.Uint64()-depth))
+	require.NoError(t, err)
+	require.NoError(t, h.Backend.Fork(ancestor.Hash()))
+	for range depth + 1 {
+		h.Backend.Commit()
+	}
+}
+
+// Lifecycle returns the job's lifecycle of the request, nil if the listener did not observe it yet.
+func (h *Harness) Lifecycle(t *testing.T, requestID *big.Int) *vrfcommon.RequestLifecycle {
+	lcs, err := h.lifecycle.FindByRequestID(testutils.Context(t), requestID)
+	require.NoError(t, err)
+	for i := range lcs {
+		if lcs[i].JobID == h.Job.ID {
+			return &lcs[i]
+		}
+	}
+	return nil
+}
+
+func (h *Harness) fulfilled(t *testing.T, requestID *big.Int) *vrf_coordinator_v2_5.VRFCoordinatorV25RandomWordsFulfilled {
+	it, err := h.Coordinator.FilterRandomWordsFulfilled(nil, []*big.Int{requestID}, nil)
+	require.NoError(t, err)
+	defer it.Close()
+	if it.Next() {
+		return it.Event
+	}
+	require.NoError(t, it.Error())
+	return nil
+}
+
+func (h *Harness) receipt(t *testing.T, tx *gethtypes.Transaction) *gethtypes.Receipt {
+	receipt, err := h.Backend.Client().TransactionReceipt(testutils.Context(t), tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, gethtypes.ReceiptStatusSuccessful, receipt.Status, "tx %s reverted", tx.Hash())
+	return receipt
+}
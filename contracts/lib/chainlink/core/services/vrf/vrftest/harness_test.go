@@ -0,0 +1,50 @@
+package vrftest_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/tests"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrftest"
+)
+
+func TestHarness(t *testing.T) {
+	tests.SkipShort(t, "runs a VRF job against a simulated chain")
+
+	h := vrftest.New(t, vrftest.Options{})
+
+	t.Run("request and fulfill", func(t *testing.T) {
+		f := h.RequestAndFulfill(t, h.RequestRandomWords(3, false))
+		assert.True(t, f.Fulfilled.Success)
+		assert.False(t, f.Fulfilled.NativePayment)
+		assert.Equal(t, h.SubID, f.Request.SubId)
+
+		words, err := f.RandomWords()
+		require.NoError(t, err)
+		require.Len(t, words, 3)
+		for i, word := range words {
+			got, err := h.ExampleConsumer.GetRandomness(nil, f.Request.RequestId, big.NewInt(int64(i)))
+			require.NoError(t, err)
+			assert.Equal(t, word, got)
+		}
+		assert.Equal(t, vrfcommon.StageFulfilled, h.Lifecycle(t, f.Request.RequestId).Stage())
+	})
+
+	t.Run("native payment after a reorg", func(t *testing.T) {
+		h.InjectReorg(t, 3)
+		f := h.RequestAndFulfill(t, h.RequestRandomWords(1, true))
+		assert.True(t, f.Fulfilled.Success)
+		assert.True(t, f.Fulfilled.NativePayment)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		req := h.ForceTimeout(t, h.RequestRandomWords(1, false))
+		lc := h.Lifecycle(t, req.RequestId)
+		require.NotNil(t, lc.DropReason)
+		assert.Equal(t, string(vrfcommon.ReasonAge), *lc.DropReason)
+	})
+}
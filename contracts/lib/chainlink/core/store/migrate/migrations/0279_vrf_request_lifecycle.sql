@@ -0,0 +1,32 @@
+-- +goose Up
+CREATE TABLE vrf_request_lifecycle (
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    request_id NUMERIC(78,0) NOT NULL,
+    evm_chain_id NUMERIC(78,0) NOT NULL,
+    coordinator_address BYTEA NOT NULL,
+    sub_id NUMERIC(78,0) NOT NULL,
+    sender BYTEA NOT NULL,
+    request_tx_hash BYTEA NOT NULL,
+    request_block_hash BYTEA NOT NULL,
+    request_block_number BIGINT NOT NULL,
+    confirmed_at_block BIGINT NOT NULL,
+    observed_at TIMESTAMPTZ NOT NULL,
+    confirmed_at TIMESTAMPTZ,
+    simulation_attempts INTEGER NOT NULL DEFAULT 0,
+    last_simulated_at TIMESTAMPTZ,
+    last_simulation_error TEXT,
+    enqueued_at TIMESTAMPTZ,
+    eth_tx_id BIGINT,
+    fulfilled_at TIMESTAMPTZ,
+    fulfillment_tx_hash BYTEA,
+    fulfillment_success BOOLEAN,
+    dropped_at TIMESTAMPTZ,
+    drop_reason TEXT,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (job_id, request_id)
+);
+
+CREATE INDEX idx_vrf_request_lifecycle_request_id ON vrf_request_lifecycle (request_id);
+
+-- +goose Down
+DROP TABLE vrf_request_lifecycle;
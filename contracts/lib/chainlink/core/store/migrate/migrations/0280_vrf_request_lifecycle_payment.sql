@@ -0,0 +1,13 @@
+-- +goose Up
+ALTER TABLE vrf_request_lifecycle
+    ADD COLUMN payment NUMERIC(78,0),
+    ADD COLUMN native_payment BOOLEAN;
+
+CREATE INDEX idx_vrf_request_lifecycle_fulfilled_at ON vrf_request_lifecycle (fulfilled_at) WHERE fulfilled_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX idx_vrf_request_lifecycle_fulfilled_at;
+
+ALTER TABLE vrf_request_lifecycle
+    DROP COLUMN payment,
+    DROP COLUMN native_payment;
@@ -0,0 +1,15 @@
+-- +goose Up
+ALTER TABLE vrf_specs
+    ADD COLUMN consumer_rate_limit DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN consumer_rate_limit_burst BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN subscription_rate_limit DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ADD COLUMN subscription_rate_limit_burst BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN subscription_weights JSONB NOT NULL DEFAULT '{}';
+
+-- +goose Down
+ALTER TABLE vrf_specs
+    DROP COLUMN consumer_rate_limit,
+    DROP COLUMN consumer_rate_limit_burst,
+    DROP COLUMN subscription_rate_limit,
+    DROP COLUMN subscription_rate_limit_burst,
+    DROP COLUMN subscription_weights;
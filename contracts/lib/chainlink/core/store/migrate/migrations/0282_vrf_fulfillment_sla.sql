@@ -0,0 +1,11 @@
+-- +goose Up
+ALTER TABLE vrf_specs
+    ADD COLUMN fulfillment_sla_blocks BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN fulfillment_sla BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN sla_webhook_url TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE vrf_specs
+    DROP COLUMN fulfillment_sla_blocks,
+    DROP COLUMN fulfillment_sla,
+    DROP COLUMN sla_webhook_url;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE vrf_specs
+    ADD COLUMN gas_lanes JSONB NOT NULL DEFAULT '[]';
+
+-- +goose Down
+ALTER TABLE vrf_specs
+    DROP COLUMN gas_lanes;
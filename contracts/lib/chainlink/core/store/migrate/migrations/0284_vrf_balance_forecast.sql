@@ -0,0 +1,31 @@
+-- +goose Up
+ALTER TABLE vrf_specs
+    ADD COLUMN low_funds_warning_horizons JSONB NOT NULL DEFAULT '[]',
+    ADD COLUMN balance_forecast_window BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE vrf_subscription_forecasts (
+    job_id INTEGER NOT NULL REFERENCES jobs(id) ON DELETE CASCADE,
+    sub_id NUMERIC(78,0) NOT NULL,
+    native_payment BOOLEAN NOT NULL,
+    evm_chain_id NUMERIC(78,0) NOT NULL,
+    coordinator_address BYTEA NOT NULL,
+    balance NUMERIC(78,0) NOT NULL,
+    reserved NUMERIC(78,0) NOT NULL,
+    spent NUMERIC(78,0) NOT NULL,
+    fulfilled BIGINT NOT NULL,
+    forecast_window BIGINT NOT NULL,
+    burn_rate_per_hour NUMERIC(78,0) NOT NULL,
+    depletes_at TIMESTAMPTZ,
+    low_funds_horizon BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (job_id, sub_id, native_payment)
+);
+
+CREATE INDEX idx_vrf_subscription_forecasts_sub_id ON vrf_subscription_forecasts (sub_id);
+
+-- +goose Down
+DROP TABLE vrf_subscription_forecasts;
+
+ALTER TABLE vrf_specs
+    DROP COLUMN low_funds_warning_horizons,
+    DROP COLUMN balance_forecast_window;
@@ -0,0 +1,9 @@
+-- +goose Up
+ALTER TABLE vrf_specs
+    ADD COLUMN blockhash_store JSONB,
+    ADD COLUMN block_header_feeder JSONB;
+
+-- +goose Down
+ALTER TABLE vrf_specs
+    DROP COLUMN blockhash_store,
+    DROP COLUMN block_header_feeder;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE pipeline_task_runs
+    ADD COLUMN attempts JSONB;
+
+-- +goose Down
+ALTER TABLE pipeline_task_runs
+    DROP COLUMN attempts;
@@ -0,0 +1,12 @@
+-- +goose Up
+CREATE TABLE pipeline_templates (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    dot_dag_source TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (name, version)
+);
+
+-- +goose Down
+DROP TABLE pipeline_templates;
@@ -0,0 +1,48 @@
+-- +goose Up
+CREATE TABLE evm.txm_transactions (
+    id BIGSERIAL PRIMARY KEY,
+    evm_chain_id NUMERIC(78,0) NOT NULL,
+    idempotency_key TEXT,
+    nonce BIGINT,
+    from_address BYTEA NOT NULL,
+    to_address BYTEA NOT NULL,
+    value NUMERIC(78,0) NOT NULL,
+    data BYTEA NOT NULL,
+    specified_gas_limit BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    initial_broadcast_at TIMESTAMPTZ,
+    last_broadcast_at TIMESTAMPTZ,
+    state evm.txes_state NOT NULL,
+    is_purgeable BOOLEAN NOT NULL DEFAULT false,
+    attempt_count INTEGER NOT NULL DEFAULT 0,
+    meta JSONB,
+    subject UUID,
+    pipeline_task_run_id UUID,
+    min_confirmations INTEGER,
+    signal_callback BOOLEAN NOT NULL DEFAULT false,
+    callback_completed BOOLEAN NOT NULL DEFAULT false,
+    legacy_tx_id BIGINT UNIQUE,
+    CONSTRAINT chk_txm_transactions_nonce CHECK (state = 'unstarted'::evm.txes_state OR state = 'fatal_error'::evm.txes_state OR nonce IS NOT NULL)
+);
+CREATE UNIQUE INDEX idx_txm_transactions_idempotency_key ON evm.txm_transactions (evm_chain_id, idempotency_key) WHERE idempotency_key IS NOT NULL;
+CREATE UNIQUE INDEX idx_txm_transactions_unconfirmed_nonce ON evm.txm_transactions (evm_chain_id, from_address, nonce) WHERE state = 'unconfirmed'::evm.txes_state;
+CREATE INDEX idx_txm_transactions_state ON evm.txm_transactions (evm_chain_id, from_address, state, id);
+
+CREATE TABLE evm.txm_attempts (
+    id BIGSERIAL PRIMARY KEY,
+    tx_id BIGINT NOT NULL REFERENCES evm.txm_transactions (id) ON DELETE CASCADE,
+    hash BYTEA NOT NULL,
+    gas_price NUMERIC(78,0),
+    gas_tip_cap NUMERIC(78,0),
+    gas_fee_cap NUMERIC(78,0),
+    gas_limit BIGINT NOT NULL,
+    tx_type SMALLINT NOT NULL,
+    signed_raw_tx BYTEA,
+    created_at TIMESTAMPTZ NOT NULL,
+    broadcast_at TIMESTAMPTZ
+);
+CREATE INDEX idx_txm_attempts_tx_id ON evm.txm_attempts (tx_id);
+
+-- +goose Down
+DROP TABLE evm.txm_attempts;
+DROP TABLE evm.txm_transactions;
@@ -0,0 +1,10 @@
+-- +goose NO TRANSACTION
+
+-- +goose Up
+-- evm.txes is large and written to constantly, so the index is built without locking out writes.
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_txes_created_at ON evm.txes (created_at);
+CREATE INDEX CONCURRENTLY IF NOT EXISTS idx_vrf_request_lifecycle_eth_tx_id ON vrf_request_lifecycle (eth_tx_id) WHERE eth_tx_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX CONCURRENTLY IF EXISTS evm.idx_txes_created_at;
+DROP INDEX CONCURRENTLY IF EXISTS idx_vrf_request_lifecycle_eth_tx_id;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE evm.txm_transactions ADD COLUMN priority SMALLINT NOT NULL DEFAULT 0;
+CREATE INDEX idx_txm_transactions_unstarted_priority ON evm.txm_transactions (evm_chain_id, from_address, priority DESC, id) WHERE state = 'unstarted'::evm.txes_state;
+
+-- +goose Down
+DROP INDEX evm.idx_txm_transactions_unstarted_priority;
+ALTER TABLE evm.txm_transactions DROP COLUMN priority;
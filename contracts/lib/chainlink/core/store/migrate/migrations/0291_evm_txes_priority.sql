@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE evm.txes ADD COLUMN priority SMALLINT NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE evm.txes DROP COLUMN priority;
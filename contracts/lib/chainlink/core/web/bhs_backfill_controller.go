@@ -0,0 +1,69 @@
+package web
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/blockheaderfeeder"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// BHSBackfillController stores the blockhashes of VRF requests that fell out of the
+// blockhash feeders' lookback window.
+type BHSBackfillController struct {
+	App chainlink.Application
+}
+
+// BHSBackfillRequest is a JSONAPI request for a blockhash backfill.
+type BHSBackfillRequest struct {
+	// JobID is the block header feeder job whose coordinators, blockhash stores and
+	// sending keys the backfill uses.
+	JobID     int32  `json:"jobID"`
+	FromBlock uint64 `json:"fromBlock"`
+	// ToBlock defaults to the latest block.
+	ToBlock *uint64 `json:"toBlock"`
+	DryRun  bool    `json:"dryRun"`
+}
+
+// Create stores the blockhashes missing from the BHS for the unfulfilled requests in a
+// block range. With dryRun set, the backfill is only planned and its cost estimated. The
+// backfill runs within the request, so the range and the headers it verifies are capped.
+// Example:
+// "POST <application>/bhs/backfill"
+func (bc *BHSBackfillController) Create(c *gin.Context) {
+	request := &BHSBackfillRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	plan, err := bc.App.BackfillBlockhashes(c.Request.Context(), request.JobID, blockheaderfeeder.BackfillOpts{
+		FromBlock: request.FromBlock,
+		ToBlock:   request.ToBlock,
+		DryRun:    request.DryRun,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("job not found"))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if !plan.DryRun && plan.Transactions() > 0 {
+		bc.App.GetAuditLogger().Audit(audit.BlockhashesBackfilled, map[string]interface{}{
+			"jobID":         plan.JobID,
+			"fromBlock":     plan.FromBlock,
+			"toBlock":       plan.ToBlock,
+			"missingBlocks": len(plan.MissingBlocks),
+			"transactions":  plan.Transactions(),
+			"fromAddress":   plan.FromAddress,
+		})
+	}
+	jsonAPIResponse(c, presenters.NewBHSBackfillResource(plan), "bhs_backfill")
+}
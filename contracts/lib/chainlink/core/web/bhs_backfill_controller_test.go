@@ -0,0 +1,47 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+)
+
+func TestBHSBackfillController_Create(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(ctx))
+	client := app.NewHTTPClient(nil)
+
+	t.Run("job not found", func(t *testing.T) {
+		body, err := json.Marshal(web.BHSBackfillRequest{JobID: 1234, FromBlock: 100, DryRun: true})
+		require.NoError(t, err)
+		resp, cleanup := client.Post("/v2/bhs/backfill", bytes.NewReader(body))
+		t.Cleanup(cleanup)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("not a block header feeder job", func(t *testing.T) {
+		jb, _ := cltest.MustInsertWebhookSpec(t, app.GetDB())
+		body, err := json.Marshal(web.BHSBackfillRequest{JobID: jb.ID, FromBlock: 100, DryRun: true})
+		require.NoError(t, err)
+		resp, cleanup := client.Post("/v2/bhs/backfill", bytes.NewReader(body))
+		t.Cleanup(cleanup)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		resp, cleanup := client.Post("/v2/bhs/backfill", bytes.NewReader([]byte(`{"fromBlock": "latest"}`)))
+		t.Cleanup(cleanup)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
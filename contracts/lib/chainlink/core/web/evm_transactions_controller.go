@@ -2,9 +2,12 @@ package web
 
 import (
 	"database/sql"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/txmgranalytics"
 	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -47,3 +50,56 @@ func (tc *TransactionsController) Show(c *gin.Context) {
 
 	jsonAPIResponse(c, presenters.NewEthTxResourceFromAttempt(*ethTxAttempt), "transaction")
 }
+
+// Stats returns the cost and outcome of the transactions created in the reporting window,
+// per job or, with groupBy=key, per sending key.
+// Example:
+//
+//	"GET <application>/transactions/evm/stats?groupBy=job&jobID=1&from=2024-01-01T00:00:00Z"
+func (tc *TransactionsController) Stats(c *gin.Context) {
+	groupBy, err := txmgranalytics.ParseGroupBy(c.Query("groupBy"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	filter, err := parseTxStatsFilter(c)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	outcomes, err := txmgranalytics.NewORM(tc.App.GetDB()).TxOutcomes(c.Request.Context(), filter)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewEthTxStatsResources(txmgranalytics.Summarize(outcomes, groupBy)), "evm_transaction_stats")
+}
+
+func parseTxStatsFilter(c *gin.Context) (txmgranalytics.Filter, error) {
+	params := txmgranalytics.FilterParams{
+		EVMChainID: c.Query("evmChainID"),
+		JobID:      c.Query("jobID"),
+		Address:    c.Query("address"),
+	}
+	if s := c.Query("from"); s != "" {
+		from, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return txmgranalytics.Filter{}, fmt.Errorf("invalid from: %w", err)
+		}
+		params.From = &from
+	}
+	if s := c.Query("to"); s != "" {
+		to, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return txmgranalytics.Filter{}, fmt.Errorf("invalid to: %w", err)
+		}
+		params.To = &to
+	}
+	filter, errs := txmgranalytics.ParseFilter(params, time.Now())
+	if len(errs) > 0 {
+		return filter, errs[0]
+	}
+	return filter, nil
+}
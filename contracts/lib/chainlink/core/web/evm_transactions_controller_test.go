@@ -130,3 +130,49 @@ func TestTransactionsController_Show_NotFound(t *testing.T) {
 	t.Cleanup(cleanup)
 	cltest.AssertServerResponse(t, resp, http.StatusNotFound)
 }
+
+func TestTransactionsController_Stats(t *testing.T) {
+	t.Parallel()
+
+	app := cltest.NewApplicationWithKey(t)
+	ctx := testutils.Context(t)
+	require.NoError(t, app.Start(ctx))
+
+	db := app.GetDB()
+	txStore := txmgrtest.NewTestTxStore(t, db)
+	ethKeyStore := cltest.NewKeyStore(t, db).Eth()
+	client := app.NewHTTPClient(nil)
+	_, from := cltest.MustInsertRandomKey(t, ethKeyStore)
+
+	txmgrtest.MustInsertConfirmedEthTxWithLegacyAttempt(t, txStore, 0, 1, from)
+	txmgrtest.MustInsertUnconfirmedEthTxWithBroadcastLegacyAttempt(t, txStore, 1, from)
+
+	resp, cleanup := client.Get("/v2/transactions/evm/stats?groupBy=key&address=" + from.Hex())
+	t.Cleanup(cleanup)
+	cltest.AssertServerResponse(t, resp, http.StatusOK)
+
+	var stats []presenters.EthTxStatsResource
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &stats))
+	require.Len(t, stats, 1)
+	require.NotNil(t, stats[0].FromAddress)
+	assert.Equal(t, from, *stats[0].FromAddress)
+	assert.Equal(t, int64(2), stats[0].Transactions)
+	assert.Equal(t, int64(2), stats[0].Pending)
+	assert.Equal(t, int64(2), stats[0].BroadcastAttempts)
+
+	for _, query := range []string{
+		"groupBy=subscription",
+		"evmChainID=abc",
+		"jobID=abc",
+		"address=0x123",
+		"from=yesterday",
+		"from=2024-01-08T00:00:00Z&to=2024-01-01T00:00:00Z",
+		"from=2024-01-01T00:00:00Z&to=2024-03-01T00:00:00Z",
+	} {
+		t.Run("invalid "+query, func(t *testing.T) {
+			resp, cleanup := client.Get("/v2/transactions/evm/stats?" + query)
+			t.Cleanup(cleanup)
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		})
+	}
+}
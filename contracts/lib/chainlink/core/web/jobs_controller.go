@@ -27,8 +27,10 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocr2/validate"
 	"github.com/smartcontractkit/chainlink/v2/core/services/ocrbootstrap"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/services/standardcapabilities"
 	"github.com/smartcontractkit/chainlink/v2/core/services/streams"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf"
 	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
 	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	"github.com/smartcontractkit/chainlink/v2/core/services/workflows"
@@ -135,6 +137,57 @@ func (jc *JobsController) Create(c *gin.Context) {
 	jsonAPIResponse(c, presenters.NewJobResource(jb), jb.Type.String())
 }
 
+// LintJobRequest represents a request to lint a job spec without creating it.
+type LintJobRequest struct {
+	TOML string `json:"toml"`
+}
+
+// Lint statically checks the pipeline of a job spec and reports the errors and
+// warnings found, with the TOML line of each.
+// Example:
+// "POST <application>/jobs/lint"
+func (jc *JobsController) Lint(c *gin.Context) {
+	request := LintJobRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	jobType, issues, err := job.LintSpec(request.TOML)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewJobLintResource(jobType, issues), "job_lints")
+}
+
+// DryRunJobRequest represents a request to dry run a job spec without creating it.
+type DryRunJobRequest struct {
+	TOML     string                  `json:"toml"`
+	Fixtures pipeline.DryRunFixtures `json:"fixtures"`
+}
+
+// DryRun runs the pipeline of a job spec once without creating the job. Its http, bridge,
+// ethcall, ethtx and estimategaslimit tasks are served from the fixtures of the request.
+// Example:
+// "POST <application>/jobs/dryrun"
+func (jc *JobsController) DryRun(c *gin.Context) {
+	request := DryRunJobRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	run, trrs, err := jc.App.DryRunJobV2(c.Request.Context(), request.TOML, request.Fixtures)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewJobDryRunResource(*run, trrs, jc.App.GetLogger()), "job_dry_runs")
+}
+
 // Delete hard deletes a job spec.
 // Example:
 // "DELETE <application>/specs/:ID"
@@ -216,6 +269,41 @@ func (jc *JobsController) Update(c *gin.Context) {
 	jsonAPIResponse(c, presenters.NewJobResource(jb), jb.Type.String())
 }
 
+// Patch validates a new TOML for an existing job and applies it to the running job, without
+// stopping it. Only the mutable fields of VRF v2 and v2plus jobs can be patched, see
+// vrfcommon.MutableVRFSpecFields.
+// Example:
+// "PATCH <application>/jobs/:ID"
+func (jc *JobsController) Patch(c *gin.Context) {
+	request := UpdateJobRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	j := job.Job{}
+	if err := j.SetID(c.Param("ID")); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	jb, err := jc.App.ReconfigureVRFJob(c.Request.Context(), j.ID, request.TOML)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			jsonAPIError(c, http.StatusNotFound, errors.New("JobSpec not found"))
+		case errors.Is(err, vrf.ErrInvalidSpecUpdate):
+			jsonAPIError(c, http.StatusBadRequest, err)
+		default:
+			jsonAPIError(c, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	jc.App.GetAuditLogger().Audit(audit.JobSpecUpdated, map[string]interface{}{"id": jb.ID, "toml": request.TOML})
+	jsonAPIResponse(c, presenters.NewJobResource(jb), jb.Type.String())
+}
+
 func (jc *JobsController) validateJobSpec(ctx context.Context, tomlString string) (jb job.Job, statusCode int, err error) {
 	jobType, err := job.ValidateSpec(tomlString)
 	if err != nil {
@@ -39,6 +39,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/p2pkey"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/testdata/testspecs"
 	"github.com/smartcontractkit/chainlink/v2/core/utils/tomlutils"
 	"github.com/smartcontractkit/chainlink/v2/core/web"
@@ -532,6 +533,81 @@ func TestJobsController_Create_WebhookSpec(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestJobsController_Lint(t *testing.T) {
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+
+	client := app.NewHTTPClient(nil)
+
+	body, err := json.Marshal(web.LintJobRequest{
+		TOML: `
+type = "webhook"
+schemaVersion = 1
+observationSource = """
+ds    [type=http method=GET url="https://example.com"]
+parse [type=jsonparse path="data" data="$(dss)"]
+ds -> parse
+"""
+`,
+	})
+	require.NoError(t, err)
+	response, cleanup := client.Post("/v2/jobs/lint", bytes.NewReader(body))
+	defer cleanup()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	resource := presenters.JobLintResource{}
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, response), &resource))
+	assert.False(t, resource.Valid)
+	require.Len(t, resource.Issues, 1)
+	assert.Equal(t, 6, resource.Issues[0].Line)
+	assert.Equal(t, "parse", resource.Issues[0].Task)
+
+	body, err = json.Marshal(web.LintJobRequest{TOML: `type = "foo"`})
+	require.NoError(t, err)
+	response, cleanup = client.Post("/v2/jobs/lint", bytes.NewReader(body))
+	defer cleanup()
+	require.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestJobsController_DryRun(t *testing.T) {
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(testutils.Context(t)))
+
+	client := app.NewHTTPClient(nil)
+
+	body, err := json.Marshal(web.DryRunJobRequest{
+		TOML: `
+type = "webhook"
+schemaVersion = 1
+observationSource = """
+ds    [type=http method=GET url="https://example.com"]
+parse [type=jsonparse path="data"]
+ds -> parse
+"""
+`,
+		Fixtures: pipeline.DryRunFixtures{
+			Tasks: map[string]pipeline.DryRunFixture{"ds": {Value: `{"data":"hello"}`}},
+		},
+	})
+	require.NoError(t, err)
+	response, cleanup := client.Post("/v2/jobs/dryrun", bytes.NewReader(body))
+	defer cleanup()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	resource := presenters.JobDryRunResource{}
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, response), &resource))
+	assert.Equal(t, pipeline.RunStatusCompleted, resource.State)
+	require.Len(t, resource.Outputs, 1)
+	assert.JSONEq(t, `"hello"`, *resource.Outputs[0])
+	require.Len(t, resource.Tasks, 2)
+	assert.True(t, resource.Tasks[0].Fixture)
+
+	// the job is not created
+	jobs, _, err := app.JobORM().FindJobs(testutils.Context(t), 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
 //go:embed webhook-spec-template.yml
 var webhookSpecTemplate string
 
@@ -756,6 +832,32 @@ func TestJobsController_Update_NonExistentID(t *testing.T) {
 	cltest.AssertServerResponse(t, response, http.StatusNotFound)
 }
 
+func TestJobsController_Patch(t *testing.T) {
+	_, client, ocrJob, _, _, _ := setupJobSpecsControllerTestsWithJobs(t)
+
+	body, err := json.Marshal(web.UpdateJobRequest{
+		TOML: testspecs.GenerateVRFSpec(testspecs.VRFSpecParams{
+			PublicKey:  "0x79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F8179800",
+			EVMChainID: cltest.FixtureChainID.String(),
+			V2:         true,
+			ChunkSize:  50,
+		}).Toml(),
+	})
+	require.NoError(t, err)
+
+	t.Run("not a VRF job", func(t *testing.T) {
+		response, cleanup := client.Patch("/v2/jobs/"+strconv.Itoa(int(ocrJob.ID)), bytes.NewReader(body))
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, response, http.StatusBadRequest)
+	})
+
+	t.Run("non-existent job", func(t *testing.T) {
+		response, cleanup := client.Patch("/v2/jobs/99999", bytes.NewReader(body))
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, response, http.StatusNotFound)
+	})
+}
+
 func runOCRJobSpecAssertions(t *testing.T, ocrJobSpecFromFileDB job.Job, ocrJobSpecFromServer presenters.JobResource) {
 	ocrJobSpecFromFile := ocrJobSpecFromFileDB.OCROracleSpec
 	assert.Equal(t, ocrJobSpecFromFile.ContractAddress, ocrJobSpecFromServer.OffChainReportingSpec.ContractAddress)
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"database/sql"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -155,6 +156,57 @@ func (prc *PipelineRunsController) Create(c *gin.Context) {
 	jsonAPIError(c, http.StatusUnprocessableEntity, errors.New("bad job ID"))
 }
 
+// ReplayPipelineRunRequest represents a request to replay a pipeline run.
+type ReplayPipelineRunRequest struct {
+	FromTask string `json:"fromTask"`
+}
+
+// Replay runs the pipeline of a finished run again with the same inputs, reusing the recorded
+// results of every task but the requested one and its descendants, and compares both runs.
+// Example:
+// "POST <application>/pipeline/runs/:runID/replay"
+func (prc *PipelineRunsController) Replay(c *gin.Context) {
+	ctx := c.Request.Context()
+	pipelineRun := pipeline.Run{}
+	err := pipelineRun.SetID(c.Param("runID"))
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	request := ReplayPipelineRunRequest{}
+	if err = c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	original, err := prc.App.PipelineORM().FindRun(ctx, pipelineRun.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.Errorf("pipeline run %d not found", pipelineRun.ID))
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	replay, replayed, err := prc.App.ReplayJobRunV2(ctx, original, request.FromTask)
+	if errors.Is(err, pipeline.ErrCannotReplay) {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	prc.App.GetAuditLogger().Audit(audit.JobRunReplayed, map[string]interface{}{
+		"jobID":    original.PipelineSpec.JobID,
+		"runID":    original.ID,
+		"replayID": replay.ID,
+		"fromTask": request.FromTask,
+	})
+	jsonAPIResponse(c, presenters.NewPipelineRunReplayResource(original, *replay, replayed, prc.App.GetLogger()), "pipelineRunReplay")
+}
+
 // Resume finishes a task and resumes the pipeline run.
 // Example:
 // "PATCH <application>/jobs/:ID/runs/:runID"
@@ -1,6 +1,7 @@
 package web_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -20,11 +21,13 @@ import (
 	"github.com/smartcontractkit/freeport"
 
 	commonconfig "github.com/smartcontractkit/chainlink-common/pkg/config"
+	"github.com/smartcontractkit/chainlink-common/pkg/utils/jsonserializable"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
 	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/configtest"
 	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
 	"github.com/smartcontractkit/chainlink/v2/core/services/job"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
 	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
 	"github.com/smartcontractkit/chainlink/v2/core/testdata/testspecs"
 	"github.com/smartcontractkit/chainlink/v2/core/web"
@@ -319,3 +322,59 @@ func setupPipelineRunsControllerTests(t *testing.T) (cltest.HTTPClientCleaner, i
 
 	return client, jb.ID, []int64{firstRunID, secondRunID}
 }
+
+func TestPipelineRunsController_Replay(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(ctx))
+	client := app.NewHTTPClient(nil)
+
+	jb, err := webhook.ValidatedWebhookSpec(ctx, `
+type = "webhook"
+schemaVersion = 1
+externalJobID = "`+uuid.New().String()+`"
+observationSource = """
+double [type=multiply input="$(jobRun.requestBody)" times=2]
+triple [type=multiply input="$(double)" times=3]
+double -> triple
+"""
+`, app.GetExternalInitiatorManager())
+	require.NoError(t, err)
+	require.NoError(t, app.AddJobV2(ctx, &jb))
+	runID, err := app.RunWebhookJobV2(ctx, jb.ExternalJobID, "5", jsonserializable.JSONSerializable{})
+	require.NoError(t, err)
+
+	replay := func(t *testing.T, runID int64, fromTask string) *http.Response {
+		body, err := json.Marshal(web.ReplayPipelineRunRequest{FromTask: fromTask})
+		require.NoError(t, err)
+		resp, cleanup := client.Post("/v2/pipeline/runs/"+strconv.FormatInt(runID, 10)+"/replay", bytes.NewReader(body))
+		t.Cleanup(cleanup)
+		return resp
+	}
+
+	t.Run("replays the run from a task", func(t *testing.T) {
+		resp := replay(t, runID, "triple")
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+		var res presenters.PipelineRunReplayResource
+		require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &res))
+		assert.NotEqual(t, strconv.FormatInt(runID, 10), res.ID)
+		assert.Equal(t, strconv.FormatInt(runID, 10), res.ReplayedRunID)
+		assert.Equal(t, pipeline.RunStatusCompleted, res.State)
+
+		require.Len(t, res.Tasks, 2)
+		for _, task := range res.Tasks {
+			assert.Equal(t, task.DotID == "triple", task.Replayed, task.DotID)
+			assert.Equal(t, task.OldOutput, task.NewOutput, task.DotID)
+		}
+	})
+
+	t.Run("rejects unknown tasks", func(t *testing.T) {
+		cltest.AssertServerResponse(t, replay(t, runID, "quadruple"), http.StatusBadRequest)
+	})
+
+	t.Run("rejects unknown runs", func(t *testing.T) {
+		cltest.AssertServerResponse(t, replay(t, runID+1000, "triple"), http.StatusNotFound)
+	})
+}
@@ -0,0 +1,128 @@
+package web
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/pipeline"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// PipelineTemplatesController manages pipeline templates
+type PipelineTemplatesController struct {
+	App chainlink.Application
+}
+
+// CreatePipelineTemplateRequest is a new version of a pipeline template
+type CreatePipelineTemplateRequest struct {
+	Name         string `json:"name"`
+	DotDagSource string `json:"dotDagSource"`
+}
+
+// Index lists all versions of all pipeline templates
+// Example:
+// "GET <application>/pipeline/templates"
+func (ptc *PipelineTemplatesController) Index(c *gin.Context) {
+	templates, err := ptc.App.PipelineTemplateORM().FindTemplates(c.Request.Context())
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewPipelineTemplateResources(templates), "pipelineTemplates")
+}
+
+// Show returns a version of a pipeline template, the latest one unless
+// the version query param is given
+// Example:
+// "GET <application>/pipeline/templates/:name?version=2"
+func (ptc *PipelineTemplatesController) Show(c *gin.Context) {
+	var version int64
+	if v := c.Query("version"); v != "" {
+		var err error
+		if version, err = strconv.ParseInt(v, 10, 32); err != nil || version <= 0 {
+			jsonAPIError(c, http.StatusUnprocessableEntity, errors.Errorf("invalid version %q", v))
+			return
+		}
+	}
+
+	template, err := ptc.App.PipelineTemplateORM().FindTemplate(c.Request.Context(), c.Param("name"), int32(version))
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("pipeline template not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewPipelineTemplateResource(template), "pipelineTemplate")
+}
+
+// Create stores a new version of a pipeline template. Jobs which expand the
+// template without pinning a version use it from their next run.
+// Example:
+// "POST <application>/pipeline/templates"
+func (ptc *PipelineTemplatesController) Create(c *gin.Context) {
+	request := CreatePipelineTemplateRequest{}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if err := pipeline.ValidateTemplate(request.Name, request.DotDagSource); err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	template, err := ptc.App.PipelineTemplateORM().CreateTemplate(c.Request.Context(), request.Name, request.DotDagSource)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	ptc.App.GetAuditLogger().Audit(audit.PipelineTemplateCreated, map[string]interface{}{
+		"templateName":    template.Name,
+		"templateVersion": template.Version,
+	})
+
+	jsonAPIResponseWithStatus(c, presenters.NewPipelineTemplateResource(template), "pipelineTemplate", http.StatusCreated)
+}
+
+// Destroy deletes all versions of a pipeline template which no job uses
+// Example:
+// "DELETE <application>/pipeline/templates/:name"
+func (ptc *PipelineTemplatesController) Destroy(c *gin.Context) {
+	ctx := c.Request.Context()
+	name := c.Param("name")
+
+	jobIDs, err := ptc.App.JobORM().FindJobIDsWithTemplate(ctx, name)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, fmt.Errorf("error searching for associated v2 jobs: %w", err))
+		return
+	}
+	if len(jobIDs) > 0 {
+		jsonAPIError(c, http.StatusConflict, fmt.Errorf("can't remove the pipeline template because jobs %v are associated with it", jobIDs))
+		return
+	}
+
+	err = ptc.App.PipelineTemplateORM().DeleteTemplate(ctx, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		jsonAPIError(c, http.StatusNotFound, errors.New("pipeline template not found"))
+		return
+	}
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	ptc.App.GetAuditLogger().Audit(audit.PipelineTemplateDeleted, map[string]interface{}{"templateName": name})
+
+	jsonAPIResponseWithStatus(c, nil, "pipelineTemplate", http.StatusNoContent)
+}
@@ -0,0 +1,105 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/webhook"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func createPipelineTemplate(t *testing.T, client cltest.HTTPClientCleaner, name, source string) *http.Response {
+	body, err := json.Marshal(web.CreatePipelineTemplateRequest{Name: name, DotDagSource: source})
+	require.NoError(t, err)
+	resp, cleanup := client.Post("/v2/pipeline/templates", bytes.NewReader(body))
+	t.Cleanup(cleanup)
+	return resp
+}
+
+// TestPipelineTemplatesController is not parallel, as the application sets
+// the TemplateSource that pipeline.Parse expands template tasks from.
+func TestPipelineTemplatesController(t *testing.T) {
+	ctx := testutils.Context(t)
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(ctx))
+	client := app.NewHTTPClient(nil)
+
+	resp := createPipelineTemplate(t, client, "ctlDouble", `double [type=multiply input="{{input}}" times=2]`)
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+	resp = createPipelineTemplate(t, client, "ctlDouble", `double [type=multiply input="{{input}}" times={{times}}]`)
+	cltest.AssertServerResponse(t, resp, http.StatusCreated)
+	var created presenters.PipelineTemplateResource
+	require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &created))
+	assert.Equal(t, "ctlDouble", created.Name)
+	assert.Equal(t, int32(2), created.Version)
+
+	t.Run("rejects invalid templates", func(t *testing.T) {
+		resp := createPipelineTemplate(t, client, "ctlInvalid", "a [type=memo]\nb [type=memo]")
+		cltest.AssertServerResponse(t, resp, http.StatusBadRequest)
+	})
+
+	t.Run("lists all versions", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/pipeline/templates")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+		var templates []presenters.PipelineTemplateResource
+		require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &templates))
+		require.Len(t, templates, 2)
+		assert.Equal(t, int32(1), templates[0].Version)
+		assert.Equal(t, int32(2), templates[1].Version)
+	})
+
+	t.Run("shows the latest or the given version", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/pipeline/templates/ctlDouble")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+		var template presenters.PipelineTemplateResource
+		require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &template))
+		assert.Equal(t, int32(2), template.Version)
+
+		resp, cleanup = client.Get("/v2/pipeline/templates/ctlDouble?version=1")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusOK)
+		require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &template))
+		assert.Equal(t, int32(1), template.Version)
+
+		resp, cleanup = client.Get("/v2/pipeline/templates/ctlUnknown")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+	})
+
+	t.Run("does not delete templates used by jobs", func(t *testing.T) {
+		jb, err := webhook.ValidatedWebhookSpec(ctx, `
+type = "webhook"
+schemaVersion = 1
+externalJobID = "`+uuid.New().String()+`"
+observationSource = """
+double [type=template template=ctlDouble version=1 input="$(jobRun.requestBody)"]
+"""
+`, app.GetExternalInitiatorManager())
+		require.NoError(t, err)
+		require.NoError(t, app.AddJobV2(ctx, &jb))
+
+		resp, cleanup := client.Delete("/v2/pipeline/templates/ctlDouble")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusConflict)
+
+		require.NoError(t, app.DeleteJob(ctx, jb.ID))
+		resp, cleanup = client.Delete("/v2/pipeline/templates/ctlDouble")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusNoContent)
+
+		resp, cleanup = client.Delete("/v2/pipeline/templates/ctlDouble")
+		t.Cleanup(cleanup)
+		cltest.AssertServerResponse(t, resp, http.StatusNotFound)
+	})
+}
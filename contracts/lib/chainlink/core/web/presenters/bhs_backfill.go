@@ -0,0 +1,60 @@
+package presenters
+
+import (
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+	"github.com/smartcontractkit/chainlink/v2/core/services/blockheaderfeeder"
+)
+
+// BHSBackfillResource is a blockhash backfill, planned or submitted through a block header feeder job.
+type BHSBackfillResource struct {
+	JAID
+	JobID               int32          `json:"jobID"`
+	FromBlock           uint64         `json:"fromBlock"`
+	ToBlock             uint64         `json:"toBlock"`
+	LatestBlock         uint64         `json:"latestBlock"`
+	UnfulfilledRequests int            `json:"unfulfilledRequests"`
+	MissingBlocks       []uint64       `json:"missingBlocks"`
+	StoreBlocks         []uint64       `json:"storeBlocks"`
+	VerifyHeaderBatches [][]uint64     `json:"verifyHeaderBatches"`
+	Transactions        int            `json:"transactions"`
+	FromAddress         common.Address `json:"fromAddress"`
+	EstimatedGas        uint64         `json:"estimatedGas"`
+	GasPriceWei         *big.Big       `json:"gasPriceWei"`
+	EstimatedCostWei    *big.Big       `json:"estimatedCostWei"`
+	DryRun              bool           `json:"dryRun"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (BHSBackfillResource) GetName() string {
+	return "bhs_backfill"
+}
+
+// NewBHSBackfillResource returns a new BHSBackfillResource.
+func NewBHSBackfillResource(p blockheaderfeeder.BackfillPlan) BHSBackfillResource {
+	r := BHSBackfillResource{
+		JAID:                NewPrefixedJAID(strconv.FormatUint(p.FromBlock, 10)+"-"+strconv.FormatUint(p.ToBlock, 10), strconv.FormatInt(int64(p.JobID), 10)),
+		JobID:               p.JobID,
+		FromBlock:           p.FromBlock,
+		ToBlock:             p.ToBlock,
+		LatestBlock:         p.LatestBlock,
+		UnfulfilledRequests: p.UnfulfilledRequests,
+		MissingBlocks:       p.MissingBlocks,
+		StoreBlocks:         p.StoreBlocks,
+		VerifyHeaderBatches: p.VerifyHeaderBatches,
+		Transactions:        p.Transactions(),
+		FromAddress:         p.FromAddress,
+		EstimatedGas:        p.EstimatedGas,
+		DryRun:              p.DryRun,
+	}
+	if p.GasPriceWei != nil {
+		r.GasPriceWei = big.New(p.GasPriceWei)
+	}
+	if p.EstimatedCostWei != nil {
+		r.EstimatedCostWei = big.New(p.EstimatedCostWei)
+	}
+	return r
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/smartcontractkit/chainlink-evm/pkg/assets"
 	"github.com/smartcontractkit/chainlink-evm/pkg/txmgr"
 	"github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+	"github.com/smartcontractkit/chainlink/v2/core/services/txmgranalytics"
 )
 
 // EthTxResource represents a Ethereum Transaction JSONAPI resource.
@@ -77,3 +78,69 @@ func NewEthTxResourceFromAttempt(txa txmgr.TxAttempt) EthTxResource {
 	}
 	return r
 }
+
+// EthTxStatsResource is the cost and outcome of the transactions sent for a job or by a key.
+type EthTxStatsResource struct {
+	JAID
+	EVMChainID                *big.Big        `json:"evmChainID"`
+	JobID                     *int32          `json:"jobID,omitempty"`
+	FromAddress               *common.Address `json:"fromAddress,omitempty"`
+	Transactions              int64           `json:"transactions"`
+	Included                  int64           `json:"included"`
+	Reverted                  int64           `json:"reverted"`
+	Fatal                     int64           `json:"fatal"`
+	Pending                   int64           `json:"pending"`
+	GasUsed                   uint64          `json:"gasUsed"`
+	GasCostWei                *big.Big        `json:"gasCostWei"`
+	AvgEffectiveGasPrice      *big.Big        `json:"avgEffectiveGasPrice"`
+	BroadcastAttempts         int64           `json:"broadcastAttempts"`
+	BumpAttempts              int64           `json:"bumpAttempts"`
+	AvgTimeToInclusionSeconds float64         `json:"avgTimeToInclusionSeconds"`
+	MaxTimeToInclusionSeconds float64         `json:"maxTimeToInclusionSeconds"`
+	RevertRate                float64         `json:"revertRate"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (EthTxStatsResource) GetName() string {
+	return "evm_transaction_stats"
+}
+
+// NewEthTxStatsResource returns a new EthTxStatsResource. Its ID is the job ID or the
+// from address, prefixed with the chain ID. Transactions sent without a job have the ID "none".
+func NewEthTxStatsResource(s txmgranalytics.Stats) EthTxStatsResource {
+	id := "none"
+	switch {
+	case s.FromAddress != nil:
+		id = s.FromAddress.Hex()
+	case s.JobID != nil:
+		id = strconv.FormatInt(int64(*s.JobID), 10)
+	}
+	return EthTxStatsResource{
+		JAID:                      NewPrefixedJAID(id, s.EVMChainID.String()),
+		EVMChainID:                big.New(s.EVMChainID),
+		JobID:                     s.JobID,
+		FromAddress:               s.FromAddress,
+		Transactions:              s.Transactions,
+		Included:                  s.Included,
+		Reverted:                  s.Reverted,
+		Fatal:                     s.Fatal,
+		Pending:                   s.Pending,
+		GasUsed:                   s.GasUsed,
+		GasCostWei:                big.New(s.GasCostWei),
+		AvgEffectiveGasPrice:      big.New(s.AvgEffectiveGasPrice),
+		BroadcastAttempts:         s.BroadcastAttempts,
+		BumpAttempts:              s.BumpAttempts,
+		AvgTimeToInclusionSeconds: s.AvgTimeToInclusion.Seconds(),
+		MaxTimeToInclusionSeconds: s.MaxTimeToInclusion.Seconds(),
+		RevertRate:                s.RevertRate,
+	}
+}
+
+// NewEthTxStatsResources returns a slice of EthTxStatsResource.
+func NewEthTxStatsResources(ss []txmgranalytics.Stats) []EthTxStatsResource {
+	rs := []EthTxStatsResource{}
+	for _, s := range ss {
+		rs = append(rs, NewEthTxStatsResource(s))
+	}
+	return rs
+}
@@ -273,25 +273,38 @@ func NewCronSpec(spec *job.CronSpec) *CronSpec {
 }
 
 type VRFSpec struct {
-	BatchCoordinatorAddress       *types.EIP55Address   `json:"batchCoordinatorAddress"`
-	BatchFulfillmentEnabled       bool                  `json:"batchFulfillmentEnabled"`
-	CustomRevertsPipelineEnabled  *bool                 `json:"customRevertsPipelineEnabled,omitempty"`
-	BatchFulfillmentGasMultiplier float64               `json:"batchFulfillmentGasMultiplier"`
-	CoordinatorAddress            types.EIP55Address    `json:"coordinatorAddress"`
-	PublicKey                     secp256k1.PublicKey   `json:"publicKey"`
-	FromAddresses                 []types.EIP55Address  `json:"fromAddresses"`
-	PollPeriod                    commonconfig.Duration `json:"pollPeriod"`
-	MinIncomingConfirmations      uint32                `json:"confirmations"`
-	CreatedAt                     time.Time             `json:"createdAt"`
-	UpdatedAt                     time.Time             `json:"updatedAt"`
-	EVMChainID                    *big.Big              `json:"evmChainID"`
-	ChunkSize                     uint32                `json:"chunkSize"`
-	RequestTimeout                commonconfig.Duration `json:"requestTimeout"`
-	BackoffInitialDelay           commonconfig.Duration `json:"backoffInitialDelay"`
-	BackoffMaxDelay               commonconfig.Duration `json:"backoffMaxDelay"`
-	GasLanePrice                  *assets.Wei           `json:"gasLanePrice"`
-	RequestedConfsDelay           int64                 `json:"requestedConfsDelay"`
-	VRFOwnerAddress               *types.EIP55Address   `json:"vrfOwnerAddress,omitempty"`
+	BatchCoordinatorAddress       *types.EIP55Address             `json:"batchCoordinatorAddress"`
+	BatchFulfillmentEnabled       bool                            `json:"batchFulfillmentEnabled"`
+	CustomRevertsPipelineEnabled  *bool                           `json:"customRevertsPipelineEnabled,omitempty"`
+	BatchFulfillmentGasMultiplier float64                         `json:"batchFulfillmentGasMultiplier"`
+	CoordinatorAddress            types.EIP55Address              `json:"coordinatorAddress"`
+	PublicKey                     secp256k1.PublicKey             `json:"publicKey"`
+	FromAddresses                 []types.EIP55Address            `json:"fromAddresses"`
+	PollPeriod                    commonconfig.Duration           `json:"pollPeriod"`
+	MinIncomingConfirmations      uint32                          `json:"confirmations"`
+	CreatedAt                     time.Time                       `json:"createdAt"`
+	UpdatedAt                     time.Time                       `json:"updatedAt"`
+	EVMChainID                    *big.Big                        `json:"evmChainID"`
+	ChunkSize                     uint32                          `json:"chunkSize"`
+	RequestTimeout                commonconfig.Duration           `json:"requestTimeout"`
+	BackoffInitialDelay           commonconfig.Duration           `json:"backoffInitialDelay"`
+	BackoffMaxDelay               commonconfig.Duration           `json:"backoffMaxDelay"`
+	GasLanePrice                  *assets.Wei                     `json:"gasLanePrice"`
+	RequestedConfsDelay           int64                           `json:"requestedConfsDelay"`
+	VRFOwnerAddress               *types.EIP55Address             `json:"vrfOwnerAddress,omitempty"`
+	ConsumerRateLimit             float64                         `json:"consumerRateLimit"`
+	ConsumerRateLimitBurst        uint32                          `json:"consumerRateLimitBurst"`
+	SubscriptionRateLimit         float64                         `json:"subscriptionRateLimit"`
+	SubscriptionRateLimitBurst    uint32                          `json:"subscriptionRateLimitBurst"`
+	SubscriptionWeights           map[string]uint32               `json:"subscriptionWeights,omitempty"`
+	FulfillmentSLABlocks          uint32                          `json:"fulfillmentSLABlocks"`
+	FulfillmentSLA                commonconfig.Duration           `json:"fulfillmentSLA"`
+	SLAWebhookURL                 string                          `json:"slaWebhookURL,omitempty"`
+	GasLanes                      job.VRFGasLanes                 `json:"gasLanes,omitempty"`
+	LowFundsWarningHorizons       job.VRFForecastHorizons         `json:"lowFundsWarningHorizons,omitempty"`
+	BalanceForecastWindow         commonconfig.Duration           `json:"balanceForecastWindow"`
+	BlockhashStore                *job.VRFBlockhashStoreConfig    `json:"blockhashStore,omitempty"`
+	BlockHeaderFeeder             *job.VRFBlockHeaderFeederConfig `json:"blockHeaderFeeder,omitempty"`
 }
 
 func NewVRFSpec(spec *job.VRFSpec) *VRFSpec {
@@ -315,6 +328,19 @@ func NewVRFSpec(spec *job.VRFSpec) *VRFSpec {
 		GasLanePrice:                  spec.GasLanePrice,
 		RequestedConfsDelay:           spec.RequestedConfsDelay,
 		VRFOwnerAddress:               spec.VRFOwnerAddress,
+		ConsumerRateLimit:             float64(spec.ConsumerRateLimit),
+		ConsumerRateLimitBurst:        spec.ConsumerRateLimitBurst,
+		SubscriptionRateLimit:         float64(spec.SubscriptionRateLimit),
+		SubscriptionRateLimitBurst:    spec.SubscriptionRateLimitBurst,
+		SubscriptionWeights:           spec.SubscriptionWeights,
+		FulfillmentSLABlocks:          spec.FulfillmentSLABlocks,
+		FulfillmentSLA:                *commonconfig.MustNewDuration(spec.FulfillmentSLA),
+		SLAWebhookURL:                 spec.SLAWebhookURL,
+		GasLanes:                      spec.GasLanes,
+		LowFundsWarningHorizons:       spec.LowFundsWarningHorizons,
+		BalanceForecastWindow:         *commonconfig.MustNewDuration(spec.BalanceForecastWindow),
+		BlockhashStore:                spec.BlockhashStore,
+		BlockHeaderFeeder:             spec.BlockHeaderFeeder,
 	}
 }
 
@@ -606,3 +632,30 @@ func NewJobResource(j job.Job) *JobResource {
 func (r JobResource) GetName() string {
 	return "jobs"
 }
+
+// JobLintResource is the result of linting a job spec without creating it.
+type JobLintResource struct {
+	JAID
+	Type   JobSpecType          `json:"type"`
+	Valid  bool                 `json:"valid"`
+	Issues []pipeline.LintIssue `json:"issues"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r JobLintResource) GetName() string {
+	return "job_lints"
+}
+
+// NewJobLintResource returns a new JobLintResource. A spec is valid if it has
+// no lint errors; warnings alone do not make it invalid.
+func NewJobLintResource(jobType job.Type, issues []pipeline.LintIssue) JobLintResource {
+	if issues == nil {
+		issues = []pipeline.LintIssue{}
+	}
+	return JobLintResource{
+		JAID:   NewJAID(string(jobType)),
+		Type:   JobSpecType(jobType),
+		Valid:  !pipeline.HasErrors(issues),
+		Issues: issues,
+	}
+}
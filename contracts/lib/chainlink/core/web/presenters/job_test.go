@@ -559,7 +559,14 @@ func TestJob(t *testing.T) {
 							"batchFulfillmentGasMultiplier": 1,
 							"backoffInitialDelay":           "0s",
 							"backoffMaxDelay":               "0s",
-							"gasLanePrice":                  "200 gwei"
+							"gasLanePrice":                  "200 gwei",
+							"consumerRateLimit":             0,
+							"consumerRateLimitBurst":        0,
+							"subscriptionRateLimit":         0,
+							"subscriptionRateLimitBurst":    0,
+							"fulfillmentSLABlocks":          0,
+							"fulfillmentSLA":                "0s",
+							"balanceForecastWindow":         "0s"
 						},
 						"webhookSpec": null,
 						"workflowSpec": null,
@@ -1,6 +1,8 @@
 package presenters
 
 import (
+	"slices"
+	"strconv"
 	"time"
 
 	"gopkg.in/guregu/null.v4"
@@ -61,12 +63,13 @@ func NewPipelineRunResource(pr pipeline.Run, lggr logger.Logger) PipelineRunReso
 
 // Corresponds with models.d.ts PipelineTaskRun
 type PipelineTaskRunResource struct {
-	Type       pipeline.TaskType `json:"type"`
-	CreatedAt  time.Time         `json:"createdAt"`
-	FinishedAt null.Time         `json:"finishedAt"`
-	Output     *string           `json:"output"`
-	Error      *string           `json:"error"`
-	DotID      string            `json:"dotId"`
+	Type       pipeline.TaskType        `json:"type"`
+	CreatedAt  time.Time                `json:"createdAt"`
+	FinishedAt null.Time                `json:"finishedAt"`
+	Output     *string                  `json:"output"`
+	Error      *string                  `json:"error"`
+	DotID      string                   `json:"dotId"`
+	Attempts   pipeline.TaskRunAttempts `json:"attempts"`
 }
 
 // GetName implements the api2go EntityNamer interface
@@ -92,6 +95,7 @@ func NewPipelineTaskRunResource(tr pipeline.TaskRun) PipelineTaskRunResource {
 		Output:     output,
 		Error:      errString,
 		DotID:      tr.GetDotID(),
+		Attempts:   tr.Attempts,
 	}
 }
 
@@ -104,3 +108,102 @@ func NewPipelineRunResources(prs []pipeline.Run, lggr logger.Logger) []PipelineR
 
 	return out
 }
+
+// JobDryRunResource is the result of a dry run of a job spec.
+type JobDryRunResource struct {
+	JAID
+	State       pipeline.RunStatus          `json:"state"`
+	Outputs     []*string                   `json:"outputs"`
+	FatalErrors []*string                   `json:"fatalErrors"`
+	Tasks       []pipeline.DryRunTaskResult `json:"tasks"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r JobDryRunResource) GetName() string {
+	return "job_dry_runs"
+}
+
+// NewJobDryRunResource returns a new JobDryRunResource.
+func NewJobDryRunResource(run pipeline.Run, trrs pipeline.TaskRunResults, lggr logger.Logger) JobDryRunResource {
+	outputs, err := run.StringOutputs()
+	if err != nil {
+		lggr.Named("JobDryRunResource").Errorw(err.Error(), "out", run.Outputs)
+	}
+	return JobDryRunResource{
+		JAID:        NewJAID(run.PipelineSpec.JobType),
+		State:       run.State,
+		Outputs:     outputs,
+		FatalErrors: run.StringFatalErrors(),
+		Tasks:       pipeline.NewDryRunTaskResults(trrs),
+	}
+}
+
+// PipelineRunReplayResource is a replay of a finished pipeline run, with the results of its
+// tasks in the original run and in the replay.
+type PipelineRunReplayResource struct {
+	JAID
+	ReplayedRunID string                        `json:"replayedRunId"`
+	State         pipeline.RunStatus            `json:"state"`
+	Outputs       []*string                     `json:"outputs"`
+	FatalErrors   []*string                     `json:"fatalErrors"`
+	Tasks         []PipelineTaskRunDiffResource `json:"tasks"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (r PipelineRunReplayResource) GetName() string {
+	return "pipelineRunReplay"
+}
+
+// PipelineTaskRunDiffResource compares the results of a task in a pipeline run and in its replay.
+// Tasks which were not replayed reuse the results of the original run.
+type PipelineTaskRunDiffResource struct {
+	DotID     string  `json:"dotId"`
+	Replayed  bool    `json:"replayed"`
+	OldOutput *string `json:"oldOutput"`
+	OldError  *string `json:"oldError"`
+	NewOutput *string `json:"newOutput"`
+	NewError  *string `json:"newError"`
+}
+
+// NewPipelineRunReplayResource returns a new PipelineRunReplayResource comparing the replay of
+// original with it, replayed being the dot IDs of the tasks which were executed again.
+func NewPipelineRunReplayResource(original, replay pipeline.Run, replayed []string, lggr logger.Logger) PipelineRunReplayResource {
+	outputs, err := replay.StringOutputs()
+	if err != nil {
+		lggr.Named("PipelineRunReplayResource").Errorw(err.Error(), "out", replay.Outputs)
+	}
+
+	var tasks []PipelineTaskRunDiffResource
+	diff := func(dotID string) {
+		task := PipelineTaskRunDiffResource{
+			DotID:    dotID,
+			Replayed: slices.Contains(replayed, dotID),
+		}
+		if tr := original.ByDotID(dotID); tr != nil {
+			old := NewPipelineTaskRunResource(*tr)
+			task.OldOutput, task.OldError = old.Output, old.Error
+		}
+		if tr := replay.ByDotID(dotID); tr != nil {
+			res := NewPipelineTaskRunResource(*tr)
+			task.NewOutput, task.NewError = res.Output, res.Error
+		}
+		tasks = append(tasks, task)
+	}
+	for _, tr := range replay.PipelineTaskRuns {
+		diff(tr.DotID)
+	}
+	for _, tr := range original.PipelineTaskRuns {
+		if replay.ByDotID(tr.DotID) == nil {
+			diff(tr.DotID)
+		}
+	}
+
+	return PipelineRunReplayResource{
+		JAID:          NewJAIDInt64(replay.ID),
+		ReplayedRunID: strconv.FormatInt(original.ID, 10),
+		State:         replay.State,
+		Outputs:       outputs,
+		FatalErrors:   replay.StringFatalErrors(),
+		Tasks:         tasks,
+	}
+}
@@ -0,0 +1,313 @@
+package presenters
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils/big"
+	v2 "github.com/smartcontractkit/chainlink/v2/core/services/vrf/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+)
+
+// VRFRequestLifecycleResource is the lifecycle of a VRF request as seen by a single job.
+type VRFRequestLifecycleResource struct {
+	JAID
+	JobID               int32          `json:"jobID"`
+	RequestID           *big.Big       `json:"requestID"`
+	EVMChainID          *big.Big       `json:"evmChainID"`
+	CoordinatorAddress  common.Address `json:"coordinatorAddress"`
+	SubID               *big.Big       `json:"subID"`
+	Sender              common.Address `json:"sender"`
+	Stage               string         `json:"stage"`
+	RequestTxHash       common.Hash    `json:"requestTxHash"`
+	RequestBlockHash    common.Hash    `json:"requestBlockHash"`
+	RequestBlockNumber  int64          `json:"requestBlockNumber"`
+	ConfirmedAtBlock    int64          `json:"confirmedAtBlock"`
+	ObservedAt          time.Time      `json:"observedAt"`
+	ConfirmedAt         *time.Time     `json:"confirmedAt"`
+	SimulationAttempts  int32          `json:"simulationAttempts"`
+	LastSimulatedAt     *time.Time     `json:"lastSimulatedAt"`
+	LastSimulationError *string        `json:"lastSimulationError"`
+	EnqueuedAt          *time.Time     `json:"enqueuedAt"`
+	EthTxID             *int64         `json:"ethTxID"`
+	FulfilledAt         *time.Time     `json:"fulfilledAt"`
+	FulfillmentTxHash   *common.Hash   `json:"fulfillmentTxHash"`
+	FulfillmentSuccess  *bool          `json:"fulfillmentSuccess"`
+	DroppedAt           *time.Time     `json:"droppedAt"`
+	DropReason          *string        `json:"dropReason"`
+	UpdatedAt           time.Time      `json:"updatedAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (VRFRequestLifecycleResource) GetName() string {
+	return "vrf_request_lifecycle"
+}
+
+// NewVRFRequestLifecycleResource returns a new VRFRequestLifecycleResource. Its ID is prefixed
+// with the job ID, since the same request may be observed by more than one job.
+func NewVRFRequestLifecycleResource(l vrfcommon.RequestLifecycle) VRFRequestLifecycleResource {
+	return VRFRequestLifecycleResource{
+		JAID:                NewPrefixedJAID(l.RequestID.String(), strconv.FormatInt(int64(l.JobID), 10)),
+		JobID:               l.JobID,
+		RequestID:           l.RequestID,
+		EVMChainID:          l.EVMChainID,
+		CoordinatorAddress:  l.CoordinatorAddress,
+		SubID:               l.SubID,
+		Sender:              l.Sender,
+		Stage:               string(l.Stage()),
+		RequestTxHash:       l.RequestTxHash,
+		RequestBlockHash:    l.RequestBlockHash,
+		RequestBlockNumber:  l.RequestBlockNumber,
+		ConfirmedAtBlock:    l.ConfirmedAtBlock,
+		ObservedAt:          l.ObservedAt,
+		ConfirmedAt:         l.ConfirmedAt,
+		SimulationAttempts:  l.SimulationAttempts,
+		LastSimulatedAt:     l.LastSimulatedAt,
+		LastSimulationError: l.LastSimulationError,
+		EnqueuedAt:          l.EnqueuedAt,
+		EthTxID:             l.EthTxID,
+		FulfilledAt:         l.FulfilledAt,
+		FulfillmentTxHash:   l.FulfillmentTxHash,
+		FulfillmentSuccess:  l.FulfillmentSuccess,
+		DroppedAt:           l.DroppedAt,
+		DropReason:          l.DropReason,
+		UpdatedAt:           l.UpdatedAt,
+	}
+}
+
+// NewVRFRequestLifecycleResources returns a slice of VRFRequestLifecycleResource.
+func NewVRFRequestLifecycleResources(ls []vrfcommon.RequestLifecycle) []VRFRequestLifecycleResource {
+	rs := []VRFRequestLifecycleResource{}
+	for _, l := range ls {
+		rs = append(rs, NewVRFRequestLifecycleResource(l))
+	}
+	return rs
+}
+
+// VRFRefulfillmentResource is the outcome of an operator-triggered re-fulfillment of a VRF request.
+type VRFRefulfillmentResource struct {
+	JAID
+	JobID          int32          `json:"jobID"`
+	RequestID      *big.Big       `json:"requestID"`
+	SubID          *big.Big       `json:"subID"`
+	Sender         common.Address `json:"sender"`
+	RequestTxHash  common.Hash    `json:"requestTxHash"`
+	NativePayment  bool           `json:"nativePayment"`
+	FromAddress    common.Address `json:"fromAddress"`
+	GasLimit       uint64         `json:"gasLimit"`
+	MaxGasPriceWei *big.Big       `json:"maxGasPriceWei"`
+	MaxFee         *big.Big       `json:"maxFee"`
+	DryRun         bool           `json:"dryRun"`
+	EthTxID        *int64         `json:"ethTxID"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (VRFRefulfillmentResource) GetName() string {
+	return "vrf_refulfillment"
+}
+
+// NewVRFRefulfillmentResource returns a new VRFRefulfillmentResource.
+func NewVRFRefulfillmentResource(r vrfcommon.RefulfillResult) VRFRefulfillmentResource {
+	return VRFRefulfillmentResource{
+		JAID:           NewPrefixedJAID(r.RequestID.String(), strconv.FormatInt(int64(r.JobID), 10)),
+		JobID:          r.JobID,
+		RequestID:      big.New(r.RequestID),
+		SubID:          big.New(r.SubID),
+		Sender:         r.Sender,
+		RequestTxHash:  r.RequestTxHash,
+		NativePayment:  r.NativePayment,
+		FromAddress:    r.FromAddress,
+		GasLimit:       r.GasLimit,
+		MaxGasPriceWei: big.New(r.MaxGasPriceWei),
+		MaxFee:         big.New(r.MaxFee),
+		DryRun:         r.DryRun,
+		EthTxID:        r.EthTxID,
+	}
+}
+
+// VRFProvenanceResource is the provenance bundle of a fulfilled VRF request.
+type VRFProvenanceResource struct {
+	JAID
+	v2.ProvenanceBundle
+}
+
+// GetName implements the api2go EntityNamer interface
+func (VRFProvenanceResource) GetName() string {
+	return "vrf_provenance"
+}
+
+// NewVRFProvenanceResource returns a new VRFProvenanceResource.
+func NewVRFProvenanceResource(b v2.ProvenanceBundle) VRFProvenanceResource {
+	return VRFProvenanceResource{
+		JAID:             NewJAID(b.RequestID.String()),
+		ProvenanceBundle: b,
+	}
+}
+
+// VRFBillingSummaryResource is the billing of a VRF subscription for a single payment currency.
+type VRFBillingSummaryResource struct {
+	JAID
+	EVMChainID         *big.Big       `json:"evmChainID"`
+	CoordinatorAddress common.Address `json:"coordinatorAddress"`
+	SubID              *big.Big       `json:"subID"`
+	NativePayment      bool           `json:"nativePayment"`
+	RequestsFulfilled  int64          `json:"requestsFulfilled"`
+	FulfillmentTxs     int64          `json:"fulfillmentTxs"`
+	GasUsed            uint64         `json:"gasUsed"`
+	GasCostWei         *big.Big       `json:"gasCostWei"`
+	Payment            *big.Big       `json:"payment"`
+	LastFulfilledAt    time.Time      `json:"lastFulfilledAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (VRFBillingSummaryResource) GetName() string {
+	return "vrf_billing_summary"
+}
+
+// NewVRFBillingSummaryResource returns a new VRFBillingSummaryResource. Its ID is the
+// subscription and payment currency, prefixed with the chain ID.
+func NewVRFBillingSummaryResource(s vrfcommon.BillingSummary) VRFBillingSummaryResource {
+	currency := "link"
+	if s.NativePayment {
+		currency = "native"
+	}
+	return VRFBillingSummaryResource{
+		JAID:               NewPrefixedJAID(s.SubID.String()+"/"+currency, s.EVMChainID.String()),
+		EVMChainID:         big.New(s.EVMChainID),
+		CoordinatorAddress: s.CoordinatorAddress,
+		SubID:              big.New(s.SubID),
+		NativePayment:      s.NativePayment,
+		RequestsFulfilled:  s.RequestsFulfilled,
+		FulfillmentTxs:     s.FulfillmentTxs,
+		GasUsed:            s.GasUsed,
+		GasCostWei:         big.New(s.GasCostWei),
+		Payment:            big.New(s.Payment),
+		LastFulfilledAt:    s.LastFulfilledAt,
+	}
+}
+
+// NewVRFBillingSummaryResources returns a slice of VRFBillingSummaryResource.
+func NewVRFBillingSummaryResources(ss []vrfcommon.BillingSummary) []VRFBillingSummaryResource {
+	rs := []VRFBillingSummaryResource{}
+	for _, s := range ss {
+		rs = append(rs, NewVRFBillingSummaryResource(s))
+	}
+	return rs
+}
+
+// VRFBillingLedgerEntryResource is a VRF request fulfilled by the node, with its gas and payment.
+type VRFBillingLedgerEntryResource struct {
+	JAID
+	JobID              int32          `json:"jobID"`
+	EVMChainID         *big.Big       `json:"evmChainID"`
+	CoordinatorAddress common.Address `json:"coordinatorAddress"`
+	SubID              *big.Big       `json:"subID"`
+	RequestID          *big.Big       `json:"requestID"`
+	NativePayment      bool           `json:"nativePayment"`
+	FulfilledAt        time.Time      `json:"fulfilledAt"`
+	FulfillmentTxHash  common.Hash    `json:"fulfillmentTxHash"`
+	FulfillmentSuccess bool           `json:"fulfillmentSuccess"`
+	GasUsed            uint64         `json:"gasUsed"`
+	EffectiveGasPrice  *big.Big       `json:"effectiveGasPrice"`
+	GasCostWei         *big.Big       `json:"gasCostWei"`
+	Payment            *big.Big       `json:"payment"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (VRFBillingLedgerEntryResource) GetName() string {
+	return "vrf_billing_ledger_entry"
+}
+
+// NewVRFBillingLedgerEntryResource returns a new VRFBillingLedgerEntryResource.
+func NewVRFBillingLedgerEntryResource(e vrfcommon.BillingLedgerEntry) VRFBillingLedgerEntryResource {
+	return VRFBillingLedgerEntryResource{
+		JAID:               NewPrefixedJAID(e.RequestID.String(), strconv.FormatInt(int64(e.JobID), 10)),
+		JobID:              e.JobID,
+		EVMChainID:         big.New(e.EVMChainID),
+		CoordinatorAddress: e.CoordinatorAddress,
+		SubID:              big.New(e.SubID),
+		RequestID:          big.New(e.RequestID),
+		NativePayment:      e.NativePayment,
+		FulfilledAt:        e.FulfilledAt,
+		FulfillmentTxHash:  e.FulfillmentTxHash,
+		FulfillmentSuccess: e.FulfillmentSuccess,
+		GasUsed:            e.GasUsed,
+		EffectiveGasPrice:  big.New(e.EffectiveGasPrice),
+		GasCostWei:         big.New(e.GasCostWei),
+		Payment:            big.New(e.Payment),
+	}
+}
+
+// NewVRFBillingLedgerEntryResources returns a slice of VRFBillingLedgerEntryResource.
+func NewVRFBillingLedgerEntryResources(es []vrfcommon.BillingLedgerEntry) []VRFBillingLedgerEntryResource {
+	rs := []VRFBillingLedgerEntryResource{}
+	for _, e := range es {
+		rs = append(rs, NewVRFBillingLedgerEntryResource(e))
+	}
+	return rs
+}
+
+// VRFSubscriptionForecastResource projects when a VRF subscription runs out of funds for a
+// single payment currency, at the rate it paid for the fulfillments of a job.
+type VRFSubscriptionForecastResource struct {
+	JAID
+	JobID              int32          `json:"jobID"`
+	EVMChainID         *big.Big       `json:"evmChainID"`
+	CoordinatorAddress common.Address `json:"coordinatorAddress"`
+	SubID              *big.Big       `json:"subID"`
+	NativePayment      bool           `json:"nativePayment"`
+	Balance            *big.Big       `json:"balance"`
+	Reserved           *big.Big       `json:"reserved"`
+	Spent              *big.Big       `json:"spent"`
+	Fulfilled          int64          `json:"fulfilled"`
+	Window             string         `json:"window"`
+	BurnRatePerHour    *big.Big       `json:"burnRatePerHour"`
+	DepletesAt         *time.Time     `json:"depletesAt"`
+	LowFundsHorizon    string         `json:"lowFundsHorizon,omitempty"`
+	UpdatedAt          time.Time      `json:"updatedAt"`
+}
+
+// GetName implements the api2go EntityNamer interface
+func (VRFSubscriptionForecastResource) GetName() string {
+	return "vrf_subscription_forecast"
+}
+
+// NewVRFSubscriptionForecastResource returns a new VRFSubscriptionForecastResource. Its ID is
+// the subscription and payment currency, prefixed with the job ID.
+func NewVRFSubscriptionForecastResource(f vrfcommon.SubscriptionForecast) VRFSubscriptionForecastResource {
+	currency := "link"
+	if f.NativePayment {
+		currency = "native"
+	}
+	r := VRFSubscriptionForecastResource{
+		JAID:               NewPrefixedJAID(f.SubID.String()+"/"+currency, strconv.FormatInt(int64(f.JobID), 10)),
+		JobID:              f.JobID,
+		EVMChainID:         big.New(f.EVMChainID),
+		CoordinatorAddress: f.CoordinatorAddress,
+		SubID:              big.New(f.SubID),
+		NativePayment:      f.NativePayment,
+		Balance:            big.New(f.Balance),
+		Reserved:           big.New(f.Reserved),
+		Spent:              big.New(f.Spent),
+		Fulfilled:          f.Fulfilled,
+		Window:             f.Window.String(),
+		BurnRatePerHour:    big.New(f.BurnRatePerHour),
+		DepletesAt:         f.DepletesAt,
+		UpdatedAt:          f.UpdatedAt,
+	}
+	if f.LowFundsHorizon > 0 {
+		r.LowFundsHorizon = f.LowFundsHorizon.String()
+	}
+	return r
+}
+
+// NewVRFSubscriptionForecastResources returns a slice of VRFSubscriptionForecastResource.
+func NewVRFSubscriptionForecastResources(fs []vrfcommon.SubscriptionForecast) []VRFSubscriptionForecastResource {
+	rs := []VRFSubscriptionForecastResource{}
+	for _, f := range fs {
+		rs = append(rs, NewVRFSubscriptionForecastResource(f))
+	}
+	return rs
+}
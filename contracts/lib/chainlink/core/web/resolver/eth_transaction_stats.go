@@ -0,0 +1,192 @@
+package resolver
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/txmgranalytics"
+	"github.com/smartcontractkit/chainlink/v2/core/utils/stringutils"
+)
+
+type EthTransactionStatsResolver struct {
+	stats txmgranalytics.Stats
+}
+
+func NewEthTransactionStats(stats txmgranalytics.Stats) *EthTransactionStatsResolver {
+	return &EthTransactionStatsResolver{stats: stats}
+}
+
+func NewEthTransactionStatsList(results []txmgranalytics.Stats) []*EthTransactionStatsResolver {
+	var resolver []*EthTransactionStatsResolver
+
+	for _, s := range results {
+		resolver = append(resolver, NewEthTransactionStats(s))
+	}
+
+	return resolver
+}
+
+func (r *EthTransactionStatsResolver) EVMChainID() graphql.ID {
+	return graphql.ID(r.stats.EVMChainID.String())
+}
+
+func (r *EthTransactionStatsResolver) JobID() *graphql.ID {
+	if r.stats.JobID == nil {
+		return nil
+	}
+	id := graphql.ID(stringutils.FromInt32(*r.stats.JobID))
+	return &id
+}
+
+func (r *EthTransactionStatsResolver) FromAddress() *string {
+	if r.stats.FromAddress == nil {
+		return nil
+	}
+	address := r.stats.FromAddress.Hex()
+	return &address
+}
+
+func (r *EthTransactionStatsResolver) Transactions() int32 {
+	return int32(r.stats.Transactions)
+}
+
+func (r *EthTransactionStatsResolver) Included() int32 {
+	return int32(r.stats.Included)
+}
+
+func (r *EthTransactionStatsResolver) Reverted() int32 {
+	return int32(r.stats.Reverted)
+}
+
+func (r *EthTransactionStatsResolver) Fatal() int32 {
+	return int32(r.stats.Fatal)
+}
+
+func (r *EthTransactionStatsResolver) Pending() int32 {
+	return int32(r.stats.Pending)
+}
+
+func (r *EthTransactionStatsResolver) GasUsed() string {
+	return strconv.FormatUint(r.stats.GasUsed, 10)
+}
+
+func (r *EthTransactionStatsResolver) GasCostWei() string {
+	return r.stats.GasCostWei.String()
+}
+
+func (r *EthTransactionStatsResolver) AvgEffectiveGasPrice() string {
+	return r.stats.AvgEffectiveGasPrice.String()
+}
+
+func (r *EthTransactionStatsResolver) BroadcastAttempts() int32 {
+	return int32(r.stats.BroadcastAttempts)
+}
+
+func (r *EthTransactionStatsResolver) BumpAttempts() int32 {
+	return int32(r.stats.BumpAttempts)
+}
+
+func (r *EthTransactionStatsResolver) AvgTimeToInclusionSeconds() float64 {
+	return r.stats.AvgTimeToInclusion.Seconds()
+}
+
+func (r *EthTransactionStatsResolver) MaxTimeToInclusionSeconds() float64 {
+	return r.stats.MaxTimeToInclusion.Seconds()
+}
+
+func (r *EthTransactionStatsResolver) RevertRate() float64 {
+	return r.stats.RevertRate
+}
+
+// -- EthTransactionStats Query --
+
+type EthTransactionStatsPayloadResolver struct {
+	results   []txmgranalytics.Stats
+	inputErrs map[string]string
+}
+
+func NewEthTransactionStatsPayload(results []txmgranalytics.Stats, inputErrs map[string]string) *EthTransactionStatsPayloadResolver {
+	return &EthTransactionStatsPayloadResolver{results: results, inputErrs: inputErrs}
+}
+
+func (r *EthTransactionStatsPayloadResolver) ToEthTransactionStatsSuccess() (*EthTransactionStatsSuccessResolver, bool) {
+	if r.inputErrs != nil {
+		return nil, false
+	}
+
+	return NewEthTransactionStatsSuccess(r.results), true
+}
+
+func (r *EthTransactionStatsPayloadResolver) ToInputErrors() (*InputErrorsResolver, bool) {
+	if r.inputErrs != nil {
+		var errs []*InputErrorResolver
+
+		for path, message := range r.inputErrs {
+			errs = append(errs, NewInputError(path, message))
+		}
+
+		return NewInputErrors(errs), true
+	}
+
+	return nil, false
+}
+
+type EthTransactionStatsSuccessResolver struct {
+	results []txmgranalytics.Stats
+}
+
+func NewEthTransactionStatsSuccess(results []txmgranalytics.Stats) *EthTransactionStatsSuccessResolver {
+	return &EthTransactionStatsSuccessResolver{results: results}
+}
+
+func (r *EthTransactionStatsSuccessResolver) Results() []*EthTransactionStatsResolver {
+	return NewEthTransactionStatsList(r.results)
+}
+
+type ethTransactionStatsArgs struct {
+	GroupBy    *string
+	EVMChainID *graphql.ID
+	JobID      *graphql.ID
+	Address    *string
+	From       *graphql.Time
+	To         *graphql.Time
+}
+
+// parse turns the query arguments into a group and a filter, returning input errors keyed by
+// argument name if any of them is invalid.
+func (args ethTransactionStatsArgs) parse() (txmgranalytics.GroupBy, txmgranalytics.Filter, map[string]string) {
+	groupBy := txmgranalytics.GroupByJob
+	if args.GroupBy != nil {
+		groupBy = txmgranalytics.GroupBy(strings.ToLower(*args.GroupBy))
+	}
+
+	var params txmgranalytics.FilterParams
+	if args.EVMChainID != nil {
+		params.EVMChainID = string(*args.EVMChainID)
+	}
+	if args.JobID != nil {
+		params.JobID = string(*args.JobID)
+	}
+	if args.Address != nil {
+		params.Address = *args.Address
+	}
+	if args.From != nil {
+		params.From = &args.From.Time
+	}
+	if args.To != nil {
+		params.To = &args.To.Time
+	}
+
+	filter, errs := txmgranalytics.ParseFilter(params, time.Now())
+	if len(errs) > 0 {
+		inputErrs := make(map[string]string, len(errs))
+		for _, err := range errs {
+			inputErrs[err.Param] = err.Message
+		}
+		return groupBy, filter, inputErrs
+	}
+	return groupBy, filter, nil
+}
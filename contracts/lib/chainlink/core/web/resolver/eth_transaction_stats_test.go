@@ -0,0 +1,72 @@
+package resolver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils/pgtest"
+)
+
+func TestResolver_EthTransactionStats(t *testing.T) {
+	t.Parallel()
+
+	query := `
+		query GetEthTransactionStats($groupBy: EthTransactionStatsGroupBy, $jobID: ID) {
+			ethTransactionStats(groupBy: $groupBy, jobID: $jobID) {
+				... on EthTransactionStatsSuccess {
+					results {
+						evmChainID
+						jobID
+						fromAddress
+						transactions
+						gasCostWei
+						revertRate
+					}
+				}
+				... on InputErrors {
+					errors {
+						path
+						message
+						code
+					}
+				}
+			}
+		}`
+
+	testCases := []GQLTestCase{
+		unauthorizedTestCase(GQLTestCase{query: query}, "ethTransactionStats"),
+		{
+			name:          "success",
+			authenticated: true,
+			before: func(ctx context.Context, f *gqlTestFramework) {
+				f.App.On("GetDB").Return(pgtest.NewSqlxDB(f.t))
+			},
+			query:     query,
+			variables: map[string]interface{}{"groupBy": "KEY"},
+			result: `
+				{
+					"ethTransactionStats": {
+						"results": []
+					}
+				}`,
+		},
+		{
+			name:          "input errors",
+			authenticated: true,
+			query:         query,
+			variables:     map[string]interface{}{"jobID": "abc"},
+			result: `
+				{
+					"ethTransactionStats": {
+						"errors": [{
+							"path": "jobID",
+							"message": "invalid job ID",
+							"code": "INVALID_INPUT"
+						}]
+					}
+				}`,
+		},
+	}
+
+	RunGQLTests(t, testCases)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore"
 	"github.com/smartcontractkit/chainlink/v2/core/services/keystore/keys/vrfkey"
 	evmrelay "github.com/smartcontractkit/chainlink/v2/core/services/relay/evm"
+	"github.com/smartcontractkit/chainlink/v2/core/services/txmgranalytics"
 	"github.com/smartcontractkit/chainlink/v2/core/utils/stringutils"
 	"github.com/smartcontractkit/chainlink/v2/core/web/loader"
 )
@@ -571,6 +572,24 @@ func (r *Resolver) EthTransactionsAttempts(ctx context.Context, args struct {
 	return NewEthTransactionsAttemptsPayload(attempts, int32(count)), nil
 }
 
+func (r *Resolver) EthTransactionStats(ctx context.Context, args ethTransactionStatsArgs) (*EthTransactionStatsPayloadResolver, error) {
+	if err := authenticateUser(ctx); err != nil {
+		return nil, err
+	}
+
+	groupBy, filter, inputErrs := args.parse()
+	if inputErrs != nil {
+		return NewEthTransactionStatsPayload(nil, inputErrs), nil
+	}
+
+	outcomes, err := txmgranalytics.NewORM(r.App.GetDB()).TxOutcomes(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewEthTransactionStatsPayload(txmgranalytics.Summarize(outcomes, groupBy), nil), nil
+}
+
 func (r *Resolver) GlobalLogLevel(ctx context.Context) (*GlobalLogLevelPayloadResolver, error) {
 	if err := authenticateUser(ctx); err != nil {
 		return nil, err
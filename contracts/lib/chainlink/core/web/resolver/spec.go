@@ -733,6 +733,229 @@ func (r *VRFSpecResolver) VRFOwnerAddress() *string {
 	return &vrfOwnerAddress
 }
 
+// ConsumerRateLimit resolves the spec's per consumer rate limit.
+func (r *VRFSpecResolver) ConsumerRateLimit() float64 {
+	return float64(r.spec.ConsumerRateLimit)
+}
+
+// ConsumerRateLimitBurst resolves the spec's per consumer rate limit burst.
+func (r *VRFSpecResolver) ConsumerRateLimitBurst() int32 {
+	return int32(r.spec.ConsumerRateLimitBurst)
+}
+
+// SubscriptionRateLimit resolves the spec's per subscription rate limit.
+func (r *VRFSpecResolver) SubscriptionRateLimit() float64 {
+	return float64(r.spec.SubscriptionRateLimit)
+}
+
+// SubscriptionRateLimitBurst resolves the spec's per subscription rate limit burst.
+func (r *VRFSpecResolver) SubscriptionRateLimitBurst() int32 {
+	return int32(r.spec.SubscriptionRateLimitBurst)
+}
+
+// SubscriptionWeights resolves the spec's subscription weights.
+func (r *VRFSpecResolver) SubscriptionWeights() gqlscalar.Map {
+	weights := gqlscalar.Map{}
+	for subID, weight := range r.spec.SubscriptionWeights {
+		weights[subID] = weight
+	}
+	return weights
+}
+
+// FulfillmentSLABlocks resolves the spec's fulfillment SLA in blocks.
+func (r *VRFSpecResolver) FulfillmentSLABlocks() int32 {
+	return int32(r.spec.FulfillmentSLABlocks)
+}
+
+// FulfillmentSLA resolves the spec's fulfillment SLA.
+func (r *VRFSpecResolver) FulfillmentSLA() string {
+	return r.spec.FulfillmentSLA.String()
+}
+
+// SLAWebhookURL resolves the spec's SLA webhook URL.
+func (r *VRFSpecResolver) SLAWebhookURL() *string {
+	if r.spec.SLAWebhookURL == "" {
+		return nil
+	}
+	return &r.spec.SLAWebhookURL
+}
+
+// GasLanes resolves the spec's additional gas lanes.
+func (r *VRFSpecResolver) GasLanes() []*VRFGasLaneResolver {
+	var resolvers []*VRFGasLaneResolver
+	for _, lane := range r.spec.GasLanes {
+		resolvers = append(resolvers, &VRFGasLaneResolver{lane: lane})
+	}
+	return resolvers
+}
+
+// LowFundsWarningHorizons resolves the spec's low funds warning horizons.
+func (r *VRFSpecResolver) LowFundsWarningHorizons() []string {
+	var horizons []string
+	for _, h := range r.spec.LowFundsWarningHorizons {
+		horizons = append(horizons, h.String())
+	}
+	return horizons
+}
+
+// BalanceForecastWindow resolves the spec's balance forecast window.
+func (r *VRFSpecResolver) BalanceForecastWindow() string {
+	return r.spec.BalanceForecastWindow.String()
+}
+
+// BlockhashStore resolves the spec's blockhash store feeder, if any.
+func (r *VRFSpecResolver) BlockhashStore() *VRFBlockhashStoreResolver {
+	if r.spec.BlockhashStore == nil {
+		return nil
+	}
+	return &VRFBlockhashStoreResolver{cfg: *r.spec.BlockhashStore}
+}
+
+// BlockHeaderFeeder resolves the spec's block header feeder, if any.
+func (r *VRFSpecResolver) BlockHeaderFeeder() *VRFBlockHeaderFeederResolver {
+	if r.spec.BlockHeaderFeeder == nil {
+		return nil
+	}
+	return &VRFBlockHeaderFeederResolver{cfg: *r.spec.BlockHeaderFeeder}
+}
+
+type VRFGasLaneResolver struct {
+	lane job.VRFGasLane
+}
+
+// PublicKey resolves the gas lane's public key.
+func (r *VRFGasLaneResolver) PublicKey() string {
+	return r.lane.PublicKey.String()
+}
+
+// GasLanePrice resolves the gas lane's price.
+func (r *VRFGasLaneResolver) GasLanePrice() *string {
+	if r.lane.GasLanePrice == nil {
+		return nil
+	}
+	gasLanePriceGWei := r.lane.GasLanePrice.String()
+	return &gasLanePriceGWei
+}
+
+// FromAddresses resolves the gas lane's from addresses.
+func (r *VRFGasLaneResolver) FromAddresses() []string {
+	var addresses []string
+	for _, a := range r.lane.FromAddresses {
+		addresses = append(addresses, a.Address().String())
+	}
+	return addresses
+}
+
+type VRFBlockhashStoreResolver struct {
+	cfg job.VRFBlockhashStoreConfig
+}
+
+// BlockhashStoreAddress resolves the feeder's blockhash store address.
+func (r *VRFBlockhashStoreResolver) BlockhashStoreAddress() string {
+	return r.cfg.BlockhashStoreAddress.String()
+}
+
+// TrustedBlockhashStoreAddress resolves the feeder's trusted blockhash store address, if any.
+func (r *VRFBlockhashStoreResolver) TrustedBlockhashStoreAddress() *string {
+	if r.cfg.TrustedBlockhashStoreAddress == nil {
+		return nil
+	}
+	addr := r.cfg.TrustedBlockhashStoreAddress.String()
+	return &addr
+}
+
+// TrustedBlockhashStoreBatchSize resolves the feeder's trusted blockhash store batch size.
+func (r *VRFBlockhashStoreResolver) TrustedBlockhashStoreBatchSize() int32 {
+	return r.cfg.TrustedBlockhashStoreBatchSize
+}
+
+// WaitBlocks resolves the feeder's wait blocks.
+func (r *VRFBlockhashStoreResolver) WaitBlocks() int32 {
+	return r.cfg.WaitBlocks
+}
+
+// LookbackBlocks resolves the feeder's lookback blocks.
+func (r *VRFBlockhashStoreResolver) LookbackBlocks() int32 {
+	return r.cfg.LookbackBlocks
+}
+
+// HeartbeatPeriod resolves the feeder's heartbeat period.
+func (r *VRFBlockhashStoreResolver) HeartbeatPeriod() string {
+	return r.cfg.HeartbeatPeriod.String()
+}
+
+// PollPeriod resolves the feeder's poll period.
+func (r *VRFBlockhashStoreResolver) PollPeriod() string {
+	return r.cfg.PollPeriod.String()
+}
+
+// RunTimeout resolves the feeder's run timeout.
+func (r *VRFBlockhashStoreResolver) RunTimeout() string {
+	return r.cfg.RunTimeout.String()
+}
+
+// FromAddresses resolves the feeder's from addresses, empty if it uses the job's.
+func (r *VRFBlockhashStoreResolver) FromAddresses() []string {
+	var addresses []string
+	for _, a := range r.cfg.FromAddresses {
+		addresses = append(addresses, a.Address().String())
+	}
+	return addresses
+}
+
+type VRFBlockHeaderFeederResolver struct {
+	cfg job.VRFBlockHeaderFeederConfig
+}
+
+// BlockhashStoreAddress resolves the feeder's blockhash store address.
+func (r *VRFBlockHeaderFeederResolver) BlockhashStoreAddress() string {
+	return r.cfg.BlockhashStoreAddress.String()
+}
+
+// BatchBlockhashStoreAddress resolves the feeder's batch blockhash store address.
+func (r *VRFBlockHeaderFeederResolver) BatchBlockhashStoreAddress() string {
+	return r.cfg.BatchBlockhashStoreAddress.String()
+}
+
+// WaitBlocks resolves the feeder's wait blocks.
+func (r *VRFBlockHeaderFeederResolver) WaitBlocks() int32 {
+	return r.cfg.WaitBlocks
+}
+
+// LookbackBlocks resolves the feeder's lookback blocks.
+func (r *VRFBlockHeaderFeederResolver) LookbackBlocks() int32 {
+	return r.cfg.LookbackBlocks
+}
+
+// PollPeriod resolves the feeder's poll period.
+func (r *VRFBlockHeaderFeederResolver) PollPeriod() string {
+	return r.cfg.PollPeriod.String()
+}
+
+// RunTimeout resolves the feeder's run timeout.
+func (r *VRFBlockHeaderFeederResolver) RunTimeout() string {
+	return r.cfg.RunTimeout.String()
+}
+
+// GetBlockhashesBatchSize resolves the feeder's get blockhashes batch size.
+func (r *VRFBlockHeaderFeederResolver) GetBlockhashesBatchSize() int32 {
+	return int32(r.cfg.GetBlockhashesBatchSize)
+}
+
+// StoreBlockhashesBatchSize resolves the feeder's store blockhashes batch size.
+func (r *VRFBlockHeaderFeederResolver) StoreBlockhashesBatchSize() int32 {
+	return int32(r.cfg.StoreBlockhashesBatchSize)
+}
+
+// FromAddresses resolves the feeder's from addresses, empty if it uses the job's.
+func (r *VRFBlockHeaderFeederResolver) FromAddresses() []string {
+	var addresses []string
+	for _, a := range r.cfg.FromAddresses {
+		addresses = append(addresses, a.Address().String())
+	}
+	return addresses
+}
+
 type WebhookSpecResolver struct {
 	spec job.WebhookSpec
 }
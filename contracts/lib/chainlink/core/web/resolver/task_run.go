@@ -59,3 +59,47 @@ func (r *TaskRunResolver) FinishedAt() *graphql.Time {
 func (r *TaskRunResolver) DotID() string {
 	return r.tr.GetDotID()
 }
+
+// Attempts resolves the attempt history of a task run which was retried.
+func (r *TaskRunResolver) Attempts() []*TaskRunAttemptResolver {
+	resolvers := []*TaskRunAttemptResolver{}
+	for _, attempt := range r.tr.Attempts {
+		resolvers = append(resolvers, &TaskRunAttemptResolver{attempt: attempt})
+	}
+	return resolvers
+}
+
+type TaskRunAttemptResolver struct {
+	attempt pipeline.TaskRunAttempt
+}
+
+func (r *TaskRunAttemptResolver) Error() *string {
+	if r.attempt.Error.Valid {
+		return r.attempt.Error.Ptr()
+	}
+
+	return nil
+}
+
+func (r *TaskRunAttemptResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: r.attempt.CreatedAt}
+}
+
+func (r *TaskRunAttemptResolver) FinishedAt() *graphql.Time {
+	if !r.attempt.FinishedAt.Valid {
+		return nil
+	}
+	return &graphql.Time{Time: r.attempt.FinishedAt.Time}
+}
+
+// Backoff resolves how long the node waited before the next attempt, or null
+// for the last attempt.
+func (r *TaskRunAttemptResolver) Backoff() *string {
+	if r.attempt.Backoff.IsZero() {
+		return nil
+	}
+
+	backoff := r.attempt.Backoff.Duration().String()
+
+	return &backoff
+}
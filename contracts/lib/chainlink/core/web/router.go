@@ -291,6 +291,7 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		txs := TransactionsController{app}
 		authv2.GET("/transactions/evm", paginatedRequest(txs.Index))
 		authv2.GET("/transactions/evm/:TxHash", txs.Show)
+		authv2.GET("/transactions/evm/stats", txs.Stats)
 		authv2.GET("/transactions", paginatedRequest(txs.Index))
 		authv2.GET("/transactions/:TxHash", txs.Show)
 
@@ -374,6 +375,21 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.POST("/keys/vrf/import", auth.RequiresAdminRole(vrfkc.Import))
 		authv2.POST("/keys/vrf/export/:keyID", auth.RequiresAdminRole(vrfkc.Export))
 
+		vrfrc := VRFRequestsController{app}
+		authv2.GET("/vrf/requests/:requestID", vrfrc.Show)
+		authv2.GET("/vrf/requests/:requestID/provenance", vrfrc.Provenance)
+		authv2.POST("/vrf/requests/:requestID/refulfill", auth.RequiresEditRole(vrfrc.Refulfill))
+
+		vrfbc := VRFBillingController{app}
+		authv2.GET("/vrf/billing", vrfbc.Show)
+		authv2.GET("/vrf/billing/ledger", vrfbc.Ledger)
+
+		vrffc := VRFForecastsController{app}
+		authv2.GET("/vrf/forecasts", vrffc.Index)
+
+		bhsbc := BHSBackfillController{app}
+		authv2.POST("/bhs/backfill", auth.RequiresEditRole(bhsbc.Create))
+
 		wfkc := WorkflowKeysController{app}
 		authv2.GET("/keys/workflow", wfkc.Index)
 
@@ -381,13 +397,23 @@ func v2Routes(app chainlink.Application, r *gin.RouterGroup) {
 		authv2.GET("/jobs", paginatedRequest(jc.Index))
 		authv2.GET("/jobs/:ID", jc.Show)
 		authv2.POST("/jobs", auth.RequiresEditRole(jc.Create))
+		authv2.POST("/jobs/lint", jc.Lint)
+		authv2.POST("/jobs/dryrun", auth.RequiresEditRole(jc.DryRun))
 		authv2.PUT("/jobs/:ID", auth.RequiresEditRole(jc.Update))
+		authv2.PATCH("/jobs/:ID", auth.RequiresEditRole(jc.Patch))
 		authv2.DELETE("/jobs/:ID", auth.RequiresEditRole(jc.Delete))
 
 		// PipelineRunsController
 		authv2.GET("/pipeline/runs", paginatedRequest(prc.Index))
 		authv2.GET("/jobs/:ID/runs", paginatedRequest(prc.Index))
 		authv2.GET("/jobs/:ID/runs/:runID", prc.Show)
+		authv2.POST("/pipeline/runs/:runID/replay", auth.RequiresRunRole(prc.Replay))
+
+		ptc := PipelineTemplatesController{app}
+		authv2.GET("/pipeline/templates", ptc.Index)
+		authv2.GET("/pipeline/templates/:name", ptc.Show)
+		authv2.POST("/pipeline/templates", auth.RequiresEditRole(ptc.Create))
+		authv2.DELETE("/pipeline/templates/:name", auth.RequiresEditRole(ptc.Destroy))
 
 		// FeaturesController
 		fc := FeaturesController{app}
@@ -0,0 +1,107 @@
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// defaultVRFBillingWindow is the billing window used when no "from" is given.
+const defaultVRFBillingWindow = 7 * 24 * time.Hour
+
+// VRFBillingController reports what fulfilling VRF requests cost the node, per subscription.
+type VRFBillingController struct {
+	App chainlink.Application
+}
+
+// Show returns the billing summary of every subscription, or of a single one with
+// subID, per payment currency.
+// Example:
+// "GET <application>/vrf/billing?subID=1&from=2024-01-01T00:00:00Z&to=2024-01-08T00:00:00Z"
+func (vbc *VRFBillingController) Show(c *gin.Context) {
+	entries, ok := vbc.ledger(c)
+	if !ok {
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewVRFBillingSummaryResources(vrfcommon.SummarizeBilling(entries)), "vrf_billing_summary")
+}
+
+// Ledger returns every request fulfilled by the node in the billing window, as
+// JSON or, with format=csv, as CSV.
+// Example:
+// "GET <application>/vrf/billing/ledger?subID=1&format=csv"
+func (vbc *VRFBillingController) Ledger(c *gin.Context) {
+	entries, ok := vbc.ledger(c)
+	if !ok {
+		return
+	}
+
+	switch format := c.DefaultQuery("format", "json"); format {
+	case "json":
+		jsonAPIResponse(c, presenters.NewVRFBillingLedgerEntryResources(entries), "vrf_billing_ledger_entry")
+	case "csv":
+		var buf bytes.Buffer
+		if err := vrfcommon.WriteBillingLedgerCSV(&buf, entries); err != nil {
+			jsonAPIError(c, http.StatusInternalServerError, err)
+			return
+		}
+		c.Header("Content-Disposition", `attachment; filename="vrf_billing_ledger.csv"`)
+		c.Data(http.StatusOK, "text/csv", buf.Bytes())
+	default:
+		jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("invalid format: %s, must be json or csv", format))
+	}
+}
+
+// ledger loads the billing ledger selected by the query params. It writes the error
+// response itself and returns false on failure.
+func (vbc *VRFBillingController) ledger(c *gin.Context) ([]vrfcommon.BillingLedgerEntry, bool) {
+	filter, err := parseVRFBillingFilter(c)
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return nil, false
+	}
+
+	orm := vrfcommon.NewRequestLifecycleORM(vbc.App.GetDB())
+	entries, err := orm.BillingLedger(c.Request.Context(), filter)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return nil, false
+	}
+	return entries, true
+}
+
+func parseVRFBillingFilter(c *gin.Context) (filter vrfcommon.BillingFilter, err error) {
+	if s := c.Query("subID"); s != "" {
+		subID, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return filter, fmt.Errorf("invalid subscription ID: %s", s)
+		}
+		filter.SubID = subID
+	}
+
+	filter.To = time.Now()
+	if s := c.Query("to"); s != "" {
+		if filter.To, err = time.Parse(time.RFC3339, s); err != nil {
+			return filter, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	filter.From = filter.To.Add(-defaultVRFBillingWindow)
+	if s := c.Query("from"); s != "" {
+		if filter.From, err = time.Parse(time.RFC3339, s); err != nil {
+			return filter, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+	if !filter.From.Before(filter.To) {
+		return filter, fmt.Errorf("from (%s) must be before to (%s)", filter.From.Format(time.RFC3339), filter.To.Format(time.RFC3339))
+	}
+	return filter, nil
+}
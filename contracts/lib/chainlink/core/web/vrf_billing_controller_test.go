@@ -0,0 +1,59 @@
+package web_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func TestVRFBillingController(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(ctx))
+	client := app.NewHTTPClient(nil)
+
+	t.Run("summary", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/vrf/billing?subID=1")
+		t.Cleanup(cleanup)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var resources []presenters.VRFBillingSummaryResource
+		require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &resources))
+		assert.Empty(t, resources)
+	})
+
+	t.Run("ledger as csv", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/vrf/billing/ledger?format=csv&from=2024-01-01T00:00:00Z&to=2024-01-08T00:00:00Z")
+		t.Cleanup(cleanup)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/csv", resp.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(body), "job_id,evm_chain_id,"))
+	})
+
+	for _, query := range []string{
+		"subID=abc",
+		"from=yesterday",
+		"from=2024-01-08T00:00:00Z&to=2024-01-01T00:00:00Z",
+		"format=xml",
+	} {
+		t.Run("invalid "+query, func(t *testing.T) {
+			resp, cleanup := client.Get("/v2/vrf/billing/ledger?" + query)
+			t.Cleanup(cleanup)
+			assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+		})
+	}
+}
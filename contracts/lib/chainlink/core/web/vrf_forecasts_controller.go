@@ -0,0 +1,54 @@
+package web
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// VRFForecastsController reports when the subscriptions served by VRF jobs are forecast to
+// run out of funds. Forecasts are refreshed by the jobs that set lowFundsWarningHorizons.
+type VRFForecastsController struct {
+	App chainlink.Application
+}
+
+// Index returns the latest subscription forecasts, those closest to running out of funds
+// first, optionally of a single job or subscription.
+// Example:
+// "GET <application>/vrf/forecasts?jobID=1&subID=1"
+func (vfc *VRFForecastsController) Index(c *gin.Context) {
+	var filter vrfcommon.ForecastFilter
+	if s := c.Query("jobID"); s != "" {
+		jobID, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("invalid job ID: %s", s))
+			return
+		}
+		id := int32(jobID)
+		filter.JobID = &id
+	}
+	if s := c.Query("subID"); s != "" {
+		subID, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("invalid subscription ID: %s", s))
+			return
+		}
+		filter.SubID = subID
+	}
+
+	orm := vrfcommon.NewBalanceForecastORM(vfc.App.GetDB())
+	forecasts, err := orm.Forecasts(c.Request.Context(), filter)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewVRFSubscriptionForecastResources(forecasts), "vrf_subscription_forecast")
+}
@@ -0,0 +1,142 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+
+	"github.com/smartcontractkit/chainlink/v2/core/logger/audit"
+	"github.com/smartcontractkit/chainlink/v2/core/services/chainlink"
+	v2 "github.com/smartcontractkit/chainlink/v2/core/services/vrf/v2"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+// VRFRequestsController exposes the lifecycle of VRF requests handled by the node.
+type VRFRequestsController struct {
+	App chainlink.Application
+}
+
+// Show returns the lifecycle of a VRF request for every job that observed it.
+// Example:
+// "GET <application>/vrf/requests/:requestID"
+func (vrc *VRFRequestsController) Show(c *gin.Context) {
+	requestID, ok := new(big.Int).SetString(c.Param("requestID"), 0)
+	if !ok {
+		jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("invalid request ID: %s", c.Param("requestID")))
+		return
+	}
+
+	orm := vrfcommon.NewRequestLifecycleORM(vrc.App.GetDB())
+	lifecycles, err := orm.FindByRequestID(c.Request.Context(), requestID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if len(lifecycles) == 0 {
+		jsonAPIError(c, http.StatusNotFound, errors.New("VRF request not found"))
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewVRFRequestLifecycleResources(lifecycles), "vrf_request_lifecycle")
+}
+
+// Provenance returns the provenance bundle of a fulfilled VRF request, for its randomness to be
+// verified offline with "vrf verify-bundle". The request and fulfillment transactions are taken
+// from the lifecycle of the request, and their logs and the proof are fetched from the chain.
+// Example:
+// "GET <application>/vrf/requests/:requestID/provenance"
+func (vrc *VRFRequestsController) Provenance(c *gin.Context) {
+	requestID, ok := new(big.Int).SetString(c.Param("requestID"), 0)
+	if !ok {
+		jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("invalid request ID: %s", c.Param("requestID")))
+		return
+	}
+
+	orm := vrfcommon.NewRequestLifecycleORM(vrc.App.GetDB())
+	lifecycles, err := orm.FindByRequestID(c.Request.Context(), requestID)
+	if err != nil {
+		jsonAPIError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if len(lifecycles) == 0 {
+		jsonAPIError(c, http.StatusNotFound, errors.New("VRF request not found"))
+		return
+	}
+	idx := slices.IndexFunc(lifecycles, func(l vrfcommon.RequestLifecycle) bool { return l.FulfillmentTxHash != nil })
+	if idx < 0 {
+		jsonAPIError(c, http.StatusConflict, errors.New("VRF request is not fulfilled yet"))
+		return
+	}
+	lifecycle := lifecycles[idx]
+
+	chain, err := getChain(vrc.App.GetRelayers().LegacyEVMChains(), lifecycle.EVMChainID.String())
+	if err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+	bundle, err := v2.BuildProvenanceBundle(c.Request.Context(), chain.Client(), lifecycle.EVMChainID.ToInt(), requestID,
+		lifecycle.RequestTxHash, *lifecycle.FulfillmentTxHash)
+	if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonAPIResponse(c, presenters.NewVRFProvenanceResource(bundle), "vrf_provenance")
+}
+
+// RefulfillVRFRequest is a JSONAPI request for re-fulfilling a VRF request.
+type RefulfillVRFRequest struct {
+	CoordinatorAddress common.Address `json:"coordinatorAddress"`
+	// BlockNumber is the block the request was made in. Only required if the
+	// request was never observed by the node.
+	BlockNumber *uint64 `json:"blockNumber"`
+	DryRun      bool    `json:"dryRun"`
+}
+
+// Refulfill re-fulfills a VRF request through the running job that serves it. With
+// dryRun set, the fulfillment is only simulated.
+// Example:
+// "POST <application>/vrf/requests/:requestID/refulfill"
+func (vrc *VRFRequestsController) Refulfill(c *gin.Context) {
+	requestID, ok := new(big.Int).SetString(c.Param("requestID"), 0)
+	if !ok {
+		jsonAPIError(c, http.StatusUnprocessableEntity, fmt.Errorf("invalid request ID: %s", c.Param("requestID")))
+		return
+	}
+	request := &RefulfillVRFRequest{}
+	if err := c.ShouldBindJSON(request); err != nil {
+		jsonAPIError(c, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	res, err := vrc.App.RefulfillVRFRequest(c.Request.Context(), request.CoordinatorAddress, requestID, vrfcommon.RefulfillOpts{
+		BlockNumber: request.BlockNumber,
+		DryRun:      request.DryRun,
+	})
+	if errors.Is(err, vrfcommon.ErrRequestNotFound) {
+		jsonAPIError(c, http.StatusNotFound, err)
+		return
+	} else if errors.Is(err, vrfcommon.ErrRequestAlreadyFulfilled) || errors.Is(err, vrfcommon.ErrRequestInflight) {
+		jsonAPIError(c, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		jsonAPIError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if !res.DryRun {
+		vrc.App.GetAuditLogger().Audit(audit.VRFRequestRefulfilled, map[string]interface{}{
+			"jobID":              res.JobID,
+			"requestID":          res.RequestID.String(),
+			"coordinatorAddress": request.CoordinatorAddress,
+			"ethTxID":            *res.EthTxID,
+		})
+	}
+	jsonAPIResponse(c, presenters.NewVRFRefulfillmentResource(res), "vrf_refulfillment")
+}
@@ -0,0 +1,128 @@
+package web_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/smartcontractkit/chainlink-evm/pkg/utils"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/cltest"
+	"github.com/smartcontractkit/chainlink/v2/core/internal/testutils"
+	"github.com/smartcontractkit/chainlink/v2/core/services/vrf/vrfcommon"
+	"github.com/smartcontractkit/chainlink/v2/core/web"
+	"github.com/smartcontractkit/chainlink/v2/core/web/presenters"
+)
+
+func TestVRFRequestsController_Show(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(ctx))
+	client := app.NewHTTPClient(nil)
+
+	jb, _ := cltest.MustInsertWebhookSpec(t, app.GetDB())
+	orm := vrfcommon.NewRequestLifecycleORM(app.GetDB())
+	reqID := big.NewInt(1234)
+	require.NoError(t, orm.RecordObserved(ctx, jb.ID, testutils.FixtureChainID, utils.RandomAddress(), []vrfcommon.ObservedRequest{{
+		RequestID:        reqID,
+		SubID:            big.NewInt(1),
+		Sender:           utils.RandomAddress(),
+		TxHash:           utils.RandomHash(),
+		BlockHash:        utils.RandomHash(),
+		BlockNumber:      10,
+		ConfirmedAtBlock: 13,
+		ObservedAt:       time.Now().UTC(),
+	}}))
+	require.NoError(t, orm.RecordDropped(ctx, jb.ID, reqID, vrfcommon.ReasonAge))
+
+	t.Run("found", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/vrf/requests/" + reqID.String())
+		t.Cleanup(cleanup)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var resources []presenters.VRFRequestLifecycleResource
+		require.NoError(t, web.ParseJSONAPIResponse(cltest.ParseResponseBody(t, resp), &resources))
+		require.Len(t, resources, 1)
+		assert.Equal(t, jb.ID, resources[0].JobID)
+		assert.Equal(t, string(vrfcommon.StageDropped), resources[0].Stage)
+		require.NotNil(t, resources[0].DropReason)
+		assert.Equal(t, string(vrfcommon.ReasonAge), *resources[0].DropReason)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/vrf/requests/4321")
+		t.Cleanup(cleanup)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("invalid request ID", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/vrf/requests/notanumber")
+		t.Cleanup(cleanup)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
+
+func TestVRFRequestsController_Provenance(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(ctx))
+	client := app.NewHTTPClient(nil)
+
+	jb, _ := cltest.MustInsertWebhookSpec(t, app.GetDB())
+	orm := vrfcommon.NewRequestLifecycleORM(app.GetDB())
+	reqID := big.NewInt(1234)
+	require.NoError(t, orm.RecordObserved(ctx, jb.ID, testutils.FixtureChainID, utils.RandomAddress(), []vrfcommon.ObservedRequest{{
+		RequestID:        reqID,
+		SubID:            big.NewInt(1),
+		Sender:           utils.RandomAddress(),
+		TxHash:           utils.RandomHash(),
+		BlockHash:        utils.RandomHash(),
+		BlockNumber:      10,
+		ConfirmedAtBlock: 13,
+		ObservedAt:       time.Now().UTC(),
+	}}))
+
+	t.Run("not fulfilled", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/vrf/requests/" + reqID.String() + "/provenance")
+		t.Cleanup(cleanup)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		resp, cleanup := client.Get("/v2/vrf/requests/4321/provenance")
+		t.Cleanup(cleanup)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestVRFRequestsController_Refulfill(t *testing.T) {
+	t.Parallel()
+
+	ctx := testutils.Context(t)
+	app := cltest.NewApplicationEVMDisabled(t)
+	require.NoError(t, app.Start(ctx))
+	client := app.NewHTTPClient(nil)
+
+	t.Run("no running job for coordinator", func(t *testing.T) {
+		body, err := json.Marshal(web.RefulfillVRFRequest{CoordinatorAddress: utils.RandomAddress(), DryRun: true})
+		require.NoError(t, err)
+		resp, cleanup := client.Post("/v2/vrf/requests/1234/refulfill", bytes.NewReader(body))
+		t.Cleanup(cleanup)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("invalid request ID", func(t *testing.T) {
+		resp, cleanup := client.Post("/v2/vrf/requests/notanumber/refulfill", bytes.NewReader([]byte(`{}`)))
+		t.Cleanup(cleanup)
+		assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	})
+}
@@ -49,6 +49,7 @@ require (
 	github.com/hdevalence/ed25519consensus v0.2.0
 	github.com/holiman/uint256 v1.3.2
 	github.com/imdario/mergo v0.3.16
+	github.com/itchyny/gojq v0.12.11
 	github.com/jackc/pgconn v1.14.3
 	github.com/jackc/pgx/v4 v4.18.3
 	github.com/jmoiron/sqlx v1.4.0
@@ -285,6 +286,7 @@ require (
 	github.com/iancoleman/strcase v0.3.0 // indirect
 	github.com/inconshreveable/mousetrap v1.1.0 // indirect
 	github.com/invopop/jsonschema v0.13.0 // indirect
+	github.com/itchyny/timefmt-go v0.1.5 // indirect
 	github.com/jackc/chunkreader/v2 v2.0.1 // indirect
 	github.com/jackc/pgio v1.0.0 // indirect
 	github.com/jackc/pgpassfile v1.0.0 // indirect
@@ -415,4 +417,7 @@ require (
 
 replace github.com/fbsobreira/gotron-sdk => github.com/smartcontractkit/chainlink-tron/relayer/gotron-sdk v0.0.5-0.20250422175525-b7575d96bd4d
 
+// Reorg subscriptions of the log poller, TxAbandoner and TXMv2 priorities are not released yet.
+replace github.com/smartcontractkit/chainlink-evm => ../chainlink-evm
+
 tool github.com/smartcontractkit/chainlink-common/pkg/loop/cmd/loopinstall